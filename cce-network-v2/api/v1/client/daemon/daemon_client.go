@@ -42,11 +42,51 @@ type Client struct {
 
 // ClientService is the interface for Client methods
 type ClientService interface {
+	GetConfig(params *GetConfigParams) (*GetConfigOK, error)
+
 	GetHealthz(params *GetHealthzParams) (*GetHealthzOK, error)
 
 	SetTransport(transport runtime.ClientTransport)
 }
 
+/*
+	GetConfig gets configuration of c c e daemon
+
+	Returns the configuration of the CCE daemon which is currently in
+
+effect, including the config overwritten by NetResourceConfigSet.
+*/
+func (a *Client) GetConfig(params *GetConfigParams) (*GetConfigOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetConfigParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "GetConfig",
+		Method:             "GET",
+		PathPattern:        "/config",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetConfigReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetConfigOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for GetConfig: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
 	GetHealthz gets health of c c e daemon
 
@@ -84,6 +84,13 @@ type GetHealthzParams struct {
 	*/
 	Brief *bool
 
+	/*FailOn
+	  Lowest severity of the failed health checks which fails the
+	request. Only the critical health checks count if it is not set.
+
+	*/
+	FailOn *string
+
 	timeout    time.Duration
 	Context    context.Context
 	HTTPClient *http.Client
@@ -133,6 +140,17 @@ func (o *GetHealthzParams) SetBrief(brief *bool) {
 	o.Brief = brief
 }
 
+// WithFailOn adds the failOn to the get healthz params
+func (o *GetHealthzParams) WithFailOn(failOn *string) *GetHealthzParams {
+	o.SetFailOn(failOn)
+	return o
+}
+
+// SetFailOn adds the failOn to the get healthz params
+func (o *GetHealthzParams) SetFailOn(failOn *string) {
+	o.FailOn = failOn
+}
+
 // WriteToRequest writes these params to a swagger request
 func (o *GetHealthzParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
 
@@ -150,6 +168,15 @@ func (o *GetHealthzParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Re
 
 	}
 
+	if o.FailOn != nil {
+
+		// header param fail-on
+		if err := r.SetHeaderParam("fail-on", *o.FailOn); err != nil {
+			return err
+		}
+
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -44,6 +44,12 @@ func (o *GetHealthzReader) ReadResponse(response runtime.ClientResponse, consume
 			return nil, err
 		}
 		return result, nil
+	case 500:
+		result := NewGetHealthzFailure()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
 
 	default:
 		return nil, runtime.NewAPIError("response status code does not match any response statuses defined for this endpoint in the swagger spec", response, response.Code())
@@ -83,3 +89,37 @@ func (o *GetHealthzOK) readResponse(response runtime.ClientResponse, consumer ru
 
 	return nil
 }
+
+// NewGetHealthzFailure creates a GetHealthzFailure with default headers values
+func NewGetHealthzFailure() *GetHealthzFailure {
+	return &GetHealthzFailure{}
+}
+
+/*
+GetHealthzFailure handles this case with default header values.
+
+Health checks of the requested severities failed
+*/
+type GetHealthzFailure struct {
+	Payload *models.StatusResponse
+}
+
+func (o *GetHealthzFailure) Error() string {
+	return fmt.Sprintf("[GET /healthz][%d] getHealthzFailure  %+v", 500, o.Payload)
+}
+
+func (o *GetHealthzFailure) GetPayload() *models.StatusResponse {
+	return o.Payload
+}
+
+func (o *GetHealthzFailure) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.StatusResponse)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
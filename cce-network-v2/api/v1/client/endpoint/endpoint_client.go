@@ -42,13 +42,55 @@ type Client struct {
 
 // ClientService is the interface for Client methods
 type ClientService interface {
+	GetEndpoint(params *GetEndpointParams) (*GetEndpointOK, error)
+
 	GetEndpointExtpluginStatus(params *GetEndpointExtpluginStatusParams) (*GetEndpointExtpluginStatusOK, error)
 
+	PutEndpointExtpluginStatus(params *PutEndpointExtpluginStatusParams) (*PutEndpointExtpluginStatusOK, error)
+
 	PutEndpointProbe(params *PutEndpointProbeParams) (*PutEndpointProbeCreated, error)
 
 	SetTransport(transport runtime.ClientTransport)
 }
 
+/*
+	GetEndpoint lists endpoints on the local node
+
+	Returns the CCEEndpoints on the local node known by the agent with the
+
+status of their external features.
+*/
+func (a *Client) GetEndpoint(params *GetEndpointParams) (*GetEndpointOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetEndpointParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "GetEndpoint",
+		Method:             "GET",
+		PathPattern:        "/endpoint",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetEndpointReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetEndpointOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for GetEndpoint: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
 GetEndpointExtpluginStatus gets external plugin status
 */
@@ -83,6 +125,43 @@ func (a *Client) GetEndpointExtpluginStatus(params *GetEndpointExtpluginStatusPa
 	panic(msg)
 }
 
+/*
+PutEndpointExtpluginStatus updates external plugin status
+
+Records the status of an external feature implemented by a CNI plugin
+on the endpoint of the container.
+*/
+func (a *Client) PutEndpointExtpluginStatus(params *PutEndpointExtpluginStatusParams) (*PutEndpointExtpluginStatusOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewPutEndpointExtpluginStatusParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "PutEndpointExtpluginStatus",
+		Method:             "PUT",
+		PathPattern:        "/endpoint/extplugin/status",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &PutEndpointExtpluginStatusReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*PutEndpointExtpluginStatusOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for PutEndpointExtpluginStatus: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
 PutEndpointProbe creates or update endpint probe
 */
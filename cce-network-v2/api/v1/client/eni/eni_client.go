@@ -44,6 +44,8 @@ type Client struct {
 type ClientService interface {
 	DeleteEni(params *DeleteEniParams) (*DeleteEniOK, error)
 
+	GetEni(params *GetEniParams) (*GetEniOK, error)
+
 	PostEni(params *PostEniParams) (*PostEniOK, error)
 
 	SetTransport(transport runtime.ClientTransport)
@@ -83,6 +85,44 @@ func (a *Client) DeleteEni(params *DeleteEniParams) (*DeleteEniOK, error) {
 	panic(msg)
 }
 
+/*
+	GetEni lists e n is of the local node
+
+	Returns the ENIs attached to the local node known by the agent with
+
+their links and route tables on the host.
+*/
+func (a *Client) GetEni(params *GetEniParams) (*GetEniOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetEniParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "GetEni",
+		Method:             "GET",
+		PathPattern:        "/eni",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetEniReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetEniOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for GetEni: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
 PostEni allocates an IP address for exclusive e n i
 */
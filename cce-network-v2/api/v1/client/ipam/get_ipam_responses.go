@@ -0,0 +1,85 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// /*
+//  * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+//  *
+//  * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  * except in compliance with the License. You may obtain a copy of the License at
+//  *
+//  * http://www.apache.org/licenses/LICENSE-2.0
+//  *
+//  * Unless required by applicable law or agreed to in writing, software distributed under the
+//  * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  * either express or implied. See the License for the specific language governing permissions
+//  * and limitations under the License.
+//  *
+//  */
+
+package ipam
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
+)
+
+// GetIpamReader is a Reader for the GetIpam structure.
+type GetIpamReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *GetIpamReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewGetIpamOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+
+	default:
+		return nil, runtime.NewAPIError("response status code does not match any response statuses defined for this endpoint in the swagger spec", response, response.Code())
+	}
+}
+
+// NewGetIpamOK creates a GetIpamOK with default headers values
+func NewGetIpamOK() *GetIpamOK {
+	return &GetIpamOK{}
+}
+
+/*
+GetIpamOK handles this case with default header values.
+
+Success
+*/
+type GetIpamOK struct {
+	Payload *models.IPAMStatus
+}
+
+func (o *GetIpamOK) Error() string {
+	return fmt.Sprintf("[GET /ipam][%d] getIpamOK  %+v", 200, o.Payload)
+}
+
+func (o *GetIpamOK) GetPayload() *models.IPAMStatus {
+	return o.Payload
+}
+
+func (o *GetIpamOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.IPAMStatus)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
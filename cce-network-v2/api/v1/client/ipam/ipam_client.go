@@ -44,10 +44,16 @@ type Client struct {
 type ClientService interface {
 	DeleteIpamIP(params *DeleteIpamIPParams) (*DeleteIpamIPOK, error)
 
+	DeleteNetworks(params *DeleteNetworksParams) (*DeleteNetworksOK, error)
+
+	GetIpam(params *GetIpamParams) (*GetIpamOK, error)
+
 	PostIpam(params *PostIpamParams) (*PostIpamCreated, error)
 
 	PostIpamIP(params *PostIpamIPParams) (*PostIpamIPOK, error)
 
+	PostNetworks(params *PostNetworksParams) (*PostNetworksCreated, error)
+
 	SetTransport(transport runtime.ClientTransport)
 }
 
@@ -85,6 +91,78 @@ func (a *Client) DeleteIpamIP(params *DeleteIpamIPParams) (*DeleteIpamIPOK, erro
 	panic(msg)
 }
 
+/*
+DeleteNetworks releases the IP addresses of the secondary interfaces of a pod
+*/
+func (a *Client) DeleteNetworks(params *DeleteNetworksParams) (*DeleteNetworksOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewDeleteNetworksParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "DeleteNetworks",
+		Method:             "DELETE",
+		PathPattern:        "/networks",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &DeleteNetworksReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*DeleteNetworksOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for DeleteNetworks: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+	GetIpam gets IP allocations of the agent
+
+	Returns the IP addresses allocated by the agent with their owners and
+
+the running expiration timers.
+*/
+func (a *Client) GetIpam(params *GetIpamParams) (*GetIpamOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetIpamParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "GetIpam",
+		Method:             "GET",
+		PathPattern:        "/ipam",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetIpamReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetIpamOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for GetIpam: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
 PostIpam allocates an IP address
 */
@@ -153,6 +231,45 @@ func (a *Client) PostIpamIP(params *PostIpamIPParams) (*PostIpamIPOK, error) {
 	panic(msg)
 }
 
+/*
+	PostNetworks allocates the IP addresses of the secondary interfaces of a pod
+
+	Allocates the IP addresses of the secondary interfaces requested by the
+
+network attachment annotation of the pod. Returns an empty list if the
+pod does not request any secondary interface.
+*/
+func (a *Client) PostNetworks(params *PostNetworksParams) (*PostNetworksCreated, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewPostNetworksParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "PostNetworks",
+		Method:             "POST",
+		PathPattern:        "/networks",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &PostNetworksReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*PostNetworksCreated)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for PostNetworks: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 // SetTransport changes the transport on the client
 func (a *Client) SetTransport(transport runtime.ClientTransport) {
 	a.transport = transport
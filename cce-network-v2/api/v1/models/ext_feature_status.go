@@ -0,0 +1,72 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// /*
+//  * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+//  *
+//  * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  * except in compliance with the License. You may obtain a copy of the License at
+//  *
+//  * http://www.apache.org/licenses/LICENSE-2.0
+//  *
+//  * Unless required by applicable law or agreed to in writing, software distributed under the
+//  * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  * either express or implied. See the License for the specific language governing permissions
+//  * and limitations under the License.
+//  *
+//  */
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// ExtFeatureStatus Status of an external feature of endpoint
+//
+// +k8s:deepcopy-gen=true
+//
+// swagger:model ExtFeatureStatus
+type ExtFeatureStatus struct {
+
+	// ID assigned by container runtime
+	ContainerID string `json:"container-id,omitempty"`
+
+	// Additional information of the external feature
+	Data map[string]string `json:"data,omitempty"`
+
+	// msg
+	Msg string `json:"msg,omitempty"`
+
+	// The external feature is ready to use
+	Ready bool `json:"ready,omitempty"`
+
+	// Time when the status was last updated
+	UpdateTime string `json:"updateTime,omitempty"`
+}
+
+// Validate validates this ext feature status
+func (m *ExtFeatureStatus) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *ExtFeatureStatus) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *ExtFeatureStatus) UnmarshalBinary(b []byte) error {
+	var res ExtFeatureStatus
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
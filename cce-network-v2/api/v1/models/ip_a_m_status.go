@@ -36,6 +36,9 @@ type IPAMStatus struct {
 	// allocations
 	Allocations AllocationMap `json:"allocations,omitempty"`
 
+	// Map of allocated IPs to the UUID of their running expiration timer
+	ExpirationTimers map[string]string `json:"expiration-timers,omitempty"`
+
 	// ipv4
 	IPV4 []string `json:"ipv4"`
 
@@ -21,6 +21,8 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"strconv"
+
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
@@ -49,6 +51,9 @@ type StatusResponse struct {
 	// Status of all endpoint controllers
 	Controllers ControllerStatuses `json:"controllers,omitempty"`
 
+	// Results of the health checks of the daemon
+	HealthChecks []*HealthCheckStatus `json:"health-checks,omitempty"`
+
 	// List of stale information in the status
 	Stale map[string]strfmt.DateTime `json:"stale,omitempty"`
 }
@@ -69,6 +74,10 @@ func (m *StatusResponse) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateHealthChecks(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateStale(formats); err != nil {
 		res = append(res, err)
 	}
@@ -131,6 +140,31 @@ func (m *StatusResponse) validateControllers(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *StatusResponse) validateHealthChecks(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.HealthChecks) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.HealthChecks); i++ {
+		if swag.IsZero(m.HealthChecks[i]) { // not required
+			continue
+		}
+
+		if m.HealthChecks[i] != nil {
+			if err := m.HealthChecks[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("health-checks" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *StatusResponse) validateStale(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.Stale) { // not required
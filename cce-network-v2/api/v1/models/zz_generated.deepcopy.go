@@ -199,6 +199,85 @@ func (in *ENI) DeepCopy() *ENI {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Endpoint) DeepCopyInto(out *Endpoint) {
+	*out = *in
+	if in.ExtFeatureGates != nil {
+		in, out := &in.ExtFeatureGates, &out.ExtFeatureGates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtFeatureStatus != nil {
+		in, out := &in.ExtFeatureStatus, &out.ExtFeatureStatus
+		*out = make(map[string]ExtFeatureStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Identifiers != nil {
+		in, out := &in.Identifiers, &out.Identifiers
+		*out = new(EndpointIdentifiers)
+		**out = **in
+	}
+	if in.Ips != nil {
+		in, out := &in.Ips, &out.Ips
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Endpoint.
+func (in *Endpoint) DeepCopy() *Endpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(Endpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtFeatureStatus) DeepCopyInto(out *ExtFeatureStatus) {
+	*out = *in
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtFeatureStatus.
+func (in *ExtFeatureStatus) DeepCopy() *ExtFeatureStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtFeatureStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheckStatus) DeepCopyInto(out *HealthCheckStatus) {
+	*out = *in
+	in.LastCheckTimestamp.DeepCopyInto(&out.LastCheckTimestamp)
+	in.LastSuccessTimestamp.DeepCopyInto(&out.LastSuccessTimestamp)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckStatus.
+func (in *HealthCheckStatus) DeepCopy() *HealthCheckStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheckStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IPAMStatus) DeepCopyInto(out *IPAMStatus) {
 	*out = *in
@@ -209,6 +288,13 @@ func (in *IPAMStatus) DeepCopyInto(out *IPAMStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.ExpirationTimers != nil {
+		in, out := &in.ExpirationTimers, &out.ExpirationTimers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.IPV4 != nil {
 		in, out := &in.IPV4, &out.IPV4
 		*out = make([]string, len(*in))
@@ -253,6 +339,32 @@ func (in *K8sStatus) DeepCopy() *K8sStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalENI) DeepCopyInto(out *LocalENI) {
+	*out = *in
+	if in.IPV4 != nil {
+		in, out := &in.IPV4, &out.IPV4
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPV6 != nil {
+		in, out := &in.IPV6, &out.IPV6
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalENI.
+func (in *LocalENI) DeepCopy() *LocalENI {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalENI)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Masquerading) DeepCopyInto(out *Masquerading) {
 	*out = *in
@@ -388,6 +500,17 @@ func (in *StatusResponse) DeepCopyInto(out *StatusResponse) {
 			}
 		}
 	}
+	if in.HealthChecks != nil {
+		in, out := &in.HealthChecks, &out.HealthChecks
+		*out = make([]*HealthCheckStatus, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(HealthCheckStatus)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 	if in.Stale != nil {
 		in, out := &in.Stale, &out.Stale
 		*out = make(map[string]strfmt.DateTime, len(*in))
@@ -47,6 +47,50 @@ func init() {
   },
   "basePath": "/v1",
   "paths": {
+    "/config": {
+      "get": {
+        "description": "Returns the configuration of the CCE daemon which is currently in\neffect, including the config overwritten by NetResourceConfigSet.\n",
+        "tags": [
+          "daemon"
+        ],
+        "summary": "Get configuration of CCE daemon",
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/DaemonConfigurationStatus"
+            }
+          }
+        }
+      }
+    },
+    "/endpoint": {
+      "get": {
+        "description": "Returns the CCEEndpoints on the local node known by the agent with the\nstatus of their external features.\n",
+        "tags": [
+          "endpoint"
+        ],
+        "summary": "List endpoints on the local node",
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/Endpoint"
+              }
+            }
+          },
+          "500": {
+            "description": "failed to list endpoints. Details in message.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      }
+    },
     "/endpoint/extplugin/status": {
       "get": {
         "tags": [
@@ -76,6 +120,39 @@ func init() {
             "x-go-name": "Failure"
           }
         }
+      },
+      "put": {
+        "description": "Records the status of an external feature implemented by a CNI plugin\non the endpoint of the container.\n",
+        "tags": [
+          "endpoint"
+        ],
+        "summary": "update external plugin status",
+        "parameters": [
+          {
+            "$ref": "#/parameters/ipam-owner"
+          },
+          {
+            "$ref": "#/parameters/ipam-containerid"
+          },
+          {
+            "$ref": "#/parameters/ext-feature"
+          },
+          {
+            "$ref": "#/parameters/ext-feature-status"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "500": {
+            "description": "failed to update external plugin status. Details in message.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
       }
     },
     "/endpoint/probe": {
@@ -119,6 +196,31 @@ func init() {
       }
     },
     "/eni": {
+      "get": {
+        "description": "Returns the ENIs attached to the local node known by the agent with\ntheir links and route tables on the host.\n",
+        "tags": [
+          "eni"
+        ],
+        "summary": "List ENIs of the local node",
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/LocalENI"
+              }
+            }
+          },
+          "500": {
+            "description": "failed to list ENIs. Details in message.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      },
       "post": {
         "tags": [
           "eni"
@@ -226,6 +328,16 @@ func init() {
             "description": "Brief will return a brief representation of the CCE status.\n",
             "name": "brief",
             "in": "header"
+          },
+          {
+            "enum": [
+              "Critical",
+              "Degraded"
+            ],
+            "type": "string",
+            "description": "Lowest severity of the failed health checks which fails the\nrequest. Only the critical health checks count if it is not set.\n",
+            "name": "fail-on",
+            "in": "header"
           }
         ],
         "responses": {
@@ -234,11 +346,33 @@ func init() {
             "schema": {
               "$ref": "#/definitions/StatusResponse"
             }
+          },
+          "500": {
+            "description": "Health checks of the requested severities failed",
+            "schema": {
+              "$ref": "#/definitions/StatusResponse"
+            },
+            "x-go-name": "Failure"
           }
         }
       }
     },
     "/ipam": {
+      "get": {
+        "description": "Returns the IP addresses allocated by the agent with their owners and\nthe running expiration timers.\n",
+        "tags": [
+          "ipam"
+        ],
+        "summary": "Get IP allocations of the agent",
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/IPAMStatus"
+            }
+          }
+        }
+      },
       "post": {
         "tags": [
           "ipam"
@@ -398,6 +532,70 @@ func init() {
         }
       }
     },
+    "/networks": {
+      "post": {
+        "description": "Allocates the IP addresses of the secondary interfaces requested by the\nnetwork attachment annotation of the pod. Returns an empty list if the\npod does not request any secondary interface.\n",
+        "tags": [
+          "ipam"
+        ],
+        "summary": "Allocate the IP addresses of the secondary interfaces of a pod",
+        "parameters": [
+          {
+            "$ref": "#/parameters/ipam-owner"
+          },
+          {
+            "$ref": "#/parameters/ipam-containerid"
+          },
+          {
+            "$ref": "#/parameters/ipam-netns"
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Success",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/NetworkAttachmentResponse"
+              }
+            }
+          },
+          "502": {
+            "description": "Allocation failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      },
+      "delete": {
+        "tags": [
+          "ipam"
+        ],
+        "summary": "Release the IP addresses of the secondary interfaces of a pod",
+        "parameters": [
+          {
+            "$ref": "#/parameters/ipam-owner"
+          },
+          {
+            "$ref": "#/parameters/ipam-containerid"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "500": {
+            "description": "Release failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      }
+    },
     "/rdmaipam": {
       "post": {
         "tags": [
@@ -520,6 +718,13 @@ func init() {
         }
       }
     },
+    "AllocationMap": {
+      "description": "Map of allocated IPs",
+      "type": "object",
+      "additionalProperties": {
+        "type": "string"
+      }
+    },
     "BandwidthOption": {
       "description": "BandwidthOption is the bandwidth option of network\n+k8s:deepcopy-gen=true\n",
       "type": "object",
@@ -617,6 +822,17 @@ func init() {
         "$ref": "#/definitions/ControllerStatus"
       }
     },
+    "DaemonConfigurationStatus": {
+      "description": "Response to a daemon configuration request",
+      "type": "object",
+      "properties": {
+        "daemonConfigurationMap": {
+          "description": "Config map which contains all the active daemon configurations",
+          "type": "object",
+          "additionalProperties": true
+        }
+      }
+    },
     "DatapathMode": {
       "description": "Datapath mode",
       "type": "string",
@@ -691,6 +907,47 @@ func init() {
         }
       }
     },
+    "Endpoint": {
+      "description": "Endpoint on the local node\n\n+k8s:deepcopy-gen=true",
+      "type": "object",
+      "properties": {
+        "extFeatureGates": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-omitempty": true
+        },
+        "extFeatureStatus": {
+          "description": "Status of external features of endpoint",
+          "type": "object",
+          "additionalProperties": {
+            "$ref": "#/definitions/ExtFeatureStatus"
+          }
+        },
+        "identifiers": {
+          "$ref": "#/definitions/EndpointIdentifiers"
+        },
+        "ips": {
+          "description": "IP addresses of endpoint",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-omitempty": true
+        },
+        "name": {
+          "type": "string"
+        },
+        "namespace": {
+          "type": "string"
+        },
+        "state": {
+          "description": "State of endpoint",
+          "type": "string"
+        }
+      }
+    },
     "EndpointIdentifiers": {
       "description": "Unique identifiers for this endpoint from outside CCE\n\n+deepequal-gen=true",
       "type": "object",
@@ -812,6 +1069,74 @@ func init() {
         }
       }
     },
+    "ExtFeatureStatus": {
+      "description": "Status of an external feature of endpoint\n\n+k8s:deepcopy-gen=true",
+      "type": "object",
+      "properties": {
+        "container-id": {
+          "description": "ID assigned by container runtime",
+          "type": "string"
+        },
+        "data": {
+          "description": "Additional information of the external feature",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
+        "msg": {
+          "type": "string"
+        },
+        "ready": {
+          "description": "The external feature is ready to use",
+          "type": "boolean"
+        },
+        "updateTime": {
+          "description": "Time when the status was last updated",
+          "type": "string"
+        }
+      }
+    },
+    "HealthCheckStatus": {
+      "description": "Result of the last run of a health check of the daemon\n\n+k8s:deepcopy-gen=true",
+      "type": "object",
+      "properties": {
+        "duration": {
+          "description": "Duration of the last run of the health check in nanoseconds",
+          "type": "integer"
+        },
+        "healthy": {
+          "description": "Whether the last run of the health check succeeded",
+          "type": "boolean"
+        },
+        "last-check-timestamp": {
+          "description": "Timestamp of the last run of the health check",
+          "type": "string",
+          "format": "date-time"
+        },
+        "last-success-timestamp": {
+          "description": "Timestamp of the last successful run of the health check",
+          "type": "string",
+          "format": "date-time"
+        },
+        "msg": {
+          "description": "Error of the last run of the health check",
+          "type": "string"
+        },
+        "name": {
+          "description": "Name of the health check",
+          "type": "string"
+        },
+        "severity": {
+          "description": "How much the failure of the health check affects the daemon",
+          "type": "string",
+          "enum": [
+            "Critical",
+            "Degraded"
+          ]
+        }
+      }
+    },
     "IPAMAddressResponse": {
       "description": "IPAM configuration of an individual address family",
       "type": "object",
@@ -867,6 +1192,109 @@ func init() {
         }
       }
     },
+    "IPAMStatus": {
+      "description": "Status of IP address management\n\n+k8s:deepcopy-gen=true",
+      "type": "object",
+      "properties": {
+        "allocations": {
+          "$ref": "#/definitions/AllocationMap"
+        },
+        "expiration-timers": {
+          "description": "Map of allocated IPs to the UUID of their running expiration timer",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
+        "ipv4": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "ipv6": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "status": {
+          "type": "string"
+        }
+      }
+    },
+    "LocalENI": {
+      "description": "ENI attached to the local node and its link on the host\n\n+k8s:deepcopy-gen=true",
+      "type": "object",
+      "properties": {
+        "cceStatus": {
+          "description": "Status of ENI in CCE",
+          "type": "string"
+        },
+        "endpoint": {
+          "description": "Endpoint which uses ENI exclusively, in the format of namespace/name",
+          "type": "string"
+        },
+        "eniIndex": {
+          "description": "Index of ENI generated by the agent",
+          "type": "integer"
+        },
+        "gatewayIPv4": {
+          "type": "string"
+        },
+        "gatewayIPv6": {
+          "type": "string"
+        },
+        "id": {
+          "description": "ID of ENI",
+          "type": "string"
+        },
+        "ipv4": {
+          "description": "IPv4 addresses of ENI",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-omitempty": true
+        },
+        "ipv6": {
+          "description": "IPv6 addresses of ENI",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-omitempty": true
+        },
+        "linkIndex": {
+          "description": "Index of the link of ENI on the host, 0 if the link has not been found",
+          "type": "integer"
+        },
+        "linkName": {
+          "description": "Name of the link of ENI on the host",
+          "type": "string"
+        },
+        "macAddress": {
+          "description": "Mac address of ENI",
+          "type": "string"
+        },
+        "name": {
+          "description": "Name of the ENI object",
+          "type": "string"
+        },
+        "routeTable": {
+          "description": "Route table of the policy routes from ENI, 0 if ENI does not use a\ndedicated route table\n",
+          "type": "integer"
+        },
+        "useMode": {
+          "description": "Use mode of ENI, such as Secondary, Primary",
+          "type": "string"
+        },
+        "vpcStatus": {
+          "description": "Status of ENI in VPC",
+          "type": "string"
+        }
+      }
+    },
     "Metric": {
       "description": "Metric information",
       "type": "object",
@@ -888,6 +1316,29 @@ func init() {
         }
       }
     },
+    "NetworkAttachmentResponse": {
+      "description": "IPAM configuration of a secondary interface of an endpoint",
+      "type": "object",
+      "properties": {
+        "interface": {
+          "description": "Name of the interface in the pod",
+          "type": "string"
+        },
+        "ipv4": {
+          "$ref": "#/definitions/IPAMAddressResponse"
+        },
+        "ipv6": {
+          "$ref": "#/definitions/IPAMAddressResponse"
+        },
+        "routes": {
+          "description": "CIDRs routed through the interface in the pod",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
     "NodeAddressing": {
       "description": "Addressing information of a node for all address families\n\n+k8s:deepcopy-gen=true",
       "type": "object",
@@ -1009,6 +1460,13 @@ func init() {
           "description": "Status of all endpoint controllers",
           "$ref": "#/definitions/ControllerStatuses"
         },
+        "health-checks": {
+          "description": "Results of the health checks of the daemon",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/HealthCheckStatus"
+          }
+        },
         "stale": {
           "description": "List of stale information in the status",
           "type": "object",
@@ -1041,6 +1499,21 @@ func init() {
         "$ref": "#/definitions/ENI"
       }
     },
+    "ext-feature": {
+      "type": "string",
+      "description": "Name of the external feature",
+      "name": "feature",
+      "in": "query",
+      "required": true
+    },
+    "ext-feature-status": {
+      "description": "Status of the external feature",
+      "name": "status",
+      "in": "body",
+      "schema": {
+        "$ref": "#/definitions/ExtFeatureStatus"
+      }
+    },
     "ipam-containerid": {
       "type": "string",
       "description": "container id provider by cni",
@@ -1138,6 +1611,50 @@ func init() {
   },
   "basePath": "/v1",
   "paths": {
+    "/config": {
+      "get": {
+        "description": "Returns the configuration of the CCE daemon which is currently in\neffect, including the config overwritten by NetResourceConfigSet.\n",
+        "tags": [
+          "daemon"
+        ],
+        "summary": "Get configuration of CCE daemon",
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/DaemonConfigurationStatus"
+            }
+          }
+        }
+      }
+    },
+    "/endpoint": {
+      "get": {
+        "description": "Returns the CCEEndpoints on the local node known by the agent with the\nstatus of their external features.\n",
+        "tags": [
+          "endpoint"
+        ],
+        "summary": "List endpoints on the local node",
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/Endpoint"
+              }
+            }
+          },
+          "500": {
+            "description": "failed to list endpoints. Details in message.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      }
+    },
     "/endpoint/extplugin/status": {
       "get": {
         "tags": [
@@ -1172,6 +1689,53 @@ func init() {
             "x-go-name": "Failure"
           }
         }
+      },
+      "put": {
+        "description": "Records the status of an external feature implemented by a CNI plugin\non the endpoint of the container.\n",
+        "tags": [
+          "endpoint"
+        ],
+        "summary": "update external plugin status",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "owner",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "container id provider by cni",
+            "name": "containerID",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Name of the external feature",
+            "name": "feature",
+            "in": "query",
+            "required": true
+          },
+          {
+            "description": "Status of the external feature",
+            "name": "status",
+            "in": "body",
+            "schema": {
+              "$ref": "#/definitions/ExtFeatureStatus"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "500": {
+            "description": "failed to update external plugin status. Details in message.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
       }
     },
     "/endpoint/probe": {
@@ -1228,6 +1792,31 @@ func init() {
       }
     },
     "/eni": {
+      "get": {
+        "description": "Returns the ENIs attached to the local node known by the agent with\ntheir links and route tables on the host.\n",
+        "tags": [
+          "eni"
+        ],
+        "summary": "List ENIs of the local node",
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/LocalENI"
+              }
+            }
+          },
+          "500": {
+            "description": "failed to list ENIs. Details in message.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      },
       "post": {
         "tags": [
           "eni"
@@ -1362,6 +1951,16 @@ func init() {
             "description": "Brief will return a brief representation of the CCE status.\n",
             "name": "brief",
             "in": "header"
+          },
+          {
+            "enum": [
+              "Critical",
+              "Degraded"
+            ],
+            "type": "string",
+            "description": "Lowest severity of the failed health checks which fails the\nrequest. Only the critical health checks count if it is not set.\n",
+            "name": "fail-on",
+            "in": "header"
           }
         ],
         "responses": {
@@ -1370,11 +1969,33 @@ func init() {
             "schema": {
               "$ref": "#/definitions/StatusResponse"
             }
+          },
+          "500": {
+            "description": "Health checks of the requested severities failed",
+            "schema": {
+              "$ref": "#/definitions/StatusResponse"
+            },
+            "x-go-name": "Failure"
           }
         }
       }
     },
     "/ipam": {
+      "get": {
+        "description": "Returns the IP addresses allocated by the agent with their owners and\nthe running expiration timers.\n",
+        "tags": [
+          "ipam"
+        ],
+        "summary": "Get IP allocations of the agent",
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/IPAMStatus"
+            }
+          }
+        }
+      },
       "post": {
         "tags": [
           "ipam"
@@ -1583,6 +2204,83 @@ func init() {
         }
       }
     },
+    "/networks": {
+      "post": {
+        "description": "Allocates the IP addresses of the secondary interfaces requested by the\nnetwork attachment annotation of the pod. Returns an empty list if the\npod does not request any secondary interface.\n",
+        "tags": [
+          "ipam"
+        ],
+        "summary": "Allocate the IP addresses of the secondary interfaces of a pod",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "owner",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "container id provider by cni",
+            "name": "containerID",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "netns provider by cni",
+            "name": "netns",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Success",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/NetworkAttachmentResponse"
+              }
+            }
+          },
+          "502": {
+            "description": "Allocation failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      },
+      "delete": {
+        "tags": [
+          "ipam"
+        ],
+        "summary": "Release the IP addresses of the secondary interfaces of a pod",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "owner",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "container id provider by cni",
+            "name": "containerID",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "500": {
+            "description": "Release failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      }
+    },
     "/rdmaipam": {
       "post": {
         "tags": [
@@ -1739,6 +2437,13 @@ func init() {
         }
       }
     },
+    "AllocationMap": {
+      "description": "Map of allocated IPs",
+      "type": "object",
+      "additionalProperties": {
+        "type": "string"
+      }
+    },
     "BandwidthOption": {
       "description": "BandwidthOption is the bandwidth option of network\n+k8s:deepcopy-gen=true\n",
       "type": "object",
@@ -1888,6 +2593,17 @@ func init() {
         "$ref": "#/definitions/ControllerStatus"
       }
     },
+    "DaemonConfigurationStatus": {
+      "description": "Response to a daemon configuration request",
+      "type": "object",
+      "properties": {
+        "daemonConfigurationMap": {
+          "description": "Config map which contains all the active daemon configurations",
+          "type": "object",
+          "additionalProperties": true
+        }
+      }
+    },
     "DatapathMode": {
       "description": "Datapath mode",
       "type": "string",
@@ -1962,6 +2678,47 @@ func init() {
         }
       }
     },
+    "Endpoint": {
+      "description": "Endpoint on the local node\n\n+k8s:deepcopy-gen=true",
+      "type": "object",
+      "properties": {
+        "extFeatureGates": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-omitempty": true
+        },
+        "extFeatureStatus": {
+          "description": "Status of external features of endpoint",
+          "type": "object",
+          "additionalProperties": {
+            "$ref": "#/definitions/ExtFeatureStatus"
+          }
+        },
+        "identifiers": {
+          "$ref": "#/definitions/EndpointIdentifiers"
+        },
+        "ips": {
+          "description": "IP addresses of endpoint",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-omitempty": true
+        },
+        "name": {
+          "type": "string"
+        },
+        "namespace": {
+          "type": "string"
+        },
+        "state": {
+          "description": "State of endpoint",
+          "type": "string"
+        }
+      }
+    },
     "EndpointIdentifiers": {
       "description": "Unique identifiers for this endpoint from outside CCE\n\n+deepequal-gen=true",
       "type": "object",
@@ -2083,6 +2840,74 @@ func init() {
         }
       }
     },
+    "ExtFeatureStatus": {
+      "description": "Status of an external feature of endpoint\n\n+k8s:deepcopy-gen=true",
+      "type": "object",
+      "properties": {
+        "container-id": {
+          "description": "ID assigned by container runtime",
+          "type": "string"
+        },
+        "data": {
+          "description": "Additional information of the external feature",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
+        "msg": {
+          "type": "string"
+        },
+        "ready": {
+          "description": "The external feature is ready to use",
+          "type": "boolean"
+        },
+        "updateTime": {
+          "description": "Time when the status was last updated",
+          "type": "string"
+        }
+      }
+    },
+    "HealthCheckStatus": {
+      "description": "Result of the last run of a health check of the daemon\n\n+k8s:deepcopy-gen=true",
+      "type": "object",
+      "properties": {
+        "duration": {
+          "description": "Duration of the last run of the health check in nanoseconds",
+          "type": "integer"
+        },
+        "healthy": {
+          "description": "Whether the last run of the health check succeeded",
+          "type": "boolean"
+        },
+        "last-check-timestamp": {
+          "description": "Timestamp of the last run of the health check",
+          "type": "string",
+          "format": "date-time"
+        },
+        "last-success-timestamp": {
+          "description": "Timestamp of the last successful run of the health check",
+          "type": "string",
+          "format": "date-time"
+        },
+        "msg": {
+          "description": "Error of the last run of the health check",
+          "type": "string"
+        },
+        "name": {
+          "description": "Name of the health check",
+          "type": "string"
+        },
+        "severity": {
+          "description": "How much the failure of the health check affects the daemon",
+          "type": "string",
+          "enum": [
+            "Critical",
+            "Degraded"
+          ]
+        }
+      }
+    },
     "IPAMAddressResponse": {
       "description": "IPAM configuration of an individual address family",
       "type": "object",
@@ -2138,6 +2963,109 @@ func init() {
         }
       }
     },
+    "IPAMStatus": {
+      "description": "Status of IP address management\n\n+k8s:deepcopy-gen=true",
+      "type": "object",
+      "properties": {
+        "allocations": {
+          "$ref": "#/definitions/AllocationMap"
+        },
+        "expiration-timers": {
+          "description": "Map of allocated IPs to the UUID of their running expiration timer",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
+        "ipv4": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "ipv6": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "status": {
+          "type": "string"
+        }
+      }
+    },
+    "LocalENI": {
+      "description": "ENI attached to the local node and its link on the host\n\n+k8s:deepcopy-gen=true",
+      "type": "object",
+      "properties": {
+        "cceStatus": {
+          "description": "Status of ENI in CCE",
+          "type": "string"
+        },
+        "endpoint": {
+          "description": "Endpoint which uses ENI exclusively, in the format of namespace/name",
+          "type": "string"
+        },
+        "eniIndex": {
+          "description": "Index of ENI generated by the agent",
+          "type": "integer"
+        },
+        "gatewayIPv4": {
+          "type": "string"
+        },
+        "gatewayIPv6": {
+          "type": "string"
+        },
+        "id": {
+          "description": "ID of ENI",
+          "type": "string"
+        },
+        "ipv4": {
+          "description": "IPv4 addresses of ENI",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-omitempty": true
+        },
+        "ipv6": {
+          "description": "IPv6 addresses of ENI",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-omitempty": true
+        },
+        "linkIndex": {
+          "description": "Index of the link of ENI on the host, 0 if the link has not been found",
+          "type": "integer"
+        },
+        "linkName": {
+          "description": "Name of the link of ENI on the host",
+          "type": "string"
+        },
+        "macAddress": {
+          "description": "Mac address of ENI",
+          "type": "string"
+        },
+        "name": {
+          "description": "Name of the ENI object",
+          "type": "string"
+        },
+        "routeTable": {
+          "description": "Route table of the policy routes from ENI, 0 if ENI does not use a\ndedicated route table\n",
+          "type": "integer"
+        },
+        "useMode": {
+          "description": "Use mode of ENI, such as Secondary, Primary",
+          "type": "string"
+        },
+        "vpcStatus": {
+          "description": "Status of ENI in VPC",
+          "type": "string"
+        }
+      }
+    },
     "Metric": {
       "description": "Metric information",
       "type": "object",
@@ -2159,6 +3087,29 @@ func init() {
         }
       }
     },
+    "NetworkAttachmentResponse": {
+      "description": "IPAM configuration of a secondary interface of an endpoint",
+      "type": "object",
+      "properties": {
+        "interface": {
+          "description": "Name of the interface in the pod",
+          "type": "string"
+        },
+        "ipv4": {
+          "$ref": "#/definitions/IPAMAddressResponse"
+        },
+        "ipv6": {
+          "$ref": "#/definitions/IPAMAddressResponse"
+        },
+        "routes": {
+          "description": "CIDRs routed through the interface in the pod",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
     "NodeAddressing": {
       "description": "Addressing information of a node for all address families\n\n+k8s:deepcopy-gen=true",
       "type": "object",
@@ -2280,6 +3231,13 @@ func init() {
           "description": "Status of all endpoint controllers",
           "$ref": "#/definitions/ControllerStatuses"
         },
+        "health-checks": {
+          "description": "Results of the health checks of the daemon",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/HealthCheckStatus"
+          }
+        },
         "stale": {
           "description": "List of stale information in the status",
           "type": "object",
@@ -2312,6 +3270,21 @@ func init() {
         "$ref": "#/definitions/ENI"
       }
     },
+    "ext-feature": {
+      "type": "string",
+      "description": "Name of the external feature",
+      "name": "feature",
+      "in": "query",
+      "required": true
+    },
+    "ext-feature-status": {
+      "description": "Status of the external feature",
+      "name": "status",
+      "in": "body",
+      "schema": {
+        "$ref": "#/definitions/ExtFeatureStatus"
+      }
+    },
     "ipam-containerid": {
       "type": "string",
       "description": "container id provider by cni",
@@ -70,15 +70,30 @@ func NewCceAPIAPI(spec *loads.Document) *CceAPIAPI {
 		IpamDeleteIpamIPHandler: ipam.DeleteIpamIPHandlerFunc(func(params ipam.DeleteIpamIPParams) middleware.Responder {
 			return middleware.NotImplemented("operation ipam.DeleteIpamIP has not yet been implemented")
 		}),
+		IpamDeleteNetworksHandler: ipam.DeleteNetworksHandlerFunc(func(params ipam.DeleteNetworksParams) middleware.Responder {
+			return middleware.NotImplemented("operation ipam.DeleteNetworks has not yet been implemented")
+		}),
 		RdmaipamDeleteRdmaipamRdmaipsHandler: rdmaipam.DeleteRdmaipamRdmaipsHandlerFunc(func(params rdmaipam.DeleteRdmaipamRdmaipsParams) middleware.Responder {
 			return middleware.NotImplemented("operation rdmaipam.DeleteRdmaipamRdmaips has not yet been implemented")
 		}),
+		DaemonGetConfigHandler: daemon.GetConfigHandlerFunc(func(params daemon.GetConfigParams) middleware.Responder {
+			return middleware.NotImplemented("operation daemon.GetConfig has not yet been implemented")
+		}),
+		EndpointGetEndpointHandler: endpoint.GetEndpointHandlerFunc(func(params endpoint.GetEndpointParams) middleware.Responder {
+			return middleware.NotImplemented("operation endpoint.GetEndpoint has not yet been implemented")
+		}),
 		EndpointGetEndpointExtpluginStatusHandler: endpoint.GetEndpointExtpluginStatusHandlerFunc(func(params endpoint.GetEndpointExtpluginStatusParams) middleware.Responder {
 			return middleware.NotImplemented("operation endpoint.GetEndpointExtpluginStatus has not yet been implemented")
 		}),
+		EniGetEniHandler: eni.GetEniHandlerFunc(func(params eni.GetEniParams) middleware.Responder {
+			return middleware.NotImplemented("operation eni.GetEni has not yet been implemented")
+		}),
 		DaemonGetHealthzHandler: daemon.GetHealthzHandlerFunc(func(params daemon.GetHealthzParams) middleware.Responder {
 			return middleware.NotImplemented("operation daemon.GetHealthz has not yet been implemented")
 		}),
+		IpamGetIpamHandler: ipam.GetIpamHandlerFunc(func(params ipam.GetIpamParams) middleware.Responder {
+			return middleware.NotImplemented("operation ipam.GetIpam has not yet been implemented")
+		}),
 		MetricsGetMetricsHandler: metrics.GetMetricsHandlerFunc(func(params metrics.GetMetricsParams) middleware.Responder {
 			return middleware.NotImplemented("operation metrics.GetMetrics has not yet been implemented")
 		}),
@@ -91,9 +106,15 @@ func NewCceAPIAPI(spec *loads.Document) *CceAPIAPI {
 		IpamPostIpamIPHandler: ipam.PostIpamIPHandlerFunc(func(params ipam.PostIpamIPParams) middleware.Responder {
 			return middleware.NotImplemented("operation ipam.PostIpamIP has not yet been implemented")
 		}),
+		IpamPostNetworksHandler: ipam.PostNetworksHandlerFunc(func(params ipam.PostNetworksParams) middleware.Responder {
+			return middleware.NotImplemented("operation ipam.PostNetworks has not yet been implemented")
+		}),
 		RdmaipamPostRdmaipamHandler: rdmaipam.PostRdmaipamHandlerFunc(func(params rdmaipam.PostRdmaipamParams) middleware.Responder {
 			return middleware.NotImplemented("operation rdmaipam.PostRdmaipam has not yet been implemented")
 		}),
+		EndpointPutEndpointExtpluginStatusHandler: endpoint.PutEndpointExtpluginStatusHandlerFunc(func(params endpoint.PutEndpointExtpluginStatusParams) middleware.Responder {
+			return middleware.NotImplemented("operation endpoint.PutEndpointExtpluginStatus has not yet been implemented")
+		}),
 		EndpointPutEndpointProbeHandler: endpoint.PutEndpointProbeHandlerFunc(func(params endpoint.PutEndpointProbeParams) middleware.Responder {
 			return middleware.NotImplemented("operation endpoint.PutEndpointProbe has not yet been implemented")
 		}),
@@ -135,12 +156,22 @@ type CceAPIAPI struct {
 	EniDeleteEniHandler eni.DeleteEniHandler
 	// IpamDeleteIpamIPHandler sets the operation handler for the delete ipam IP operation
 	IpamDeleteIpamIPHandler ipam.DeleteIpamIPHandler
+	// IpamDeleteNetworksHandler sets the operation handler for the delete networks operation
+	IpamDeleteNetworksHandler ipam.DeleteNetworksHandler
 	// RdmaipamDeleteRdmaipamRdmaipsHandler sets the operation handler for the delete rdmaipam rdmaips operation
 	RdmaipamDeleteRdmaipamRdmaipsHandler rdmaipam.DeleteRdmaipamRdmaipsHandler
+	// DaemonGetConfigHandler sets the operation handler for the get config operation
+	DaemonGetConfigHandler daemon.GetConfigHandler
+	// EndpointGetEndpointHandler sets the operation handler for the get endpoint operation
+	EndpointGetEndpointHandler endpoint.GetEndpointHandler
 	// EndpointGetEndpointExtpluginStatusHandler sets the operation handler for the get endpoint extplugin status operation
 	EndpointGetEndpointExtpluginStatusHandler endpoint.GetEndpointExtpluginStatusHandler
+	// EniGetEniHandler sets the operation handler for the get eni operation
+	EniGetEniHandler eni.GetEniHandler
 	// DaemonGetHealthzHandler sets the operation handler for the get healthz operation
 	DaemonGetHealthzHandler daemon.GetHealthzHandler
+	// IpamGetIpamHandler sets the operation handler for the get ipam operation
+	IpamGetIpamHandler ipam.GetIpamHandler
 	// MetricsGetMetricsHandler sets the operation handler for the get metrics operation
 	MetricsGetMetricsHandler metrics.GetMetricsHandler
 	// EniPostEniHandler sets the operation handler for the post eni operation
@@ -149,8 +180,12 @@ type CceAPIAPI struct {
 	IpamPostIpamHandler ipam.PostIpamHandler
 	// IpamPostIpamIPHandler sets the operation handler for the post ipam IP operation
 	IpamPostIpamIPHandler ipam.PostIpamIPHandler
+	// IpamPostNetworksHandler sets the operation handler for the post networks operation
+	IpamPostNetworksHandler ipam.PostNetworksHandler
 	// RdmaipamPostRdmaipamHandler sets the operation handler for the post rdmaipam operation
 	RdmaipamPostRdmaipamHandler rdmaipam.PostRdmaipamHandler
+	// EndpointPutEndpointExtpluginStatusHandler sets the operation handler for the put endpoint extplugin status operation
+	EndpointPutEndpointExtpluginStatusHandler endpoint.PutEndpointExtpluginStatusHandler
 	// EndpointPutEndpointProbeHandler sets the operation handler for the put endpoint probe operation
 	EndpointPutEndpointProbeHandler endpoint.PutEndpointProbeHandler
 	// ServeError is called when an error is received, there is a default handler
@@ -235,15 +270,30 @@ func (o *CceAPIAPI) Validate() error {
 	if o.IpamDeleteIpamIPHandler == nil {
 		unregistered = append(unregistered, "ipam.DeleteIpamIPHandler")
 	}
+	if o.IpamDeleteNetworksHandler == nil {
+		unregistered = append(unregistered, "ipam.DeleteNetworksHandler")
+	}
 	if o.RdmaipamDeleteRdmaipamRdmaipsHandler == nil {
 		unregistered = append(unregistered, "rdmaipam.DeleteRdmaipamRdmaipsHandler")
 	}
+	if o.DaemonGetConfigHandler == nil {
+		unregistered = append(unregistered, "daemon.GetConfigHandler")
+	}
+	if o.EndpointGetEndpointHandler == nil {
+		unregistered = append(unregistered, "endpoint.GetEndpointHandler")
+	}
 	if o.EndpointGetEndpointExtpluginStatusHandler == nil {
 		unregistered = append(unregistered, "endpoint.GetEndpointExtpluginStatusHandler")
 	}
+	if o.EniGetEniHandler == nil {
+		unregistered = append(unregistered, "eni.GetEniHandler")
+	}
 	if o.DaemonGetHealthzHandler == nil {
 		unregistered = append(unregistered, "daemon.GetHealthzHandler")
 	}
+	if o.IpamGetIpamHandler == nil {
+		unregistered = append(unregistered, "ipam.GetIpamHandler")
+	}
 	if o.MetricsGetMetricsHandler == nil {
 		unregistered = append(unregistered, "metrics.GetMetricsHandler")
 	}
@@ -256,9 +306,15 @@ func (o *CceAPIAPI) Validate() error {
 	if o.IpamPostIpamIPHandler == nil {
 		unregistered = append(unregistered, "ipam.PostIpamIPHandler")
 	}
+	if o.IpamPostNetworksHandler == nil {
+		unregistered = append(unregistered, "ipam.PostNetworksHandler")
+	}
 	if o.RdmaipamPostRdmaipamHandler == nil {
 		unregistered = append(unregistered, "rdmaipam.PostRdmaipamHandler")
 	}
+	if o.EndpointPutEndpointExtpluginStatusHandler == nil {
+		unregistered = append(unregistered, "endpoint.PutEndpointExtpluginStatusHandler")
+	}
 	if o.EndpointPutEndpointProbeHandler == nil {
 		unregistered = append(unregistered, "endpoint.PutEndpointProbeHandler")
 	}
@@ -361,18 +417,38 @@ func (o *CceAPIAPI) initHandlerCache() {
 	if o.handlers["DELETE"] == nil {
 		o.handlers["DELETE"] = make(map[string]http.Handler)
 	}
+	o.handlers["DELETE"]["/networks"] = ipam.NewDeleteNetworks(o.context, o.IpamDeleteNetworksHandler)
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
 	o.handlers["DELETE"]["/rdmaipam/{rdmaips}"] = rdmaipam.NewDeleteRdmaipamRdmaips(o.context, o.RdmaipamDeleteRdmaipamRdmaipsHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/config"] = daemon.NewGetConfig(o.context, o.DaemonGetConfigHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/endpoint"] = endpoint.NewGetEndpoint(o.context, o.EndpointGetEndpointHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/endpoint/extplugin/status"] = endpoint.NewGetEndpointExtpluginStatus(o.context, o.EndpointGetEndpointExtpluginStatusHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/eni"] = eni.NewGetEni(o.context, o.EniGetEniHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/healthz"] = daemon.NewGetHealthz(o.context, o.DaemonGetHealthzHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/ipam"] = ipam.NewGetIpam(o.context, o.IpamGetIpamHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/metrics"] = metrics.NewGetMetrics(o.context, o.MetricsGetMetricsHandler)
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
@@ -389,10 +465,18 @@ func (o *CceAPIAPI) initHandlerCache() {
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
+	o.handlers["POST"]["/networks"] = ipam.NewPostNetworks(o.context, o.IpamPostNetworksHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
 	o.handlers["POST"]["/rdmaipam"] = rdmaipam.NewPostRdmaipam(o.context, o.RdmaipamPostRdmaipamHandler)
 	if o.handlers["PUT"] == nil {
 		o.handlers["PUT"] = make(map[string]http.Handler)
 	}
+	o.handlers["PUT"]["/endpoint/extplugin/status"] = endpoint.NewPutEndpointExtpluginStatus(o.context, o.EndpointPutEndpointExtpluginStatusHandler)
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
 	o.handlers["PUT"]["/endpoint/probe"] = endpoint.NewPutEndpointProbe(o.context, o.EndpointPutEndpointProbeHandler)
 }
 
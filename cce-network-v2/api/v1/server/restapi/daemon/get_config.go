@@ -0,0 +1,76 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// /*
+//  * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+//  *
+//  * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  * except in compliance with the License. You may obtain a copy of the License at
+//  *
+//  * http://www.apache.org/licenses/LICENSE-2.0
+//  *
+//  * Unless required by applicable law or agreed to in writing, software distributed under the
+//  * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  * either express or implied. See the License for the specific language governing permissions
+//  * and limitations under the License.
+//  *
+//  */
+
+package daemon
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// GetConfigHandlerFunc turns a function with the right signature into a get config handler
+type GetConfigHandlerFunc func(GetConfigParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn GetConfigHandlerFunc) Handle(params GetConfigParams) middleware.Responder {
+	return fn(params)
+}
+
+// GetConfigHandler interface for that can handle valid get config params
+type GetConfigHandler interface {
+	Handle(GetConfigParams) middleware.Responder
+}
+
+// NewGetConfig creates a new http.Handler for the get config operation
+func NewGetConfig(ctx *middleware.Context, handler GetConfigHandler) *GetConfig {
+	return &GetConfig{Context: ctx, Handler: handler}
+}
+
+/*
+GetConfig swagger:route GET /config daemon getConfig
+
+# Get configuration of CCE daemon
+
+Returns the configuration of the CCE daemon which is currently in
+effect, including the config overwritten by NetResourceConfigSet.
+*/
+type GetConfig struct {
+	Context *middleware.Context
+	Handler GetConfigHandler
+}
+
+func (o *GetConfig) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewGetConfigParams()
+
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
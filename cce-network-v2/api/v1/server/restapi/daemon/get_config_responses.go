@@ -0,0 +1,74 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// /*
+//  * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+//  *
+//  * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  * except in compliance with the License. You may obtain a copy of the License at
+//  *
+//  * http://www.apache.org/licenses/LICENSE-2.0
+//  *
+//  * Unless required by applicable law or agreed to in writing, software distributed under the
+//  * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  * either express or implied. See the License for the specific language governing permissions
+//  * and limitations under the License.
+//  *
+//  */
+
+package daemon
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
+)
+
+// GetConfigOKCode is the HTTP code returned for type GetConfigOK
+const GetConfigOKCode int = 200
+
+/*
+GetConfigOK Success
+
+swagger:response getConfigOK
+*/
+type GetConfigOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.DaemonConfigurationStatus `json:"body,omitempty"`
+}
+
+// NewGetConfigOK creates GetConfigOK with default headers values
+func NewGetConfigOK() *GetConfigOK {
+
+	return &GetConfigOK{}
+}
+
+// WithPayload adds the payload to the get config o k response
+func (o *GetConfigOK) WithPayload(payload *models.DaemonConfigurationStatus) *GetConfigOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get config o k response
+func (o *GetConfigOK) SetPayload(payload *models.DaemonConfigurationStatus) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetConfigOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
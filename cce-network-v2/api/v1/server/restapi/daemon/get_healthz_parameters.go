@@ -27,6 +27,7 @@ import (
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
 )
 
 // NewGetHealthzParams creates a new GetHealthzParams object
@@ -50,6 +51,13 @@ type GetHealthzParams struct {
 	  In: header
 	*/
 	Brief *bool
+
+	/*Lowest severity of the failed health checks which fails the
+	request. Only the critical health checks count if it is not set.
+
+	  In: header
+	*/
+	FailOn *string
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -65,6 +73,10 @@ func (o *GetHealthzParams) BindRequest(r *http.Request, route *middleware.Matche
 		res = append(res, err)
 	}
 
+	if err := o.bindFailOn(r.Header[http.CanonicalHeaderKey("fail-on")], true, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -92,3 +104,35 @@ func (o *GetHealthzParams) bindBrief(rawData []string, hasKey bool, formats strf
 
 	return nil
 }
+
+// bindFailOn binds and validates parameter FailOn from header.
+func (o *GetHealthzParams) bindFailOn(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.FailOn = &raw
+
+	if err := o.validateFailOn(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateFailOn carries on validations for parameter FailOn
+func (o *GetHealthzParams) validateFailOn(formats strfmt.Registry) error {
+
+	if err := validate.EnumCase("fail-on", "header", *o.FailOn, []interface{}{"Critical", "Degraded"}, true); err != nil {
+		return err
+	}
+
+	return nil
+}
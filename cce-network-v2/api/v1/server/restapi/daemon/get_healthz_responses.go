@@ -72,3 +72,48 @@ func (o *GetHealthzOK) WriteResponse(rw http.ResponseWriter, producer runtime.Pr
 		}
 	}
 }
+
+// GetHealthzFailureCode is the HTTP code returned for type GetHealthzFailure
+const GetHealthzFailureCode int = 500
+
+/*
+GetHealthzFailure Health checks of the requested severities failed
+
+swagger:response getHealthzFailure
+*/
+type GetHealthzFailure struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StatusResponse `json:"body,omitempty"`
+}
+
+// NewGetHealthzFailure creates GetHealthzFailure with default headers values
+func NewGetHealthzFailure() *GetHealthzFailure {
+
+	return &GetHealthzFailure{}
+}
+
+// WithPayload adds the payload to the get healthz failure response
+func (o *GetHealthzFailure) WithPayload(payload *models.StatusResponse) *GetHealthzFailure {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get healthz failure response
+func (o *GetHealthzFailure) SetPayload(payload *models.StatusResponse) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetHealthzFailure) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
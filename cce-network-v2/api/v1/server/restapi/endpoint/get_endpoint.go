@@ -0,0 +1,76 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// /*
+//  * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+//  *
+//  * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  * except in compliance with the License. You may obtain a copy of the License at
+//  *
+//  * http://www.apache.org/licenses/LICENSE-2.0
+//  *
+//  * Unless required by applicable law or agreed to in writing, software distributed under the
+//  * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  * either express or implied. See the License for the specific language governing permissions
+//  * and limitations under the License.
+//  *
+//  */
+
+package endpoint
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// GetEndpointHandlerFunc turns a function with the right signature into a get endpoint handler
+type GetEndpointHandlerFunc func(GetEndpointParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn GetEndpointHandlerFunc) Handle(params GetEndpointParams) middleware.Responder {
+	return fn(params)
+}
+
+// GetEndpointHandler interface for that can handle valid get endpoint params
+type GetEndpointHandler interface {
+	Handle(GetEndpointParams) middleware.Responder
+}
+
+// NewGetEndpoint creates a new http.Handler for the get endpoint operation
+func NewGetEndpoint(ctx *middleware.Context, handler GetEndpointHandler) *GetEndpoint {
+	return &GetEndpoint{Context: ctx, Handler: handler}
+}
+
+/*
+GetEndpoint swagger:route GET /endpoint endpoint getEndpoint
+
+# List endpoints on the local node
+
+Returns the CCEEndpoints on the local node known by the agent with the
+status of their external features.
+*/
+type GetEndpoint struct {
+	Context *middleware.Context
+	Handler GetEndpointHandler
+}
+
+func (o *GetEndpoint) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewGetEndpointParams()
+
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
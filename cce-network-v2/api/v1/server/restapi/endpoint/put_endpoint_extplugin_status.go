@@ -0,0 +1,76 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// /*
+//  * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+//  *
+//  * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  * except in compliance with the License. You may obtain a copy of the License at
+//  *
+//  * http://www.apache.org/licenses/LICENSE-2.0
+//  *
+//  * Unless required by applicable law or agreed to in writing, software distributed under the
+//  * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  * either express or implied. See the License for the specific language governing permissions
+//  * and limitations under the License.
+//  *
+//  */
+
+package endpoint
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// PutEndpointExtpluginStatusHandlerFunc turns a function with the right signature into a put endpoint extplugin status handler
+type PutEndpointExtpluginStatusHandlerFunc func(PutEndpointExtpluginStatusParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn PutEndpointExtpluginStatusHandlerFunc) Handle(params PutEndpointExtpluginStatusParams) middleware.Responder {
+	return fn(params)
+}
+
+// PutEndpointExtpluginStatusHandler interface for that can handle valid put endpoint extplugin status params
+type PutEndpointExtpluginStatusHandler interface {
+	Handle(PutEndpointExtpluginStatusParams) middleware.Responder
+}
+
+// NewPutEndpointExtpluginStatus creates a new http.Handler for the put endpoint extplugin status operation
+func NewPutEndpointExtpluginStatus(ctx *middleware.Context, handler PutEndpointExtpluginStatusHandler) *PutEndpointExtpluginStatus {
+	return &PutEndpointExtpluginStatus{Context: ctx, Handler: handler}
+}
+
+/*
+PutEndpointExtpluginStatus swagger:route PUT /endpoint/extplugin/status endpoint putEndpointExtpluginStatus
+
+update external plugin status
+
+Records the status of an external feature implemented by a CNI plugin
+on the endpoint of the container.
+*/
+type PutEndpointExtpluginStatus struct {
+	Context *middleware.Context
+	Handler PutEndpointExtpluginStatusHandler
+}
+
+func (o *PutEndpointExtpluginStatus) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewPutEndpointExtpluginStatusParams()
+
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
@@ -0,0 +1,76 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// /*
+//  * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+//  *
+//  * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  * except in compliance with the License. You may obtain a copy of the License at
+//  *
+//  * http://www.apache.org/licenses/LICENSE-2.0
+//  *
+//  * Unless required by applicable law or agreed to in writing, software distributed under the
+//  * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  * either express or implied. See the License for the specific language governing permissions
+//  * and limitations under the License.
+//  *
+//  */
+
+package eni
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// GetEniHandlerFunc turns a function with the right signature into a get eni handler
+type GetEniHandlerFunc func(GetEniParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn GetEniHandlerFunc) Handle(params GetEniParams) middleware.Responder {
+	return fn(params)
+}
+
+// GetEniHandler interface for that can handle valid get eni params
+type GetEniHandler interface {
+	Handle(GetEniParams) middleware.Responder
+}
+
+// NewGetEni creates a new http.Handler for the get eni operation
+func NewGetEni(ctx *middleware.Context, handler GetEniHandler) *GetEni {
+	return &GetEni{Context: ctx, Handler: handler}
+}
+
+/*
+GetEni swagger:route GET /eni eni getEni
+
+# List ENIs of the local node
+
+Returns the ENIs attached to the local node known by the agent with
+their links and route tables on the host.
+*/
+type GetEni struct {
+	Context *middleware.Context
+	Handler GetEniHandler
+}
+
+func (o *GetEni) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewGetEniParams()
+
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
@@ -0,0 +1,73 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// /*
+//  * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+//  *
+//  * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  * except in compliance with the License. You may obtain a copy of the License at
+//  *
+//  * http://www.apache.org/licenses/LICENSE-2.0
+//  *
+//  * Unless required by applicable law or agreed to in writing, software distributed under the
+//  * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  * either express or implied. See the License for the specific language governing permissions
+//  * and limitations under the License.
+//  *
+//  */
+
+package ipam
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// DeleteNetworksHandlerFunc turns a function with the right signature into a delete networks handler
+type DeleteNetworksHandlerFunc func(DeleteNetworksParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn DeleteNetworksHandlerFunc) Handle(params DeleteNetworksParams) middleware.Responder {
+	return fn(params)
+}
+
+// DeleteNetworksHandler interface for that can handle valid delete networks params
+type DeleteNetworksHandler interface {
+	Handle(DeleteNetworksParams) middleware.Responder
+}
+
+// NewDeleteNetworks creates a new http.Handler for the delete networks operation
+func NewDeleteNetworks(ctx *middleware.Context, handler DeleteNetworksHandler) *DeleteNetworks {
+	return &DeleteNetworks{Context: ctx, Handler: handler}
+}
+
+/*
+DeleteNetworks swagger:route DELETE /networks ipam deleteNetworks
+
+Release the IP addresses of the secondary interfaces of a pod
+*/
+type DeleteNetworks struct {
+	Context *middleware.Context
+	Handler DeleteNetworksHandler
+}
+
+func (o *DeleteNetworks) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewDeleteNetworksParams()
+
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
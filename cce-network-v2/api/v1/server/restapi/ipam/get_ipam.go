@@ -0,0 +1,76 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// /*
+//  * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+//  *
+//  * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  * except in compliance with the License. You may obtain a copy of the License at
+//  *
+//  * http://www.apache.org/licenses/LICENSE-2.0
+//  *
+//  * Unless required by applicable law or agreed to in writing, software distributed under the
+//  * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  * either express or implied. See the License for the specific language governing permissions
+//  * and limitations under the License.
+//  *
+//  */
+
+package ipam
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// GetIpamHandlerFunc turns a function with the right signature into a get ipam handler
+type GetIpamHandlerFunc func(GetIpamParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn GetIpamHandlerFunc) Handle(params GetIpamParams) middleware.Responder {
+	return fn(params)
+}
+
+// GetIpamHandler interface for that can handle valid get ipam params
+type GetIpamHandler interface {
+	Handle(GetIpamParams) middleware.Responder
+}
+
+// NewGetIpam creates a new http.Handler for the get ipam operation
+func NewGetIpam(ctx *middleware.Context, handler GetIpamHandler) *GetIpam {
+	return &GetIpam{Context: ctx, Handler: handler}
+}
+
+/*
+GetIpam swagger:route GET /ipam ipam getIpam
+
+# Get IP allocations of the agent
+
+Returns the IP addresses allocated by the agent with their owners and
+the running expiration timers.
+*/
+type GetIpam struct {
+	Context *middleware.Context
+	Handler GetIpamHandler
+}
+
+func (o *GetIpam) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewGetIpamParams()
+
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
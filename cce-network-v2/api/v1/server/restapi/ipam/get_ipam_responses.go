@@ -0,0 +1,74 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// /*
+//  * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+//  *
+//  * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  * except in compliance with the License. You may obtain a copy of the License at
+//  *
+//  * http://www.apache.org/licenses/LICENSE-2.0
+//  *
+//  * Unless required by applicable law or agreed to in writing, software distributed under the
+//  * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  * either express or implied. See the License for the specific language governing permissions
+//  * and limitations under the License.
+//  *
+//  */
+
+package ipam
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
+)
+
+// GetIpamOKCode is the HTTP code returned for type GetIpamOK
+const GetIpamOKCode int = 200
+
+/*
+GetIpamOK Success
+
+swagger:response getIpamOK
+*/
+type GetIpamOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.IPAMStatus `json:"body,omitempty"`
+}
+
+// NewGetIpamOK creates GetIpamOK with default headers values
+func NewGetIpamOK() *GetIpamOK {
+
+	return &GetIpamOK{}
+}
+
+// WithPayload adds the payload to the get ipam o k response
+func (o *GetIpamOK) WithPayload(payload *models.IPAMStatus) *GetIpamOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get ipam o k response
+func (o *GetIpamOK) SetPayload(payload *models.IPAMStatus) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetIpamOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// /*
+//  * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+//  *
+//  * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  * except in compliance with the License. You may obtain a copy of the License at
+//  *
+//  * http://www.apache.org/licenses/LICENSE-2.0
+//  *
+//  * Unless required by applicable law or agreed to in writing, software distributed under the
+//  * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  * either express or implied. See the License for the specific language governing permissions
+//  * and limitations under the License.
+//  *
+//  */
+
+package ipam
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// PostNetworksHandlerFunc turns a function with the right signature into a post networks handler
+type PostNetworksHandlerFunc func(PostNetworksParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn PostNetworksHandlerFunc) Handle(params PostNetworksParams) middleware.Responder {
+	return fn(params)
+}
+
+// PostNetworksHandler interface for that can handle valid post networks params
+type PostNetworksHandler interface {
+	Handle(PostNetworksParams) middleware.Responder
+}
+
+// NewPostNetworks creates a new http.Handler for the post networks operation
+func NewPostNetworks(ctx *middleware.Context, handler PostNetworksHandler) *PostNetworks {
+	return &PostNetworks{Context: ctx, Handler: handler}
+}
+
+/*
+PostNetworks swagger:route POST /networks ipam postNetworks
+
+Allocate the IP addresses of the secondary interfaces of a pod
+*/
+type PostNetworks struct {
+	Context *middleware.Context
+	Handler PostNetworksHandler
+}
+
+func (o *PostNetworks) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewPostNetworksParams()
+
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
@@ -27,8 +27,11 @@ const (
 	apiRequestPostIPAM           = "cni/ipam/post"
 	apiRequestDeleteIPAMIP       = "cni/ipam/deleteIP"
 	apiRequestPostIPAMIP         = "cni/ipam/postIP"
+	apiRequestPostNetworks       = "cni/ipam/postNetworks"
+	apiRequestDeleteNetworks     = "cni/ipam/deleteNetworks"
 	apiRequestGetExtPluginStatus = "cni/endpoint/getExtPluginStatus"
 	apiRequestPutEndpointProbe   = "cni/endpoint/probe"
+	apiRequestPutExtPluginStatus = "cni/endpoint/putExtPluginStatus"
 )
 
 var apiRateLimitDefaults = map[string]rate.APILimiterParameters{
@@ -72,6 +75,26 @@ var apiRateLimitDefaults = map[string]rate.APILimiterParameters{
 		SkipInitial:                 1,
 		MaxWaitDuration:             30 * time.Second,
 	},
+	// the secondary interfaces of a pod are allocated after its primary
+	// interface, so they share the same limits as the primary interface
+	apiRequestPostNetworks: {
+		AutoAdjust:                  true,
+		EstimatedProcessingDuration: time.Second,
+		RateLimit:                   10,
+		RateBurst:                   15,
+		ParallelRequests:            10,
+		SkipInitial:                 1,
+		MaxWaitDuration:             30 * time.Second,
+	},
+	apiRequestDeleteNetworks: {
+		AutoAdjust:                  true,
+		EstimatedProcessingDuration: 200 * time.Millisecond,
+		RateLimit:                   10,
+		RateBurst:                   15,
+		ParallelRequests:            10,
+		SkipInitial:                 1,
+		MaxWaitDuration:             30 * time.Second,
+	},
 	apiRequestGetExtPluginStatus: {
 		RateLimit:        10,
 		RateBurst:        10,
@@ -84,4 +107,10 @@ var apiRateLimitDefaults = map[string]rate.APILimiterParameters{
 		ParallelRequests: 100,
 		MaxWaitDuration:  200 * time.Second,
 	},
+	apiRequestPutExtPluginStatus: {
+		RateLimit:        100,
+		RateBurst:        100,
+		ParallelRequests: 100,
+		MaxWaitDuration:  30 * time.Second,
+	},
 }
@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+package cmd
+
+import (
+	"reflect"
+
+	"github.com/go-openapi/runtime/middleware"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/server/restapi/daemon"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/option"
+)
+
+type getConfig struct {
+}
+
+// NewGetConfigHandler creates a new getConfig handler.
+func NewGetConfigHandler() daemon.GetConfigHandler {
+	return &getConfig{}
+}
+
+// Handle implements daemon.GetConfigHandler. It returns all the exported
+// fields of option.Config, so the config overwritten by NRCS is also included.
+func (*getConfig) Handle(daemon.GetConfigParams) middleware.Responder {
+	m := make(map[string]interface{})
+	e := reflect.ValueOf(option.Config).Elem()
+	for i := 0; i < e.NumField(); i++ {
+		if e.Type().Field(i).IsExported() {
+			m[e.Type().Field(i).Name] = e.Field(i).Interface()
+		}
+	}
+	return daemon.NewGetConfigOK().WithPayload(&models.DaemonConfigurationStatus{
+		DaemonConfigurationMap: m,
+	})
+}
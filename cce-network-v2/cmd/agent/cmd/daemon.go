@@ -236,6 +236,7 @@ func NewDaemon(ctx context.Context, cancel context.CancelFunc) (*Daemon, error)
 	d.rdmaDiscovery.RegisterNrcsNodeGetter(d.k8sWatcher)
 
 	d.k8sWatcher.NodeChain.Register(d.nodeDiscovery)
+	d.k8sWatcher.RegisterNetResourceConfigSetSubscriber(d.nodeDiscovery)
 
 	bootstrapStats.daemonInit.End(true)
 
@@ -290,6 +291,14 @@ func NewDaemon(ctx context.Context, cancel context.CancelFunc) (*Daemon, error)
 
 		<-cachesSynced
 		bootstrapStats.k8sInit.End(true)
+
+		// agent wide config of nrcs must be applied before configuring IPAM and RDMA
+		d.nodeDiscovery.ApplyNrcsAgentConfig()
+		if option.Config.MTU != configuredMTU {
+			d.mtuConfig = mtu.NewConfiguration(0, false, false, false, option.Config.MTU, externalIP)
+			d.nodeDiscovery.LocalConfig.MtuConfig = d.mtuConfig
+			d.rdmaDiscovery.LocalConfig.MtuConfig = d.mtuConfig
+		}
 	}
 
 	// confugure and start ENIM
@@ -313,6 +322,10 @@ func NewDaemon(ctx context.Context, cancel context.CancelFunc) (*Daemon, error)
 	// Must occur after d.allocateIPs(), see GH-14245 and its fix.
 	d.nodeDiscovery.StartDiscovery()
 	d.rdmaDiscovery.StartDiscovery()
+	if k8s.IsEnabled() {
+		// apply the changes of nrcs after the NetResourceSet of local node has been created
+		d.nodeDiscovery.StartNrcsSync()
+	}
 
 	// Annotation of the k8s node must happen after discovery of the
 	// PodCIDR range and allocation of the health IPs.
@@ -355,6 +368,10 @@ func NewDaemon(ctx context.Context, cancel context.CancelFunc) (*Daemon, error)
 			ri.RestoreFinished()
 		}
 	}
+
+	// Must occur after the IPs restored, so that the health endpoint reuses
+	// the IPs published in the NetResourceSet.
+	d.initHealth()
 	return &d, nil
 }
 
@@ -50,6 +50,7 @@ import (
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/pidfile"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/pprof"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/sysctl"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/tracing"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/version"
 )
 
@@ -179,6 +180,9 @@ func initializeFlags() {
 	flags.Int(option.ClusterHealthPort, defaults.ClusterHealthPort, "TCP port for cluster-wide network connectivity health API")
 	option.BindEnv(option.ClusterHealthPort)
 
+	flags.Duration(option.ClusterHealthProbeInterval, defaults.ClusterHealthProbeInterval, "Interval between the connectivity probes of all nodes")
+	option.BindEnv(option.ClusterHealthProbeInterval)
+
 	flags.StringSlice(option.AgentLabels, []string{}, "Additional labels to identify this agent")
 	option.BindEnv(option.AgentLabels)
 
@@ -219,6 +223,9 @@ func initializeFlags() {
 	flags.Bool(option.EnableRDMAName, defaults.EnableRDMA, "Enable RDMA support")
 	option.BindEnv(option.EnableRDMAName)
 
+	flags.Bool(option.EnableMultiNetworkName, defaults.EnableMultiNetwork, "Enable the secondary interfaces of the pods requested by the network attachment annotation")
+	option.BindEnv(option.EnableMultiNetworkName)
+
 	flags.String(option.IPv6MCastDevice, "", "Device that joins a Solicited-Node multicast group for IPv6")
 	option.BindEnv(option.IPv6MCastDevice)
 
@@ -380,6 +387,30 @@ func initializeFlags() {
 	flags.Int(option.PluginIpRetryTimes, 3, "Number of retries for the plugin to request agent IPAM allocation")
 	option.BindEnv(option.PluginIpRetryTimes)
 
+	flags.String(option.PluginVerifyMode, defaults.PluginVerifyMode, "Mode of the connectivity verification of the pods after their network is set up, off, warn or enforce")
+	option.BindEnv(option.PluginVerifyMode)
+
+	flags.StringSlice(option.PluginVerifyTargets, []string{"gateway"}, "Targets pinged in the connectivity verification of the pods, gateway, node or IP addresses")
+	option.BindEnv(option.PluginVerifyTargets)
+
+	flags.Int(option.PluginVerifyRetries, defaults.PluginVerifyRetries, "Number of ICMP packets sent to each target in the connectivity verification")
+	option.BindEnv(option.PluginVerifyRetries)
+
+	flags.Duration(option.PluginVerifyTimeout, defaults.PluginVerifyTimeout, "Timeout of waiting for each ICMP reply in the connectivity verification")
+	option.BindEnv(option.PluginVerifyTimeout)
+
+	flags.Bool(option.EnableTracing, false, "Enable exporting the OpenTelemetry spans of the pod network allocation by the agent and the CNI plugins")
+	option.BindEnv(option.EnableTracing)
+
+	flags.String(option.TracingEndpoint, defaults.TracingEndpoint, "host:port of the OTLP/HTTP collector which receives the spans")
+	option.BindEnv(option.TracingEndpoint)
+
+	flags.Bool(option.TracingInsecure, true, "Export the spans over HTTP instead of HTTPS")
+	option.BindEnv(option.TracingInsecure)
+
+	flags.Float64(option.TracingSampleRatio, defaults.TracingSampleRatio, "Ratio of the pod network allocations traced, in [0, 1]")
+	option.BindEnv(option.TracingSampleRatio)
+
 	flags.String(option.ProcFs, "/proc", "Root's proc filesystem path")
 	option.BindEnv(option.ProcFs)
 
@@ -490,6 +521,12 @@ func initializeFlags() {
 	flags.String(option.ENIUseMode, string(ccev2.ENIUseModeSecondaryIP), "eni use mode")
 	option.BindEnv(option.ENIUseMode)
 
+	flags.String(option.DatapathMode, defaults.DatapathMode, "datapath mode of the pods using the secondary IPs of the ENIs, veth or ipvlan")
+	option.BindEnv(option.DatapathMode)
+
+	flags.String(option.IpvlanMode, defaults.IpvlanMode, "mode of the ipvlan slaves in the ipvlan datapath, l2 or l3")
+	option.BindEnv(option.IpvlanMode)
+
 	flags.Bool(option.ENIInstallSourceBasedRouting, true, "install source based routing to eni device")
 	option.BindEnv(option.ENIInstallSourceBasedRouting)
 
@@ -581,6 +618,12 @@ func initEnv(cmd *cobra.Command) {
 		pprof.Enable(option.Config.PProfPort)
 	}
 
+	shutdownTracing, err := tracing.Init(components.CCEAgentName, option.Config.TracingConfig())
+	if err != nil {
+		log.WithError(err).Fatal("Unable to initialize tracing")
+	}
+	cleaner.cleanupFuncs.Add(shutdownTracing)
+
 	scopedLog := log.WithFields(logrus.Fields{
 		logfields.Path + ".RunDir": option.Config.RunDir,
 	})
@@ -771,16 +814,21 @@ func (d *Daemon) instantiateAPI() *restapi.CceAPIAPI {
 
 	restAPI.Logger = log.Infof
 
-	restAPI.DaemonGetHealthzHandler = &daemonHealth{}
+	restAPI.DaemonGetHealthzHandler = &daemonHealth{d}
+	restAPI.DaemonGetConfigHandler = NewGetConfigHandler()
 
 	// /ipam/{ip}/
+	restAPI.IpamGetIpamHandler = NewGetIPAMHandler(d)
 	restAPI.IpamPostIpamHandler = NewPostIPAMHandler(d)
 	restAPI.IpamPostIpamIPHandler = NewPostIPAMIPHandler(d)
 	restAPI.IpamDeleteIpamIPHandler = NewDeleteIPAMIPHandler(d)
+	restAPI.IpamPostNetworksHandler = NewPostNetworksHandler(d)
+	restAPI.IpamDeleteNetworksHandler = NewDeleteNetworksHandler(d)
 	restAPI.RdmaipamPostRdmaipamHandler = NewPostRDMAIPAMHandler(d)
 	restAPI.RdmaipamDeleteRdmaipamRdmaipsHandler = NewDeleteRDMAIPAMIPHandler(d)
 
 	// /eni
+	restAPI.EniGetEniHandler = NewGetENIHandler(d)
 	restAPI.EniPostEniHandler = NewPostEniHandler(d)
 	restAPI.EniDeleteEniHandler = NewDeleteENIHandler(d)
 
@@ -788,7 +836,9 @@ func (d *Daemon) instantiateAPI() *restapi.CceAPIAPI {
 	restAPI.MetricsGetMetricsHandler = NewGetMetricsHandler(d)
 
 	// endpoints
+	restAPI.EndpointGetEndpointHandler = NewGetEndpointHandler(d)
 	restAPI.EndpointGetEndpointExtpluginStatusHandler = NewGetEndpointExtpluginStatusHandler(d)
 	restAPI.EndpointPutEndpointProbeHandler = NewPutEndpointProbeHandler(d)
+	restAPI.EndpointPutEndpointExtpluginStatusHandler = NewPutEndpointExtpluginStatusHandler(d)
 	return restAPI
 }
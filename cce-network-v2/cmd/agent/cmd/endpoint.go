@@ -18,7 +18,9 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
 	endpointapi "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/server/restapi/endpoint"
@@ -26,6 +28,7 @@ import (
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/datapath/qos"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/defaults"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/endpoint"
+	ccev2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging/logfields"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/rate"
 	"github.com/go-openapi/runtime/middleware"
@@ -90,6 +93,68 @@ func NewGetEndpointExtpluginStatusHandler(d *Daemon) endpointapi.GetEndpointExtp
 	return &getEndpointExtpluginStatus{daemon: d}
 }
 
+type putEndpointExtpluginStatus struct {
+	daemon *Daemon
+}
+
+// Handle implements endpoint.PutEndpointExtpluginStatusHandler
+func (handler *putEndpointExtpluginStatus) Handle(param endpointapi.PutEndpointExtpluginStatusParams) middleware.Responder {
+	var (
+		err error
+		ctx = context.Background()
+
+		// tmp variable
+		limit       rate.LimitedRequest
+		containerID = swag.StringValue(param.ContainerID)
+		scopeLog    = endpointLog.WithFields(logrus.Fields{
+			"method":      "PutEndpointExtpluginStatus",
+			"containerID": containerID,
+			"feature":     param.Feature,
+			"owner":       swag.StringValue(param.Owner),
+		})
+	)
+
+	owner := strings.Split(swag.StringValue(param.Owner), "/")
+	if len(owner) != 2 {
+		err = fmt.Errorf("invalid owner parameter")
+		return endpointapi.NewPutEndpointExtpluginStatusFailure().WithPayload(models.Error(err.Error()))
+	}
+	if param.Status == nil {
+		err = fmt.Errorf("status of feature %s is missing", param.Feature)
+		return endpointapi.NewPutEndpointExtpluginStatusFailure().WithPayload(models.Error(err.Error()))
+	}
+	namespace := owner[0]
+	name := owner[1]
+
+	// api rate limit
+	ctx, cancel := context.WithTimeout(ctx, defaults.ClientConnectTimeout)
+	defer cancel()
+	limit, err = handler.daemon.apiLimiterSet.Wait(ctx, apiRequestPutExtPluginStatus)
+	if err != nil {
+		return endpointapi.NewPutEndpointExtpluginStatusFailure().WithPayload(models.Error(err.Error()))
+	}
+	defer func() {
+		limit.Error(err)
+		scopeLog = scopeLog.WithField("status", logfields.Repr(param.Status))
+		if err != nil {
+			scopeLog.WithError(err).Error("failed to handler api")
+		} else {
+			scopeLog.Info("success to handler api")
+		}
+	}()
+
+	err = handler.daemon.endpointAPIHandler.PutEndpointExtpluginStatus(ctx, namespace, name, containerID, param.Feature, param.Status)
+	if err != nil {
+		return endpointapi.NewPutEndpointExtpluginStatusFailure().WithPayload(models.Error(err.Error()))
+	}
+	return endpointapi.NewPutEndpointExtpluginStatusOK()
+}
+
+// NewPutEndpointExtpluginStatusHandler creates a new putEndpointExtpluginStatus from the daemon.
+func NewPutEndpointExtpluginStatusHandler(d *Daemon) endpointapi.PutEndpointExtpluginStatusHandler {
+	return &putEndpointExtpluginStatus{daemon: d}
+}
+
 type putEndpointProbe struct {
 	daemon *Daemon
 }
@@ -151,8 +216,71 @@ func NewPutEndpointProbeHandler(d *Daemon) endpointapi.PutEndpointProbeHandler {
 	return &putEndpointProbe{daemon: d}
 }
 
+type getEndpoint struct {
+	daemon *Daemon
+}
+
+// NewGetEndpointHandler creates a new getEndpoint from the daemon.
+func NewGetEndpointHandler(d *Daemon) endpointapi.GetEndpointHandler {
+	return &getEndpoint{daemon: d}
+}
+
+// Handle returns the endpoints on the local node known by the daemon.
+func (handler *getEndpoint) Handle(param endpointapi.GetEndpointParams) middleware.Responder {
+	ceps, err := handler.daemon.k8sWatcher.NewCCEEndpointClient().List()
+	if err != nil {
+		return endpointapi.NewGetEndpointFailure().WithPayload(models.Error(err.Error()))
+	}
+
+	result := make([]*models.Endpoint, 0, len(ceps))
+	for _, cep := range ceps {
+		result = append(result, endpointModel(cep))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Name < result[j].Name
+	})
+	return endpointapi.NewGetEndpointOK().WithPayload(result)
+}
+
+func endpointModel(cep *ccev2.CCEEndpoint) *models.Endpoint {
+	result := &models.Endpoint{
+		Namespace:       cep.Namespace,
+		Name:            cep.Name,
+		State:           cep.Status.State,
+		Identifiers:     cep.Spec.ExternalIdentifiers,
+		ExtFeatureGates: cep.Spec.ExtFeatureGates,
+	}
+	if cep.Status.Networking != nil {
+		for _, addr := range cep.Status.Networking.Addressing {
+			result.Ips = append(result.Ips, addr.IP)
+		}
+	}
+	if len(cep.Status.ExtFeatureStatus) > 0 {
+		result.ExtFeatureStatus = make(map[string]models.ExtFeatureStatus, len(cep.Status.ExtFeatureStatus))
+	}
+	for feature, status := range cep.Status.ExtFeatureStatus {
+		if status == nil {
+			continue
+		}
+		featureStatus := models.ExtFeatureStatus{
+			Ready:       status.Ready,
+			ContainerID: status.ContainerID,
+			Msg:         status.Msg,
+		}
+		if status.UpdateTime != nil {
+			featureStatus.UpdateTime = status.UpdateTime.Format(time.RFC3339)
+		}
+		result.ExtFeatureStatus[feature] = featureStatus
+	}
+	return result
+}
+
 func (d *Daemon) startEndpointHanler() {
 	d.endpointAPIHandler = endpoint.NewEndpointAPIHandler(d.k8sWatcher)
 	bandwidth.InitBandwidthManager()
+	bandwidth.GlobalManager.Start(d.k8sWatcher.NewCCEEndpointClient())
 	qos.InitEgressPriorityManager()
 }
@@ -17,6 +17,7 @@ package cmd
 
 import (
 	"context"
+	"sort"
 
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/defaults"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/enim"
@@ -27,6 +28,8 @@ import (
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
 	eniapi "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/server/restapi/eni"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/api"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/bce/agent"
+	ccev2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging/logfields"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/node"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/option"
@@ -142,6 +145,57 @@ func (h *deleteENI) Handle(params eniapi.DeleteEniParams) middleware.Responder {
 	return eniapi.NewDeleteEniOK()
 }
 
+type getENI struct {
+	daemon *Daemon
+}
+
+// NewGetENIHandler creates a new getENI from the daemon.
+func NewGetENIHandler(d *Daemon) eniapi.GetEniHandler {
+	return &getENI{daemon: d}
+}
+
+// Handle returns the ENIs of the local node known by the daemon.
+func (h *getENI) Handle(params eniapi.GetEniParams) middleware.Responder {
+	enis, err := h.daemon.k8sWatcher.NewENIClient().List()
+	if err != nil {
+		return eniapi.NewGetEniFailure().WithPayload(models.Error(err.Error()))
+	}
+
+	result := make([]*models.LocalENI, 0, len(enis))
+	for _, eni := range enis {
+		result = append(result, localENIModel(eni))
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return eniapi.NewGetEniOK().WithPayload(result)
+}
+
+func localENIModel(eni *ccev2.ENI) *models.LocalENI {
+	result := &models.LocalENI{
+		ID:          eni.Spec.ENI.ID,
+		Name:        eni.Name,
+		MacAddress:  eni.Spec.ENI.MacAddress,
+		UseMode:     string(eni.Spec.UseMode),
+		CceStatus:   string(eni.Status.CCEStatus),
+		VpcStatus:   string(eni.Status.VPCStatus),
+		LinkIndex:   int64(eni.Status.InterfaceIndex),
+		LinkName:    eni.Status.InterfaceName,
+		EniIndex:    int64(eni.Status.ENIIndex),
+		RouteTable:  int64(agent.RouteTableID(eni)),
+		GatewayIPV4: eni.Status.GatewayIPv4,
+		GatewayIPV6: eni.Status.GatewayIPv6,
+	}
+	for _, privateIP := range eni.Spec.ENI.PrivateIPSet {
+		result.IPV4 = append(result.IPV4, privateIP.PrivateIPAddress)
+	}
+	for _, privateIP := range eni.Spec.ENI.IPV6PrivateIPSet {
+		result.IPV6 = append(result.IPV6, privateIP.PrivateIPAddress)
+	}
+	if ref := eni.Status.EndpointReference; ref != nil {
+		result.Endpoint = ref.Namespace + "/" + ref.Name
+	}
+	return result
+}
+
 func (d *Daemon) configureENIM() {
 
 }
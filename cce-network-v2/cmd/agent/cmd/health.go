@@ -15,26 +15,137 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/server/restapi/daemon"
-	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/api"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/controller"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/defaults"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/health/launch"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/health/plugin"
+	healthServer "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/health/server"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging"
+	nodeTypes "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/node/types"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/option"
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
 )
 
+const healthEndpointControllerName = "health-endpoint"
+
 var healthLog = logging.NewSubysLogger("daemon-health")
 
 type daemonHealth struct {
+	d *Daemon
 }
 
-// Handle implements daemon.GetHealthzHandler
-func (*daemonHealth) Handle(daemon.GetHealthzParams) middleware.Responder {
-	manager := plugin.GlobalHealthManager()
-	if err := manager.Check(); err != nil {
-		healthLog.WithError(err).Error("fail to health check manager")
-		return api.Error(500, err)
+// Handle implements daemon.GetHealthzHandler, the request fails if a health
+// check whose severity is covered by the fail-on header failed
+func (h *daemonHealth) Handle(params daemon.GetHealthzParams) middleware.Responder {
+	brief := params.Brief != nil && *params.Brief
+	failOn := plugin.SeverityCritical
+	if params.FailOn != nil {
+		failOn = plugin.Severity(*params.FailOn)
+	}
+
+	sr := h.d.getStatus(brief)
+	if failed := failedHealthChecks(sr.HealthChecks, failOn); len(failed) > 0 {
+		healthLog.WithField("checks", failed).Error("health checks failed")
+		return daemon.NewGetHealthzFailure().WithPayload(&sr)
 	}
-	return daemon.NewGetHealthzOK()
+	return daemon.NewGetHealthzOK().WithPayload(&sr)
 }
 
 var _ daemon.GetHealthzHandler = &daemonHealth{}
+
+// initHealth starts the cluster connectivity health server and the health
+// endpoint of the node
+func (d *Daemon) initHealth() {
+	if option.Config.HealthEndpointEnabled() {
+		var ep *launch.Endpoint
+		d.controllers.UpdateController(healthEndpointControllerName, controller.ControllerParams{
+			RunInterval: time.Minute,
+			DoFunc: func(ctx context.Context) error {
+				if ep != nil && ep.Alive() {
+					return nil
+				}
+				ipv4, ipv6, err := d.ipam.AllocateHealthIPs()
+				if err != nil {
+					return err
+				}
+				ep, err = launch.LaunchAsEndpoint(ipv4, ipv6, option.Config.ClusterHealthPort, d.mtuConfig.GetDeviceMTU())
+				if err != nil {
+					return fmt.Errorf("failed to launch health endpoint: %w", err)
+				}
+				return d.nodeDiscovery.UpdateHealthAddressing()
+			},
+			StopFunc: func(ctx context.Context) error {
+				if ep != nil {
+					ep.Stop()
+				}
+				return nil
+			},
+		})
+	} else {
+		launch.CleanupEndpoint()
+	}
+
+	if !option.Config.EnableHealthChecking {
+		return
+	}
+	s := healthServer.NewServer(healthServer.Config{
+		ClusterID:     option.Config.ClusterID,
+		LocalNodeName: nodeTypes.GetName(),
+		HTTPPort:      option.Config.ClusterHealthPort,
+		SocketPath:    defaults.HealthSockPath,
+		ProbeInterval: option.Config.ClusterHealthProbeInterval,
+		DaemonStatus: func() models.StatusResponse {
+			return d.getStatus(true)
+		},
+	}, d.ctx.Done())
+	go func() {
+		if err := s.Serve(d.ctx); err != nil {
+			healthLog.WithError(err).Error("health server stopped")
+		}
+	}()
+}
+
+// healthCheckStatuses converts the results of the health checks to the models
+// of the API
+func healthCheckStatuses(results []plugin.CheckResult) []*models.HealthCheckStatus {
+	statuses := make([]*models.HealthCheckStatus, 0, len(results))
+	for _, r := range results {
+		hc := &models.HealthCheckStatus{
+			Name:     r.Name,
+			Severity: string(r.Severity),
+			Healthy:  r.Healthy,
+			Msg:      r.Message,
+			Duration: r.Duration.Nanoseconds(),
+		}
+		if !r.LastCheck.IsZero() {
+			hc.LastCheckTimestamp = strfmt.DateTime(r.LastCheck)
+		}
+		if !r.LastSuccess.IsZero() {
+			hc.LastSuccessTimestamp = strfmt.DateTime(r.LastSuccess)
+		}
+		statuses = append(statuses, hc)
+	}
+	return statuses
+}
+
+// failedHealthChecks returns the names of the failed health checks whose
+// severity is covered by failOn. The checks which never ran are not failed.
+func failedHealthChecks(checks []*models.HealthCheckStatus, failOn plugin.Severity) []string {
+	var failed []string
+	for _, hc := range checks {
+		if hc.Healthy || time.Time(hc.LastCheckTimestamp).IsZero() {
+			continue
+		}
+		if plugin.Severity(hc.Severity).Covers(failOn) {
+			failed = append(failed, hc.Name)
+		}
+	}
+	return failed
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/swag"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
 	ipamapi "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/server/restapi/ipam"
@@ -33,6 +34,7 @@ import (
 	nodeTypes "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/node/types"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/option"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/rate"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/tracing"
 )
 
 var (
@@ -62,10 +64,17 @@ func (h *postIPAM) Handle(params ipamapi.PostIpamParams) middleware.Responder {
 		scopeLog    = ipamLog.WithField("owner", owner).WithField("containerID", containerID).WithField("netns", netns)
 	)
 
+	// the span is the child of the span of cipam
+	ctx, span := tracing.Start(tracing.FromHTTPHeader(context.Background(), params.HTTPRequest.Header), "agent/ipam-add",
+		attribute.String("owner", owner), attribute.String("containerID", containerID))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
 	// api rate limit
-	ctx, cancel := context.WithTimeout(context.Background(), defaults.ClientConnectTimeout)
+	limitCtx, cancel := context.WithTimeout(ctx, defaults.ClientConnectTimeout)
 	defer cancel()
-	limit, err = h.daemon.apiLimiterSet.Wait(ctx, apiRequestPostIPAM)
+	limit, err = h.daemon.apiLimiterSet.Wait(limitCtx, apiRequestPostIPAM)
 	if err != nil {
 		return api.Error(ipamapi.PostIpamFailureCode, err)
 	}
@@ -78,7 +87,7 @@ func (h *postIPAM) Handle(params ipamapi.PostIpamParams) middleware.Responder {
 		}
 	}()
 
-	ipv4Result, ipv6Result, err := h.daemon.ipam.ADD(family, owner, containerID, netns)
+	ipv4Result, ipv6Result, err := h.daemon.ipam.ADD(ctx, family, owner, containerID, netns)
 	if err != nil {
 		scopeLog.WithError(err).Error("allocate next ip error")
 		return api.Error(ipamapi.PostIpamFailureCode, err)
@@ -174,12 +183,27 @@ func (h *deleteIPAMIP) Handle(params ipamapi.DeleteIpamIPParams) middleware.Resp
 	return ipamapi.NewDeleteIpamIPOK()
 }
 
+type getIPAM struct {
+	daemon *Daemon
+}
+
+// NewGetIPAMHandler creates a new getIPAM from the daemon.
+func NewGetIPAMHandler(d *Daemon) ipamapi.GetIpamHandler {
+	return &getIPAM{daemon: d}
+}
+
+// Handle returns the IP addresses allocated by the daemon.
+func (h *getIPAM) Handle(params ipamapi.GetIpamParams) middleware.Responder {
+	return ipamapi.NewGetIpamOK().WithPayload(h.daemon.DumpIPAM())
+}
+
 // DumpIPAM dumps in the form of a map, the list of
 // reserved IPv4 and IPv6 addresses.
 func (d *Daemon) DumpIPAM() *models.IPAMStatus {
 	allocv4, allocv6, st := d.ipam.Dump()
 	status := &models.IPAMStatus{
-		Status: st,
+		Status:           st,
+		ExpirationTimers: d.ipam.ExpirationTimers(),
 	}
 
 	v4 := make([]string, 0, len(allocv4))
@@ -0,0 +1,140 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/swag"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
+	ipamapi "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/server/restapi/ipam"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/api"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/defaults"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/ipam"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging/logfields"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/rate"
+)
+
+type postNetworks struct {
+	daemon *Daemon
+}
+
+// NewPostNetworksHandler creates a new postNetworks from the daemon.
+func NewPostNetworksHandler(d *Daemon) ipamapi.PostNetworksHandler {
+	return &postNetworks{daemon: d}
+}
+
+// Handle allocates the IPs of the secondary interfaces of the pod.
+func (h *postNetworks) Handle(params ipamapi.PostNetworksParams) middleware.Responder {
+	var (
+		err   error
+		limit rate.LimitedRequest
+
+		owner       = swag.StringValue(params.Owner)
+		containerID = swag.StringValue(params.ContainerID)
+		netns       = swag.StringValue(params.Netns)
+		resp        []*models.NetworkAttachmentResponse
+		scopeLog    = ipamLog.WithField("owner", owner).WithField("containerID", containerID).WithField("netns", netns)
+	)
+
+	// api rate limit
+	ctx, cancel := context.WithTimeout(context.Background(), defaults.ClientConnectTimeout)
+	defer cancel()
+	limit, err = h.daemon.apiLimiterSet.Wait(ctx, apiRequestPostNetworks)
+	if err != nil {
+		return api.Error(ipamapi.PostNetworksFailureCode, err)
+	}
+	defer func() {
+		limit.Error(err)
+		if err != nil {
+			scopeLog.WithError(err).Error("allocate secondary interfaces error")
+		} else {
+			scopeLog.WithField("response", logfields.Repr(resp)).Info("allocate secondary interfaces success")
+		}
+	}()
+
+	results, err := h.daemon.ipam.ADDNetworks(owner, containerID, netns)
+	if err != nil {
+		return api.Error(ipamapi.PostNetworksFailureCode, err)
+	}
+	for _, result := range results {
+		resp = append(resp, &models.NetworkAttachmentResponse{
+			Interface: result.Interface,
+			IPV4:      toIPAMAddressResponse(result.IPv4),
+			IPV6:      toIPAMAddressResponse(result.IPv6),
+			Routes:    result.Routes,
+		})
+	}
+	return ipamapi.NewPostNetworksCreated().WithPayload(resp)
+}
+
+func toIPAMAddressResponse(result *ipam.AllocationResult) *models.IPAMAddressResponse {
+	if result == nil {
+		return nil
+	}
+	return &models.IPAMAddressResponse{
+		Cidrs:           result.CIDRs,
+		IP:              result.IP.String(),
+		MasterMac:       result.PrimaryMAC,
+		Gateway:         result.GatewayIP,
+		ExpirationUUID:  result.ExpirationUUID,
+		InterfaceNumber: result.InterfaceNumber,
+	}
+}
+
+type deleteNetworks struct {
+	daemon *Daemon
+}
+
+// NewDeleteNetworksHandler creates a new deleteNetworks from the daemon.
+func NewDeleteNetworksHandler(d *Daemon) ipamapi.DeleteNetworksHandler {
+	return &deleteNetworks{daemon: d}
+}
+
+// Handle releases the IPs of the secondary interfaces of the pod.
+func (h *deleteNetworks) Handle(params ipamapi.DeleteNetworksParams) middleware.Responder {
+	var (
+		err   error
+		limit rate.LimitedRequest
+
+		owner       = swag.StringValue(params.Owner)
+		containerID = swag.StringValue(params.ContainerID)
+	)
+
+	// api rate limit
+	ctx, cancel := context.WithTimeout(context.Background(), defaults.ClientConnectTimeout)
+	defer cancel()
+	limit, err = h.daemon.apiLimiterSet.Wait(ctx, apiRequestDeleteNetworks)
+	if err != nil {
+		return api.Error(ipamapi.DeleteNetworksFailureCode, err)
+	}
+	defer func() {
+		limit.Error(err)
+		scopeLog := ipamLog.WithField("owner", owner).WithField("containerID", containerID)
+		if err != nil {
+			scopeLog.WithError(err).Error("release secondary interfaces error")
+		} else {
+			scopeLog.Info("release secondary interfaces success")
+		}
+	}()
+
+	if err = h.daemon.ipam.DELNetworks(owner, containerID); err != nil {
+		return api.Error(ipamapi.DeleteNetworksFailureCode, err)
+	}
+	return ipamapi.NewDeleteNetworksOK()
+}
@@ -37,6 +37,7 @@ import (
 	nodeTypes "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/node/types"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/option"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/rate"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/tracing"
 )
 
 var (
@@ -85,9 +86,11 @@ func (h *postRDMAIPAM) Handle(params rdmaipamapi.PostRdmaipamParams) middleware.
 		}
 	}()
 
+	// the endpoints are traced as the children of the span of the plugin
+	traceCtx := tracing.FromHTTPHeader(context.Background(), params.HTTPRequest.Header)
 	for masterMac, ri := range h.daemon.rdmaIpam {
 		var ipv4Result, ipv6Result *ipam.AllocationResult
-		ipv4Result, ipv6Result, err = ri.ADD(family, owner, containerID, netns)
+		ipv4Result, ipv6Result, err = ri.ADD(traceCtx, family, owner, containerID, netns)
 		if err != nil {
 			scopeLog.WithError(err).Error("allocate next rdma ip error")
 			allErr = append(allErr, err)
@@ -216,7 +219,8 @@ func (d *Daemon) DumpRDMAIPAM() (statusMap map[string]*models.IPAMStatus) {
 	for key, ri := range d.rdmaIpam {
 		allocv4, allocv6, st := ri.Dump()
 		status := &models.IPAMStatus{
-			Status: st,
+			Status:           st,
+			ExpirationTimers: ri.ExpirationTimers(),
 		}
 
 		v4 := make([]string, 0, len(allocv4))
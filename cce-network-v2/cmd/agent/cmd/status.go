@@ -19,6 +19,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/datapath"
@@ -29,6 +30,7 @@ import (
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/backoff"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/controller"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/health/plugin"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s"
 	k8smetrics "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/metrics"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/lock"
@@ -242,8 +244,16 @@ func (d *Daemon) getStatus(brief bool) models.StatusResponse {
 				}
 			}
 		}
+		// With brief, only the failed health checks are returned
+		var minimalHealthChecks []*models.HealthCheckStatus
+		for _, hc := range d.statusResponse.HealthChecks {
+			if !hc.Healthy {
+				minimalHealthChecks = append(minimalHealthChecks, hc.DeepCopy())
+			}
+		}
 		sr = models.StatusResponse{
-			Controllers: minimalControllers,
+			Controllers:  minimalControllers,
+			HealthChecks: minimalHealthChecks,
 		}
 	} else {
 		// d.statusResponse contains references, so we do a deep copy to be able to
@@ -257,6 +267,9 @@ func (d *Daemon) getStatus(brief bool) models.StatusResponse {
 	ver := version.GetCCEVersion()
 	cceVer := fmt.Sprintf("%s (v%s-%s)", ver.Version, ver.Version, ver.Revision)
 
+	failedCritical := failedHealthChecks(d.statusResponse.HealthChecks, plugin.SeverityCritical)
+	failedDegraded := failedHealthChecks(d.statusResponse.HealthChecks, plugin.SeverityDegraded)
+
 	switch {
 	case len(sr.Stale) > 0:
 		msg := "Stale status data"
@@ -273,6 +286,16 @@ func (d *Daemon) getStatus(brief bool) models.StatusResponse {
 			State: d.statusResponse.ContainerRuntime.State,
 			Msg:   fmt.Sprintf("%s    %s", cceVer, msg),
 		}
+	case len(failedCritical) > 0:
+		sr.Cce = &models.Status{
+			State: models.StatusStateFailure,
+			Msg:   fmt.Sprintf("%s    Health checks failed: %s", cceVer, strings.Join(failedCritical, ", ")),
+		}
+	case len(failedDegraded) > 0:
+		sr.Cce = &models.Status{
+			State: models.StatusStateWarning,
+			Msg:   fmt.Sprintf("%s    Health checks degraded: %s", cceVer, strings.Join(failedDegraded, ", ")),
+		}
 
 	default:
 		sr.Cce = &models.Status{
@@ -355,6 +378,22 @@ func (d *Daemon) startStatusCollector() {
 				}
 			},
 		},
+		{
+			Name: "health-checks",
+			Probe: func(ctx context.Context) (interface{}, error) {
+				return plugin.GlobalHealthManager().Run(ctx), nil
+			},
+			OnStatusUpdate: func(status status.Status) {
+				d.statusCollectMutex.Lock()
+				defer d.statusCollectMutex.Unlock()
+
+				if status.Err == nil {
+					if results, ok := status.Data.([]plugin.CheckResult); ok {
+						d.statusResponse.HealthChecks = healthCheckStatuses(results)
+					}
+				}
+			},
+		},
 	}
 
 	d.statusCollector = status.NewCollector(probes, status.Config{})
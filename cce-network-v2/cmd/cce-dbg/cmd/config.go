@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/command"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Display the configuration of the agent which is in effect",
+	Run: func(cmd *cobra.Command, args []string) {
+		config, err := client.ConfigGet()
+		if err != nil {
+			Fatalf("Cannot get config: %s", err)
+		}
+
+		if command.OutputOption() {
+			if err := command.PrintOutput(config.DaemonConfigurationMap); err != nil {
+				os.Exit(1)
+			}
+			return
+		}
+
+		keys := make([]string, 0, len(config.DaemonConfigurationMap))
+		for key := range config.DaemonConfigurationMap {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		w := tabwriter.NewWriter(os.Stdout, 5, 0, 3, ' ', 0)
+		for _, key := range keys {
+			fmt.Fprintf(w, "%s\t%v\n", key, config.DaemonConfigurationMap[key])
+		}
+		w.Flush()
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(configCmd)
+	command.AddOutputOption(configCmd)
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/command"
+)
+
+var endpointCmd = &cobra.Command{
+	Use:     "endpoint",
+	Aliases: []string{"ep"},
+	Short:   "Access CCEEndpoints on the local node",
+}
+
+var endpointListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List CCEEndpoints on the local node",
+	Run: func(cmd *cobra.Command, args []string) {
+		endpoints, err := client.EndpointList()
+		if err != nil {
+			Fatalf("Cannot get endpoint list: %s", err)
+		}
+
+		if command.OutputOption() {
+			if err := command.PrintOutput(endpoints); err != nil {
+				os.Exit(1)
+			}
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 5, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "NAMESPACE\tNAME\tSTATE\tCONTAINER ID\tIPS")
+		for _, ep := range endpoints {
+			containerID := ""
+			if ep.Identifiers != nil {
+				containerID = ep.Identifiers.ContainerID
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", ep.Namespace, ep.Name, ep.State, containerID, strings.Join(ep.Ips, ","))
+		}
+		w.Flush()
+	},
+}
+
+var endpointGetCmd = &cobra.Command{
+	Use:   "get <namespace>/<name>",
+	Short: "Display the CCEEndpoint on the local node",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		endpoints, err := client.EndpointList()
+		if err != nil {
+			Fatalf("Cannot get endpoint list: %s", err)
+		}
+		ep := findEndpoint(endpoints, args[0])
+		if ep == nil {
+			Fatalf("Endpoint %s not found on the node", args[0])
+		}
+
+		// print json by default as the endpoint has nested fields
+		if command.OutputOption() {
+			err = command.PrintOutput(ep)
+		} else {
+			err = command.PrintOutputWithType(ep, "json")
+		}
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+// findEndpoint returns the endpoint with the key namespace/name, the namespace
+// default is used if the key has no namespace.
+func findEndpoint(endpoints []*models.Endpoint, key string) *models.Endpoint {
+	if !strings.Contains(key, "/") {
+		key = "default/" + key
+	}
+	for _, ep := range endpoints {
+		if ep.Namespace+"/"+ep.Name == key {
+			return ep
+		}
+	}
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(endpointCmd)
+	endpointCmd.AddCommand(endpointListCmd)
+	endpointCmd.AddCommand(endpointGetCmd)
+	command.AddOutputOption(endpointListCmd)
+	command.AddOutputOption(endpointGetCmd)
+}
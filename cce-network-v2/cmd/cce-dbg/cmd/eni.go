@@ -0,0 +1,65 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/command"
+)
+
+var eniCmd = &cobra.Command{
+	Use:   "eni",
+	Short: "Access ENIs of the local node",
+}
+
+var eniListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List ENIs of the local node",
+	Run: func(cmd *cobra.Command, args []string) {
+		enis, err := client.ENIList()
+		if err != nil {
+			Fatalf("Cannot get eni list: %s", err)
+		}
+
+		if command.OutputOption() {
+			if err := command.PrintOutput(enis); err != nil {
+				os.Exit(1)
+			}
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 5, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "ID\tMAC\tUSE MODE\tCCE STATUS\tVPC STATUS\tLINK\tROUTE TABLE\tIPS")
+		for _, eni := range enis {
+			ips := append(append([]string{}, eni.IPV4...), eni.IPV6...)
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\n", eni.ID, eni.MacAddress, eni.UseMode,
+				eni.CceStatus, eni.VpcStatus, eni.LinkName, eni.RouteTable, strings.Join(ips, ","))
+		}
+		w.Flush()
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(eniCmd)
+	eniCmd.AddCommand(eniListCmd)
+	command.AddOutputOption(eniListCmd)
+}
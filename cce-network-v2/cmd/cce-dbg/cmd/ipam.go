@@ -0,0 +1,119 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/command"
+)
+
+var ipamCmd = &cobra.Command{
+	Use:   "ipam",
+	Short: "Manage IP addresses allocated by the agent",
+}
+
+var ipamListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List IP addresses allocated by the agent",
+	Run: func(cmd *cobra.Command, args []string) {
+		status, err := client.IPAMGet()
+		if err != nil {
+			Fatalf("Cannot get ipam status: %s", err)
+		}
+
+		if command.OutputOption() {
+			if err := command.PrintOutput(status); err != nil {
+				os.Exit(1)
+			}
+			return
+		}
+
+		ips := make([]string, 0, len(status.Allocations))
+		for ip := range status.Allocations {
+			ips = append(ips, ip)
+		}
+		sort.Strings(ips)
+
+		w := tabwriter.NewWriter(os.Stdout, 5, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "IP\tOWNER\tEXPIRATION")
+		for _, ip := range ips {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", ip, status.Allocations[ip], status.ExpirationTimers[ip])
+		}
+		w.Flush()
+	},
+}
+
+var ipamReleaseCmd = &cobra.Command{
+	Use:   "release <ip>",
+	Short: "Release an IP address allocated by the agent",
+	Long: `Release an IP address in the same way as the CNI DEL of the container which
+owns the IP, the CCEEndpoint of the container is also deleted. Do not release
+the IP of a running pod.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ip := args[0]
+		status, err := client.IPAMGet()
+		if err != nil {
+			Fatalf("Cannot get ipam status: %s", err)
+		}
+		endpoints, err := client.EndpointList()
+		if err != nil {
+			Fatalf("Cannot get endpoint list: %s", err)
+		}
+		owner, containerID, err := ipOwner(status, endpoints, ip)
+		if err != nil {
+			Fatalf("%s", err)
+		}
+
+		if err := client.IPAMReleaseOwnedIP(ip, owner, containerID); err != nil {
+			Fatalf("Cannot release ip %s: %s", ip, err)
+		}
+		fmt.Printf("Released ip %s of %s (container %s)\n", ip, owner, containerID)
+	},
+}
+
+// ipOwner returns the owner and the container ID of the endpoint which the
+// ip is allocated to.
+func ipOwner(status *models.IPAMStatus, endpoints []*models.Endpoint, ip string) (owner, containerID string, err error) {
+	owner, ok := status.Allocations[ip]
+	if !ok {
+		return "", "", fmt.Errorf("ip %s is not allocated by agent", ip)
+	}
+	for _, ep := range endpoints {
+		if ep.Namespace+"/"+ep.Name != owner {
+			continue
+		}
+		if ep.Identifiers == nil || ep.Identifiers.ContainerID == "" {
+			return "", "", fmt.Errorf("container id of endpoint %s is unknown", owner)
+		}
+		return owner, ep.Identifiers.ContainerID, nil
+	}
+	return "", "", fmt.Errorf("ip %s is owned by %q which is not an endpoint on the node", ip, owner)
+}
+
+func init() {
+	RootCmd.AddCommand(ipamCmd)
+	ipamCmd.AddCommand(ipamListCmd)
+	ipamCmd.AddCommand(ipamReleaseCmd)
+	command.AddOutputOption(ipamListCmd)
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
+)
+
+func TestIPOwner(t *testing.T) {
+	status := &models.IPAMStatus{
+		Allocations: models.AllocationMap{
+			"10.0.0.2": "default/a",
+			"10.0.0.3": "default/b",
+			"10.0.0.4": "ipv4-vpc-route",
+		},
+	}
+	endpoints := []*models.Endpoint{
+		{Namespace: "default", Name: "a", Identifiers: &models.EndpointIdentifiers{ContainerID: "c1"}},
+		{Namespace: "default", Name: "b"},
+	}
+
+	owner, containerID, err := ipOwner(status, endpoints, "10.0.0.2")
+	assert.NoError(t, err)
+	assert.Equal(t, "default/a", owner)
+	assert.Equal(t, "c1", containerID)
+
+	for _, ip := range []string{"10.0.0.3", "10.0.0.4", "10.0.0.5"} {
+		_, _, err = ipOwner(status, endpoints, ip)
+		assert.Error(t, err, ip)
+	}
+}
+
+func TestFindEndpoint(t *testing.T) {
+	endpoints := []*models.Endpoint{
+		{Namespace: "default", Name: "a"},
+		{Namespace: "kube-system", Name: "a"},
+	}
+	assert.Equal(t, endpoints[0], findEndpoint(endpoints, "a"))
+	assert.Equal(t, endpoints[1], findEndpoint(endpoints, "kube-system/a"))
+	assert.Nil(t, findEndpoint(endpoints, "kube-system/b"))
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/command"
+)
+
+var matchPattern string
+
+var metricsCmd = &cobra.Command{
+	Use:   "metrics",
+	Short: "Access metrics of the agent",
+}
+
+var metricsListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List all metrics of the agent",
+	Run: func(cmd *cobra.Command, args []string) {
+		res, err := client.MetricsList()
+		if err != nil {
+			Fatalf("Cannot get metrics list: %s", err)
+		}
+
+		re, err := regexp.Compile(matchPattern)
+		if err != nil {
+			Fatalf("Cannot compile regex: %s", err)
+		}
+
+		metrics := make([]*models.Metric, 0, len(res))
+		for _, metric := range res {
+			if re.MatchString(metric.Name) {
+				metrics = append(metrics, metric)
+			}
+		}
+
+		if command.OutputOption() {
+			if err := command.PrintOutput(metrics); err != nil {
+				os.Exit(1)
+			}
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 5, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "Metric\tLabels\tValue")
+		for _, metric := range metrics {
+			labelArray := make([]string, 0, len(metric.Labels))
+			for key, value := range metric.Labels {
+				labelArray = append(labelArray, fmt.Sprintf(`%s="%s"`, key, value))
+			}
+			sort.Strings(labelArray)
+			fmt.Fprintf(w, "%s\t%s\t%f\n", metric.Name, strings.Join(labelArray, " "), metric.Value)
+		}
+		w.Flush()
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(metricsCmd)
+	metricsCmd.AddCommand(metricsListCmd)
+
+	metricsListCmd.Flags().StringVarP(&matchPattern, "match-pattern", "p", "", "Show only metrics whose names match matchpattern")
+	command.AddOutputOption(metricsListCmd)
+}
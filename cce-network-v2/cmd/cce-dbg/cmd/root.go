@@ -0,0 +1,66 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	clientPkg "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/client"
+)
+
+var (
+	host   string
+	client *clientPkg.Client
+)
+
+// RootCmd represents the base command when called without any subcommands
+var RootCmd = &cobra.Command{
+	Use:   "cce-dbg",
+	Short: "CLI for debugging cce-network-agent on the local node",
+	Long: `cce-dbg talks to the API of cce-network-agent through its unix socket,
+it must be run on the same node with the agent, e.g. in the agent pod.`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		initClient()
+	},
+	SilenceUsage: true,
+}
+
+// Execute adds all child commands to the root command and runs it.
+func Execute() {
+	if err := RootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func init() {
+	RootCmd.PersistentFlags().StringVarP(&host, "host", "H", "", "URI to the API of agent (default from env CCE_SOCK or "+clientPkg.DefaultSockPath()+")")
+}
+
+func initClient() {
+	c, err := clientPkg.NewClient(host)
+	if err != nil {
+		Fatalf("Error while creating client: %s", err)
+	}
+	client = c
+}
+
+// Fatalf prints the message to stderr and exits the program with code 1
+func Fatalf(msg string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "Error: "+msg+"\n", args...)
+	os.Exit(1)
+}
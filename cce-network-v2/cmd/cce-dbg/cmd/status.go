@@ -0,0 +1,123 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/command"
+)
+
+// statusResponse is the brief status of agent on the local node
+type statusResponse struct {
+	Health       string                      `json:"health"`
+	HealthMsg    string                      `json:"healthMsg,omitempty"`
+	HealthChecks []*models.HealthCheckStatus `json:"healthChecks,omitempty"`
+	IPAM         string                      `json:"ipam,omitempty"`
+	Allocations  int                         `json:"allocations"`
+	ENIs         int                         `json:"enis"`
+	Endpoints    int                         `json:"endpoints"`
+}
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Display the health and a summary of resources of the agent",
+	Run: func(cmd *cobra.Command, args []string) {
+		status := &statusResponse{Health: models.StatusStateOk}
+		sr, err := client.Healthz()
+		switch {
+		case sr != nil && sr.Cce != nil:
+			status.Health = sr.Cce.State
+			status.HealthMsg = sr.Cce.Msg
+			status.HealthChecks = sr.HealthChecks
+		case err != nil:
+			status.Health = models.StatusStateFailure
+			status.HealthMsg = err.Error()
+		}
+
+		ipamStatus, err := client.IPAMGet()
+		if err != nil {
+			Fatalf("Cannot get ipam status: %s", err)
+		}
+		status.IPAM = ipamStatus.Status
+		status.Allocations = len(ipamStatus.Allocations)
+
+		enis, err := client.ENIList()
+		if err != nil {
+			Fatalf("Cannot get eni list: %s", err)
+		}
+		status.ENIs = len(enis)
+
+		endpoints, err := client.EndpointList()
+		if err != nil {
+			Fatalf("Cannot get endpoint list: %s", err)
+		}
+		status.Endpoints = len(endpoints)
+
+		if command.OutputOption() {
+			if err := command.PrintOutput(status); err != nil {
+				os.Exit(1)
+			}
+		} else {
+			w := tabwriter.NewWriter(os.Stdout, 5, 0, 3, ' ', 0)
+			health := status.Health
+			if status.HealthMsg != "" {
+				health = fmt.Sprintf("%s   %s", status.Health, status.HealthMsg)
+			}
+			fmt.Fprintf(w, "Health:\t%s\n", health)
+			for _, hc := range status.HealthChecks {
+				fmt.Fprintf(w, "  %s\t%s\n", hc.Name, healthCheckString(hc))
+			}
+			fmt.Fprintf(w, "IPAM:\t%s\n", status.IPAM)
+			fmt.Fprintf(w, "Allocations:\t%d\n", status.Allocations)
+			fmt.Fprintf(w, "ENIs:\t%d\n", status.ENIs)
+			fmt.Fprintf(w, "Endpoints:\t%d\n", status.Endpoints)
+			w.Flush()
+		}
+
+		// degraded health checks do not fail the command
+		if status.Health == models.StatusStateFailure {
+			os.Exit(1)
+		}
+	},
+}
+
+// healthCheckString returns the summary of the result of a health check
+func healthCheckString(hc *models.HealthCheckStatus) string {
+	state := "ok"
+	if !hc.Healthy {
+		state = "failed"
+	}
+	summary := fmt.Sprintf("%s (%s)", state, hc.Severity)
+	if hc.Msg != "" {
+		summary = fmt.Sprintf("%s   %s", summary, hc.Msg)
+	}
+	if !hc.Healthy && !time.Time(hc.LastSuccessTimestamp).IsZero() {
+		summary = fmt.Sprintf("%s   last success %s ago", summary,
+			time.Since(time.Time(hc.LastSuccessTimestamp)).Truncate(time.Second))
+	}
+	return summary
+}
+
+func init() {
+	RootCmd.AddCommand(statusCmd)
+	command.AddOutputOption(statusCmd)
+}
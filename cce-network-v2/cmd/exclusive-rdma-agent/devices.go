@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	v1 "k8s.io/api/core/v1"
+	podresourcesapi "k8s.io/kubelet/pkg/apis/podresources/v1"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/plugins/exclusive-rdma/topology"
+)
+
+const (
+	// allocatedPCIDevicesAnnotation lists the PCI addresses of the devices
+	// allocated to the pod, separated by commas. It takes precedence over the
+	// kubelet pod resources, for the device plugins whose device IDs are not
+	// PCI addresses and do not report NUMA nodes.
+	allocatedPCIDevicesAnnotation = "cce.baidubce.com/allocated-pci-devices"
+
+	podResourcesTimeout = 10 * time.Second
+)
+
+// httpHandleDevices responds the devices and cpus allocated to the pod in JSON
+func httpHandleDevices(w http.ResponseWriter, r *http.Request) {
+	podName, podNs := r.URL.Query().Get("podName"), r.URL.Query().Get("podNs")
+	logger.Infof("http request received: get devices allocated to pod %s/%s", podNs, podName)
+
+	podDevices, err := getPodDevices(r.Context(), podNs, podName)
+	if err != nil {
+		logger.Errorf("get devices of pod %s/%s error: %s", podNs, podName, err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(podDevices); err != nil {
+		logger.Errorf("write devices of pod %s/%s error: %s", podNs, podName, err.Error())
+	}
+}
+
+func getPodDevices(ctx context.Context, podNs, podName string) (*topology.PodDevices, error) {
+	h := newHandler(podName, podNs)
+	pod, err := h.getPod()
+	if err != nil {
+		return nil, err
+	}
+	if devices := pod.Annotations[allocatedPCIDevicesAnnotation]; devices != "" {
+		return devicesFromAnnotation(devices), nil
+	}
+	return devicesFromPodResources(ctx, pod)
+}
+
+func devicesFromAnnotation(value string) *topology.PodDevices {
+	podDevices := &topology.PodDevices{}
+	for _, address := range strings.Split(value, ",") {
+		if address = strings.TrimSpace(address); address != "" {
+			podDevices.Devices = append(podDevices.Devices, topology.Device{ID: address})
+		}
+	}
+	return podDevices
+}
+
+// devicesFromPodResources lists the devices and exclusive cpus allocated to
+// the pod by kubelet, except the rdma devices themselves
+func devicesFromPodResources(ctx context.Context, pod *v1.Pod) (*topology.PodDevices, error) {
+	ctx, cancel := context.WithTimeout(ctx, podResourcesTimeout)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, "unix://"+podResourcesSocket,
+		grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	if err != nil {
+		return nil, fmt.Errorf("connect to kubelet pod resources %s error: %s", podResourcesSocket, err.Error())
+	}
+	defer conn.Close()
+
+	resp, err := podresourcesapi.NewPodResourcesListerClient(conn).List(ctx, &podresourcesapi.ListPodResourcesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("list kubelet pod resources error: %s", err.Error())
+	}
+
+	podDevices := &topology.PodDevices{}
+	for _, podResources := range resp.GetPodResources() {
+		if podResources.GetNamespace() != pod.Namespace || podResources.GetName() != pod.Name {
+			continue
+		}
+		for _, container := range podResources.GetContainers() {
+			for _, devices := range container.GetDevices() {
+				if isRDMAResource(devices.GetResourceName()) {
+					continue
+				}
+				var numaNodes []int64
+				for _, node := range devices.GetTopology().GetNodes() {
+					numaNodes = append(numaNodes, node.GetID())
+				}
+				for _, id := range devices.GetDeviceIds() {
+					podDevices.Devices = append(podDevices.Devices, topology.Device{
+						ResourceName: devices.GetResourceName(),
+						ID:           id,
+						NUMANodes:    numaNodes,
+					})
+				}
+			}
+			podDevices.CPUs = append(podDevices.CPUs, container.GetCpuIds()...)
+		}
+	}
+	return podDevices, nil
+}
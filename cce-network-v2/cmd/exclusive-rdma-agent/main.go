@@ -28,8 +28,9 @@ const (
 )
 
 type Plugin struct {
-	PluginType string `json:"type"`
-	DriverName string `json:"driver"`
+	PluginType    string `json:"type"`
+	DriverName    string `json:"driver"`
+	TopologyAware bool   `json:"topologyAware,omitempty"`
 }
 
 type handler struct {
@@ -42,6 +43,9 @@ var (
 	conflistFilePath string
 	resourceType     string // resource type used to judge if the pod has rdma requests, it shuld be the same as the one in pod yaml
 	driverName       string // driver name used to identify the RDMA network device
+	topologyAware    bool   // choose the RDMA network devices closest to the devices allocated to the pod
+	// socket of the kubelet pod resources API, used to get the devices allocated to the pod
+	podResourcesSocket string
 )
 
 func init() {
@@ -49,6 +53,8 @@ func init() {
 	flag.StringVar(&conflistFilePath, "config", "", "path to the conflist file together with its name")
 	flag.StringVar(&resourceType, "rstype", "rdma", "set the resource type used to announce that pod needs rdma")
 	flag.StringVar(&driverName, "driver", "", "Set the driver name used to identify the RDMA network device")
+	flag.BoolVar(&topologyAware, "topology-aware", false, "Choose the RDMA network devices closest to the GPUs or CPUs allocated to the pod")
+	flag.StringVar(&podResourcesSocket, "pod-resources-socket", "/var/lib/kubelet/pod-resources/kubelet.sock", "Socket of the kubelet pod resources API")
 	flag.Parse()
 
 	if conflistFilePath == "" {
@@ -111,6 +117,7 @@ func run() {
 
 	// register responding function to handle http request and give response
 	http.HandleFunc("/", httpHandleRequest)
+	http.HandleFunc("/devices", httpHandleDevices)
 	// start http server in another goroutine
 	logger.Infof("Starting HTTP server using uds socket file: %s", udsSocketFilePath)
 	if err := http.Serve(unixListener, nil); err != nil {
@@ -180,7 +187,7 @@ func checkUpAndUpdateJSON(filePath string) error {
 	}
 	logger.Infof("checkup and update json: %s opened successfully, start to check and update it", filePath)
 
-	found := false
+	found, changed := false, false
 	plugins, ok := conflist["plugins"].([]any)
 	if !ok {
 		return errors.New("plugins not found in conflist")
@@ -191,6 +198,11 @@ func checkUpAndUpdateJSON(filePath string) error {
 			continue
 		} else if plugin["type"] == "exclusive-rdma" {
 			found = true
+			// keep the topology awareness of the plugin the same as the agent
+			if aware, _ := plugin["topologyAware"].(bool); aware != topologyAware {
+				plugin["topologyAware"] = topologyAware
+				changed = true
+			}
 			break
 		}
 	}
@@ -198,10 +210,14 @@ func checkUpAndUpdateJSON(filePath string) error {
 	if !found {
 		logger.Info("exclusive-rdma plugin not found in conflist, start to add ")
 		newPlugin := Plugin{
-			PluginType: "exclusive-rdma",
-			DriverName: driverName}
+			PluginType:    "exclusive-rdma",
+			DriverName:    driverName,
+			TopologyAware: topologyAware}
 		conflist["plugins"] = append(plugins, newPlugin)
+		changed = true
+	}
 
+	if changed {
 		updatedata, err := json.MarshalIndent(conflist, "", "  ")
 		if err != nil {
 			return fmt.Errorf("marshal json error when writing back changes to file: %s", err.Error())
@@ -209,7 +225,7 @@ func checkUpAndUpdateJSON(filePath string) error {
 		if err := os.WriteFile(filePath, updatedata, 0644); err != nil {
 			return fmt.Errorf("write file error: %s", err.Error())
 		} else {
-			logger.Info("add exclusive-rdma plugin successfully, together with its driver name")
+			logger.Info("add or update exclusive-rdma plugin successfully, together with its driver name")
 		}
 	} else {
 		logger.Info("checkup and update json: exclusive-rdma plugin exists in conflist")
@@ -243,20 +259,28 @@ func newHandler(podName string, podNs string) *handler {
 	}
 }
 
-func (h *handler) handleRequest() (bool, error) {
+func (h *handler) getPod() (*v1.Pod, error) {
 	config, err := rest.InClusterConfig()
 	if err != nil {
-		return false, fmt.Errorf("get in cluster config error: %s", err.Error())
+		return nil, fmt.Errorf("get in cluster config error: %s", err.Error())
 	}
 
 	client, err := kubernetes.NewForConfig(config)
 	if err != nil {
-		return false, fmt.Errorf("build in cluster client error: %s", err.Error())
+		return nil, fmt.Errorf("build in cluster client error: %s", err.Error())
 	}
 
 	pod, err := client.CoreV1().Pods(h.podNs).Get(context.TODO(), h.podName, metav1.GetOptions{})
 	if err != nil {
-		return false, fmt.Errorf("get pod: %s in namespace: %s error: %s", h.podName, h.podNs, err.Error())
+		return nil, fmt.Errorf("get pod: %s in namespace: %s error: %s", h.podName, h.podNs, err.Error())
+	}
+	return pod, nil
+}
+
+func (h *handler) handleRequest() (bool, error) {
+	pod, err := h.getPod()
+	if err != nil {
+		return false, err
 	}
 
 	for _, container := range pod.Spec.Containers {
@@ -270,13 +294,15 @@ func (h *handler) handleRequest() (bool, error) {
 
 func hasRDMARequest(rl v1.ResourceList) bool {
 	for key := range rl {
-		arr := strings.Split(string(key), "/")
-		if len(arr) != 2 {
-			continue
-		}
-		if arr[0] == resourceType {
+		if isRDMAResource(string(key)) {
 			return true
 		}
 	}
 	return false
 }
+
+// isRDMAResource checks if the resource is the rdma resource of resourceType
+func isRDMAResource(name string) bool {
+	arr := strings.Split(name, "/")
+	return len(arr) == 2 && arr[0] == resourceType
+}
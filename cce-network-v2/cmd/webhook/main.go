@@ -43,6 +43,7 @@ func NewRootCommand() *cobra.Command {
 	}
 
 	webhook.RegisterWebhookFlags(cmd.Flags())
+	webhook.RegisterSchedulerExtenderFlags(cmd.Flags())
 	podmutating.RegisterCustomerFlags(cmd.Flags())
 	cmd.Flags().IntVar(&metricsPort, "metrics-port", metricsPort, "port for webhook server")
 	return cmd
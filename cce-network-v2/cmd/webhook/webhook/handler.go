@@ -15,6 +15,7 @@ package webhook
 import (
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/cmd/webhook/webhook/podmutating"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/cmd/webhook/webhook/pstsmutating"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/cmd/webhook/webhook/validating"
 )
 
 func init() {
@@ -24,4 +25,7 @@ func init() {
 	addHandlersWithGate(pstsmutating.HandlerMap, func() (enabled bool) {
 		return true
 	})
+	addHandlersWithGate(validating.HandlerMap, func() (enabled bool) {
+		return true
+	})
 }
@@ -107,6 +107,16 @@ func (h *MutatingPSTSHandler) defaultPstsSpec(spec *ccev2.PodSubnetTopologySprea
 }
 
 func (h *MutatingPSTSHandler) validatePstsSpec(spec *ccev2.PodSubnetTopologySpreadSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := ValidatePstsSpec(spec, fldPath)
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+	return h.validateSubnet(spec.Strategy, spec.Subnets, fldPath.Child("subnets"))
+}
+
+// ValidatePstsSpec validates the fields of the psts spec which do not depend
+// on the subnets in VPC
+func ValidatePstsSpec(spec *ccev2.PodSubnetTopologySpreadSpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if spec.Priority < 0 {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("priority"), spec.Priority, "priority must be non-negative"))
@@ -153,10 +163,7 @@ func (h *MutatingPSTSHandler) validatePstsSpec(spec *ccev2.PodSubnetTopologySpre
 		}
 	}
 
-	if len(allErrs) > 0 {
-		return allErrs
-	}
-	return h.validateSubnet(spec.Strategy, spec.Subnets, fldPath.Child("subnets"))
+	return allErrs
 }
 
 // Verify the availability of the subnet
@@ -0,0 +1,40 @@
+package webhook
+
+import (
+	"github.com/spf13/pflag"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/scheduler/ipavailability"
+)
+
+// enableSchedulerExtender serves the scheduler extender by the webhook server
+var enableSchedulerExtender = false
+
+// RegisterSchedulerExtenderFlags registers the flags of the scheduler extender
+func RegisterSchedulerExtenderFlags(pset *pflag.FlagSet) {
+	pset.BoolVar(&enableSchedulerExtender, "enable-scheduler-extender", enableSchedulerExtender,
+		"serve the scheduler extender filtering and scoring nodes by the IPs can be allocated to pods under "+
+			ipavailability.URLPrefix+" of the webhook server")
+}
+
+// registerSchedulerExtender registers the scheduler extender which filters out
+// the nodes whose subnets have no more IP for the pod and scores the nodes by
+// the remaining IPs to the webhook server, so that it is served over TLS behind
+// the webhook service. It must be called before the informers are started.
+func registerSchedulerExtender(server *webhook.Server) {
+	if !enableSchedulerExtender {
+		return
+	}
+
+	informers := k8s.CCEClient().Informers
+	plugin := ipavailability.New(
+		informers.Cce().V2().NetResourceSets().Lister(),
+		informers.Cce().V1().Subnets().Lister(),
+		informers.Cce().V2().PodSubnetTopologySpreads().Lister(),
+		informers.Cce().V2().CCEEndpoints().Lister(),
+	)
+	ipavailability.NewExtender(plugin).Register(server.Register)
+	klog.Infof("scheduler extender %s registered under %s", ipavailability.Name, ipavailability.URLPrefix)
+}
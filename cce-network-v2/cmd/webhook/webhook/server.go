@@ -129,6 +129,7 @@ func standaloneWebhookServer() *webhook.Server {
 		server.Register(path, &webhook.Admission{Handler: handler})
 		klog.V(3).Infof("Registered webhook handler %s", path)
 	}
+	registerSchedulerExtender(server)
 	return server
 }
 
@@ -155,9 +156,13 @@ func initialize() error {
 	k8s.CCEClient().Informers.Cce().V2().CCEEndpoints().Informer()
 	k8s.CCEClient().Informers.Cce().V2().PodSubnetTopologySpreads().Informer()
 	k8s.CCEClient().Informers.Cce().V1().Subnets().Informer()
+	k8s.CCEClient().Informers.Cce().V2alpha1().NetResourceConfigSets().Informer()
+	k8s.WatcherClient().Informers.Core().V1().Nodes().Informer()
 	k8s.CCEClient().Informers.Start(wait.NeverStop)
+	k8s.WatcherClient().Informers.Start(wait.NeverStop)
 	ctx, cancel := context.WithTimeout(context.TODO(), time.Minute*5)
 	k8s.CCEClient().Informers.WaitForCacheSync(ctx.Done())
+	k8s.WatcherClient().Informers.WaitForCacheSync(ctx.Done())
 	cancel()
 	c, err := webhookcontroller.New(HandlerMap)
 	if err != nil {
@@ -0,0 +1,55 @@
+package validating
+
+import (
+	"context"
+	"net/http"
+
+	unversionedvalidation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/cmd/webhook/webhook/pstsmutating"
+	ccev2alpha1 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2alpha1"
+)
+
+// ValidatingCPSTSHandler validates the ClusterPodSubnetTopologySpread after
+// it is mutated, the PodSubnetTopologySpreads of the namespaces are generated
+// from it by the operator
+type ValidatingCPSTSHandler struct {
+	// Decoder decodes objects
+	Decoder *admission.Decoder
+}
+
+// Handle handles admission requests.
+func (h *ValidatingCPSTSHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	cpsts := &ccev2alpha1.ClusterPodSubnetTopologySpread{}
+	err := h.Decoder.Decode(req, cpsts)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	allErrs := validateCPSTSSpec(&cpsts.Spec, field.NewPath("spec"))
+	if len(allErrs) > 0 {
+		log.WithField("cpsts", cpsts.Name).WithError(allErrs.ToAggregate()).Info("reject invalid cpsts")
+	}
+	return response(allErrs, nil)
+}
+
+// validateCPSTSSpec validates the psts spec and the namespace selector of the
+// ClusterPodSubnetTopologySpread
+func validateCPSTSSpec(spec *ccev2alpha1.ClusterPodSubnetTopologySpreadSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := pstsmutating.ValidatePstsSpec(&spec.PodSubnetTopologySpreadSpec, fldPath)
+	if spec.NamespaceSelector != nil {
+		allErrs = append(allErrs, unversionedvalidation.ValidateLabelSelector(spec.NamespaceSelector,
+			unversionedvalidation.LabelSelectorValidationOptions{}, fldPath.Child("namespaceSelector"))...)
+	}
+	return allErrs
+}
+
+var _ admission.DecoderInjector = &ValidatingCPSTSHandler{}
+
+// InjectDecoder injects the decoder into the ValidatingCPSTSHandler
+func (h *ValidatingCPSTSHandler) InjectDecoder(d *admission.Decoder) error {
+	h.Decoder = d
+	return nil
+}
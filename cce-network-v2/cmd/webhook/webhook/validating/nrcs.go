@@ -0,0 +1,187 @@
+package validating
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	unversionedvalidation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s"
+	ccev2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2"
+	ccev2alpha1 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2alpha1"
+	listerv2alpha1 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/client/listers/cce.baidubce.com/v2alpha1"
+)
+
+// maxOverlappingNodesInWarning is the max number of nodes listed in the
+// warning of overlapping NRCS
+const maxOverlappingNodesInWarning = 5
+
+var supportedENIUseModes = []string{
+	string(ccev2.ENIUseModeSecondaryIP),
+	string(ccev2.ENIUseModePrimaryIP),
+	string(ccev2.ENIUseModePrimaryWithSecondaryIP),
+}
+
+// ValidatingNRCSHandler validates the NetResourceConfigSet, and warns the
+// NetResourceConfigSets with the same priority selecting the same nodes
+type ValidatingNRCSHandler struct {
+	// Decoder decodes objects
+	Decoder *admission.Decoder
+}
+
+// Handle handles admission requests.
+func (h *ValidatingNRCSHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	nrcs := &ccev2alpha1.NetResourceConfigSet{}
+	err := h.Decoder.Decode(req, nrcs)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	allErrs := validateNrcsSpec(&nrcs.Spec, field.NewPath("spec"))
+	if len(allErrs) > 0 {
+		log.WithField("nrcs", nrcs.Name).WithError(allErrs.ToAggregate()).Info("reject invalid nrcs")
+		return response(allErrs, nil)
+	}
+
+	warnings, err := overlappingNrcsWarnings(nrcs,
+		k8s.CCEClient().Informers.Cce().V2alpha1().NetResourceConfigSets().Lister(),
+		k8s.WatcherClient().Informers.Core().V1().Nodes().Lister())
+	if err != nil {
+		// the warnings are informative, do not block the request
+		log.WithField("nrcs", nrcs.Name).WithError(err).Warning("failed to find overlapping nrcs")
+	}
+	return response(allErrs, warnings)
+}
+
+// validateNrcsSpec validates the selector and the agent configuration of the
+// NetResourceConfigSet
+func validateNrcsSpec(spec *ccev2alpha1.NetResourceConfigSetSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.Selector == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("selector"), "selector of nodes must be set"))
+	} else {
+		allErrs = append(allErrs, unversionedvalidation.ValidateLabelSelector(spec.Selector, unversionedvalidation.LabelSelectorValidationOptions{}, fldPath.Child("selector"))...)
+	}
+	if spec.Priority < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("priority"), spec.Priority, "priority must be non-negative"))
+	}
+
+	agentPath := fldPath.Child("agent")
+	agent := &spec.AgentConfig
+	if agent.EniUseMode != nil && !contains(supportedENIUseModes, *agent.EniUseMode) {
+		allErrs = append(allErrs, field.NotSupported(agentPath.Child(ccev2alpha1.ElasticNetworkInterfaceUseModeConfigKey), *agent.EniUseMode, supportedENIUseModes))
+	}
+	allErrs = append(allErrs, validateIDs(agent.EniSubnetIDs, agentPath.Child(ccev2alpha1.ENISubnetIDsConfigKey))...)
+	allErrs = append(allErrs, validateIDs(agent.EniSecurityGroupIds, agentPath.Child(ccev2alpha1.EniSecurityGroupIdsConfigKey))...)
+	allErrs = append(allErrs, validateIDs(agent.EniEnterpriseSecurityGroupIds, agentPath.Child("eni-enterprise-security-group-id"))...)
+
+	for _, counter := range []struct {
+		name  string
+		value *int
+	}{
+		{ccev2alpha1.ManualMTUConfigKey, agent.ManualMTU},
+		{ccev2alpha1.BurstableMehrfachENIConfigKey, agent.BurstableMehrfachENI},
+		{ccev2alpha1.RouteTableOffsetConfigKey, agent.RouteTableOffset},
+		{"ippool-pre-allocate-eni", agent.IPPoolPreAllocateENI},
+		{"ippool-min-allocate", agent.IPPoolMinAllocate},
+		{"ippool-pre-allocate", agent.IPPoolPreAllocate},
+		{"ippool-max-above-watermark", agent.IPPoolMaxAboveWatermark},
+	} {
+		if counter.value != nil && *counter.value < 0 {
+			allErrs = append(allErrs, field.Invalid(agentPath.Child(counter.name), *counter.value, "must be non-negative"))
+		}
+	}
+	for i, plugin := range agent.ExtCniPlugins {
+		if strings.TrimSpace(plugin) == "" {
+			allErrs = append(allErrs, field.Invalid(agentPath.Child(ccev2alpha1.ExtCNIPluginsConfigKey).Index(i), plugin, "plugin name must not be empty"))
+		}
+	}
+	return allErrs
+}
+
+// validateIDs validates the IDs of VPC resources are not empty or duplicate
+func validateIDs(ids []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := make(map[string]bool, len(ids))
+	for i, id := range ids {
+		if strings.TrimSpace(id) == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), id, "id must not be empty"))
+			continue
+		}
+		if seen[id] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), id))
+		}
+		seen[id] = true
+	}
+	return allErrs
+}
+
+// overlappingNrcsWarnings returns the warnings for the other NRCS with the same
+// priority as the nrcs and selecting the same nodes. Only the NRCS with the
+// smallest name takes effect on the nodes, the configuration of the others is
+// ignored silently.
+func overlappingNrcsWarnings(nrcs *ccev2alpha1.NetResourceConfigSet,
+	nrcsLister listerv2alpha1.NetResourceConfigSetLister, nodeLister corelisters.NodeLister) ([]string, error) {
+	selector, err := metav1.LabelSelectorAsSelector(nrcs.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	nrcsList, err := nrcsLister.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("list NetResourceConfigSets error %w", err)
+	}
+	sort.Slice(nrcsList, func(i, j int) bool {
+		return nrcsList[i].Name < nrcsList[j].Name
+	})
+	nodes, err := nodeLister.List(selector)
+	if err != nil {
+		return nil, fmt.Errorf("list nodes error %w", err)
+	}
+
+	var warnings []string
+	for _, other := range nrcsList {
+		if other.Name == nrcs.Name || other.Spec.Priority != nrcs.Spec.Priority {
+			continue
+		}
+		if reflect.DeepEqual(other.Spec.Selector, nrcs.Spec.Selector) {
+			warnings = append(warnings, fmt.Sprintf("NetResourceConfigSet %s has the same priority %d and selector, only the one with the smallest name takes effect",
+				other.Name, other.Spec.Priority))
+			continue
+		}
+
+		var overlapping []string
+		for _, node := range nodes {
+			if ok, err := other.SelectNode(node.Labels); err == nil && ok {
+				overlapping = append(overlapping, node.Name)
+			}
+		}
+		if len(overlapping) == 0 {
+			continue
+		}
+		sort.Strings(overlapping)
+		names := overlapping
+		if len(names) > maxOverlappingNodesInWarning {
+			names = append(names[:maxOverlappingNodesInWarning:maxOverlappingNodesInWarning], "...")
+		}
+		warnings = append(warnings, fmt.Sprintf("NetResourceConfigSet %s has the same priority %d and selects %d of the same nodes [%s], only the one with the smallest name takes effect on them",
+			other.Name, other.Spec.Priority, len(overlapping), strings.Join(names, ",")))
+	}
+	return warnings, nil
+}
+
+var _ admission.DecoderInjector = &ValidatingNRCSHandler{}
+
+// InjectDecoder injects the decoder into the ValidatingNRCSHandler
+func (h *ValidatingNRCSHandler) InjectDecoder(d *admission.Decoder) error {
+	h.Decoder = d
+	return nil
+}
@@ -0,0 +1,166 @@
+package validating
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/datapath/bandwidth"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/datapath/qos"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s"
+	ccev2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2"
+	listerv2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/client/listers/cce.baidubce.com/v2"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/pststrategy"
+)
+
+var (
+	supportedBandwidthModes   = []string{ccev2.BindwidthModeEDT, ccev2.BindwidthModeTC}
+	supportedEgressPriorities = []string{"Guaranteed", "Burstable", "BestEffort"}
+)
+
+// ValidatingPodHandler rejects the pods whose network annotations can not be
+// applied by the CNI, so that the errors are reported when the pod is created
+// instead of the pod being stuck in ContainerCreating.
+type ValidatingPodHandler struct {
+	// Decoder decodes objects
+	Decoder *admission.Decoder
+}
+
+// Handle handles admission requests.
+func (h *ValidatingPodHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	// only the new pods are validated, the annotations of the running pods
+	// have been applied, rejecting their updates may block their deletion
+	if req.Operation != admissionv1.Create {
+		return admission.Allowed("")
+	}
+
+	pod := &corev1.Pod{}
+	err := h.Decoder.Decode(req, pod)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if pod.Namespace == "" {
+		pod.Namespace = req.Namespace
+	}
+
+	allErrs, warnings := validatePod(pod, k8s.CCEClient().Informers.Cce().V2().PodSubnetTopologySpreads().Lister())
+	if len(allErrs) > 0 {
+		log.WithField("pod", pod.Namespace+"/"+pod.Name).WithError(allErrs.ToAggregate()).Info("reject pod with invalid network annotations")
+	}
+	return response(allErrs, warnings)
+}
+
+// validatePod validates the network annotations and labels of the pod
+func validatePod(pod *corev1.Pod, pstsLister listerv2.PodSubnetTopologySpreadLister) (field.ErrorList, []string) {
+	var (
+		allErrs  = field.ErrorList{}
+		warnings []string
+
+		annotationsPath = field.NewPath("metadata", "annotations")
+	)
+	if pod.Spec.HostNetwork {
+		return allErrs, nil
+	}
+
+	for _, key := range []string{bandwidth.AnnotaionPodIngressBandwidth, bandwidth.AnnotaionPodEgressBandwidth} {
+		if v, ok := pod.Annotations[key]; ok {
+			if _, err := bandwidth.ParseBandwidth(v); err != nil {
+				allErrs = append(allErrs, field.Invalid(annotationsPath.Key(key), v, "bandwidth must be a positive number with an optional unit of K, M, G or T, such as 100M"))
+			}
+		}
+	}
+	if v, ok := pod.Annotations[bandwidth.AnnotaionPodBindwidthMode]; ok && !contains(supportedBandwidthModes, v) {
+		allErrs = append(allErrs, field.NotSupported(annotationsPath.Key(bandwidth.AnnotaionPodBindwidthMode), v, supportedBandwidthModes))
+	}
+	if v, ok := pod.Annotations[qos.AnnotaionPodEgressPriority]; ok && ccev2.NewEngressPriorityOpt(v) == nil {
+		allErrs = append(allErrs, field.NotSupported(annotationsPath.Key(qos.AnnotaionPodEgressPriority), v, supportedEgressPriorities))
+	}
+	if v, ok := pod.Annotations[k8s.AnnotationFixedIPTTLSeconds]; ok {
+		if seconds, err := strconv.ParseInt(v, 10, 64); err != nil || seconds < 0 {
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key(k8s.AnnotationFixedIPTTLSeconds), v, "ttl must be a non-negative integer in seconds"))
+		}
+	}
+	allErrs = append(allErrs, validatePodNetworks(pod, pstsLister, annotationsPath)...)
+	if _, err := k8s.ExtractPodSecurityGroups(pod); err != nil {
+		key := k8s.AnnotationPodSecurityGroupIDs
+		if _, ok := pod.Annotations[key]; !ok {
+			key = k8s.AnnotationPodEnterpriseSecurityGroupIDs
+		}
+		allErrs = append(allErrs, field.Invalid(annotationsPath.Key(key), pod.Annotations[key], err.Error()))
+	}
+
+	fixedIPErrs, fixedIPWarnings := validatePodFixedIP(pod, pstsLister)
+	allErrs = append(allErrs, fixedIPErrs...)
+	warnings = append(warnings, fixedIPWarnings...)
+	return allErrs, warnings
+}
+
+// validatePodNetworks validates the secondary interfaces requested by the
+// pod, the PSTS of every interface must exist in the namespace of the pod
+func validatePodNetworks(pod *corev1.Pod, pstsLister listerv2.PodSubnetTopologySpreadLister, annotationsPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	fldPath := annotationsPath.Key(k8s.AnnotationPodNetworks)
+	attachments, err := k8s.ExtractPodNetworkAttachments(pod)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, pod.Annotations[k8s.AnnotationPodNetworks], err.Error()))
+	}
+	for _, attachment := range attachments {
+		_, err := pstsLister.PodSubnetTopologySpreads(pod.Namespace).Get(attachment.PSTSName)
+		if kerrors.IsNotFound(err) {
+			allErrs = append(allErrs, field.Invalid(fldPath, attachment.PSTSName,
+				fmt.Sprintf("PodSubnetTopologySpread %s of interface %s is not found", attachment.PSTSName, attachment.Interface)))
+		} else if err != nil {
+			allErrs = append(allErrs, field.InternalError(fldPath, err))
+		}
+	}
+	return allErrs
+}
+
+// validatePodFixedIP validates the pod using fixed IP is selected by a
+// PodSubnetTopologySpread, the fixed IP is allocated from the subnets of it
+func validatePodFixedIP(pod *corev1.Pod, pstsLister listerv2.PodSubnetTopologySpreadLister) (field.ErrorList, []string) {
+	allErrs := field.ErrorList{}
+	if !k8s.HaveFixedIPLabel(&pod.ObjectMeta) {
+		return allErrs, nil
+	}
+	fldPath := field.NewPath("metadata", "labels").Key(k8s.LabelPodUseFixedIP)
+
+	pstsList, err := pstsLister.PodSubnetTopologySpreads(pod.Namespace).List(labels.Everything())
+	if err != nil {
+		return append(allErrs, field.InternalError(fldPath, err)), nil
+	}
+	psts := pststrategy.SelectPriorityPSTS(pstsList, labels.Set(pod.Labels))
+	if psts == nil {
+		return append(allErrs, field.Invalid(fldPath, pod.Labels[k8s.LabelPodUseFixedIP],
+			"pod using fixed IP must be selected by a PodSubnetTopologySpread with Fixed strategy")), nil
+	}
+	if psts.Spec.Strategy == nil || psts.Spec.Strategy.Type != ccev2.IPAllocTypeFixed {
+		return allErrs, []string{fmt.Sprintf("PodSubnetTopologySpread %s selecting the pod does not use Fixed strategy, the IP of the pod is not fixed", psts.Name)}
+	}
+	return allErrs, nil
+}
+
+var _ admission.DecoderInjector = &ValidatingPodHandler{}
+
+// InjectDecoder injects the decoder into the ValidatingPodHandler
+func (h *ValidatingPodHandler) InjectDecoder(d *admission.Decoder) error {
+	h.Decoder = d
+	return nil
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
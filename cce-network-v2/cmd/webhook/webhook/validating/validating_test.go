@@ -0,0 +1,251 @@
+package validating
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/datapath/bandwidth"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/datapath/qos"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s"
+	ccev2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2"
+	ccev2alpha1 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2alpha1"
+	listerv2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/client/listers/cce.baidubce.com/v2"
+	listerv2alpha1 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/client/listers/cce.baidubce.com/v2alpha1"
+)
+
+func newTestPSTSLister(t *testing.T, pstses ...*ccev2.PodSubnetTopologySpread) listerv2.PodSubnetTopologySpreadLister {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, psts := range pstses {
+		require.NoError(t, indexer.Add(psts))
+	}
+	return listerv2.NewPodSubnetTopologySpreadLister(indexer)
+}
+
+func newTestPSTS(name string, allocType ccev2.IPAllocType, matchLabels map[string]string) *ccev2.PodSubnetTopologySpread {
+	return &ccev2.PodSubnetTopologySpread{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: ccev2.PodSubnetTopologySpreadSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: matchLabels},
+			Subnets:  map[string]ccev2.CustomAllocationList{"sbn-a": {}},
+			Strategy: &ccev2.IPAllocationStrategy{Type: allocType},
+		},
+	}
+}
+
+func newTestPod(labels, annotations map[string]string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "pod",
+			Namespace:   "default",
+			Labels:      labels,
+			Annotations: annotations,
+		},
+	}
+}
+
+func errorFields(allErrs field.ErrorList) []string {
+	var fields []string
+	for _, err := range allErrs {
+		fields = append(fields, err.Field)
+	}
+	return fields
+}
+
+func TestValidatePod(t *testing.T) {
+	tests := []struct {
+		name        string
+		labels      map[string]string
+		annotations map[string]string
+		pstses      []*ccev2.PodSubnetTopologySpread
+		hostNetwork bool
+		wantFields  []string
+		wantWarning bool
+	}{
+		{
+			name: "valid annotations",
+			annotations: map[string]string{
+				bandwidth.AnnotaionPodIngressBandwidth: "100M",
+				bandwidth.AnnotaionPodEgressBandwidth:  "1000",
+				bandwidth.AnnotaionPodBindwidthMode:    ccev2.BindwidthModeEDT,
+				qos.AnnotaionPodEgressPriority:         "Burstable",
+				k8s.AnnotationFixedIPTTLSeconds:        "3600",
+			},
+		},
+		{
+			name: "invalid annotations",
+			annotations: map[string]string{
+				bandwidth.AnnotaionPodIngressBandwidth: "100X",
+				bandwidth.AnnotaionPodEgressBandwidth:  "-1M",
+				bandwidth.AnnotaionPodBindwidthMode:    "htb",
+				qos.AnnotaionPodEgressPriority:         "High",
+				k8s.AnnotationFixedIPTTLSeconds:        "1h",
+			},
+			wantFields: []string{
+				"metadata.annotations[kubernetes.io/ingress-bandwidth]",
+				"metadata.annotations[kubernetes.io/egress-bandwidth]",
+				"metadata.annotations[kubernetes.io/bindwidth-mode]",
+				"metadata.annotations[cce.baidubce.com/egress-priority]",
+				"metadata.annotations[fixedip.cce.baidubce.com/ttl]",
+			},
+		},
+		{
+			name:        "host network pod is not validated",
+			annotations: map[string]string{bandwidth.AnnotaionPodIngressBandwidth: "100X"},
+			hostNetwork: true,
+		},
+		{
+			name: "unknown psts of network",
+			annotations: map[string]string{
+				k8s.AnnotationPodNetworks: `[{"interface":"eth1","psts":"psts-b"}]`,
+			},
+			pstses:     []*ccev2.PodSubnetTopologySpread{newTestPSTS("psts-a", ccev2.IPAllocTypeElastic, map[string]string{"app": "other"})},
+			wantFields: []string{"metadata.annotations[cce.baidubce.com/networks]"},
+		},
+		{
+			name: "invalid security groups",
+			annotations: map[string]string{
+				k8s.AnnotationPodSecurityGroupIDs:           "g-a",
+				k8s.AnnotationPodEnterpriseSecurityGroupIDs: "esg-a",
+			},
+			wantFields: []string{"metadata.annotations[cce.baidubce.com/security-group-ids]"},
+		},
+		{
+			name:       "fixed ip without psts",
+			labels:     map[string]string{k8s.LabelPodUseFixedIP: "true"},
+			wantFields: []string{"metadata.labels[cce.baidubce.com/fixedip]"},
+		},
+		{
+			name:   "fixed ip with fixed psts",
+			labels: map[string]string{k8s.LabelPodUseFixedIP: "true", "app": "a"},
+			pstses: []*ccev2.PodSubnetTopologySpread{newTestPSTS("psts-a", ccev2.IPAllocTypeFixed, map[string]string{"app": "a"})},
+		},
+		{
+			name:        "fixed ip with elastic psts",
+			labels:      map[string]string{k8s.LabelPodUseFixedIP: "true", "app": "a"},
+			pstses:      []*ccev2.PodSubnetTopologySpread{newTestPSTS("psts-a", ccev2.IPAllocTypeElastic, map[string]string{"app": "a"})},
+			wantWarning: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pod := newTestPod(test.labels, test.annotations)
+			pod.Spec.HostNetwork = test.hostNetwork
+			allErrs, warnings := validatePod(pod, newTestPSTSLister(t, test.pstses...))
+			assert.ElementsMatch(t, test.wantFields, errorFields(allErrs))
+			assert.Equal(t, test.wantWarning, len(warnings) != 0)
+		})
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestValidateNrcsSpec(t *testing.T) {
+	valid := ccev2alpha1.NetResourceConfigSetSpec{
+		Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "a"}},
+		Priority: 10,
+		AgentConfig: ccev2alpha1.AgentConfig{
+			EniSubnetIDs:     []string{"sbn-a", "sbn-b"},
+			EniUseMode:       stringPtr(string(ccev2.ENIUseModePrimaryIP)),
+			ManualMTU:        intPtr(1500),
+			RouteTableOffset: intPtr(127),
+		},
+	}
+	assert.Empty(t, validateNrcsSpec(&valid, field.NewPath("spec")))
+
+	invalid := ccev2alpha1.NetResourceConfigSetSpec{
+		Priority: -1,
+		AgentConfig: ccev2alpha1.AgentConfig{
+			EniSubnetIDs:        []string{"sbn-a", "sbn-a"},
+			EniSecurityGroupIds: []string{""},
+			EniUseMode:          stringPtr("Exclusive"),
+			IPPoolPreAllocate:   intPtr(-2),
+			ExtCniPlugins:       []string{"sbr-eip", " "},
+		},
+	}
+	assert.ElementsMatch(t, []string{
+		"spec.selector",
+		"spec.priority",
+		"spec.agent.eni-use-mode",
+		"spec.agent.eni-subnet-ids[1]",
+		"spec.agent.eni-security-group-ids[0]",
+		"spec.agent.ippool-pre-allocate",
+		"spec.agent.ext-cni-plugins[1]",
+	}, errorFields(validateNrcsSpec(&invalid, field.NewPath("spec"))))
+}
+
+func newTestNrcs(name string, priority int32, matchLabels map[string]string) *ccev2alpha1.NetResourceConfigSet {
+	return &ccev2alpha1.NetResourceConfigSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: ccev2alpha1.NetResourceConfigSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: matchLabels},
+			Priority: priority,
+		},
+	}
+}
+
+func TestOverlappingNrcsWarnings(t *testing.T) {
+	nrcsIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	nodeIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, nrcs := range []*ccev2alpha1.NetResourceConfigSet{
+		newTestNrcs("same-selector", 10, map[string]string{"pool": "a"}),
+		newTestNrcs("same-nodes", 10, map[string]string{"gpu": "true"}),
+		newTestNrcs("other-nodes", 10, map[string]string{"pool": "b"}),
+		newTestNrcs("other-priority", 20, map[string]string{"pool": "a"}),
+		newTestNrcs("new", 10, map[string]string{"pool": "a"}),
+	} {
+		require.NoError(t, nrcsIndexer.Add(nrcs))
+	}
+	for _, node := range []*corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"pool": "a", "gpu": "true"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-2", Labels: map[string]string{"pool": "a"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-3", Labels: map[string]string{"pool": "b", "gpu": "true"}}},
+	} {
+		require.NoError(t, nodeIndexer.Add(node))
+	}
+
+	// the updated object is compared with the others, not with itself
+	nrcs := newTestNrcs("new", 10, map[string]string{"pool": "a"})
+	warnings, err := overlappingNrcsWarnings(nrcs, listerv2alpha1.NewNetResourceConfigSetLister(nrcsIndexer), corelisters.NewNodeLister(nodeIndexer))
+	require.NoError(t, err)
+	require.Len(t, warnings, 2)
+	assert.True(t, strings.HasPrefix(warnings[0], "NetResourceConfigSet same-nodes "))
+	assert.Contains(t, warnings[0], "[node-1]")
+	assert.True(t, strings.HasPrefix(warnings[1], "NetResourceConfigSet same-selector "))
+}
+
+func TestValidateCPSTSSpec(t *testing.T) {
+	spec := ccev2alpha1.ClusterPodSubnetTopologySpreadSpec{
+		PodSubnetTopologySpreadSpec: ccev2.PodSubnetTopologySpreadSpec{
+			Subnets: map[string]ccev2.CustomAllocationList{"sbn-a": {}},
+			Strategy: &ccev2.IPAllocationStrategy{
+				Type:                 ccev2.IPAllocTypeElastic,
+				EnableReuseIPAddress: true,
+				TTL:                  &metav1.Duration{Duration: time.Hour},
+			},
+		},
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+	}
+	assert.Empty(t, validateCPSTSSpec(&spec, field.NewPath("spec")))
+
+	spec.Subnets = nil
+	spec.NamespaceSelector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+		{Key: "env", Operator: metav1.LabelSelectorOpIn},
+	}}
+	assert.ElementsMatch(t, []string{"spec.subnets", "spec.namespaceSelector.matchExpressions[0].values"},
+		errorFields(validateCPSTSSpec(&spec, field.NewPath("spec"))))
+}
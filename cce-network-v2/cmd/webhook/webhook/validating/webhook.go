@@ -0,0 +1,28 @@
+package validating
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging"
+)
+
+var (
+	// HandlerMap contains admission webhook handlers
+	HandlerMap = map[string]admission.Handler{
+		"validating-pod":          &ValidatingPodHandler{},
+		"validating-nrcs":         &ValidatingNRCSHandler{},
+		"validating-cluster-psts": &ValidatingCPSTSHandler{},
+	}
+
+	log = logging.NewSubysLogger("validating-webhook")
+)
+
+// response denies the request with the field errors, and allows it with
+// the warnings if there is no error
+func response(allErrs field.ErrorList, warnings []string) admission.Response {
+	if len(allErrs) > 0 {
+		return admission.Denied(allErrs.ToAggregate().Error()).WithWarnings(warnings...)
+	}
+	return admission.Allowed("").WithWarnings(warnings...)
+}
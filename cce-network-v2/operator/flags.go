@@ -317,6 +317,9 @@ func init() {
 	flags.String(operatorOption.BCECloudVPCID, "", "vpc id")
 	option.BindEnv(operatorOption.BCECloudVPCID)
 
+	flags.String(operatorOption.BCECloudSimulatorConfig, "", "config file of the in-memory BCE cloud simulator, only for testing")
+	option.BindEnv(operatorOption.BCECloudSimulatorConfig)
+
 	flags.Duration(option.ResourceResyncInterval, operatorOption.DefaultResourceResyncInterval, "synchronization cycle of vpc resources, such as subnet and ENI")
 	option.BindEnv(option.ResourceResyncInterval)
 
@@ -330,6 +333,12 @@ func init() {
 	flags.Duration(operatorOption.SecurityGroupSynerDuration, 0, "How often to resync security group")
 	option.BindEnv(operatorOption.SecurityGroupSynerDuration)
 
+	flags.Int(operatorOption.VPCRouteRuleQuota, operatorOption.DefaultVPCRouteRuleQuota, "Max number of route rules in a route table of VPC, used to warn before the quota is exceeded in vpc-route mode")
+	option.BindEnv(operatorOption.VPCRouteRuleQuota)
+
+	flags.Float64(operatorOption.VPCRouteRuleQuotaWarningRatio, operatorOption.DefaultVPCRouteRuleQuotaWarningRatio, "Ratio of the used route rules to the quota of a route table, above which a warning event is recorded")
+	option.BindEnv(operatorOption.VPCRouteRuleQuotaWarningRatio)
+
 	flags.String(operatorOption.CCEClusterID, "", "cluster id defined in CCE")
 	option.BindEnv(operatorOption.CCEClusterID)
 
@@ -338,5 +347,17 @@ func init() {
 
 	flags.Int(operatorOption.BCECustomerMaxRdmaIP, 0, "max rdma ip number of rdma-interface for customer")
 	option.BindEnv(operatorOption.BCECustomerMaxRdmaIP)
+
+	flags.Bool(option.EnableTracing, false, "Enable exporting the OpenTelemetry spans of the IP allocation and the cloud API calls")
+	option.BindEnv(option.EnableTracing)
+
+	flags.String(option.TracingEndpoint, defaults.TracingEndpoint, "host:port of the OTLP/HTTP collector which receives the spans")
+	option.BindEnv(option.TracingEndpoint)
+
+	flags.Bool(option.TracingInsecure, true, "Export the spans over HTTP instead of HTTPS")
+	option.BindEnv(option.TracingInsecure)
+
+	flags.Float64(option.TracingSampleRatio, defaults.TracingSampleRatio, "Ratio of the root spans sampled, in [0, 1], the IP allocation follows the sampling decision of the CNI request")
+	option.BindEnv(option.TracingSampleRatio)
 	viper.BindPFlags(flags)
 }
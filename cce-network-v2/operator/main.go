@@ -61,6 +61,7 @@ import (
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/option"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/pprof"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/rand"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/tracing"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/version"
 )
 
@@ -230,6 +231,15 @@ func runOperator() {
 		pprof.Enable(operatorOption.Config.PProfPort)
 	}
 
+	shutdownTracing, err := tracing.Init(components.CCEOperatortName, option.Config.TracingConfig())
+	if err != nil {
+		log.WithError(err).Fatal("Unable to initialize tracing")
+	}
+	go func() {
+		<-shutdownSignal
+		shutdownTracing()
+	}()
+
 	initK8s(k8sInitDone)
 
 	capabilities := k8sversion.Capabilities()
@@ -386,6 +396,9 @@ func startEthernetOperator(ctx context.Context) {
 				log.WithError(err).Fatalf("Unable to init %s cce security syncer", option.Config.IPAM)
 			}
 			sgHandler = sgSyncer.StartSecurityGroupSyncer(ctx, operatorWatchers.SecurityGroupClient)
+			// security groups requested by pods are validated only if the
+			// security groups are synced from the VPC
+			eniSyncer.SetSecurityGroupValidator(bcesg.BceSecurityValidator)
 		}
 	}
 
@@ -514,6 +527,10 @@ func onOperatorStartLeading(ctx context.Context) {
 	log.WithField(logfields.Mode, option.Config.IPAM).Info("Initializing IPAM")
 	startEthernetOperator(ctx)
 
+	if err := operatorWatchers.StartSynchronizingNRCS(ctx); err != nil {
+		log.WithError(err).Fatal("Unable to setup NRCS watcher")
+	}
+
 	if operatorOption.Config.NodeGCInterval != 0 {
 		operatorWatchers.RunNetResourceSetGC(ctx, operatorOption.Config.NodeGCInterval)
 	}
@@ -130,6 +130,12 @@ var (
 
 	// ControllerHandlerDurationMilliseconds is the histogram of the duration
 	ControllerHandlerDurationMilliseconds *prometheus.HistogramVec
+
+	// VPCRouteRules is the number of route rules in each route table of VPC in vpc-route mode
+	VPCRouteRules *prometheus.GaugeVec
+
+	// VPCRouteRuleQuota is the quota of route rules in each route table of VPC in vpc-route mode
+	VPCRouteRuleQuota *prometheus.GaugeVec
 )
 
 const (
@@ -170,6 +176,9 @@ const (
 
 	// LabelEventMethod is the label for the method of an event
 	LabelEventMethod = "method"
+
+	// LabelRouteTable is the label for the route table of VPC
+	LabelRouteTable = "route_table"
 )
 
 func registerMetrics() []prometheus.Collector {
@@ -271,6 +280,20 @@ func registerMetrics() []prometheus.Collector {
 	)
 	collectors = append(collectors, ControllerHandlerDurationMilliseconds)
 
+	VPCRouteRules = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Name:      "vpc_route_rules",
+		Help:      "The number of route rules in the route table of VPC",
+	}, []string{LabelRouteTable})
+	collectors = append(collectors, VPCRouteRules)
+
+	VPCRouteRuleQuota = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Name:      "vpc_route_rule_quota",
+		Help:      "The max number of route rules in the route table of VPC",
+	}, []string{LabelRouteTable})
+	collectors = append(collectors, VPCRouteRuleQuota)
+
 	Registry.MustRegister(collectors...)
 	return collectors
 }
@@ -47,6 +47,12 @@ const (
 
 	// DefaultResourceResyncInterval is the default time for the resource resync
 	DefaultResourceResyncInterval = 30 * time.Second
+
+	// DefaultVPCRouteRuleQuota is the default quota of route rules in a route table of VPC
+	DefaultVPCRouteRuleQuota = 50
+
+	// DefaultVPCRouteRuleQuotaWarningRatio is the default ratio of used route rules to warn
+	DefaultVPCRouteRuleQuotaWarningRatio = 0.8
 )
 
 const (
@@ -166,6 +172,9 @@ const (
 	BCECloudAccessKey          = "bce-cloud-access-key"
 	BCECloudSecureKey          = "bce-cloud-secure-key"
 	BCECloudForceViaCCEGateway = "bce-cloud-force-via-cce-gateway"
+	// BCECloudSimulatorConfig is the config file of the in-memory cloud simulator,
+	// the operator runs against the simulator instead of BCE cloud if it is set
+	BCECloudSimulatorConfig = "bce-cloud-simulator-config"
 
 	ResourceENIResyncInterval   = "resource-eni-resync-interval"
 	ResourceHPCResyncInterval   = "resource-hpc-resync-interval"
@@ -222,6 +231,18 @@ const (
 
 	// EnableSecurityGroupSynerDuration is the duration of security group syner send alter event, default is 1h
 	SecurityGroupSynerDuration = "securitygroup-syner-duration"
+
+	// VPCRouteTableSelectors maps the route tables to the label selectors of nodes in vpc-route mode,
+	// the routes of a node are created in all the route tables whose selectors match the labels of node,
+	// or in the default route table of VPC if no selector matches
+	VPCRouteTableSelectors = "vpc-route-table-selectors"
+
+	// VPCRouteRuleQuota is the max number of route rules in a route table
+	VPCRouteRuleQuota = "vpc-route-rule-quota"
+
+	// VPCRouteRuleQuotaWarningRatio is the ratio of used route rules to the quota,
+	// above which a warning event is recorded on the netresourceset
+	VPCRouteRuleQuotaWarningRatio = "vpc-route-rule-quota-warning-ratio"
 )
 
 // OperatorConfig is the configuration used by the operator.
@@ -392,6 +413,9 @@ type OperatorConfig struct {
 
 	BCECloudContry string
 
+	// BCECloudSimulatorConfig is the config file of the in-memory cloud simulator
+	BCECloudSimulatorConfig string
+
 	// FixedIPTTL
 	FixedIPTTL time.Duration
 
@@ -418,6 +442,15 @@ type OperatorConfig struct {
 
 	// SecurityGroupSynerDuration is the duration of security group syner send alter event
 	SecurityGroupSynerDuration time.Duration
+
+	// VPCRouteTableSelectors maps the route tables to the label selectors of nodes in vpc-route mode
+	VPCRouteTableSelectors map[string]string
+
+	// VPCRouteRuleQuota is the max number of route rules in a route table
+	VPCRouteRuleQuota int
+
+	// VPCRouteRuleQuotaWarningRatio is the ratio of used route rules to the quota to warn
+	VPCRouteRuleQuotaWarningRatio float64
 }
 
 // Populate sets all options with the values from viper.
@@ -482,6 +515,7 @@ func (c *OperatorConfig) Populate() {
 	c.BCECloudAccessKey = viper.GetString(BCECloudAccessKey)
 	c.BCECloudSecureKey = viper.GetString(BCECloudSecureKey)
 	c.BCEForceViaCCEGateway = viper.GetBool(BCECloudForceViaCCEGateway)
+	c.BCECloudSimulatorConfig = viper.GetString(BCECloudSimulatorConfig)
 	c.ResourceResyncInterval = viper.GetDuration(option.ResourceResyncInterval)
 	c.ResourceENIResyncInterval = viper.GetDuration(ResourceENIResyncInterval)
 	c.ResourceHPCResyncInterval = viper.GetDuration(ResourceHPCResyncInterval)
@@ -505,6 +539,10 @@ func (c *OperatorConfig) Populate() {
 	// secuirty group
 	c.SecurityGroupSynerDuration = viper.GetDuration(SecurityGroupSynerDuration)
 
+	// vpc route
+	c.VPCRouteRuleQuota = viper.GetInt(VPCRouteRuleQuota)
+	c.VPCRouteRuleQuotaWarningRatio = viper.GetFloat64(VPCRouteRuleQuotaWarningRatio)
+
 	// Option maps and slices
 
 	if m := viper.GetStringSlice(IPAMSubnetsIDs); len(m) != 0 {
@@ -523,13 +561,19 @@ func (c *OperatorConfig) Populate() {
 		c.IPAMInstanceTags = m
 	}
 
+	if m, err := command.GetStringMapStringE(viper.GetViper(), VPCRouteTableSelectors); err != nil {
+		log.Fatalf("unable to parse %s: %s", VPCRouteTableSelectors, err)
+	} else {
+		c.VPCRouteTableSelectors = m
+	}
 }
 
 // Config represents the operator configuration.
 var Config = &OperatorConfig{
-	IPAMSubnetsIDs:        make([]string, 0),
-	IPAMSubnetsTags:       make(map[string]string),
-	IPAMInstanceTags:      make(map[string]string),
-	APIRateLimit:          make(map[string]string),
-	SkipManagerNodeLabels: make(map[string]string),
+	IPAMSubnetsIDs:         make([]string, 0),
+	IPAMSubnetsTags:        make(map[string]string),
+	IPAMInstanceTags:       make(map[string]string),
+	APIRateLimit:           make(map[string]string),
+	SkipManagerNodeLabels:  make(map[string]string),
+	VPCRouteTableSelectors: make(map[string]string),
 }
@@ -123,3 +123,19 @@ func (c *CCEEndpointUpdaterImpl) GetByIP(ip string) ([]*ccev2.CCEEndpoint, error
 	}
 	return data, err
 }
+
+// GetByPSTSSubnet get CCEEndpoints which are allocated from the subnet by psts
+func (c *CCEEndpointUpdaterImpl) GetByPSTSSubnet(namespace, pstsName, subnetID string) ([]*ccev2.CCEEndpoint, error) {
+	var data []*ccev2.CCEEndpoint
+	endpointIndexer := k8s.CCEClient().Informers.Cce().V2().CCEEndpoints().Informer().GetIndexer()
+
+	objs, err := endpointIndexer.ByIndex(IndexPSTSSubnetToEndpoint, PSTSSubnetIndexKey(namespace, pstsName, subnetID))
+	if err == nil {
+		for _, obj := range objs {
+			if cep, ok := obj.(*ccev2.CCEEndpoint); ok {
+				data = append(data, cep)
+			}
+		}
+	}
+	return data, err
+}
@@ -0,0 +1,218 @@
+package watchers
+
+import (
+	"context"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/scheme"
+	listv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	operatorOption "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/operator/option"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s"
+	ccev2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2"
+	ccev2alpha1 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2alpha1"
+	listv2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/client/listers/cce.baidubce.com/v2"
+	listv2alpha1 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/client/listers/cce.baidubce.com/v2alpha1"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/watchers/cm"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging"
+)
+
+const (
+	nrcsComponent = "nrcs-watcher"
+)
+
+var (
+	nrcsLog = logging.NewSubysLogger(nrcsComponent)
+)
+
+// StartSynchronizingNRCS starts a controller to reconcile the status of NetResourceConfigSet.
+// The status of a NRCS depends on all NRCS, the labels of nodes and the NRCS applied
+// by agents, so any change of them will requeue all NRCS.
+func StartSynchronizingNRCS(ctx context.Context) error {
+	var nrcsManager = &nrcsSyncher{
+		nrcsLister: k8s.CCEClient().Informers.Cce().V2alpha1().NetResourceConfigSets().Lister(),
+		nrsLister:  k8s.CCEClient().Informers.Cce().V2().NetResourceSets().Lister(),
+		nodeLister: k8s.WatcherClient().Informers.Core().V1().Nodes().Lister(),
+		recorder:   k8s.EventBroadcaster().NewRecorder(scheme.Scheme, corev1.EventSource{Component: nrcsComponent}),
+	}
+
+	log.Info("Starting to synchronize NRCS custom resources")
+
+	nrcsInformer := k8s.CCEClient().Informers.Cce().V2alpha1().NetResourceConfigSets().Informer()
+	controller := cm.NewResyncController("cce-nrcs-controller", int(operatorOption.Config.ResourceResyncWorkers), k8s.GetQPS(), k8s.GetBurst(),
+		nrcsInformer, nrcsManager.Update)
+	controller.RunWithResync(operatorOption.Config.ResourceResyncInterval)
+
+	enqueueAll := func() {
+		allNrcs, err := nrcsManager.nrcsLister.List(labels.Everything())
+		if err != nil {
+			nrcsLog.WithError(err).Error("Unable to list NRCS")
+			return
+		}
+		for _, nrcs := range allNrcs {
+			controller.Queue.Add(nrcs.Name)
+		}
+	}
+
+	// changing the selector or priority of a NRCS may change the nodes resolved to other NRCS
+	nrcsInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) { enqueueAll() },
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldNrcs, oldOk := oldObj.(*ccev2alpha1.NetResourceConfigSet)
+			newNrcs, newOk := newObj.(*ccev2alpha1.NetResourceConfigSet)
+			if oldOk && newOk && reflect.DeepEqual(oldNrcs.Spec, newNrcs.Spec) {
+				return
+			}
+			enqueueAll()
+		},
+		DeleteFunc: func(obj interface{}) { enqueueAll() },
+	})
+
+	k8s.WatcherClient().Informers.Core().V1().Nodes().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) { enqueueAll() },
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldNode, oldOk := oldObj.(*corev1.Node)
+			newNode, newOk := newObj.(*corev1.Node)
+			if oldOk && newOk && reflect.DeepEqual(oldNode.Labels, newNode.Labels) {
+				return
+			}
+			enqueueAll()
+		},
+		DeleteFunc: func(obj interface{}) { enqueueAll() },
+	})
+
+	k8s.CCEClient().Informers.Cce().V2().NetResourceSets().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldNrs, oldOk := oldObj.(*ccev2.NetResourceSet)
+			newNrs, newOk := newObj.(*ccev2.NetResourceSet)
+			if !oldOk || !newOk {
+				return
+			}
+			if oldNrs.Annotations[k8s.AnnotationNrcsName] == newNrs.Annotations[k8s.AnnotationNrcsName] &&
+				oldNrs.Annotations[k8s.AnnotationNrcsVersion] == newNrs.Annotations[k8s.AnnotationNrcsVersion] {
+				return
+			}
+			enqueueAll()
+		},
+	})
+
+	return nil
+}
+
+type nrcsSyncher struct {
+	nrcsLister listv2alpha1.NetResourceConfigSetLister
+	nrsLister  listv2.NetResourceSetLister
+	nodeLister listv1.NodeLister
+	recorder   record.EventRecorder
+}
+
+// Update reconciles the status of the NRCS
+func (s *nrcsSyncher) Update(key string) error {
+	resource, err := s.nrcsLister.Get(key)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		nrcsLog.WithError(err).Warning("Unable to retrieve NRCS from watcher store")
+		return err
+	}
+	scopedLog := nrcsLog.WithField("name", resource.Name)
+
+	allNrcs, err := s.nrcsLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	nodes, err := s.nodeLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+
+	newStatus, err := s.nrcsStatus(resource, allNrcs, nodes)
+	if err != nil {
+		s.recorder.Eventf(resource, corev1.EventTypeWarning, "InvalidSelector", "nrcs has invalid selector: %v", err)
+		scopedLog.WithError(err).Warning("nrcs has invalid selector")
+		return nil
+	}
+
+	if !reflect.DeepEqual(&resource.Status, newStatus) {
+		resource = resource.DeepCopy()
+		resource.Status = *newStatus
+		_, err = k8s.CCEClient().CceV2alpha1().NetResourceConfigSets().UpdateStatus(context.TODO(), resource, metav1.UpdateOptions{})
+		if err != nil {
+			scopedLog.WithError(err).Error("failed to update nrcs status")
+			return err
+		}
+	}
+	return nil
+}
+
+// nrcsStatus computes the status of resource from all NRCS and nodes in the cluster
+func (s *nrcsSyncher) nrcsStatus(resource *ccev2alpha1.NetResourceConfigSet, allNrcs []*ccev2alpha1.NetResourceConfigSet, nodes []*corev1.Node) (*ccev2alpha1.NetResourceConfigSetStatus, error) {
+	newStatus := &ccev2alpha1.NetResourceConfigSetStatus{}
+	for _, node := range nodes {
+		selected, err := resource.SelectNode(node.Labels)
+		if err != nil {
+			return nil, err
+		}
+		if !selected {
+			continue
+		}
+		newStatus.NodeCount++
+
+		matched, ties := ccev2alpha1.ResolveNodeNrcs(allNrcs, node.Labels)
+		if matched == nil {
+			continue
+		}
+		if matched.Name == resource.Name && len(ties) > 0 || containsNrcs(ties, resource.Name) {
+			if newStatus.PriorityConflicts == nil {
+				newStatus.PriorityConflicts = make(map[string]string)
+			}
+			newStatus.PriorityConflicts[node.Name] = matched.Name
+		}
+		if matched.Name != resource.Name {
+			continue
+		}
+
+		newStatus.EffectiveNodeCount++
+		version := s.appliedVersion(node.Name, resource.Name)
+		if newStatus.AgentVersion == nil {
+			newStatus.AgentVersion = make(map[string]string)
+		}
+		newStatus.AgentVersion[node.Name] = version
+		if version != "" {
+			if newStatus.AgentVersionCount == nil {
+				newStatus.AgentVersionCount = make(map[string]int32)
+			}
+			newStatus.AgentVersionCount[version]++
+		}
+	}
+	return newStatus, nil
+}
+
+// appliedVersion returns the resource version of NRCS which has been applied
+// by the agent on the node, empty if the agent has not applied the NRCS
+func (s *nrcsSyncher) appliedVersion(nodeName, nrcsName string) string {
+	nrs, err := s.nrsLister.Get(nodeName)
+	if err != nil {
+		return ""
+	}
+	if nrs.Annotations[k8s.AnnotationNrcsName] != nrcsName {
+		return ""
+	}
+	return nrs.Annotations[k8s.AnnotationNrcsVersion]
+}
+
+func containsNrcs(nrcsList []*ccev2alpha1.NetResourceConfigSet, name string) bool {
+	for _, nrcs := range nrcsList {
+		if nrcs.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -10,9 +10,10 @@ import (
 )
 
 const (
-	IndexIPToEndpoint    = "ipToEndpoint"
-	IndexIPToENI         = "ipToENI"
-	IndexInstanceIDToNRS = "instanceIDToNRS"
+	IndexIPToEndpoint         = "ipToEndpoint"
+	IndexPSTSSubnetToEndpoint = "pstsSubnetToEndpoint"
+	IndexIPToENI              = "ipToENI"
+	IndexInstanceIDToNRS      = "instanceIDToNRS"
 )
 
 func StartWatchers(stopCh <-chan struct{}) {
@@ -30,6 +31,7 @@ func StartWatchers(stopCh <-chan struct{}) {
 	k8s.CCEClient().Informers.Cce().V1().Subnets().Informer()
 	k8s.CCEClient().Informers.Cce().V2().PodSubnetTopologySpreads().Informer()
 	k8s.CCEClient().Informers.Cce().V2alpha1().ClusterPodSubnetTopologySpreads().Informer()
+	k8s.CCEClient().Informers.Cce().V2alpha1().NetResourceConfigSets().Informer()
 
 	if operatorOption.Config.SecurityGroupSynerDuration > 0 {
 		k8s.CCEClient().Informers.Cce().V2alpha1().SecurityGroups().Informer()
@@ -63,6 +65,20 @@ func addIndexer() {
 			}
 			return ips, nil
 		},
+		IndexPSTSSubnetToEndpoint: func(obj interface{}) ([]string, error) {
+			ep, ok := obj.(*ccev2.CCEEndpoint)
+			if !ok {
+				return nil, fmt.Errorf("object is not CCEEndpoint")
+			}
+			if ep.Spec.Network.IPAllocation == nil || ep.Spec.Network.IPAllocation.PSTSName == "" {
+				return nil, nil
+			}
+			if ep.Status.Networking == nil || len(ep.Status.Networking.Addressing) == 0 {
+				return nil, nil
+			}
+			return []string{PSTSSubnetIndexKey(ep.Namespace, ep.Spec.Network.IPAllocation.PSTSName,
+				ep.Status.Networking.Addressing[0].Subnet)}, nil
+		},
 	})
 
 	informer = k8s.CCEClient().Informers.Cce().V2().ENIs().Informer()
@@ -95,3 +111,8 @@ func addIndexer() {
 		},
 	})
 }
+
+// PSTSSubnetIndexKey returns the key of IndexPSTSSubnetToEndpoint
+func PSTSSubnetIndexKey(namespace, pstsName, subnetID string) string {
+	return namespace + "/" + pstsName + "/" + subnetID
+}
@@ -294,6 +294,24 @@ func (ec *eniLink) ensureFromPrimaryRoute() (err error) {
 	return nil
 }
 
+// RouteTableID returns the route table of the policy routes from ENI, 0 if
+// the ENI does not use a dedicated route table
+func RouteTableID(eni *ccev2.ENI) int {
+	switch {
+	case eni.Spec.UseMode == ccev2.ENIUseModePrimaryIP,
+		eni.Spec.UseMode == ccev2.ENIUseModePrimaryWithSecondaryIP,
+		eni.Spec.Type == ccev2.ENIForBBC,
+		eni.Spec.Type == ccev2.ENIForHPC,
+		eni.Spec.Type == ccev2.ENIForERI:
+		return 0
+	}
+	offset := eni.Spec.RouteTableOffset
+	if offset <= 0 {
+		offset = defaultRouteTableIDOffset
+	}
+	return offset + eni.Status.ENIIndex
+}
+
 func (ec *eniLink) ensureENINeigh() error {
 	// set proxy neigh
 	err := ensureENIArpProxy(ec.log, ec.macAddr)
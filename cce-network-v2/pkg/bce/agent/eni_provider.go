@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	bceutils "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/bce/utils"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/datapath/bandwidth"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/datapath/qos"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/enim/eniprovider"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/health/plugin"
@@ -178,6 +179,7 @@ func (eh *eniInitFactory) OnUpdateENI(oldObj, newObj *ccev2.ENI) error {
 
 	eh.localENIs[resource.Spec.ENI.ID] = resource
 	qos.GlobalManager.ENIUpdateEventHandler(resource)
+	bandwidth.GlobalManager.ENIUpdateEventHandler(resource)
 
 	return err
 }
@@ -146,8 +146,6 @@ func (c *Client) ListENIs(_ context.Context, args eni.ListEniArgs) ([]eni.Eni, e
 	nextMarker := ""
 
 	for isTruncated {
-		t := time.Now()
-
 		listArgs := &eni.ListEniArgs{
 			VpcId:      args.VpcId,
 			Name:       args.Name,
@@ -156,7 +154,6 @@ func (c *Client) ListENIs(_ context.Context, args eni.ListEniArgs) ([]eni.Eni, e
 		}
 
 		res, err := c.eniClient.ListEnis(listArgs)
-		exportMetric("ListENI", t, err)
 		if err != nil {
 			return nil, err
 		}
@@ -177,8 +174,6 @@ func (c *Client) ListERIs(_ context.Context, args eni.ListEniArgs) ([]eni.Eni, e
 	nextMarker := ""
 
 	for isTruncated {
-		t := time.Now()
-
 		listArgs := &eni.ListEniArgs{
 			VpcId:      args.VpcId,
 			Name:       args.Name,
@@ -187,7 +182,6 @@ func (c *Client) ListERIs(_ context.Context, args eni.ListEniArgs) ([]eni.Eni, e
 		}
 
 		res, err := c.eniClient.ListEris(listArgs)
-		exportMetric("ListERI", t, err)
 		if err != nil {
 			return nil, err
 		}
@@ -202,14 +196,11 @@ func (c *Client) ListERIs(_ context.Context, args eni.ListEniArgs) ([]eni.Eni, e
 }
 
 func (c *Client) AddPrivateIP(ctx context.Context, privateIP string, eniID string, isIpv6 bool) (string, error) {
-	t := time.Now()
 	resp, err := c.eniClient.AddPrivateIp(&eni.EniPrivateIpArgs{
 		EniId:            eniID,
 		PrivateIpAddress: privateIP,
 		IsIpv6:           isIpv6,
 	})
-	exportMetricAndLog(ctx, "AddPrivateIP", t, err)
-
 	if err != nil {
 		return "", err
 	}
@@ -218,48 +209,38 @@ func (c *Client) AddPrivateIP(ctx context.Context, privateIP string, eniID strin
 }
 
 func (c *Client) DeletePrivateIP(ctx context.Context, privateIP string, eniID string, isIpv6 bool) error {
-	t := time.Now()
 	err := c.eniClient.DeletePrivateIp(&eni.EniPrivateIpArgs{
 		EniId:            eniID,
 		PrivateIpAddress: privateIP,
 		IsIpv6:           isIpv6,
 	})
-	exportMetricAndLog(ctx, "DeletePrivateIP", t, err)
 	return err
 }
 
 func (c *Client) BindENIPublicIP(ctx context.Context, privateIP string, publicIP string, eniID string) error {
-	t := time.Now()
 	err := c.eniClient.BindEniPublicIp(&eni.BindEniPublicIpArgs{
 		EniId:            eniID,
 		PrivateIpAddress: privateIP,
 		PublicIpAddress:  publicIP,
 	})
-	exportMetricAndLog(ctx, "BindENIPublicIP", t, err)
 	return err
 }
 
 func (c *Client) UnBindENIPublicIP(ctx context.Context, publicIP string, eniID string) error {
-	t := time.Now()
 	err := c.eniClient.UnBindEniPublicIp(&eni.UnBindEniPublicIpArgs{
 		EniId:           eniID,
 		PublicIpAddress: publicIP,
 	})
-	exportMetricAndLog(ctx, "UnBindENIPublicIP", t, err)
 	return err
 }
 
 func (c *Client) DirectEIP(ctx context.Context, eip string) error {
-	t := time.Now()
 	err := c.eipClient.DirectEip(eip, "")
-	exportMetricAndLog(ctx, "DirectEIP", t, err)
 	return err
 }
 
 func (c *Client) UnDirectEIP(ctx context.Context, eip string) error {
-	t := time.Now()
 	err := c.eipClient.UnDirectEip(eip, "")
-	exportMetricAndLog(ctx, "UnDirectEIP", t, err)
 	return err
 }
 
@@ -270,12 +251,9 @@ func (c *Client) ListEIPs(_ context.Context, args eip.ListEipArgs) ([]eip.EipMod
 	nextMarker := ""
 
 	for isTruncated {
-		t := time.Now()
-
 		args.Marker = nextMarker
 
 		res, err := c.eipClient.ListEip(&args)
-		exportMetric("ListEIP", t, err)
 		if err != nil {
 			return nil, err
 		}
@@ -289,24 +267,40 @@ func (c *Client) ListEIPs(_ context.Context, args eip.ListEipArgs) ([]eip.EipMod
 	return eips, nil
 }
 
-func (c *Client) BatchAddPrivateIP(ctx context.Context, privateIPs []string, count int, eniID string, isIpv6 bool) ([]string, error) {
-	t := time.Now()
+func (c *Client) CreateEIP(ctx context.Context, args *eip.CreateEipArgs) (string, error) {
+	resp, err := c.eipClient.CreateEip(args)
+	if err != nil {
+		return "", err
+	}
+	return resp.Eip, nil
+}
+
+func (c *Client) DeleteEIP(ctx context.Context, eip string) error {
+	err := c.eipClient.DeleteEip(eip, "")
+	return err
+}
+
+func (c *Client) EIPGroupMoveIn(ctx context.Context, groupID string, eips []string) error {
+	err := c.eipClient.EipGroupMoveIn(groupID, &eip.EipGroupMoveInArgs{
+		Eips: eips,
+	})
+	return err
+}
 
+func (c *Client) BatchAddPrivateIP(ctx context.Context, privateIPs []string, count int, eniID string, isIpv6 bool) ([]string, error) {
 	resp, err := c.eniClient.BatchAddPrivateIp(&eni.EniBatchPrivateIpArgs{
 		EniId:                 eniID,
 		PrivateIpAddresses:    privateIPs,
 		PrivateIpAddressCount: count,
 		IsIpv6:                isIpv6,
 	})
-
-	exportMetricAndLog(ctx, "BatchAddPrivateIP", t, err)
-
-	return resp.PrivateIpAddresses, err
+	if err != nil {
+		return nil, err
+	}
+	return resp.PrivateIpAddresses, nil
 }
 
 func (c *Client) BatchAddPrivateIpCrossSubnet(ctx context.Context, eniID, subnetID string, privateIPs []string, count int, isIpv6 bool) ([]string, error) {
-	t := time.Now()
-
 	var ips []eni.PrivateIpArgs
 	arg := &eni.EniBatchAddPrivateIpCrossSubnetArgs{
 		EniId:  eniID,
@@ -324,30 +318,23 @@ func (c *Client) BatchAddPrivateIpCrossSubnet(ctx context.Context, eniID, subnet
 	}
 
 	resp, err := c.eniClient.BatchAddPrivateIpCrossSubnet(arg)
-
-	exportMetricAndLog(ctx, "BatchAddPrivateIpCrossSubnet", t, err)
-
-	return resp.PrivateIpAddresses, err
+	if err != nil {
+		return nil, err
+	}
+	return resp.PrivateIpAddresses, nil
 }
 
 func (c *Client) BatchDeletePrivateIP(ctx context.Context, privateIPs []string, eniID string, isIpv6 bool) error {
-	t := time.Now()
-
 	err := c.eniClient.BatchDeletePrivateIp(&eni.EniBatchPrivateIpArgs{
 		EniId:              eniID,
 		PrivateIpAddresses: privateIPs,
 		IsIpv6:             isIpv6,
 	})
-
-	exportMetricAndLog(ctx, "BatchDeletePrivateIP", t, err)
-
 	return err
 }
 
 func (c *Client) CreateENI(ctx context.Context, args *eni.CreateEniArgs) (string, error) {
-	t := time.Now()
 	resp, err := c.eniClient.CreateEni(args)
-	exportMetric("CreateENI", t, err)
 	if err != nil {
 		return "", err
 	}
@@ -355,49 +342,53 @@ func (c *Client) CreateENI(ctx context.Context, args *eni.CreateEniArgs) (string
 }
 
 func (c *Client) DeleteENI(ctx context.Context, eniID string) error {
-	t := time.Now()
 	err := c.eniClient.DeleteEni(&eni.DeleteEniArgs{
 		EniId: eniID,
 	})
-	exportMetric("DeleteENI", t, err)
 	return err
 }
 
 func (c *Client) AttachENI(ctx context.Context, args *eni.EniInstance) error {
-	t := time.Now()
 	err := c.eniClient.AttachEniInstance(args)
-	exportMetric("AttachENI", t, err)
 	return err
 }
 
 func (c *Client) DetachENI(ctx context.Context, args *eni.EniInstance) error {
-	t := time.Now()
 	err := c.eniClient.DetachEniInstance(args)
-	exportMetric("DetachENI", t, err)
 	return err
 }
 
 func (c *Client) StatENI(ctx context.Context, eniID string) (*eni.Eni, error) {
-	t := time.Now()
 	resp, err := c.eniClient.GetEniDetail(eniID)
-	exportMetric("StatENI", t, err)
 	return resp, err
 }
 
+// UpdateENISecurityGroup implements Interface.
+func (c *Client) UpdateENISecurityGroup(ctx context.Context, eniID string, securityGroupIDs []string) error {
+	return c.eniClient.UpdateEniSecurityGroup(&eni.UpdateEniSecurityGroupArgs{
+		EniId:            eniID,
+		SecurityGroupIds: securityGroupIDs,
+	})
+}
+
+// UpdateENIEnterpriseSecurityGroup implements Interface.
+func (c *Client) UpdateENIEnterpriseSecurityGroup(ctx context.Context, eniID string, enterpriseSecurityGroupIDs []string) error {
+	return c.eniClient.UpdateEniEnterpriseSecurityGroup(&eni.UpdateEniEnterpriseSecurityGroupArgs{
+		EniId:                      eniID,
+		EnterpriseSecurityGroupIds: enterpriseSecurityGroupIDs,
+	})
+}
+
 // GetENIQuota implements Interface.
 func (c *Client) GetENIQuota(ctx context.Context, instanceID string) (*eni.EniQuoteInfo, error) {
-	t := time.Now()
 	resp, err := c.eniClient.GetEniQuota(&eni.EniQuoteArgs{
 		InstanceId: instanceID,
 	})
-	exportMetric("GET /v1/eni/quota", t, err)
 	return resp, err
 }
 
 func (c *Client) ListRouteTable(ctx context.Context, vpcID, routeTableID string) ([]vpc.RouteRule, error) {
-	t := time.Now()
 	resp, err := c.vpcClient.GetRouteTableDetail(routeTableID, vpcID)
-	exportMetric("ListRouteTable", t, err)
 	if err != nil {
 		return nil, err
 	}
@@ -405,9 +396,7 @@ func (c *Client) ListRouteTable(ctx context.Context, vpcID, routeTableID string)
 }
 
 func (c *Client) CreateRouteRule(ctx context.Context, args *vpc.CreateRouteRuleArgs) (string, error) {
-	t := time.Now()
 	resp, err := c.vpcClient.CreateRouteRule(args)
-	exportMetric("CreateRouteRule", t, err)
 	if err != nil {
 		return "", err
 	}
@@ -415,16 +404,12 @@ func (c *Client) CreateRouteRule(ctx context.Context, args *vpc.CreateRouteRuleA
 }
 
 func (c *Client) DeleteRouteRule(ctx context.Context, routeID string) error {
-	t := time.Now()
 	err := c.vpcClient.DeleteRouteRule(routeID, "")
-	exportMetric("DeleteRouteRule", t, err)
 	return err
 }
 
 func (c *Client) DescribeSubnet(ctx context.Context, subnetID string) (*vpc.Subnet, error) {
-	t := time.Now()
 	resp, err := c.vpcClient.GetSubnetDetail(subnetID)
-	exportMetric("DescribeSubnet", t, err)
 	if err != nil {
 		return nil, err
 	}
@@ -432,9 +417,7 @@ func (c *Client) DescribeSubnet(ctx context.Context, subnetID string) (*vpc.Subn
 }
 
 func (c *Client) ListSubnets(ctx context.Context, args *vpc.ListSubnetArgs) ([]vpc.Subnet, error) {
-	t := time.Now()
 	resp, err := c.vpcClient.ListSubnets(args)
-	exportMetric("ListSubnets", t, err)
 	if err != nil {
 		return nil, err
 	}
@@ -442,9 +425,7 @@ func (c *Client) ListSubnets(ctx context.Context, args *vpc.ListSubnetArgs) ([]v
 }
 
 func (c *Client) GetBCCInstanceDetail(ctx context.Context, instanceID string) (*bccapi.InstanceModel, error) {
-	t := time.Now()
 	resp, err := c.bccClient.GetInstanceDetail(instanceID)
-	exportMetric("GetBCCInstanceDetail", t, err)
 	if err != nil {
 		return nil, err
 	}
@@ -458,8 +439,6 @@ func (c *Client) ListSecurityGroup(ctx context.Context, vpcID, instanceID string
 	nextMarker := ""
 
 	for isTruncated {
-		t := time.Now()
-
 		args := bccapi.ListSecurityGroupArgs{
 			Marker:     nextMarker,
 			InstanceId: instanceID,
@@ -467,7 +446,6 @@ func (c *Client) ListSecurityGroup(ctx context.Context, vpcID, instanceID string
 		}
 
 		res, err := c.bccClient.ListSecurityGroup(&args)
-		exportMetricAndLog(ctx, "ListSecurityGroup", t, err)
 		if err != nil {
 			return nil, err
 		}
@@ -482,9 +460,7 @@ func (c *Client) ListSecurityGroup(ctx context.Context, vpcID, instanceID string
 }
 
 func (c *Client) ListAclEntrys(ctx context.Context, vpcID string) ([]vpc.AclEntry, error) {
-	t := time.Now()
 	result, err := c.vpcClient.ListAclEntrys(vpcID)
-	exportMetric("ListAclEntrys", t, err)
 	if err != nil {
 		return nil, err
 	}
@@ -497,13 +473,11 @@ func (c *Client) ListEsg(ctx context.Context, instanceID string) ([]esg.Enterpri
 	isTruncated := true
 	nextMarker := ""
 	for isTruncated {
-		t := time.Now()
 		args := esg.ListEsgArgs{
 			Marker:     nextMarker,
 			InstanceId: instanceID,
 		}
 		res, err := c.esgClient.ListEsg(&args)
-		exportMetricAndLog(ctx, "ListEsg", t, err)
 		if err != nil {
 			return nil, err
 		}
@@ -515,82 +489,60 @@ func (c *Client) ListEsg(ctx context.Context, instanceID string) ([]esg.Enterpri
 }
 
 func (c *Client) GetBBCInstanceDetail(ctx context.Context, instanceID string) (*bbc.InstanceModel, error) {
-	t := time.Now()
 	resp, err := c.bbcClient.GetInstanceDetail(instanceID)
-	exportMetric("GetBBCInstanceDetail", t, err)
 	return resp, err
 }
 
 func (c *Client) GetBBCInstanceENI(ctx context.Context, instanceID string) (*bbc.GetInstanceEniResult, error) {
-	t := time.Now()
 	resp, err := c.bbcClient.GetInstanceEni(instanceID)
-	exportMetric("GetBBCInstanceENI", t, err)
 	return resp, err
 }
 
 func (c *Client) BBCBatchAddIP(ctx context.Context, args *bbc.BatchAddIpArgs) (*bbc.BatchAddIpResponse, error) {
-	t := time.Now()
 	resp, err := c.bbcClient.BatchAddIP(args)
-	exportMetricAndLog(ctx, "BBCBatchAddIP", t, err)
 	return resp, err
 }
 
 func (c *Client) BBCBatchDelIP(ctx context.Context, args *bbc.BatchDelIpArgs) error {
-	t := time.Now()
 	err := c.bbcClient.BatchDelIP(args)
-	exportMetricAndLog(ctx, "BBCBatchDelIP", t, err)
 	return err
 }
 
 func (c *Client) BBCBatchAddIPCrossSubnet(ctx context.Context, args *bbc.BatchAddIpCrossSubnetArgs) (*bbc.BatchAddIpResponse, error) {
-	t := time.Now()
 	resp, err := c.bbcClient.BatchAddIPCrossSubnet(args)
-	exportMetricAndLog(ctx, "BBCBatchAddIPCrossSubnet", t, err)
 	return resp, err
 }
 
 func (c *Client) GetHPCEniID(ctx context.Context, instanceID string) (*hpc.EniList, error) {
-	t := time.Now()
 	resp, err := c.hpcClient.GetHPCEniID(instanceID)
-	exportMetricAndLog(ctx, "GetHPCEniID", t, err)
 	return resp, err
 }
 
 func (c *Client) BatchDeleteHpcEniPrivateIP(ctx context.Context, args *hpc.EniBatchDeleteIPArgs) error {
-	t := time.Now()
 	err := c.hpcClient.BatchDeletePrivateIPByHpc(args)
-	exportMetricAndLog(ctx, "BatchDeleteHpcEniPrivateIP", t, err)
 	return err
 }
 
 func (c *Client) BatchAddHpcEniPrivateIP(ctx context.Context, args *hpc.EniBatchPrivateIPArgs) (*hpc.BatchAddPrivateIPResult, error) {
-	t := time.Now()
 	resp, err := c.hpcClient.BatchAddPrivateIPByHpc(args)
-	exportMetricAndLog(ctx, "BatchAddHpcEniPrivateIP", t, err)
 	return resp, err
 }
 
 // BCCBatchAddIP implements Interface.
 func (c *Client) BCCBatchAddIP(ctx context.Context, args *bccapi.BatchAddIpArgs) (*bccapi.BatchAddIpResponse, error) {
-	t := time.Now()
 	resp, err := c.bccClient.BatchAddIP(args)
-	exportMetricAndLog(ctx, BCCBatchAddIP, t, err)
 	return resp, err
 }
 
 // BCCBatchDelIP implements Interface.
 func (c *Client) BCCBatchDelIP(ctx context.Context, args *bccapi.BatchDelIpArgs) error {
-	t := time.Now()
 	err := c.bccClient.BatchDelIP(args)
-	exportMetricAndLog(ctx, BCCBatchDelIP, t, err)
 	return err
 }
 
 // ListBCCInstanceEni implements Interface.
 func (c *Client) ListBCCInstanceEni(ctx context.Context, instanceID string) ([]bccapi.Eni, error) {
-	t := time.Now()
 	resp, err := c.bccClient.ListInstanceEnis(instanceID)
-	exportMetricAndLog(ctx, BCCListENIs, t, err)
 	if err != nil {
 		return nil, err
 	}
@@ -599,9 +551,7 @@ func (c *Client) ListBCCInstanceEni(ctx context.Context, instanceID string) ([]b
 
 // DescribeVPC implements Interface.
 func (c *Client) DescribeVPC(ctx context.Context, vpcID string) (*vpc.ShowVPCModel, error) {
-	t := time.Now()
 	resp, err := c.vpcClient.GetVPCDetail(vpcID)
-	exportMetricAndLog(ctx, DescribeVPC, t, err)
 	if err != nil {
 		return nil, err
 	}
@@ -16,10 +16,16 @@
 package cloud
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/baidubce/bce-sdk-go/bce"
+
+	ccev2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2"
 )
 
 type ErrorReason string
@@ -40,43 +46,179 @@ const (
 	ErrorReasonNoSRouteRuleExceedQuota             ErrorReason = "RouteRuleExceedQuota"
 )
 
+// ErrorClass tells whether a failed cloud API call is worth retrying
+type ErrorClass string
+
+const (
+	// ErrorClassThrottled means the request is rejected by the rate limit of cloud, retry it with backoff
+	ErrorClassThrottled ErrorClass = "Throttled"
+	// ErrorClassTransient is a temporary failure of cloud or network, the request can be retried
+	ErrorClassTransient ErrorClass = "Transient"
+	// ErrorClassPermanent fails again if the request is retried unchanged
+	ErrorClassPermanent ErrorClass = "Permanent"
+)
+
+// errorReasonByCode maps the error code of BCE openapi to the reason
+var errorReasonByCode = map[string]ErrorReason{
+	"privateipnotexistexception":          ErrorReasonENIPrivateIPNotFound,
+	"eniidexception":                      ErrorReasonENINotFound,
+	"subnethasnomoreipexception":          ErrorReasonSubnetHasNoMoreIP,
+	"ratelimit":                           ErrorReasonRateLimit,
+	"nosuchobject":                        ErrorReasonNoSuchObject,
+	"vmmemorycannotattachmoreipexception": ErrorReasonVmMemoryCanNotAttachMoreIpException,
+	"privateipinuseexception":             ErrorReasonPrivateIPInUse,
+	"routerulerepeated":                   ErrorReasonRouteRuleRepeated,
+	"quotalimitexceeded":                  ErrorReasonQuotaLimitExceeded,
+	"routeruleexceedquota":                ErrorReasonNoSRouteRuleExceedQuota,
+}
+
+// errorReasonByAPICode maps the error code of the BBC openapi, which only makes sense
+// together with the API returning it, to the reason
+var errorReasonByAPICode = map[string]map[string]ErrorReason{
+	"BBCBatchDelIP": {
+		// TODO: remove BadRequest when IaaS fixes their API
+		"badrequest": ErrorReasonBBCENIPrivateIPNotFound,
+	},
+	"BBCBatchAddIP": {
+		"badrequest": ErrorReasonBBCENIPrivateIPExceedLimit,
+	},
+	"BBCBatchAddIPCrossSubnet": {
+		"badrequest": ErrorReasonBBCENIPrivateIPExceedLimit,
+	},
+}
+
+// Error is the error returned by cloud.Client. It carries the details of the
+// BCE service error, so that callers need not to parse the error message.
+type Error struct {
+	// API is the name of cloud API returning the error
+	API        string
+	Code       string
+	Message    string
+	StatusCode int
+	RequestID  string
+	Reason     ErrorReason
+	Class      ErrorClass
+
+	err error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s failed: %v", e.API, e.err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// WrapError wraps the error returned by the cloud API into *Error
+func WrapError(api string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var cloudErr *Error
+	if errors.As(err, &cloudErr) {
+		return err
+	}
+
+	e := &Error{API: api, Message: err.Error(), err: err}
+	var serviceErr *bce.BceServiceError
+	if errors.As(err, &serviceErr) {
+		e.Code = serviceErr.Code
+		e.Message = serviceErr.Message
+		e.StatusCode = serviceErr.StatusCode
+		e.RequestID = serviceErr.RequestId
+	} else if parsed := parseServiceError(err.Error()); parsed != nil {
+		e.Code = parsed.Code
+		e.StatusCode = parsed.StatusCode
+		e.RequestID = parsed.RequestId
+	}
+	e.Reason = reasonForCode(api, e.Code)
+	e.Class = classForError(e)
+	return e
+}
+
+func reasonForCode(api, code string) ErrorReason {
+	code = strings.ToLower(code)
+	if reason, ok := errorReasonByAPICode[api][code]; ok {
+		return reason
+	}
+	if reason, ok := errorReasonByCode[code]; ok {
+		return reason
+	}
+	if strings.HasSuffix(code, "exceedlimitexception") {
+		return ErrorReasonBBCENIPrivateIPExceedLimit
+	}
+	if strings.HasSuffix(code, "limitexceeded") {
+		return ErrorReasonQuotaLimitExceeded
+	}
+	return ErrorReasonUnknown
+}
+
+func classForError(e *Error) ErrorClass {
+	switch {
+	case e.Reason == ErrorReasonRateLimit || e.StatusCode == http.StatusTooManyRequests:
+		return ErrorClassThrottled
+	case e.StatusCode >= http.StatusInternalServerError:
+		return ErrorClassTransient
+	case e.StatusCode == 0 && e.Code == "":
+		// the request failed before it reached cloud, such as network error and timeout
+		return ErrorClassTransient
+	}
+	return ErrorClassPermanent
+}
+
+var (
+	// serviceErrorPattern matches the message of bce.BceServiceError
+	serviceErrorPattern = regexp.MustCompile(`\[Code: ([^;]*); Message: .*; RequestId: ([^\]]*)\]`)
+	// legacyServiceErrorPattern matches the message of BCE service error from the legacy SDK
+	legacyServiceErrorPattern = regexp.MustCompile(`Error Code: "([^"]*)", Status Code: (\d+), Request Id: "([^"]*)"`)
+)
+
+// parseServiceError recovers the BCE service error from the message of error, which
+// has lost its type after being formatted by fmt.Errorf("%v") or passed as a string
+func parseServiceError(msg string) *bce.BceServiceError {
+	if m := legacyServiceErrorPattern.FindStringSubmatch(msg); m != nil {
+		status, _ := strconv.Atoi(m[2])
+		return &bce.BceServiceError{Code: m[1], StatusCode: status, RequestId: m[3]}
+	}
+	if m := serviceErrorPattern.FindStringSubmatch(msg); m != nil {
+		return &bce.BceServiceError{Code: m[1], RequestId: m[2]}
+	}
+	return nil
+}
+
+// asError returns the *Error of err, errors not returned by cloud.Client are wrapped with an empty API
+func asError(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	var cloudErr *Error
+	if errors.As(WrapError("", err), &cloudErr) {
+		return cloudErr
+	}
+	return nil
+}
+
 func ReasonForError(err error) ErrorReason {
-	if err != nil {
-		errMsg := err.Error()
-		switch {
-		case caseInsensitiveContains(errMsg, "PrivateIPNotExistException"):
-			return ErrorReasonENIPrivateIPNotFound
-		case caseInsensitiveContains(errMsg, "EniIdException"):
-			return ErrorReasonENINotFound
-		case caseInsensitiveContains(errMsg, "SubnetHasNoMoreIpException"):
-			return ErrorReasonSubnetHasNoMoreIP
-		case caseInsensitiveContains(errMsg, "RateLimit"):
-			return ErrorReasonRateLimit
-		case caseInsensitiveContains(errMsg, "NoSuchObject"):
-			return ErrorReasonNoSuchObject
-		case caseInsensitiveContains(errMsg, "is invalid"):
-			// TODO: remove BadRequest when IaaS fixes their API
-			return ErrorReasonBBCENIPrivateIPNotFound
-		case caseInsensitiveContains(errMsg, "VmMemoryCanNotAttachMoreIpException"):
-			return ErrorReasonVmMemoryCanNotAttachMoreIpException
-		case caseInsensitiveContains(errMsg, "ExceedLimitException") || caseInsensitiveContains(errMsg, "BadRequest"):
-			// TODO: remove BadRequest when IaaS fixes their API
-			return ErrorReasonBBCENIPrivateIPExceedLimit
-		case caseInsensitiveContains(errMsg, "PrivateIpInUseException"):
-			return ErrorReasonPrivateIPInUse
-		case caseInsensitiveContains(errMsg, "RouteRuleRepeated"):
-			return ErrorReasonRouteRuleRepeated
-		case caseInsensitiveContains(errMsg, "QuotaLimitExceeded"), caseInsensitiveContains(errMsg, "LimitExceeded"):
-			return ErrorReasonQuotaLimitExceeded
-		case caseInsensitiveContains(errMsg, "ErrorReasonNoSuchObject"):
-			return ErrorReasonNoSuchObject
-		case caseInsensitiveContains(errMsg, "RouteRuleExceedQuota"):
-			return ErrorReasonNoSRouteRuleExceedQuota
-		}
+	if e := asError(err); e != nil {
+		return e.Reason
 	}
 	return ErrorReasonUnknown
 }
 
+// ClassForError returns the retryability of the error, nil error is permanent
+func ClassForError(err error) ErrorClass {
+	if e := asError(err); e != nil {
+		return e.Class
+	}
+	return ErrorClassPermanent
+}
+
+// IsErrorRetryable tells whether the failed request can succeed if it is retried
+func IsErrorRetryable(err error) bool {
+	return err != nil && ClassForError(err) != ErrorClassPermanent
+}
+
 // IsErrorENIPrivateIPNotFound 判定删除辅助 IP 的 err 是否是因为 IP 不属于弹性网卡
 func IsErrorENIPrivateIPNotFound(err error) bool {
 	return ReasonForError(err) == ErrorReasonENIPrivateIPNotFound
@@ -126,20 +268,23 @@ func IsErrorCreateRouteRuleExceededQuota(err error) bool {
 	return ReasonForError(err) == ErrorReasonNoSRouteRuleExceedQuota
 }
 
-func caseInsensitiveContains(s, substr string) bool {
-	s, substr = strings.ToLower(s), strings.ToLower(substr)
-	return strings.Contains(s, substr)
-}
-
 func BceServiceErrorToHTTPCode(err error) int {
 	if err == nil {
 		return http.StatusOK
 	}
 
-	bceErr, ok := err.(*bce.BceServiceError)
-	if ok {
-		return bceErr.StatusCode
+	if e := asError(err); e != nil {
+		return e.StatusCode
 	}
-
 	return 0
 }
+
+// NewErrorStatusChange records the failure of cloud API in the status of ENI. The code
+// of status change is the reason of cloud error if it is known.
+func NewErrorStatusChange(err error, msg string) *ccev2.StatusChange {
+	reason := ReasonForError(err)
+	if reason == ErrorReasonUnknown {
+		return ccev2.NewErrorStatusChange(msg)
+	}
+	return ccev2.NewCustomerErrorStatusChange(string(reason), msg)
+}
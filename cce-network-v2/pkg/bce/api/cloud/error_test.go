@@ -16,8 +16,12 @@
 package cloud
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"testing"
+
+	"github.com/baidubce/bce-sdk-go/bce"
 )
 
 func TestIsENINotFound(t *testing.T) {
@@ -81,3 +85,100 @@ func TestIsErrorBBCENIPrivateIPNotFound(t *testing.T) {
 		})
 	}
 }
+
+func TestWrapError(t *testing.T) {
+	tests := []struct {
+		name       string
+		api        string
+		err        error
+		wantReason ErrorReason
+		wantClass  ErrorClass
+		wantStatus int
+	}{
+		{
+			name:       "bad request of bbc api",
+			api:        "BBCBatchDelIP",
+			err:        bce.NewBceServiceError("BadRequest", "The ips [192.168.24.51] is invalid", "req-1", http.StatusBadRequest),
+			wantReason: ErrorReasonBBCENIPrivateIPNotFound,
+			wantClass:  ErrorClassPermanent,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "bad request of other api is not classified",
+			api:        "BatchAddPrivateIP",
+			err:        bce.NewBceServiceError("BadRequest", "The param eniId is invalid", "req-2", http.StatusBadRequest),
+			wantReason: ErrorReasonUnknown,
+			wantClass:  ErrorClassPermanent,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "subnet has no more ip",
+			api:        "BatchAddPrivateIP",
+			err:        bce.NewBceServiceError("SubnetHasNoMoreIpException", "no more ip", "req-3", http.StatusBadRequest),
+			wantReason: ErrorReasonSubnetHasNoMoreIP,
+			wantClass:  ErrorClassPermanent,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "rate limit",
+			api:        "ListENI",
+			err:        bce.NewBceServiceError("RateLimit", "too many requests", "req-4", http.StatusTooManyRequests),
+			wantReason: ErrorReasonRateLimit,
+			wantClass:  ErrorClassThrottled,
+			wantStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:       "server error",
+			api:        "ListENI",
+			err:        bce.NewBceServiceError("InternalError", "internal error", "req-5", http.StatusInternalServerError),
+			wantReason: ErrorReasonUnknown,
+			wantClass:  ErrorClassTransient,
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "network error",
+			api:        "ListENI",
+			err:        bce.NewBceClientError("execute http request failed"),
+			wantReason: ErrorReasonUnknown,
+			wantClass:  ErrorClassTransient,
+		},
+		{
+			name:       "message of service error without type",
+			api:        "DeleteENI",
+			err:        fmt.Errorf("delete eni failed: %v", bce.NewBceServiceError("EniIdException", "The param eniId is invalid", "req-6", http.StatusBadRequest)),
+			wantReason: ErrorReasonENINotFound,
+			wantClass:  ErrorClassPermanent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := WrapError(tt.api, tt.err)
+			var cloudErr *Error
+			if !errors.As(err, &cloudErr) {
+				t.Fatalf("WrapError() = %T, want *Error", err)
+			}
+			if cloudErr.API != tt.api {
+				t.Errorf("API = %v, want %v", cloudErr.API, tt.api)
+			}
+			if cloudErr.Reason != tt.wantReason || ReasonForError(err) != tt.wantReason {
+				t.Errorf("Reason = %v, want %v", cloudErr.Reason, tt.wantReason)
+			}
+			if ClassForError(fmt.Errorf("wrapped: %w", err)) != tt.wantClass {
+				t.Errorf("Class = %v, want %v", cloudErr.Class, tt.wantClass)
+			}
+			if BceServiceErrorToHTTPCode(err) != tt.wantStatus {
+				t.Errorf("StatusCode = %v, want %v", BceServiceErrorToHTTPCode(err), tt.wantStatus)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("WrapError() should keep the original error")
+			}
+		})
+	}
+
+	if WrapError("ListENI", nil) != nil {
+		t.Errorf("WrapError(nil) should be nil")
+	}
+	if IsErrorRetryable(nil) {
+		t.Errorf("nil error should not be retryable")
+	}
+}
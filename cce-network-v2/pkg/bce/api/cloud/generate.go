@@ -0,0 +1,19 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+package cloud
+
+//go:generate go run github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/tools/cloudgen -input types.go -interface Interface -header ../../../../hack/custom-boilerplate.go.txt
+//go:generate go run github.com/golang/mock/mockgen -copyright_file ../../../../hack/custom-boilerplate.go.txt -source types.go -destination testing/mock_cloud.go -package=testing
@@ -14,16 +14,38 @@ var (
 	log = logging.NewSubysLogger("bce-cloud")
 )
 
-func exportMetric(funcName string, startTime time.Time, err error) {
+// exportMetric exports the latency of cloud API and returns err wrapped as *Error
+func exportMetric(funcName string, startTime time.Time, err error) error {
+	err = WrapError(funcName, err)
+	reason := ""
+	if err != nil {
+		reason = string(ReasonForError(err))
+	}
 	metrics.CloudAPIRequestDurationMillisesconds.WithLabelValues(
 		option.Config.CCEClusterID,
 		funcName,
 		fmt.Sprint(err != nil),
 		fmt.Sprint(BceServiceErrorToHTTPCode(err)),
+		reason,
 	).Observe(float64(time.Since(startTime) / time.Millisecond))
+	return err
 }
 
-func exportMetricAndLog(ctx context.Context, funcName string, startTime time.Time, err error) {
-	exportMetric(funcName, startTime, err)
+// metricsClient implements Interface, it exports the latency of each API
+// call and wraps the returned error as *Error. The methods are generated into
+// zz_generated.metrics.go from the annotations of Interface.
+type metricsClient struct {
+	client Interface
+}
+
+// NewMetricsClient returns a client which exports the metrics of the API calls
+// of client
+func NewMetricsClient(client Interface) Interface {
+	return &metricsClient{client: client}
+}
+
+func exportMetricAndLog(ctx context.Context, funcName string, startTime time.Time, err error) error {
+	err = exportMetric(funcName, startTime, err)
 	log.WithContext(ctx).WithField("funcName", funcName).WithField("elapsed", time.Since(startTime)).Debug("export metric success")
+	return err
 }
@@ -0,0 +1,59 @@
+/*
+ * Copyright (c) 2021 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+package cloud
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/services/eni"
+)
+
+type fakeENIClient struct {
+	Interface
+	err error
+}
+
+func (f *fakeENIClient) CreateENI(ctx context.Context, args *eni.CreateEniArgs) (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+	return "eni-test", nil
+}
+
+func TestMetricsClientWrapError(t *testing.T) {
+	serviceErr := &bce.BceServiceError{Code: "RateLimit", StatusCode: http.StatusTooManyRequests}
+	client := NewMetricsClient(&fakeENIClient{err: serviceErr})
+
+	_, err := client.CreateENI(context.TODO(), &eni.CreateEniArgs{})
+	var cloudErr *Error
+	if !errors.As(err, &cloudErr) {
+		t.Fatalf("CreateENI() error = %T, want *Error", err)
+	}
+	if cloudErr.API != "CreateENI" {
+		t.Errorf("Error.API = %s, want CreateENI", cloudErr.API)
+	}
+	if ClassForError(err) != ErrorClassThrottled {
+		t.Errorf("ClassForError() = %v, want %v", ClassForError(err), ErrorClassThrottled)
+	}
+
+	client = NewMetricsClient(&fakeENIClient{})
+	if id, err := client.CreateENI(context.TODO(), &eni.CreateEniArgs{}); err != nil || id != "eni-test" {
+		t.Errorf("CreateENI() = %s, %v, want eni-test, nil", id, err)
+	}
+}
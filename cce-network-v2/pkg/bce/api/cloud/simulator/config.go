@@ -0,0 +1,152 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+package simulator
+
+import (
+	"fmt"
+	"os"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// InstanceType is the kind of machine simulated, which decides the APIs used to
+// manage the IPs of its primary interface
+type InstanceType string
+
+const (
+	InstanceTypeBCC InstanceType = "BCC"
+	InstanceTypeEBC InstanceType = "EBC"
+	InstanceTypeBBC InstanceType = "BBC"
+)
+
+const (
+	defaultENIQuota       = 8
+	defaultIPQuotaPerENI  = 16
+	defaultRouteRuleQuota = 200
+	defaultEIPCIDR        = "106.12.0.0/16"
+)
+
+// Config is the initial state of the simulated cloud
+type Config struct {
+	// Seed of the random generator used by fault injection, so that a soak test can be replayed
+	Seed int64 `json:"seed,omitempty"`
+
+	VPCs      []VPCConfig      `json:"vpcs"`
+	Instances []InstanceConfig `json:"instances,omitempty"`
+
+	// ENIAttachDelay is how long an ENI stays attaching or detaching before it becomes inuse or available
+	ENIAttachDelay metav1.Duration `json:"eniAttachDelay,omitempty"`
+	// RouteRuleQuota is the max number of route rules in a route table
+	RouteRuleQuota int `json:"routeRuleQuota,omitempty"`
+	// EIPCIDR is the range of EIPs created by CreateEIP
+	EIPCIDR string `json:"eipCIDR,omitempty"`
+	// EnterpriseSecurityGroups are returned by ListEsg
+	EnterpriseSecurityGroups []string `json:"enterpriseSecurityGroups,omitempty"`
+
+	Faults []FaultRule `json:"faults,omitempty"`
+}
+
+// VPCConfig is a VPC with its subnets
+type VPCConfig struct {
+	ID            string   `json:"id"`
+	CIDR          string   `json:"cidr"`
+	SecondaryCIDR []string `json:"secondaryCIDR,omitempty"`
+	// RouteTableID is the default route table of VPC, default is rt-<vpc id>
+	RouteTableID string `json:"routeTableID,omitempty"`
+	// RouteTableIDs are the other route tables of VPC, such as the route tables associated with subnets
+	RouteTableIDs  []string       `json:"routeTableIDs,omitempty"`
+	SecurityGroups []string       `json:"securityGroups,omitempty"`
+	Subnets        []SubnetConfig `json:"subnets"`
+}
+
+// SubnetConfig is a subnet whose IPs are accounted by its CIDR
+type SubnetConfig struct {
+	ID       string `json:"id"`
+	Zone     string `json:"zone"`
+	CIDR     string `json:"cidr"`
+	IPv6CIDR string `json:"ipv6CIDR,omitempty"`
+	// Type is the subnet type of VPC, default is BCC
+	Type string `json:"type,omitempty"`
+}
+
+// InstanceConfig is a machine in VPC
+type InstanceConfig struct {
+	ID   string       `json:"id"`
+	Type InstanceType `json:"type"`
+	// SubnetID is the subnet of the primary interface
+	SubnetID string `json:"subnetID"`
+	// ENIQuota is the max number of ENIs attached to the instance, 0 means the instance does not support ENI
+	ENIQuota *int `json:"eniQuota,omitempty"`
+	// IPQuotaPerENI is the max number of private IPs of an ENI, including the primary IP
+	IPQuotaPerENI int `json:"ipQuotaPerENI,omitempty"`
+	// RDMASubnetID creates an HPC RDMA interface in the subnet if it is set
+	RDMASubnetID string `json:"rdmaSubnetID,omitempty"`
+}
+
+// FaultRule injects faults into the API of simulator
+type FaultRule struct {
+	// API is the method name of cloud.Interface, such as BatchAddPrivateIP. Empty or "*" matches all APIs.
+	API string `json:"api,omitempty"`
+	// Latency is added before the API is handled
+	Latency metav1.Duration `json:"latency,omitempty"`
+	// Error is the BCE error code returned with the probability of ErrorRate,
+	// such as RateLimit, SubnetHasNoMoreIpException and InternalError
+	Error     string  `json:"error,omitempty"`
+	ErrorRate float64 `json:"errorRate,omitempty"`
+	// PartialBatchRatio makes the batch add IP APIs only allocate the ratio of the requested IPs
+	PartialBatchRatio float64 `json:"partialBatchRatio,omitempty"`
+}
+
+func (f *FaultRule) match(api string) bool {
+	return f.API == "" || f.API == "*" || f.API == api
+}
+
+// LoadConfig reads the config of simulator from a yaml or json file
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read simulator config %s: %w", path, err)
+	}
+	cfg := &Config{}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse simulator config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+func (c *Config) setDefaults() {
+	if c.RouteRuleQuota == 0 {
+		c.RouteRuleQuota = defaultRouteRuleQuota
+	}
+	if c.EIPCIDR == "" {
+		c.EIPCIDR = defaultEIPCIDR
+	}
+	for i := range c.VPCs {
+		if c.VPCs[i].RouteTableID == "" {
+			c.VPCs[i].RouteTableID = "rt-" + c.VPCs[i].ID
+		}
+	}
+	for i := range c.Instances {
+		if c.Instances[i].ENIQuota == nil {
+			quota := defaultENIQuota
+			c.Instances[i].ENIQuota = &quota
+		}
+		if c.Instances[i].IPQuotaPerENI == 0 {
+			c.Instances[i].IPQuotaPerENI = defaultIPQuotaPerENI
+		}
+	}
+}
@@ -0,0 +1,259 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+package simulator
+
+import (
+	"context"
+	"sort"
+
+	"github.com/baidubce/bce-sdk-go/services/eip"
+)
+
+const (
+	eipStatusAvailable = "available"
+	eipStatusBinded    = "binded"
+
+	eipInstanceTypeENI = "ENI"
+)
+
+type eipState struct {
+	model eip.EipModel
+	// eniID and privateIP are the ENI and private IP the EIP is bound to
+	eniID     string
+	privateIP string
+	direct    bool
+}
+
+func (e *eipState) unbind() {
+	e.eniID = ""
+	e.privateIP = ""
+	e.direct = false
+	e.model.Status = eipStatusAvailable
+	e.model.InstanceType = ""
+	e.model.InstanceId = ""
+}
+
+// getEIP returns the EIP by its address, caller must hold the mutex
+func (s *Simulator) getEIP(address string) (*eipState, error) {
+	e, ok := s.eips[address]
+	if !ok {
+		return nil, newError(CodeNoSuchObject, "eip %s not found", address)
+	}
+	return e, nil
+}
+
+func (s *Simulator) CreateEIP(ctx context.Context, args *eip.CreateEipArgs) (string, error) {
+	if _, err := s.inject(ctx, "CreateEIP"); err != nil {
+		return "", err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if args.ClientToken != "" {
+		if address, ok := s.eipTokens[args.ClientToken]; ok {
+			return address, nil
+		}
+	}
+	if args.BandWidthInMbps <= 0 {
+		return "", newError(CodeInvalidParameter, "bandwidth of eip must be positive")
+	}
+
+	id := s.nextID("ip")
+	address, err := s.eipPool.allocateNext(id)
+	if err != nil {
+		return "", newError(CodeQuotaLimitExceeded, "no more eip can be created")
+	}
+	model := eip.EipModel{
+		Name:            args.Name,
+		Eip:             address,
+		EipId:           id,
+		Status:          eipStatusAvailable,
+		EipInstanceType: "normal",
+		BandWidthInMbps: args.BandWidthInMbps,
+		CreateTime:      s.now().UTC().Format(createdTimeFormat),
+		Tags:            args.Tags,
+	}
+	if args.Billing != nil {
+		model.PaymentTiming = args.Billing.PaymentTiming
+		model.BillingMethod = args.Billing.BillingMethod
+	}
+	s.eips[address] = &eipState{model: model}
+	if args.ClientToken != "" {
+		s.eipTokens[args.ClientToken] = address
+	}
+	return address, nil
+}
+
+func (s *Simulator) DeleteEIP(ctx context.Context, address string) error {
+	if _, err := s.inject(ctx, "DeleteEIP"); err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	e, err := s.getEIP(address)
+	if err != nil {
+		return err
+	}
+	if e.model.Status != eipStatusAvailable {
+		return newError(CodeEIPStatus, "eip %s can not be deleted in status %s", address, e.model.Status)
+	}
+	s.eipPool.release(address)
+	delete(s.eips, address)
+	return nil
+}
+
+func (s *Simulator) ListEIPs(ctx context.Context, args eip.ListEipArgs) ([]eip.EipModel, error) {
+	if _, err := s.inject(ctx, "ListEIPs"); err != nil {
+		return nil, err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var result []eip.EipModel
+	for _, e := range s.eips {
+		if (args.Eip != "" && e.model.Eip != args.Eip) ||
+			(args.InstanceType != "" && e.model.InstanceType != args.InstanceType) ||
+			(args.InstanceId != "" && e.model.InstanceId != args.InstanceId) ||
+			(args.Status != "" && e.model.Status != args.Status) {
+			continue
+		}
+		result = append(result, e.model)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].EipId < result[j].EipId })
+	return result, nil
+}
+
+func (s *Simulator) BindENIPublicIP(ctx context.Context, privateIP string, publicIP string, eniID string) error {
+	if _, err := s.inject(ctx, "BindENIPublicIP"); err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	e, err := s.getEIP(publicIP)
+	if err != nil {
+		return err
+	}
+	if e.model.Status != eipStatusAvailable {
+		return newError(CodeEIPStatus, "eip %s can not be bound in status %s", publicIP, e.model.Status)
+	}
+	n, err := s.getENI(eniID)
+	if err != nil {
+		return err
+	}
+	ip := findIP(n, privateIP)
+	if ip == nil {
+		return newError(CodePrivateIPNotExist, "private ip %s does not exist in eni %s", privateIP, eniID)
+	}
+	if ip.publicIP != "" {
+		return newError(CodeEIPStatus, "private ip %s is already bound to eip %s", privateIP, ip.publicIP)
+	}
+
+	ip.publicIP = publicIP
+	e.eniID = eniID
+	e.privateIP = ip.ip
+	e.model.Status = eipStatusBinded
+	e.model.InstanceType = eipInstanceTypeENI
+	e.model.InstanceId = eniID
+	return nil
+}
+
+func (s *Simulator) UnBindENIPublicIP(ctx context.Context, publicIP string, eniID string) error {
+	if _, err := s.inject(ctx, "UnBindENIPublicIP"); err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	e, err := s.getEIP(publicIP)
+	if err != nil {
+		return err
+	}
+	if e.model.Status != eipStatusBinded || e.eniID != eniID {
+		return newError(CodeEIPStatus, "eip %s is not bound to eni %s", publicIP, eniID)
+	}
+	if n, ok := s.enis[eniID]; ok {
+		if ip := findIP(n, e.privateIP); ip != nil {
+			ip.publicIP = ""
+		}
+	}
+	e.unbind()
+	return nil
+}
+
+func (s *Simulator) DirectEIP(ctx context.Context, address string) error {
+	return s.setDirect(ctx, "DirectEIP", address, true)
+}
+
+func (s *Simulator) UnDirectEIP(ctx context.Context, address string) error {
+	return s.setDirect(ctx, "UnDirectEIP", address, false)
+}
+
+func (s *Simulator) setDirect(ctx context.Context, api, address string, direct bool) error {
+	if _, err := s.inject(ctx, api); err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	e, err := s.getEIP(address)
+	if err != nil {
+		return err
+	}
+	if e.model.Status != eipStatusBinded || e.direct == direct {
+		return newError(CodeEIPStatus, "eip %s in status %s can not be changed to direct=%t", address, e.model.Status, direct)
+	}
+	e.direct = direct
+	return nil
+}
+
+func (s *Simulator) EIPGroupMoveIn(ctx context.Context, groupID string, eips []string) error {
+	if _, err := s.inject(ctx, "EIPGroupMoveIn"); err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, address := range eips {
+		e, err := s.getEIP(address)
+		if err != nil {
+			return err
+		}
+		if e.model.ShareGroupId != "" && e.model.ShareGroupId != groupID {
+			return newError(CodeEIPStatus, "eip %s is already in share group %s", address, e.model.ShareGroupId)
+		}
+	}
+	for _, address := range eips {
+		s.eips[address].model.ShareGroupId = groupID
+	}
+	return nil
+}
+
+func findIP(e *eniState, ip string) *eniIP {
+	ip = normalizeIP(ip)
+	for _, eniIP := range e.ips {
+		if eniIP.ip == ip {
+			return eniIP
+		}
+	}
+	for _, eniIP := range e.ipv6s {
+		if eniIP.ip == ip {
+			return eniIP
+		}
+	}
+	return nil
+}
@@ -0,0 +1,603 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"time"
+
+	"github.com/baidubce/bce-sdk-go/services/eni"
+)
+
+const (
+	eniStatusAvailable = "available"
+	eniStatusAttaching = "attaching"
+	eniStatusInuse     = "inuse"
+	eniStatusDetaching = "detaching"
+)
+
+type eniState struct {
+	id          string
+	name        string
+	description string
+	instanceID  string
+	vpcID       string
+	subnetID    string
+	zone        string
+	mac         string
+	createdTime string
+
+	status string
+	// transitionAt is the time when an attaching or detaching ENI finishes
+	transitionAt time.Time
+
+	ips   []*eniIP
+	ipv6s []*eniIP
+
+	securityGroupIDs           []string
+	enterpriseSecurityGroupIDs []string
+
+	// primaryInterface is the primary or RDMA interface of instance, which
+	// is not listed by the ENI APIs of VPC
+	primaryInterface bool
+}
+
+type eniIP struct {
+	ip       string
+	subnetID string
+	publicIP string
+	primary  bool
+}
+
+// newENI creates an ENI with a primary IP in subnet, caller must hold the mutex
+func (s *Simulator) newENI(id, name string, subnet *subnetState, instanceID, primaryIP string) (*eniState, error) {
+	var err error
+	if primaryIP == "" {
+		primaryIP, err = subnet.ipv4.allocateNext(id)
+	} else {
+		err = subnet.ipv4.allocate(primaryIP, id)
+	}
+	if err != nil {
+		return nil, allocateError(err, subnet, primaryIP)
+	}
+
+	e := &eniState{
+		id:          id,
+		name:        name,
+		instanceID:  instanceID,
+		vpcID:       subnet.vpcID,
+		subnetID:    subnet.config.ID,
+		zone:        subnet.config.Zone,
+		mac:         fmt.Sprintf("fa:26:00:%02x:%02x:%02x", byte(s.seq>>16), byte(s.seq>>8), byte(s.seq)),
+		createdTime: s.now().UTC().Format(createdTimeFormat),
+		status:      eniStatusAvailable,
+		ips:         []*eniIP{{ip: primaryIP, subnetID: subnet.config.ID, primary: true}},
+	}
+	s.enis[id] = e
+	return e, nil
+}
+
+// refresh finishes the attaching or detaching of ENI when its delay is over, caller must hold the mutex
+func (s *Simulator) refresh(e *eniState) {
+	if s.now().Before(e.transitionAt) {
+		return
+	}
+	switch e.status {
+	case eniStatusAttaching:
+		e.status = eniStatusInuse
+	case eniStatusDetaching:
+		e.status = eniStatusAvailable
+		e.instanceID = ""
+	}
+}
+
+// getENI returns the ENI managed by the ENI APIs of VPC, caller must hold the mutex
+func (s *Simulator) getENI(eniID string) (*eniState, error) {
+	e, ok := s.enis[eniID]
+	if !ok || e.primaryInterface {
+		return nil, newError(CodeENIID, "eni %s not found", eniID)
+	}
+	s.refresh(e)
+	return e, nil
+}
+
+// ipQuota is the max number of private IPs of ENI
+func (s *Simulator) ipQuota(e *eniState) int {
+	if instance, ok := s.instances[e.instanceID]; ok {
+		return instance.config.IPQuotaPerENI
+	}
+	return defaultIPQuotaPerENI
+}
+
+// addIPs allocates IPs in subnet to ENI. The IPs are allocated all or nothing unless
+// ratio is less than 1, then only the ratio of IPs are allocated to simulate the
+// partial success of batch APIs. Caller must hold the mutex.
+func (s *Simulator) addIPs(e *eniState, subnetID string, ips []string, count int, ipv6 bool, ratio float64) ([]string, error) {
+	subnet, ok := s.subnets[subnetID]
+	if !ok || subnet.vpcID != e.vpcID {
+		return nil, newError(CodeInvalidParameter, "subnet %s not found in vpc %s", subnetID, e.vpcID)
+	}
+	pool, existing := subnet.ipv4, &e.ips
+	if ipv6 {
+		pool, existing = subnet.ipv6, &e.ipv6s
+		if pool == nil {
+			return nil, newError(CodeInvalidParameter, "subnet %s has no ipv6 cidr", subnetID)
+		}
+	}
+
+	n := count
+	if len(ips) > 0 {
+		n = len(ips)
+	}
+	if n <= 0 {
+		return nil, newError(CodeInvalidParameter, "no private ip to add")
+	}
+	if len(*existing)+n > s.ipQuota(e) {
+		return nil, newError(CodePrivateIPExceedLimit, "eni %s can have at most %d private ips", e.id, s.ipQuota(e))
+	}
+	if ratio < 1 {
+		n = int(float64(n) * ratio)
+		if len(ips) > 0 {
+			ips = ips[:n]
+		}
+	}
+	if len(ips) == 0 && pool.available() < n {
+		return nil, newError(CodeSubnetHasNoMoreIP, "subnet %s has no more ip", subnetID)
+	}
+
+	var allocated []string
+	for i := 0; i < n; i++ {
+		var (
+			ip  string
+			err error
+		)
+		if len(ips) > 0 {
+			ip, err = ips[i], pool.allocate(ips[i], e.id)
+		} else {
+			ip, err = pool.allocateNext(e.id)
+		}
+		if err != nil {
+			for _, a := range allocated {
+				pool.release(a)
+			}
+			return nil, allocateError(err, subnet, ip)
+		}
+		allocated = append(allocated, ip)
+	}
+
+	for _, ip := range allocated {
+		*existing = append(*existing, &eniIP{ip: ip, subnetID: subnetID})
+	}
+	return allocated, nil
+}
+
+// deleteIPs releases the secondary IPs of ENI. No IP is released if any of them
+// does not belong to the ENI. Caller must hold the mutex.
+func (s *Simulator) deleteIPs(e *eniState, ips []string, ipv6 bool) error {
+	existing := &e.ips
+	if ipv6 {
+		existing = &e.ipv6s
+	}
+	index := make(map[string]int, len(*existing))
+	for i, eniIP := range *existing {
+		index[eniIP.ip] = i
+	}
+	toDelete := make(map[int]bool, len(ips))
+	for _, ip := range ips {
+		i, ok := index[normalizeIP(ip)]
+		if !ok || (*existing)[i].primary {
+			return newError(CodePrivateIPNotExist, "private ip %s does not exist in eni %s", ip, e.id)
+		}
+		toDelete[i] = true
+	}
+
+	var remain []*eniIP
+	for i, eniIP := range *existing {
+		if !toDelete[i] {
+			remain = append(remain, eniIP)
+			continue
+		}
+		s.releaseIP(eniIP, ipv6)
+	}
+	*existing = remain
+	return nil
+}
+
+// releaseIP releases the IP to its subnet and unbinds its EIP, caller must hold the mutex
+func (s *Simulator) releaseIP(eniIP *eniIP, ipv6 bool) {
+	if subnet, ok := s.subnets[eniIP.subnetID]; ok {
+		if ipv6 {
+			subnet.ipv6.release(eniIP.ip)
+		} else {
+			subnet.ipv4.release(eniIP.ip)
+		}
+	}
+	if eip, ok := s.eips[eniIP.publicIP]; ok {
+		eip.unbind()
+	}
+}
+
+func (s *Simulator) toENI(e *eniState) eni.Eni {
+	result := eni.Eni{
+		EniId:                      e.id,
+		Name:                       e.name,
+		ZoneName:                   e.zone,
+		Description:                e.description,
+		InstanceId:                 e.instanceID,
+		MacAddress:                 e.mac,
+		VpcId:                      e.vpcID,
+		SubnetId:                   e.subnetID,
+		Status:                     e.status,
+		SecurityGroupIds:           e.securityGroupIDs,
+		EnterpriseSecurityGroupIds: e.enterpriseSecurityGroupIDs,
+		CreatedTime:                e.createdTime,
+	}
+	for _, ip := range e.ips {
+		result.PrivateIpSet = append(result.PrivateIpSet, eni.PrivateIp{
+			PrivateIpAddress: ip.ip,
+			PublicIpAddress:  ip.publicIP,
+			Primary:          ip.primary,
+		})
+	}
+	for _, ip := range e.ipv6s {
+		result.Ipv6PrivateIpSet = append(result.Ipv6PrivateIpSet, eni.PrivateIp{
+			PrivateIpAddress: ip.ip,
+			PublicIpAddress:  ip.publicIP,
+		})
+	}
+	return result
+}
+
+func (s *Simulator) ListENIs(ctx context.Context, args eni.ListEniArgs) ([]eni.Eni, error) {
+	if _, err := s.inject(ctx, "ListENIs"); err != nil {
+		return nil, err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var result []eni.Eni
+	for _, e := range s.sortedENIs() {
+		if e.primaryInterface ||
+			(args.VpcId != "" && e.vpcID != args.VpcId) ||
+			(args.InstanceId != "" && e.instanceID != args.InstanceId) ||
+			(args.Name != "" && e.name != args.Name) {
+			continue
+		}
+		result = append(result, s.toENI(e))
+	}
+	return result, nil
+}
+
+// ListERIs returns no ERI, the elastic RDMA interfaces are not simulated
+func (s *Simulator) ListERIs(ctx context.Context, args eni.ListEniArgs) ([]eni.Eni, error) {
+	if _, err := s.inject(ctx, "ListERIs"); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (s *Simulator) sortedENIs() []*eniState {
+	var enis []*eniState
+	for _, e := range s.enis {
+		s.refresh(e)
+		enis = append(enis, e)
+	}
+	sort.Slice(enis, func(i, j int) bool { return enis[i].id < enis[j].id })
+	return enis
+}
+
+func (s *Simulator) CreateENI(ctx context.Context, args *eni.CreateEniArgs) (string, error) {
+	if _, err := s.inject(ctx, "CreateENI"); err != nil {
+		return "", err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if args.ClientToken != "" {
+		if eniID, ok := s.eniTokens[args.ClientToken]; ok {
+			return eniID, nil
+		}
+	}
+	subnet, ok := s.subnets[args.SubnetId]
+	if !ok {
+		return "", newError(CodeInvalidParameter, "subnet %s not found", args.SubnetId)
+	}
+
+	var (
+		primaryIP    string
+		secondaryIPs []string
+	)
+	for _, ip := range args.PrivateIpSet {
+		if ip.Primary {
+			primaryIP = ip.PrivateIpAddress
+		} else if ip.PrivateIpAddress != "" {
+			secondaryIPs = append(secondaryIPs, ip.PrivateIpAddress)
+		}
+	}
+
+	e, err := s.newENI(s.nextID("eni"), args.Name, subnet, "", primaryIP)
+	if err != nil {
+		return "", err
+	}
+	e.description = args.Description
+	e.securityGroupIDs = args.SecurityGroupIds
+	e.enterpriseSecurityGroupIDs = args.EnterpriseSecurityGroupIds
+	if len(secondaryIPs) > 0 {
+		if _, err := s.addIPs(e, subnet.config.ID, secondaryIPs, 0, false, 1); err != nil {
+			s.removeENI(e)
+			return "", err
+		}
+	}
+	var ipv6s []string
+	for _, ip := range args.Ipv6PrivateIpSet {
+		ipv6s = append(ipv6s, ip.PrivateIpAddress)
+	}
+	if len(ipv6s) > 0 {
+		if _, err := s.addIPs(e, subnet.config.ID, ipv6s, 0, true, 1); err != nil {
+			s.removeENI(e)
+			return "", err
+		}
+	}
+
+	if args.InstanceId != "" {
+		if err := s.attach(e, args.InstanceId); err != nil {
+			s.removeENI(e)
+			return "", err
+		}
+	}
+	if args.ClientToken != "" {
+		s.eniTokens[args.ClientToken] = e.id
+	}
+	return e.id, nil
+}
+
+// removeENI releases all IPs of ENI and deletes it, caller must hold the mutex
+func (s *Simulator) removeENI(e *eniState) {
+	for _, ip := range e.ips {
+		s.releaseIP(ip, false)
+	}
+	for _, ip := range e.ipv6s {
+		s.releaseIP(ip, true)
+	}
+	delete(s.enis, e.id)
+}
+
+func (s *Simulator) DeleteENI(ctx context.Context, eniID string) error {
+	if _, err := s.inject(ctx, "DeleteENI"); err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	e, err := s.getENI(eniID)
+	if err != nil {
+		return err
+	}
+	if e.status != eniStatusAvailable {
+		return newError(CodeENIStatus, "eni %s can not be deleted in status %s", eniID, e.status)
+	}
+	s.removeENI(e)
+	return nil
+}
+
+func (s *Simulator) AttachENI(ctx context.Context, args *eni.EniInstance) error {
+	if _, err := s.inject(ctx, "AttachENI"); err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	e, err := s.getENI(args.EniId)
+	if err != nil {
+		return err
+	}
+	return s.attach(e, args.InstanceId)
+}
+
+// attach starts to attach the ENI to instance, caller must hold the mutex
+func (s *Simulator) attach(e *eniState, instanceID string) error {
+	instance, ok := s.instances[instanceID]
+	if !ok {
+		return newError(CodeInvalidParameter, "instance %s not found", instanceID)
+	}
+	if e.status != eniStatusAvailable {
+		return newError(CodeENIStatus, "eni %s can not be attached in status %s", e.id, e.status)
+	}
+	if instance.vpcID != e.vpcID {
+		return newError(CodeInvalidParameter, "eni %s and instance %s are not in the same vpc", e.id, instanceID)
+	}
+	if s.attachedENIs(instanceID) >= *instance.config.ENIQuota {
+		return newError(CodeQuotaLimitExceeded, "instance %s can attach at most %d enis", instanceID, *instance.config.ENIQuota)
+	}
+
+	e.instanceID = instanceID
+	e.status = eniStatusAttaching
+	e.transitionAt = s.now().Add(s.config.ENIAttachDelay.Duration)
+	return nil
+}
+
+func (s *Simulator) attachedENIs(instanceID string) int {
+	count := 0
+	for _, e := range s.enis {
+		if !e.primaryInterface && e.instanceID == instanceID {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *Simulator) DetachENI(ctx context.Context, args *eni.EniInstance) error {
+	if _, err := s.inject(ctx, "DetachENI"); err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	e, err := s.getENI(args.EniId)
+	if err != nil {
+		return err
+	}
+	if e.status != eniStatusInuse || e.instanceID != args.InstanceId {
+		return newError(CodeENIStatus, "eni %s in status %s can not be detached from %s", e.id, e.status, args.InstanceId)
+	}
+	e.status = eniStatusDetaching
+	e.transitionAt = s.now().Add(s.config.ENIAttachDelay.Duration)
+	return nil
+}
+
+func (s *Simulator) StatENI(ctx context.Context, eniID string) (*eni.Eni, error) {
+	if _, err := s.inject(ctx, "StatENI"); err != nil {
+		return nil, err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	e, err := s.getENI(eniID)
+	if err != nil {
+		return nil, err
+	}
+	result := s.toENI(e)
+	return &result, nil
+}
+
+func (s *Simulator) UpdateENISecurityGroup(ctx context.Context, eniID string, securityGroupIDs []string) error {
+	return s.updateSecurityGroups(ctx, "UpdateENISecurityGroup", eniID, securityGroupIDs, nil)
+}
+
+func (s *Simulator) UpdateENIEnterpriseSecurityGroup(ctx context.Context, eniID string, enterpriseSecurityGroupIDs []string) error {
+	return s.updateSecurityGroups(ctx, "UpdateENIEnterpriseSecurityGroup", eniID, nil, enterpriseSecurityGroupIDs)
+}
+
+// updateSecurityGroups replaces the security groups of the ENI, an ENI is
+// bound to either normal or enterprise security groups
+func (s *Simulator) updateSecurityGroups(ctx context.Context, api, eniID string, securityGroupIDs, enterpriseSecurityGroupIDs []string) error {
+	if _, err := s.inject(ctx, api); err != nil {
+		return err
+	}
+	if len(securityGroupIDs) == 0 && len(enterpriseSecurityGroupIDs) == 0 {
+		return newError(CodeInvalidParameter, "security groups of eni %s are empty", eniID)
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	e, err := s.getENI(eniID)
+	if err != nil {
+		return err
+	}
+	e.securityGroupIDs = securityGroupIDs
+	e.enterpriseSecurityGroupIDs = enterpriseSecurityGroupIDs
+	return nil
+}
+
+func (s *Simulator) GetENIQuota(ctx context.Context, instanceID string) (*eni.EniQuoteInfo, error) {
+	if _, err := s.inject(ctx, "GetENIQuota"); err != nil {
+		return nil, err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	instance, ok := s.instances[instanceID]
+	if !ok {
+		return nil, newError(CodeNoSuchObject, "instance %s not found", instanceID)
+	}
+	total := *instance.config.ENIQuota
+	return &eni.EniQuoteInfo{
+		TotalQuantity:     total,
+		AvailableQuantity: total - s.attachedENIs(instanceID),
+	}, nil
+}
+
+func (s *Simulator) AddPrivateIP(ctx context.Context, privateIP string, eniID string, isIpv6 bool) (string, error) {
+	var ips []string
+	if privateIP != "" {
+		ips = []string{privateIP}
+	}
+	result, err := s.batchAddPrivateIP(ctx, "AddPrivateIP", eniID, "", ips, 1, isIpv6)
+	if err != nil {
+		return "", err
+	}
+	return result[0], nil
+}
+
+func (s *Simulator) DeletePrivateIP(ctx context.Context, privateIP string, eniID string, isIpv6 bool) error {
+	return s.BatchDeletePrivateIP(ctx, []string{privateIP}, eniID, isIpv6)
+}
+
+func (s *Simulator) BatchAddPrivateIP(ctx context.Context, privateIPs []string, count int, eniID string, isIpv6 bool) ([]string, error) {
+	return s.batchAddPrivateIP(ctx, "BatchAddPrivateIP", eniID, "", privateIPs, count, isIpv6)
+}
+
+func (s *Simulator) BatchAddPrivateIpCrossSubnet(ctx context.Context, eniID, subnetID string, privateIPs []string, count int, isIpv6 bool) ([]string, error) {
+	return s.batchAddPrivateIP(ctx, "BatchAddPrivateIpCrossSubnet", eniID, subnetID, privateIPs, count, isIpv6)
+}
+
+// batchAddPrivateIP adds IPs to the ENI, the subnet of ENI is used if subnetID is empty
+func (s *Simulator) batchAddPrivateIP(ctx context.Context, api, eniID, subnetID string, privateIPs []string, count int, isIpv6 bool) ([]string, error) {
+	ratio, err := s.inject(ctx, api)
+	if err != nil {
+		return nil, err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	e, err := s.getENI(eniID)
+	if err != nil {
+		return nil, err
+	}
+	if subnetID == "" {
+		subnetID = e.subnetID
+	}
+	return s.addIPs(e, subnetID, privateIPs, count, isIpv6, ratio)
+}
+
+func (s *Simulator) BatchDeletePrivateIP(ctx context.Context, privateIPs []string, eniID string, isIpv6 bool) error {
+	if _, err := s.inject(ctx, "BatchDeletePrivateIP"); err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	e, err := s.getENI(eniID)
+	if err != nil {
+		return err
+	}
+	return s.deleteIPs(e, privateIPs, isIpv6)
+}
+
+func allocateError(err error, subnet *subnetState, ip string) error {
+	switch err {
+	case errNoMoreIP:
+		return newError(CodeSubnetHasNoMoreIP, "subnet %s has no more ip", subnet.config.ID)
+	case errIPInUse:
+		return newError(CodePrivateIPInUse, "private ip %s is in use", ip)
+	}
+	return newError(CodeInvalidParameter, "%v", err)
+}
+
+func isIPv6(ip string) bool {
+	parsed := net.ParseIP(ip)
+	return parsed != nil && parsed.To4() == nil
+}
+
+func normalizeIP(ip string) string {
+	if parsed := net.ParseIP(ip); parsed != nil {
+		return parsed.String()
+	}
+	return ip
+}
@@ -0,0 +1,545 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+package simulator
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/baidubce/bce-sdk-go/services/bbc"
+	bccapi "github.com/baidubce/bce-sdk-go/services/bcc/api"
+	"github.com/baidubce/bce-sdk-go/services/esg"
+	"github.com/baidubce/bce-sdk-go/services/vpc"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/bce/api/hpc"
+)
+
+// getInstance returns the instance of the types, caller must hold the mutex
+func (s *Simulator) getInstance(instanceID string, types ...InstanceType) (*instanceState, error) {
+	instance, ok := s.instances[instanceID]
+	if ok {
+		for _, t := range types {
+			if instance.config.Type == t {
+				return instance, nil
+			}
+		}
+	}
+	return nil, newError(CodeNoSuchObject, "instance %s not found", instanceID)
+}
+
+func (s *Simulator) GetBCCInstanceDetail(ctx context.Context, instanceID string) (*bccapi.InstanceModel, error) {
+	if _, err := s.inject(ctx, "GetBCCInstanceDetail"); err != nil {
+		return nil, err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	instance, err := s.getInstance(instanceID, InstanceTypeBCC, InstanceTypeEBC)
+	if err != nil {
+		return nil, err
+	}
+	primary := instance.primaryENI
+	nic := bccapi.NicInfo{
+		Status:         primary.status,
+		MacAddress:     primary.mac,
+		VpcId:          primary.vpcID,
+		EniId:          primary.id,
+		Name:           primary.name,
+		Type:           "primary",
+		CreatedTime:    primary.createdTime,
+		SubnetId:       primary.subnetID,
+		EniNum:         s.attachedENIs(instanceID),
+		Az:             primary.zone,
+		SecurityGroups: instance.securityGroups(s),
+	}
+	for _, ip := range primary.ips {
+		nic.Ips = append(nic.Ips, bccapi.IpModel{
+			Eip:       ip.publicIP,
+			Primary:   strconv.FormatBool(ip.primary),
+			PrivateIp: ip.ip,
+		})
+	}
+	return &bccapi.InstanceModel{
+		InstanceId:   instanceID,
+		InstanceName: instanceID,
+		Hostname:     instanceID,
+		InstanceType: bccapi.InstanceTypeN5,
+		Status:       bccapi.InstanceStatusRunning,
+		CreationTime: primary.createdTime,
+		InternalIP:   primary.ips[0].ip,
+		ZoneName:     instance.zone,
+		SubnetId:     primary.subnetID,
+		VpcId:        instance.vpcID,
+		EniQuota:     *instance.config.ENIQuota,
+		NicInfo:      nic,
+		EniNum:       strconv.Itoa(s.attachedENIs(instanceID)),
+	}, nil
+}
+
+func (s *Simulator) ListBCCInstanceEni(ctx context.Context, instanceID string) ([]bccapi.Eni, error) {
+	if _, err := s.inject(ctx, "ListBCCInstanceEni"); err != nil {
+		return nil, err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, err := s.getInstance(instanceID, InstanceTypeBCC, InstanceTypeEBC); err != nil {
+		return nil, err
+	}
+	var result []bccapi.Eni
+	for _, e := range s.sortedENIs() {
+		if e.instanceID != instanceID {
+			continue
+		}
+		item := bccapi.Eni{
+			EniId:       e.id,
+			Name:        e.name,
+			ZoneName:    e.zone,
+			Description: e.description,
+			InstanceId:  e.instanceID,
+			MacAddress:  e.mac,
+			VpcId:       e.vpcID,
+			SubnetId:    e.subnetID,
+			Status:      e.status,
+		}
+		for _, ip := range e.ips {
+			item.PrivateIpSet = append(item.PrivateIpSet, bccapi.PrivateIP{
+				PublicIpAddress:  ip.publicIP,
+				Primary:          ip.primary,
+				PrivateIpAddress: ip.ip,
+			})
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+func (s *Simulator) BCCBatchAddIP(ctx context.Context, args *bccapi.BatchAddIpArgs) (*bccapi.BatchAddIpResponse, error) {
+	ratio, err := s.inject(ctx, "BCCBatchAddIP")
+	if err != nil {
+		return nil, err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	instance, err := s.getInstance(args.InstanceId, InstanceTypeBCC, InstanceTypeEBC)
+	if err != nil {
+		return nil, err
+	}
+	primary := instance.primaryENI
+	ips, err := s.addIPs(primary, primary.subnetID, args.PrivateIps, args.SecondaryPrivateIpAddressCount, args.AllocateMultiIpv6Addr, ratio)
+	if err != nil {
+		return nil, err
+	}
+	return &bccapi.BatchAddIpResponse{PrivateIps: ips}, nil
+}
+
+func (s *Simulator) BCCBatchDelIP(ctx context.Context, args *bccapi.BatchDelIpArgs) error {
+	if _, err := s.inject(ctx, "BCCBatchDelIP"); err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	instance, err := s.getInstance(args.InstanceId, InstanceTypeBCC, InstanceTypeEBC)
+	if err != nil {
+		return err
+	}
+	return s.deletePrimaryIPs(instance.primaryENI, args.PrivateIps)
+}
+
+// deletePrimaryIPs deletes the IPv4 and IPv6 secondary IPs of the primary interface, caller must hold the mutex
+func (s *Simulator) deletePrimaryIPs(e *eniState, ips []string) error {
+	var ipv4s, ipv6s []string
+	for _, ip := range ips {
+		if isIPv6(ip) {
+			ipv6s = append(ipv6s, ip)
+		} else {
+			ipv4s = append(ipv4s, ip)
+		}
+	}
+	if len(ipv4s) > 0 {
+		if err := s.deleteIPs(e, ipv4s, false); err != nil {
+			return err
+		}
+	}
+	if len(ipv6s) > 0 {
+		return s.deleteIPs(e, ipv6s, true)
+	}
+	return nil
+}
+
+func (s *Simulator) GetBBCInstanceDetail(ctx context.Context, instanceID string) (*bbc.InstanceModel, error) {
+	if _, err := s.inject(ctx, "GetBBCInstanceDetail"); err != nil {
+		return nil, err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	instance, err := s.getInstance(instanceID, InstanceTypeBBC)
+	if err != nil {
+		return nil, err
+	}
+	model := &bbc.InstanceModel{
+		Id:         instanceID,
+		Name:       instanceID,
+		Hostname:   instanceID,
+		Status:     bbc.InstanceStatusRunning,
+		CreateTime: instance.primaryENI.createdTime,
+		InternalIp: instance.primaryENI.ips[0].ip,
+		Zone:       instance.zone,
+	}
+	if instance.rdmaENI != nil {
+		model.RdmaIp = instance.rdmaENI.ips[0].ip
+	}
+	return model, nil
+}
+
+func (s *Simulator) GetBBCInstanceENI(ctx context.Context, instanceID string) (*bbc.GetInstanceEniResult, error) {
+	if _, err := s.inject(ctx, "GetBBCInstanceENI"); err != nil {
+		return nil, err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	instance, err := s.getInstance(instanceID, InstanceTypeBBC)
+	if err != nil {
+		return nil, err
+	}
+	e := instance.primaryENI
+	result := &bbc.GetInstanceEniResult{
+		Id:          e.id,
+		Name:        e.name,
+		ZoneName:    e.zone,
+		Description: e.description,
+		InstanceId:  e.instanceID,
+		MacAddress:  e.mac,
+		VpcId:       e.vpcID,
+		SubnetId:    e.subnetID,
+		Status:      e.status,
+	}
+	for _, ip := range e.ips {
+		result.PrivateIpSet = append(result.PrivateIpSet, bbc.PrivateIP{
+			PublicIpAddress:  ip.publicIP,
+			Primary:          ip.primary,
+			PrivateIpAddress: ip.ip,
+			SubnetId:         ip.subnetID,
+		})
+	}
+	return result, nil
+}
+
+func (s *Simulator) BBCBatchAddIP(ctx context.Context, args *bbc.BatchAddIpArgs) (*bbc.BatchAddIpResponse, error) {
+	ratio, err := s.inject(ctx, "BBCBatchAddIP")
+	if err != nil {
+		return nil, err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	instance, err := s.getInstance(args.InstanceId, InstanceTypeBBC)
+	if err != nil {
+		return nil, err
+	}
+	primary := instance.primaryENI
+	ips, err := s.addIPs(primary, primary.subnetID, args.PrivateIps, args.SecondaryPrivateIpAddressCount, args.AllocateMultiIpv6Addr, ratio)
+	if err != nil {
+		return nil, err
+	}
+	return &bbc.BatchAddIpResponse{PrivateIps: ips}, nil
+}
+
+func (s *Simulator) BBCBatchAddIPCrossSubnet(ctx context.Context, args *bbc.BatchAddIpCrossSubnetArgs) (*bbc.BatchAddIpResponse, error) {
+	ratio, err := s.inject(ctx, "BBCBatchAddIPCrossSubnet")
+	if err != nil {
+		return nil, err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	instance, err := s.getInstance(args.InstanceId, InstanceTypeBBC)
+	if err != nil {
+		return nil, err
+	}
+	primary := instance.primaryENI
+	result := &bbc.BatchAddIpResponse{}
+	for _, item := range args.SingleEniAndSubentIps {
+		if item.EniId != "" && item.EniId != primary.id {
+			return nil, newError(CodeENIID, "eni %s is not the primary interface of %s", item.EniId, args.InstanceId)
+		}
+		if item.SecondaryPrivateIpAddressCount > 0 {
+			ips, err := s.addIPs(primary, item.SubnetId, nil, item.SecondaryPrivateIpAddressCount, false, ratio)
+			if err != nil {
+				return nil, err
+			}
+			result.PrivateIps = append(result.PrivateIps, ips...)
+		}
+		for _, ipAndSubnet := range item.IpAndSubnets {
+			ips, err := s.addIPs(primary, ipAndSubnet.SubnetId, []string{ipAndSubnet.PrivateIp}, 0, false, 1)
+			if err != nil {
+				return nil, err
+			}
+			result.PrivateIps = append(result.PrivateIps, ips...)
+		}
+	}
+	return result, nil
+}
+
+func (s *Simulator) BBCBatchDelIP(ctx context.Context, args *bbc.BatchDelIpArgs) error {
+	if _, err := s.inject(ctx, "BBCBatchDelIP"); err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	instance, err := s.getInstance(args.InstanceId, InstanceTypeBBC)
+	if err != nil {
+		return err
+	}
+	return s.deletePrimaryIPs(instance.primaryENI, args.PrivateIps)
+}
+
+func (s *Simulator) GetHPCEniID(ctx context.Context, instanceID string) (*hpc.EniList, error) {
+	if _, err := s.inject(ctx, "GetHPCEniID"); err != nil {
+		return nil, err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	instance, ok := s.instances[instanceID]
+	if !ok {
+		return nil, newError(CodeNoSuchObject, "instance %s not found", instanceID)
+	}
+	result := &hpc.EniList{}
+	if e := instance.rdmaENI; e != nil {
+		item := hpc.Result{
+			EniID:       e.id,
+			Name:        e.name,
+			Description: e.description,
+			InstanceID:  e.instanceID,
+			MacAddress:  e.mac,
+			Status:      e.status,
+			CreatedTime: e.createdTime,
+		}
+		for _, ip := range e.ips {
+			item.PrivateIPSet = append(item.PrivateIPSet, hpc.PrivateIP{
+				Primary:          ip.primary,
+				PrivateIPAddress: ip.ip,
+			})
+		}
+		result.Result = append(result.Result, item)
+	}
+	return result, nil
+}
+
+// getRDMAENI returns the HPC RDMA interface, caller must hold the mutex
+func (s *Simulator) getRDMAENI(eniID string) (*eniState, error) {
+	for _, instance := range s.instances {
+		if instance.rdmaENI != nil && instance.rdmaENI.id == eniID {
+			return instance.rdmaENI, nil
+		}
+	}
+	return nil, newError(CodeENIID, "hpc eni %s not found", eniID)
+}
+
+func (s *Simulator) BatchAddHpcEniPrivateIP(ctx context.Context, args *hpc.EniBatchPrivateIPArgs) (*hpc.BatchAddPrivateIPResult, error) {
+	ratio, err := s.inject(ctx, "BatchAddHpcEniPrivateIP")
+	if err != nil {
+		return nil, err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	e, err := s.getRDMAENI(args.EniID)
+	if err != nil {
+		return nil, err
+	}
+	ips, err := s.addIPs(e, e.subnetID, nil, args.PrivateIPAddressCount, false, ratio)
+	if err != nil {
+		return nil, err
+	}
+	return &hpc.BatchAddPrivateIPResult{PrivateIPAddresses: ips}, nil
+}
+
+func (s *Simulator) BatchDeleteHpcEniPrivateIP(ctx context.Context, args *hpc.EniBatchDeleteIPArgs) error {
+	if _, err := s.inject(ctx, "BatchDeleteHpcEniPrivateIP"); err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	e, err := s.getRDMAENI(args.EniID)
+	if err != nil {
+		return err
+	}
+	return s.deleteIPs(e, args.PrivateIPAddresses, false)
+}
+
+// HPASWrapper does nothing, the simulator does not sign requests
+func (s *Simulator) HPASWrapper(ctx context.Context) error {
+	return nil
+}
+
+func (s *Simulator) DescribeVPC(ctx context.Context, vpcID string) (*vpc.ShowVPCModel, error) {
+	if _, err := s.inject(ctx, "DescribeVPC"); err != nil {
+		return nil, err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	v, ok := s.vpcs[vpcID]
+	if !ok {
+		return nil, newError(CodeNoSuchObject, "vpc %s not found", vpcID)
+	}
+	result := &vpc.ShowVPCModel{
+		VPCId:         vpcID,
+		Name:          vpcID,
+		Cidr:          v.config.CIDR,
+		SecondaryCidr: v.config.SecondaryCIDR,
+	}
+	for _, subnet := range s.sortedSubnets() {
+		if subnet.vpcID == vpcID {
+			result.Subnets = append(result.Subnets, subnet.toSubnet())
+		}
+	}
+	return result, nil
+}
+
+func (s *Simulator) DescribeSubnet(ctx context.Context, subnetID string) (*vpc.Subnet, error) {
+	if _, err := s.inject(ctx, "DescribeSubnet"); err != nil {
+		return nil, err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	subnet, ok := s.subnets[subnetID]
+	if !ok {
+		return nil, newError(CodeNoSuchObject, "subnet %s not found", subnetID)
+	}
+	result := subnet.toSubnet()
+	return &result, nil
+}
+
+func (s *Simulator) ListSubnets(ctx context.Context, args *vpc.ListSubnetArgs) ([]vpc.Subnet, error) {
+	if _, err := s.inject(ctx, "ListSubnets"); err != nil {
+		return nil, err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var result []vpc.Subnet
+	for _, subnet := range s.sortedSubnets() {
+		if args != nil && ((args.VpcId != "" && subnet.vpcID != args.VpcId) ||
+			(args.ZoneName != "" && subnet.config.Zone != args.ZoneName) ||
+			(args.SubnetType != "" && subnet.config.Type != string(args.SubnetType))) {
+			continue
+		}
+		result = append(result, subnet.toSubnet())
+	}
+	return result, nil
+}
+
+func (s *Simulator) sortedSubnets() []*subnetState {
+	var subnets []*subnetState
+	for _, subnet := range s.subnets {
+		subnets = append(subnets, subnet)
+	}
+	sort.Slice(subnets, func(i, j int) bool { return subnets[i].config.ID < subnets[j].config.ID })
+	return subnets
+}
+
+func (subnet *subnetState) toSubnet() vpc.Subnet {
+	return vpc.Subnet{
+		SubnetId:    subnet.config.ID,
+		Name:        subnet.config.ID,
+		ZoneName:    subnet.config.Zone,
+		Cidr:        subnet.config.CIDR,
+		Ipv6Cidr:    subnet.config.IPv6CIDR,
+		VPCId:       subnet.vpcID,
+		SubnetType:  vpc.SubnetType(subnet.config.Type),
+		CreatedTime: subnet.createdTime,
+		AvailableIp: subnet.ipv4.available(),
+	}
+}
+
+func (s *Simulator) ListSecurityGroup(ctx context.Context, vpcID, instanceID string) ([]bccapi.SecurityGroupModel, error) {
+	if _, err := s.inject(ctx, "ListSecurityGroup"); err != nil {
+		return nil, err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var result []bccapi.SecurityGroupModel
+	for _, v := range s.vpcs {
+		if vpcID != "" && v.config.ID != vpcID {
+			continue
+		}
+		if instance, ok := s.instances[instanceID]; instanceID != "" && (!ok || instance.vpcID != v.config.ID) {
+			continue
+		}
+		for _, sg := range v.config.SecurityGroups {
+			result = append(result, bccapi.SecurityGroupModel{Id: sg, Name: sg, VpcId: v.config.ID})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Id < result[j].Id })
+	return result, nil
+}
+
+// ListAclEntrys returns the subnets of VPC without any ACL rule
+func (s *Simulator) ListAclEntrys(ctx context.Context, vpcID string) ([]vpc.AclEntry, error) {
+	if _, err := s.inject(ctx, "ListAclEntrys"); err != nil {
+		return nil, err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.vpcs[vpcID]; !ok {
+		return nil, newError(CodeNoSuchObject, "vpc %s not found", vpcID)
+	}
+	var result []vpc.AclEntry
+	for _, subnet := range s.sortedSubnets() {
+		if subnet.vpcID == vpcID {
+			result = append(result, vpc.AclEntry{
+				SubnetId:   subnet.config.ID,
+				SubnetName: subnet.config.ID,
+				SubnetCidr: subnet.config.CIDR,
+			})
+		}
+	}
+	return result, nil
+}
+
+func (s *Simulator) ListEsg(ctx context.Context, instanceID string) ([]esg.EnterpriseSecurityGroup, error) {
+	if _, err := s.inject(ctx, "ListEsg"); err != nil {
+		return nil, err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var result []esg.EnterpriseSecurityGroup
+	for _, id := range s.config.EnterpriseSecurityGroups {
+		result = append(result, esg.EnterpriseSecurityGroup{Id: id, Name: id})
+	}
+	return result, nil
+}
+
+func (instance *instanceState) securityGroups(s *Simulator) []string {
+	if v, ok := s.vpcs[instance.vpcID]; ok {
+		return v.config.SecurityGroups
+	}
+	return nil
+}
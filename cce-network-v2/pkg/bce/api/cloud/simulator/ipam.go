@@ -0,0 +1,140 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+package simulator
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+)
+
+// maxIPv6Capacity caps the reported capacity of an IPv6 CIDR, which is too large to count
+const maxIPv6Capacity = 1 << 20
+
+// cidrPool accounts the allocated IPs of a CIDR. The network address, the gateway
+// (the first address) and the broadcast address of IPv4 are reserved like VPC does.
+type cidrPool struct {
+	cidr      *net.IPNet
+	base      *big.Int
+	first     *big.Int
+	last      *big.Int
+	allocated map[string]string
+}
+
+func newCIDRPool(cidr string) (*cidrPool, error) {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cidr %q: %w", cidr, err)
+	}
+	ones, bits := ipNet.Mask.Size()
+	size := new(big.Int).Lsh(big.NewInt(1), uint(bits-ones))
+	base := ipToInt(ipNet.IP)
+
+	p := &cidrPool{
+		cidr:      ipNet,
+		base:      base,
+		first:     new(big.Int).Add(base, big.NewInt(2)),
+		last:      new(big.Int).Sub(new(big.Int).Add(base, size), big.NewInt(1)),
+		allocated: make(map[string]string),
+	}
+	if bits == 32 {
+		// broadcast address
+		p.last.Sub(p.last, big.NewInt(1))
+	}
+	if p.first.Cmp(p.last) > 0 {
+		return nil, fmt.Errorf("cidr %q is too small", cidr)
+	}
+	return p, nil
+}
+
+func (p *cidrPool) isIPv6() bool {
+	return p.cidr.IP.To4() == nil
+}
+
+// capacity is the number of IPs which can be allocated
+func (p *cidrPool) capacity() int {
+	c := new(big.Int).Sub(p.last, p.first)
+	c.Add(c, big.NewInt(1))
+	if !c.IsInt64() || c.Int64() > maxIPv6Capacity {
+		return maxIPv6Capacity
+	}
+	return int(c.Int64())
+}
+
+func (p *cidrPool) available() int {
+	return p.capacity() - len(p.allocated)
+}
+
+func (p *cidrPool) contains(ip net.IP) bool {
+	if !p.cidr.Contains(ip) {
+		return false
+	}
+	i := ipToInt(ip)
+	return i.Cmp(p.first) >= 0 && i.Cmp(p.last) <= 0
+}
+
+// allocate allocates the given IP to owner
+func (p *cidrPool) allocate(ip, owner string) error {
+	parsed := net.ParseIP(ip)
+	if parsed == nil || !p.contains(parsed) {
+		return fmt.Errorf("ip %s is not in the range of %s", ip, p.cidr)
+	}
+	key := parsed.String()
+	if _, ok := p.allocated[key]; ok {
+		return errIPInUse
+	}
+	p.allocated[key] = owner
+	return nil
+}
+
+// allocateNext allocates the lowest free IP to owner
+func (p *cidrPool) allocateNext(owner string) (string, error) {
+	if p.available() <= 0 {
+		return "", errNoMoreIP
+	}
+	for i := new(big.Int).Set(p.first); i.Cmp(p.last) <= 0; i.Add(i, big.NewInt(1)) {
+		ip := intToIP(i, p.isIPv6()).String()
+		if _, ok := p.allocated[ip]; !ok {
+			p.allocated[ip] = owner
+			return ip, nil
+		}
+	}
+	return "", errNoMoreIP
+}
+
+func (p *cidrPool) release(ip string) {
+	if parsed := net.ParseIP(ip); parsed != nil {
+		delete(p.allocated, parsed.String())
+	}
+}
+
+func ipToInt(ip net.IP) *big.Int {
+	if v4 := ip.To4(); v4 != nil {
+		return new(big.Int).SetBytes(v4)
+	}
+	return new(big.Int).SetBytes(ip.To16())
+}
+
+func intToIP(i *big.Int, ipv6 bool) net.IP {
+	size := net.IPv4len
+	if ipv6 {
+		size = net.IPv6len
+	}
+	b := i.Bytes()
+	ip := make(net.IP, size)
+	copy(ip[size-len(b):], b)
+	return ip
+}
@@ -0,0 +1,116 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+package simulator
+
+import (
+	"context"
+	"strings"
+
+	"github.com/baidubce/bce-sdk-go/services/vpc"
+)
+
+type routeTableState struct {
+	id    string
+	vpcID string
+	rules []vpc.RouteRule
+}
+
+func newRouteTableState(id, vpcID string) *routeTableState {
+	return &routeTableState{id: id, vpcID: vpcID}
+}
+
+// ListRouteTable lists the rules of route table, the default route table of VPC is used if routeTableID is empty
+func (s *Simulator) ListRouteTable(ctx context.Context, vpcID, routeTableID string) ([]vpc.RouteRule, error) {
+	if _, err := s.inject(ctx, "ListRouteTable"); err != nil {
+		return nil, err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if routeTableID == "" {
+		v, ok := s.vpcs[vpcID]
+		if !ok {
+			return nil, newError(CodeNoSuchObject, "vpc %s not found", vpcID)
+		}
+		routeTableID = v.config.RouteTableID
+	}
+	rt, ok := s.routeTables[routeTableID]
+	if !ok || (vpcID != "" && rt.vpcID != vpcID) {
+		return nil, newError(CodeNoSuchObject, "route table %s not found", routeTableID)
+	}
+	return append([]vpc.RouteRule(nil), rt.rules...), nil
+}
+
+func (s *Simulator) CreateRouteRule(ctx context.Context, args *vpc.CreateRouteRuleArgs) (string, error) {
+	if _, err := s.inject(ctx, "CreateRouteRule"); err != nil {
+		return "", err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	rt, ok := s.routeTables[args.RouteTableId]
+	if !ok {
+		return "", newError(CodeNoSuchObject, "route table %s not found", args.RouteTableId)
+	}
+	for _, rule := range rt.rules {
+		if rule.SourceAddress == args.SourceAddress && rule.DestinationAddress == args.DestinationAddress {
+			return "", newError(CodeRouteRuleRepeated, "route rule to %s already exists", args.DestinationAddress)
+		}
+	}
+	if len(rt.rules) >= s.config.RouteRuleQuota {
+		return "", newError(CodeRouteRuleExceedQuota, "route table %s can have at most %d rules", rt.id, s.config.RouteRuleQuota)
+	}
+	// ipVersion defaults to 4, IPv6 rules must be created with ipVersion 6
+	if isIPv6 := strings.Contains(args.DestinationAddress, ":"); isIPv6 != (args.IpVersion == "6") {
+		return "", newError(CodeInvalidParameter, "destination %s does not match ip version %q", args.DestinationAddress, args.IpVersion)
+	}
+	if args.NexthopType == vpc.NEXTHOP_TYPE_CUSTOM {
+		instance, ok := s.instances[args.NexthopId]
+		if !ok || instance.vpcID != rt.vpcID {
+			return "", newError(CodeInvalidParameter, "next hop %s not found in vpc %s", args.NexthopId, rt.vpcID)
+		}
+	}
+
+	rule := vpc.RouteRule{
+		RouteRuleId:        s.nextID("rr"),
+		RouteTableId:       rt.id,
+		SourceAddress:      args.SourceAddress,
+		DestinationAddress: args.DestinationAddress,
+		NexthopId:          args.NexthopId,
+		NexthopType:        args.NexthopType,
+		Description:        args.Description,
+	}
+	rt.rules = append(rt.rules, rule)
+	return rule.RouteRuleId, nil
+}
+
+func (s *Simulator) DeleteRouteRule(ctx context.Context, routeID string) error {
+	if _, err := s.inject(ctx, "DeleteRouteRule"); err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, rt := range s.routeTables {
+		for i, rule := range rt.rules {
+			if rule.RouteRuleId == routeID {
+				rt.rules = append(rt.rules[:i], rt.rules[i+1:]...)
+				return nil
+			}
+		}
+	}
+	return newError(CodeNoSuchObject, "route rule %s not found", routeID)
+}
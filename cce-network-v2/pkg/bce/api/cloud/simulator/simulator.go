@@ -0,0 +1,284 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+// Package simulator implements cloud.Interface with an in-memory model of VPC,
+// so that IPAM can be exercised against realistic sequences of cloud API calls
+// without a cloud account.
+package simulator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/bce/api/cloud"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/lock"
+)
+
+// BCE error codes returned by simulator. They are recognized by cloud.ReasonForError
+// in the same way as the errors of the real cloud.
+const (
+	CodeRateLimit            = "RateLimit"
+	CodeSubnetHasNoMoreIP    = "SubnetHasNoMoreIpException"
+	CodePrivateIPInUse       = "PrivateIpInUseException"
+	CodePrivateIPNotExist    = "PrivateIPNotExistException"
+	CodePrivateIPExceedLimit = "PrivateIpExceedLimitException"
+	CodeENIID                = "EniIdException"
+	CodeENIStatus            = "EniStatusException"
+	CodeQuotaLimitExceeded   = "QuotaLimitExceeded"
+	CodeRouteRuleRepeated    = "RouteRuleRepeated"
+	CodeRouteRuleExceedQuota = "RouteRuleExceedQuota"
+	CodeNoSuchObject         = "NoSuchObject"
+	CodeInvalidParameter     = "InvalidParameter"
+	CodeEIPStatus            = "EipStatusException"
+	CodeInternalError        = "InternalError"
+)
+
+const (
+	requestID         = "simulator"
+	createdTimeFormat = "2006-01-02T15:04:05Z"
+)
+
+var (
+	errNoMoreIP = errors.New("no more ip")
+	errIPInUse  = errors.New("ip in use")
+)
+
+var _ cloud.Interface = &Simulator{}
+
+// Simulator is a stateful in-memory BCE cloud. All APIs are safe for concurrent use.
+type Simulator struct {
+	mutex lock.Mutex
+
+	config Config
+	rand   *rand.Rand
+	now    func() time.Time
+	seq    int
+
+	vpcs        map[string]*vpcState
+	subnets     map[string]*subnetState
+	instances   map[string]*instanceState
+	enis        map[string]*eniState
+	routeTables map[string]*routeTableState
+	eips        map[string]*eipState
+	eipPool     *cidrPool
+
+	// client tokens of the idempotent create APIs
+	eniTokens map[string]string
+	eipTokens map[string]string
+
+	faults []FaultRule
+}
+
+type vpcState struct {
+	config VPCConfig
+}
+
+type subnetState struct {
+	config      SubnetConfig
+	vpcID       string
+	ipv4        *cidrPool
+	ipv6        *cidrPool
+	createdTime string
+}
+
+type instanceState struct {
+	config InstanceConfig
+	vpcID  string
+	zone   string
+	// primaryENI is the primary interface of the instance
+	primaryENI *eniState
+	// rdmaENI is the HPC RDMA interface of the instance
+	rdmaENI *eniState
+}
+
+// New creates a simulator with the initial state of config
+func New(config *Config) (*Simulator, error) {
+	s := &Simulator{
+		config:      *config,
+		rand:        rand.New(rand.NewSource(config.Seed)),
+		now:         time.Now,
+		vpcs:        make(map[string]*vpcState),
+		subnets:     make(map[string]*subnetState),
+		instances:   make(map[string]*instanceState),
+		enis:        make(map[string]*eniState),
+		routeTables: make(map[string]*routeTableState),
+		eips:        make(map[string]*eipState),
+		eniTokens:   make(map[string]string),
+		eipTokens:   make(map[string]string),
+		faults:      append([]FaultRule(nil), config.Faults...),
+	}
+	s.config.setDefaults()
+
+	var err error
+	if s.eipPool, err = newCIDRPool(s.config.EIPCIDR); err != nil {
+		return nil, err
+	}
+
+	createdTime := s.now().UTC().Format(createdTimeFormat)
+	for _, vpcConfig := range s.config.VPCs {
+		if _, ok := s.vpcs[vpcConfig.ID]; ok {
+			return nil, fmt.Errorf("duplicated vpc %s", vpcConfig.ID)
+		}
+		s.vpcs[vpcConfig.ID] = &vpcState{config: vpcConfig}
+		for _, routeTableID := range append([]string{vpcConfig.RouteTableID}, vpcConfig.RouteTableIDs...) {
+			if _, ok := s.routeTables[routeTableID]; ok {
+				return nil, fmt.Errorf("duplicated route table %s", routeTableID)
+			}
+			s.routeTables[routeTableID] = newRouteTableState(routeTableID, vpcConfig.ID)
+		}
+
+		for _, subnetConfig := range vpcConfig.Subnets {
+			if _, ok := s.subnets[subnetConfig.ID]; ok {
+				return nil, fmt.Errorf("duplicated subnet %s", subnetConfig.ID)
+			}
+			if subnetConfig.Type == "" {
+				subnetConfig.Type = "BCC"
+			}
+			subnet := &subnetState{config: subnetConfig, vpcID: vpcConfig.ID, createdTime: createdTime}
+			if subnet.ipv4, err = newCIDRPool(subnetConfig.CIDR); err != nil {
+				return nil, fmt.Errorf("subnet %s: %w", subnetConfig.ID, err)
+			}
+			if subnetConfig.IPv6CIDR != "" {
+				if subnet.ipv6, err = newCIDRPool(subnetConfig.IPv6CIDR); err != nil {
+					return nil, fmt.Errorf("subnet %s: %w", subnetConfig.ID, err)
+				}
+			}
+			s.subnets[subnetConfig.ID] = subnet
+		}
+	}
+
+	for _, instanceConfig := range s.config.Instances {
+		if err := s.addInstance(instanceConfig); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// NewFromFile creates a simulator with the config file
+func NewFromFile(path string) (*Simulator, error) {
+	config, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	return New(config)
+}
+
+func (s *Simulator) addInstance(config InstanceConfig) error {
+	if _, ok := s.instances[config.ID]; ok {
+		return fmt.Errorf("duplicated instance %s", config.ID)
+	}
+	switch config.Type {
+	case InstanceTypeBCC, InstanceTypeEBC, InstanceTypeBBC:
+	default:
+		return fmt.Errorf("instance %s: unknown instance type %q", config.ID, config.Type)
+	}
+	subnet, ok := s.subnets[config.SubnetID]
+	if !ok {
+		return fmt.Errorf("instance %s: subnet %s not found", config.ID, config.SubnetID)
+	}
+
+	instance := &instanceState{config: config, vpcID: subnet.vpcID, zone: subnet.config.Zone}
+	primaryENI, err := s.newENI(s.nextID("eni"), "primary", subnet, config.ID, "")
+	if err != nil {
+		return fmt.Errorf("instance %s: %w", config.ID, err)
+	}
+	primaryENI.primaryInterface = true
+	primaryENI.status = eniStatusInuse
+	instance.primaryENI = primaryENI
+
+	if config.RDMASubnetID != "" {
+		rdmaSubnet, ok := s.subnets[config.RDMASubnetID]
+		if !ok {
+			return fmt.Errorf("instance %s: rdma subnet %s not found", config.ID, config.RDMASubnetID)
+		}
+		rdmaENI, err := s.newENI(s.nextID("eni"), "rdma", rdmaSubnet, config.ID, "")
+		if err != nil {
+			return fmt.Errorf("instance %s: %w", config.ID, err)
+		}
+		rdmaENI.primaryInterface = true
+		rdmaENI.status = eniStatusInuse
+		instance.rdmaENI = rdmaENI
+	}
+
+	s.instances[config.ID] = instance
+	return nil
+}
+
+// SetFaults replaces the fault rules of simulator at runtime
+func (s *Simulator) SetFaults(faults []FaultRule) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.faults = append([]FaultRule(nil), faults...)
+}
+
+// inject applies the fault rules matched by api. It returns the ratio of IPs which
+// can be allocated by a batch API, 1 means no partial success is injected.
+func (s *Simulator) inject(ctx context.Context, api string) (float64, error) {
+	var (
+		latency time.Duration
+		err     error
+		ratio   = 1.0
+	)
+
+	s.mutex.Lock()
+	for _, rule := range s.faults {
+		if !rule.match(api) {
+			continue
+		}
+		latency += rule.Latency.Duration
+		if err == nil && rule.Error != "" && s.rand.Float64() < rule.ErrorRate {
+			err = newError(rule.Error, "injected fault of %s", api)
+		}
+		if rule.PartialBatchRatio > 0 && rule.PartialBatchRatio < ratio {
+			ratio = rule.PartialBatchRatio
+		}
+	}
+	s.mutex.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-ctx.Done():
+			return ratio, ctx.Err()
+		}
+	}
+	return ratio, err
+}
+
+// nextID generates a unique resource id with the prefix, caller must hold the mutex
+func (s *Simulator) nextID(prefix string) string {
+	s.seq++
+	return fmt.Sprintf("%s-%08x", prefix, s.seq)
+}
+
+func newError(code, format string, args ...interface{}) error {
+	status := http.StatusBadRequest
+	switch code {
+	case CodeRateLimit:
+		status = http.StatusTooManyRequests
+	case CodeNoSuchObject:
+		status = http.StatusNotFound
+	case CodeInternalError:
+		status = http.StatusInternalServerError
+	}
+	return bce.NewBceServiceError(code, fmt.Sprintf(format, args...), requestID, status)
+}
@@ -0,0 +1,320 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+package simulator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/baidubce/bce-sdk-go/services/bbc"
+	bccapi "github.com/baidubce/bce-sdk-go/services/bcc/api"
+	"github.com/baidubce/bce-sdk-go/services/eip"
+	"github.com/baidubce/bce-sdk-go/services/eni"
+	"github.com/baidubce/bce-sdk-go/services/vpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/bce/api/cloud"
+)
+
+func newTestSimulator(t *testing.T) *Simulator {
+	s, err := New(&Config{
+		Seed: 1,
+		VPCs: []VPCConfig{{
+			ID:            "vpc-test",
+			CIDR:          "10.0.0.0/16",
+			RouteTableIDs: []string{"rt-zone-a"},
+			Subnets: []SubnetConfig{
+				{ID: "sbn-a", Zone: "zoneA", CIDR: "10.0.0.0/24"},
+				// 10.0.1.2 ~ 10.0.1.6 can be allocated
+				{ID: "sbn-small", Zone: "zoneA", CIDR: "10.0.1.0/29"},
+			},
+		}},
+		Instances: []InstanceConfig{
+			{ID: "i-bcc", Type: InstanceTypeBCC, SubnetID: "sbn-a"},
+			{ID: "i-bbc", Type: InstanceTypeBBC, SubnetID: "sbn-a"},
+		},
+		RouteRuleQuota: 2,
+	})
+	require.NoError(t, err)
+	return s
+}
+
+func TestENILifecycle(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSimulator(t)
+
+	eniID, err := s.CreateENI(ctx, &eni.CreateEniArgs{Name: "eni-test", SubnetId: "sbn-small", ClientToken: "token"})
+	require.NoError(t, err)
+	again, err := s.CreateENI(ctx, &eni.CreateEniArgs{Name: "eni-test", SubnetId: "sbn-small", ClientToken: "token"})
+	require.NoError(t, err)
+	assert.Equal(t, eniID, again, "create with the same client token should be idempotent")
+
+	require.NoError(t, s.AttachENI(ctx, &eni.EniInstance{EniId: eniID, InstanceId: "i-bcc"}))
+	err = s.DeleteENI(ctx, eniID)
+	assert.Error(t, err, "an inuse eni can not be deleted")
+
+	enis, err := s.ListENIs(ctx, eni.ListEniArgs{InstanceId: "i-bcc"})
+	require.NoError(t, err)
+	require.Len(t, enis, 1)
+	assert.Equal(t, eniStatusInuse, enis[0].Status)
+
+	ips, err := s.BatchAddPrivateIP(ctx, nil, 3, eniID, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.1.3", "10.0.1.4", "10.0.1.5"}, ips)
+
+	_, err = s.BatchAddPrivateIP(ctx, nil, 2, eniID, false)
+	assert.True(t, cloud.IsErrorSubnetHasNoMoreIP(err), "unexpected error %v", err)
+
+	_, err = s.AddPrivateIP(ctx, "10.0.1.3", eniID, false)
+	assert.True(t, cloud.IsErrorPrivateIPInUse(err), "unexpected error %v", err)
+
+	err = s.BatchDeletePrivateIP(ctx, []string{"10.0.1.3", "10.0.1.200"}, eniID, false)
+	assert.True(t, cloud.IsErrorENIPrivateIPNotFound(err), "unexpected error %v", err)
+	require.NoError(t, s.BatchDeletePrivateIP(ctx, []string{"10.0.1.3"}, eniID, false))
+
+	subnet, err := s.DescribeSubnet(ctx, "sbn-small")
+	require.NoError(t, err)
+	assert.Equal(t, 2, subnet.AvailableIp)
+
+	require.NoError(t, s.DetachENI(ctx, &eni.EniInstance{EniId: eniID, InstanceId: "i-bcc"}))
+	require.NoError(t, s.DeleteENI(ctx, eniID))
+	subnet, err = s.DescribeSubnet(ctx, "sbn-small")
+	require.NoError(t, err)
+	assert.Equal(t, 5, subnet.AvailableIp)
+
+	_, err = s.StatENI(ctx, eniID)
+	assert.True(t, cloud.IsErrorENINotFound(err), "unexpected error %v", err)
+}
+
+func TestENIAttachDelayAndQuota(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSimulator(t)
+	now := time.Now()
+	s.now = func() time.Time { return now }
+	s.config.ENIAttachDelay = metav1.Duration{Duration: time.Minute}
+	quota := 1
+	s.instances["i-bcc"].config.ENIQuota = &quota
+
+	eniID, err := s.CreateENI(ctx, &eni.CreateEniArgs{SubnetId: "sbn-a", InstanceId: "i-bcc"})
+	require.NoError(t, err)
+	result, err := s.StatENI(ctx, eniID)
+	require.NoError(t, err)
+	assert.Equal(t, eniStatusAttaching, result.Status)
+
+	now = now.Add(time.Minute)
+	result, err = s.StatENI(ctx, eniID)
+	require.NoError(t, err)
+	assert.Equal(t, eniStatusInuse, result.Status)
+
+	_, err = s.CreateENI(ctx, &eni.CreateEniArgs{SubnetId: "sbn-a", InstanceId: "i-bcc"})
+	assert.True(t, cloud.IsErrorQuotaLimitExceeded(err), "unexpected error %v", err)
+
+	info, err := s.GetENIQuota(ctx, "i-bcc")
+	require.NoError(t, err)
+	assert.Equal(t, 0, info.AvailableQuantity)
+}
+
+func TestENISecurityGroups(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSimulator(t)
+
+	eniID, err := s.CreateENI(ctx, &eni.CreateEniArgs{SubnetId: "sbn-a", SecurityGroupIds: []string{"g-node"}})
+	require.NoError(t, err)
+
+	require.NoError(t, s.UpdateENIEnterpriseSecurityGroup(ctx, eniID, []string{"esg-pod"}))
+	result, err := s.StatENI(ctx, eniID)
+	require.NoError(t, err)
+	assert.Empty(t, result.SecurityGroupIds)
+	assert.Equal(t, []string{"esg-pod"}, result.EnterpriseSecurityGroupIds)
+
+	require.NoError(t, s.UpdateENISecurityGroup(ctx, eniID, []string{"g-node"}))
+	result, err = s.StatENI(ctx, eniID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"g-node"}, result.SecurityGroupIds)
+	assert.Empty(t, result.EnterpriseSecurityGroupIds)
+
+	assert.Error(t, s.UpdateENISecurityGroup(ctx, eniID, nil))
+	err = s.UpdateENISecurityGroup(ctx, "eni-none", []string{"g-node"})
+	assert.True(t, cloud.IsErrorENINotFound(err), "unexpected error %v", err)
+}
+
+func TestFaultInjection(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSimulator(t)
+
+	s.SetFaults([]FaultRule{{API: "ListENIs", Error: CodeRateLimit, ErrorRate: 1}})
+	_, err := s.ListENIs(ctx, eni.ListEniArgs{})
+	assert.True(t, cloud.IsErrorRateLimit(err), "unexpected error %v", err)
+	_, err = s.ListSubnets(ctx, &vpc.ListSubnetArgs{})
+	assert.NoError(t, err, "fault should only be injected into the matched api")
+
+	s.SetFaults([]FaultRule{{API: "BCCBatchAddIP", PartialBatchRatio: 0.5}})
+	resp, err := s.BCCBatchAddIP(ctx, &bccapi.BatchAddIpArgs{InstanceId: "i-bcc", SecondaryPrivateIpAddressCount: 4})
+	require.NoError(t, err)
+	assert.Len(t, resp.PrivateIps, 2)
+
+	s.SetFaults([]FaultRule{{Latency: metav1.Duration{Duration: time.Hour}}})
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = s.ListEIPs(timeout, eip.ListEipArgs{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestPrimaryInterfaceIPs(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSimulator(t)
+
+	_, err := s.BBCBatchAddIP(ctx, &bbc.BatchAddIpArgs{InstanceId: "i-bcc", SecondaryPrivateIpAddressCount: 1})
+	assert.True(t, cloud.IsErrorReasonNoSuchObject(err), "bcc can not be managed by bbc api")
+
+	resp, err := s.BBCBatchAddIPCrossSubnet(ctx, &bbc.BatchAddIpCrossSubnetArgs{
+		InstanceId: "i-bbc",
+		SingleEniAndSubentIps: []bbc.SingleEniAndSubentIp{
+			{SubnetId: "sbn-small", SecondaryPrivateIpAddressCount: 2},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.1.2", "10.0.1.3"}, resp.PrivateIps)
+
+	result, err := s.GetBBCInstanceENI(ctx, "i-bbc")
+	require.NoError(t, err)
+	assert.Len(t, result.PrivateIpSet, 3)
+
+	require.NoError(t, s.BBCBatchDelIP(ctx, &bbc.BatchDelIpArgs{InstanceId: "i-bbc", PrivateIps: resp.PrivateIps}))
+	err = s.BBCBatchDelIP(ctx, &bbc.BatchDelIpArgs{InstanceId: "i-bbc", PrivateIps: []string{result.PrivateIpSet[0].PrivateIpAddress}})
+	assert.Error(t, err, "primary ip can not be deleted")
+}
+
+func TestRouteRule(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSimulator(t)
+
+	args := &vpc.CreateRouteRuleArgs{
+		RouteTableId:       "rt-vpc-test",
+		SourceAddress:      "0.0.0.0/0",
+		DestinationAddress: "172.16.0.0/24",
+		NexthopId:          "i-bcc",
+		NexthopType:        vpc.NEXTHOP_TYPE_CUSTOM,
+	}
+	ruleID, err := s.CreateRouteRule(ctx, args)
+	require.NoError(t, err)
+	_, err = s.CreateRouteRule(ctx, args)
+	assert.True(t, cloud.IsErrorRouteRuleRepeated(err), "unexpected error %v", err)
+
+	args.DestinationAddress = "172.16.1.0/24"
+	_, err = s.CreateRouteRule(ctx, args)
+	require.NoError(t, err)
+	args.DestinationAddress = "172.16.2.0/24"
+	_, err = s.CreateRouteRule(ctx, args)
+	assert.True(t, cloud.IsErrorCreateRouteRuleExceededQuota(err), "unexpected error %v", err)
+
+	require.NoError(t, s.DeleteRouteRule(ctx, ruleID))
+	rules, err := s.ListRouteTable(ctx, "vpc-test", "")
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	assert.Equal(t, "172.16.1.0/24", rules[0].DestinationAddress)
+
+	// the quota is counted by route table, and ipv6 rules must be created with ip version 6
+	args.RouteTableId = "rt-zone-a"
+	args.SourceAddress = "::/0"
+	args.DestinationAddress = "fd00::/80"
+	_, err = s.CreateRouteRule(ctx, args)
+	assert.Error(t, err, "ipv6 rule must be created with ip version 6")
+	args.IpVersion = "6"
+	_, err = s.CreateRouteRule(ctx, args)
+	require.NoError(t, err)
+	rules, err = s.ListRouteTable(ctx, "vpc-test", "rt-zone-a")
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	assert.Equal(t, "fd00::/80", rules[0].DestinationAddress)
+}
+
+func TestEIPLifecycle(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSimulator(t)
+
+	address, err := s.CreateEIP(ctx, &eip.CreateEipArgs{BandWidthInMbps: 10, ClientToken: "token"})
+	require.NoError(t, err)
+	eniID, err := s.CreateENI(ctx, &eni.CreateEniArgs{SubnetId: "sbn-a", InstanceId: "i-bcc"})
+	require.NoError(t, err)
+	ips, err := s.BatchAddPrivateIP(ctx, nil, 1, eniID, false)
+	require.NoError(t, err)
+
+	require.NoError(t, s.BindENIPublicIP(ctx, ips[0], address, eniID))
+	require.NoError(t, s.DirectEIP(ctx, address))
+	assert.Error(t, s.DeleteEIP(ctx, address), "a binded eip can not be deleted")
+
+	eips, err := s.ListEIPs(ctx, eip.ListEipArgs{Status: eipStatusBinded})
+	require.NoError(t, err)
+	require.Len(t, eips, 1)
+	assert.Equal(t, eniID, eips[0].InstanceId)
+
+	// releasing the private ip unbinds its eip
+	require.NoError(t, s.BatchDeletePrivateIP(ctx, ips, eniID, false))
+	eips, err = s.ListEIPs(ctx, eip.ListEipArgs{Eip: address})
+	require.NoError(t, err)
+	require.Len(t, eips, 1)
+	assert.Equal(t, eipStatusAvailable, eips[0].Status)
+
+	require.NoError(t, s.EIPGroupMoveIn(ctx, "bwp-test", []string{address}))
+	require.NoError(t, s.DeleteEIP(ctx, address))
+	err = s.DeleteEIP(ctx, address)
+	assert.True(t, cloud.IsErrorReasonNoSuchObject(err), "unexpected error %v", err)
+}
+
+func TestNewFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "simulator.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+vpcs:
+- id: vpc-file
+  cidr: 192.168.0.0/16
+  subnets:
+  - id: sbn-file
+    zone: zoneB
+    cidr: 192.168.0.0/24
+    ipv6CIDR: "2400:da00::/64"
+instances:
+- id: i-file
+  type: EBC
+  subnetID: sbn-file
+faults:
+- api: ListENIs
+  error: RateLimit
+  errorRate: 0.5
+`), 0644))
+
+	s, err := NewFromFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, defaultENIQuota, *s.instances["i-file"].config.ENIQuota)
+
+	detail, err := s.GetBCCInstanceDetail(context.Background(), "i-file")
+	require.NoError(t, err)
+	assert.Equal(t, "192.168.0.2", detail.InternalIP)
+	assert.Equal(t, defaultENIQuota, detail.EniQuota)
+
+	resp, err := s.BCCBatchAddIP(context.Background(), &bccapi.BatchAddIpArgs{
+		InstanceId:                     "i-file",
+		SecondaryPrivateIpAddressCount: 1,
+		AllocateMultiIpv6Addr:          true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2400:da00::2"}, resp.PrivateIps)
+}
@@ -16,7 +16,7 @@
 //
 
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/bce/api/cloud (interfaces: Interface)
+// Source: types.go
 
 // Package testing is a generated GoMock package.
 package testing
@@ -247,6 +247,21 @@ func (mr *MockInterfaceMockRecorder) BindENIPublicIP(ctx, privateIP, publicIP, e
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BindENIPublicIP", reflect.TypeOf((*MockInterface)(nil).BindENIPublicIP), ctx, privateIP, publicIP, eniID)
 }
 
+// CreateEIP mocks base method.
+func (m *MockInterface) CreateEIP(ctx context.Context, args *eip.CreateEipArgs) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEIP", ctx, args)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEIP indicates an expected call of CreateEIP.
+func (mr *MockInterfaceMockRecorder) CreateEIP(ctx, args interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEIP", reflect.TypeOf((*MockInterface)(nil).CreateEIP), ctx, args)
+}
+
 // CreateENI mocks base method.
 func (m *MockInterface) CreateENI(ctx context.Context, args *eni.CreateEniArgs) (string, error) {
 	m.ctrl.T.Helper()
@@ -277,6 +292,20 @@ func (mr *MockInterfaceMockRecorder) CreateRouteRule(ctx, args interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRouteRule", reflect.TypeOf((*MockInterface)(nil).CreateRouteRule), ctx, args)
 }
 
+// DeleteEIP mocks base method.
+func (m *MockInterface) DeleteEIP(ctx context.Context, eip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEIP", ctx, eip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEIP indicates an expected call of DeleteEIP.
+func (mr *MockInterfaceMockRecorder) DeleteEIP(ctx, eip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEIP", reflect.TypeOf((*MockInterface)(nil).DeleteEIP), ctx, eip)
+}
+
 // DeleteENI mocks base method.
 func (m *MockInterface) DeleteENI(ctx context.Context, eniID string) error {
 	m.ctrl.T.Helper()
@@ -377,6 +406,20 @@ func (mr *MockInterfaceMockRecorder) DirectEIP(ctx, eip interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DirectEIP", reflect.TypeOf((*MockInterface)(nil).DirectEIP), ctx, eip)
 }
 
+// EIPGroupMoveIn mocks base method.
+func (m *MockInterface) EIPGroupMoveIn(ctx context.Context, groupID string, eips []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EIPGroupMoveIn", ctx, groupID, eips)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EIPGroupMoveIn indicates an expected call of EIPGroupMoveIn.
+func (mr *MockInterfaceMockRecorder) EIPGroupMoveIn(ctx, groupID, eips interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EIPGroupMoveIn", reflect.TypeOf((*MockInterface)(nil).EIPGroupMoveIn), ctx, groupID, eips)
+}
+
 // GetBBCInstanceDetail mocks base method.
 func (m *MockInterface) GetBBCInstanceDetail(ctx context.Context, instanceID string) (*bbc.InstanceModel, error) {
 	m.ctrl.T.Helper()
@@ -643,3 +686,31 @@ func (mr *MockInterfaceMockRecorder) UnDirectEIP(ctx, eip interface{}) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnDirectEIP", reflect.TypeOf((*MockInterface)(nil).UnDirectEIP), ctx, eip)
 }
+
+// UpdateENIEnterpriseSecurityGroup mocks base method.
+func (m *MockInterface) UpdateENIEnterpriseSecurityGroup(ctx context.Context, eniID string, enterpriseSecurityGroupIDs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateENIEnterpriseSecurityGroup", ctx, eniID, enterpriseSecurityGroupIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateENIEnterpriseSecurityGroup indicates an expected call of UpdateENIEnterpriseSecurityGroup.
+func (mr *MockInterfaceMockRecorder) UpdateENIEnterpriseSecurityGroup(ctx, eniID, enterpriseSecurityGroupIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateENIEnterpriseSecurityGroup", reflect.TypeOf((*MockInterface)(nil).UpdateENIEnterpriseSecurityGroup), ctx, eniID, enterpriseSecurityGroupIDs)
+}
+
+// UpdateENISecurityGroup mocks base method.
+func (m *MockInterface) UpdateENISecurityGroup(ctx context.Context, eniID string, securityGroupIDs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateENISecurityGroup", ctx, eniID, securityGroupIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateENISecurityGroup indicates an expected call of UpdateENISecurityGroup.
+func (mr *MockInterfaceMockRecorder) UpdateENISecurityGroup(ctx, eniID, securityGroupIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateENISecurityGroup", reflect.TypeOf((*MockInterface)(nil).UpdateENISecurityGroup), ctx, eniID, securityGroupIDs)
+}
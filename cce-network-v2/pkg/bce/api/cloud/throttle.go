@@ -0,0 +1,192 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+package cloud
+
+import (
+	"context"
+	"time"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/lock"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/rate"
+)
+
+const (
+	// defaultThrottleDecreaseFactor is the factor the limits of an API are
+	// multiplied by when the cloud responds with RateLimit
+	defaultThrottleDecreaseFactor = 0.5
+
+	// throttleYieldWindow is the time after the last throttling response
+	// during which batch IP allocation yields to ENI creation
+	throttleYieldWindow = 30 * time.Second
+
+	// maxYieldDuration is the maximum time batch IP allocation waits for
+	// the ENI creation in flight to complete
+	maxYieldDuration = 10 * time.Second
+)
+
+// batchIPAllocationAPIs are the APIs which yield to ENI creation while the
+// account is throttled. A node without ENI can not get any IP from batch
+// allocation, so creating ENIs first gets more pods started during scale-out.
+var batchIPAllocationAPIs = map[string]bool{
+	BatchAddPrivateIP:            true,
+	BatchAddPrivateIpCrossSubnet: true,
+	BCCBatchAddIP:                true,
+	BBCBatchAddIP:                true,
+	BBCBatchAddIPCrossSubnet:     true,
+	BatchAddHpcEniPrivateIP:      true,
+}
+
+// adaptiveAPIRateLimitDefaults returns a copy of the defaults with the
+// adaptive throttling enabled for all APIs which do not configure it
+func adaptiveAPIRateLimitDefaults(defaults map[string]rate.APILimiterParameters) map[string]rate.APILimiterParameters {
+	result := make(map[string]rate.APILimiterParameters, len(defaults))
+	for name, p := range defaults {
+		if p.ThrottleDecreaseFactor == 0 {
+			p.ThrottleDecreaseFactor = defaultThrottleDecreaseFactor
+		}
+		result[name] = p
+	}
+	return result
+}
+
+// eniCreationGate tracks the ENI creation in flight and the throttling
+// responses of one account, so that batch IP allocation can yield to ENI
+// creation while the account is throttled.
+type eniCreationGate struct {
+	mutex lock.Mutex
+	// creating is the number of ENI creation waiting for or holding a slot
+	// of the limiter
+	creating int
+	// created is closed when the last ENI creation in flight has completed
+	created chan struct{}
+	// lastThrottled is the time of the last throttling response of any API
+	lastThrottled time.Time
+}
+
+func (g *eniCreationGate) enter() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if g.creating == 0 {
+		g.created = make(chan struct{})
+	}
+	g.creating++
+}
+
+func (g *eniCreationGate) leave() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.creating--
+	if g.creating == 0 {
+		close(g.created)
+	}
+}
+
+func (g *eniCreationGate) throttled() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.lastThrottled = time.Now()
+}
+
+// yield blocks until the ENI creation in flight has completed if the account
+// has been throttled recently. It never blocks longer than maxYieldDuration.
+func (g *eniCreationGate) yield(ctx context.Context, name string) {
+	g.mutex.Lock()
+	if g.creating == 0 || time.Since(g.lastThrottled) > throttleYieldWindow {
+		g.mutex.Unlock()
+		return
+	}
+	created := g.created
+	g.mutex.Unlock()
+
+	log.WithField("api", name).Debug("account is throttled, yield to ENI creation")
+	timer := time.NewTimer(maxYieldDuration)
+	defer timer.Stop()
+	select {
+	case <-created:
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
+
+// throttleAwareLimiter reports the throttling responses of the cloud to the
+// limiters, and lets batch IP allocation yield to ENI creation while the
+// account is throttled. It is created for each flowControlClient, so the
+// limits adapt for each region and account separately.
+type throttleAwareLimiter struct {
+	rate.ServiceLimiterManager
+	gate *eniCreationGate
+}
+
+func newThrottleAwareLimiter(limiter rate.ServiceLimiterManager) *throttleAwareLimiter {
+	return &throttleAwareLimiter{
+		ServiceLimiterManager: limiter,
+		gate:                  &eniCreationGate{},
+	}
+}
+
+// Wait implements rate.ServiceLimiterManager
+func (t *throttleAwareLimiter) Wait(ctx context.Context, name string) (rate.LimitedRequest, error) {
+	creatingENI := name == CreateENI
+	if creatingENI {
+		t.gate.enter()
+	} else if batchIPAllocationAPIs[name] {
+		t.gate.yield(ctx, name)
+	}
+
+	req, err := t.ServiceLimiterManager.Wait(ctx, name)
+	if err != nil {
+		if creatingENI {
+			t.gate.leave()
+		}
+		return nil, err
+	}
+	return &throttleAwareRequest{
+		LimitedRequest: req,
+		gate:           t.gate,
+		creatingENI:    creatingENI,
+	}, nil
+}
+
+type throttleAwareRequest struct {
+	rate.LimitedRequest
+	gate        *eniCreationGate
+	creatingENI bool
+	finished    bool
+}
+
+func (r *throttleAwareRequest) finish() {
+	if r.creatingENI && !r.finished {
+		r.finished = true
+		r.gate.leave()
+	}
+}
+
+// Done implements rate.LimitedRequest
+func (r *throttleAwareRequest) Done() {
+	r.finish()
+	r.LimitedRequest.Done()
+}
+
+// Error implements rate.LimitedRequest, throttling responses of the cloud
+// decrease the limits of the API
+func (r *throttleAwareRequest) Error(err error) {
+	r.finish()
+	if ClassForError(err) == ErrorClassThrottled {
+		r.gate.throttled()
+		r.LimitedRequest.Throttled(err)
+		return
+	}
+	r.LimitedRequest.Error(err)
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/baidubce/bce-sdk-go/bce"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/rate"
+)
+
+func newTestThrottleAwareLimiter(t *testing.T) *throttleAwareLimiter {
+	defaults := map[string]rate.APILimiterParameters{
+		CreateENI:         {RateLimit: 10, RateBurst: 10},
+		BatchAddPrivateIP: {RateLimit: 10, RateBurst: 10},
+	}
+	set, err := rate.NewAPILimiterSet(nil, adaptiveAPIRateLimitDefaults(defaults), nil)
+	if err != nil {
+		t.Fatalf("NewAPILimiterSet() error = %v", err)
+	}
+	return newThrottleAwareLimiter(rate.APILimiterSetWrapDefault(set, &rate.APILimiterParameters{}))
+}
+
+func TestThrottleAwareLimiterThrottled(t *testing.T) {
+	l := newTestThrottleAwareLimiter(t)
+
+	req, err := l.Wait(context.Background(), BatchAddPrivateIP)
+	if err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	req.Error(WrapError(BatchAddPrivateIP, &bce.BceServiceError{
+		Code:       "RateLimit",
+		StatusCode: http.StatusBadRequest,
+	}))
+
+	if got := l.Limiter(BatchAddPrivateIP).ThrottleFactor(); got != defaultThrottleDecreaseFactor {
+		t.Errorf("ThrottleFactor() = %v, want %v", got, defaultThrottleDecreaseFactor)
+	}
+	if got := l.Limiter(CreateENI).ThrottleFactor(); got != 1.0 {
+		t.Errorf("ThrottleFactor() of other API = %v, want 1.0", got)
+	}
+
+	req, err = l.Wait(context.Background(), BatchAddPrivateIP)
+	if err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	req.Error(fmt.Errorf("Error Message: \"private ip not exist\", Error Code: \"PrivateIpNotExist\", Status Code: 400"))
+	if got := l.Limiter(BatchAddPrivateIP).ThrottleFactor(); got != defaultThrottleDecreaseFactor {
+		t.Errorf("ThrottleFactor() after permanent error = %v, want %v", got, defaultThrottleDecreaseFactor)
+	}
+}
+
+func TestThrottleAwareLimiterYield(t *testing.T) {
+	l := newTestThrottleAwareLimiter(t)
+
+	// batch IP allocation does not yield as long as the account is not throttled
+	eniReq, err := l.Wait(context.Background(), CreateENI)
+	if err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	req, err := l.Wait(context.Background(), BatchAddPrivateIP)
+	if err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	req.Error(WrapError(BatchAddPrivateIP, &bce.BceServiceError{
+		Code:       "RateLimit",
+		StatusCode: http.StatusBadRequest,
+	}))
+
+	released := make(chan struct{})
+	go func() {
+		defer close(released)
+		req, err := l.Wait(context.Background(), BatchAddPrivateIP)
+		if err == nil {
+			req.Done()
+		}
+	}()
+
+	select {
+	case <-released:
+		t.Fatal("batch IP allocation did not yield to ENI creation")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	eniReq.Done()
+	select {
+	case <-released:
+	case <-time.After(time.Second):
+		t.Fatal("batch IP allocation is not released after ENI creation completed")
+	}
+}
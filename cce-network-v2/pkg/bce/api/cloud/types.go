@@ -30,69 +30,130 @@ import (
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/bce/api/hpc"
 )
 
+// Interface is the client of BCE cloud APIs. The flow control and metrics
+// wrappers of Interface are generated by tools/cloudgen from the annotations
+// of the methods, run `make generate-cloud-client` after changing Interface.
+//
+//   - +cce:api:key is the name of the rate limiter, bcecloud/apis/v1/<method> by default
+//   - +cce:api:ratelimit is the default rate limit in the format of --api-rate-limit
+//   - +cce:api:metric is the label of the method in the cloud API metrics, the method name by default
+//   - +cce:api:local marks methods which do not call the cloud
 type Interface interface {
+	// +cce:api:key=GET/bcecloud/apis/v1/vpc
+	// +cce:api:metric=GET/bcecloud/apis/v1/vpc
 	DescribeVPC(ctx context.Context, vpcID string) (*vpc.ShowVPCModel, error)
 
+	// +cce:api:ratelimit=rate-limit:1/s,rate-burst:1,parallel-requests:1,max-wait-duration:30s,log:false
+	// +cce:api:metric=ListENI
 	ListENIs(ctx context.Context, args eni.ListEniArgs) ([]eni.Eni, error)
+	// +cce:api:ratelimit=rate-limit:5/s,rate-burst:10,parallel-requests:5,max-wait-duration:30s,log:false
+	// +cce:api:metric=ListERI
 	ListERIs(ctx context.Context, args eni.ListEniArgs) ([]eni.Eni, error)
 	AddPrivateIP(ctx context.Context, privateIP string, eniID string, isIpv6 bool) (string, error)
 	DeletePrivateIP(ctx context.Context, privateIP string, eniID string, isIpv6 bool) error
 
+	// +cce:api:key=bcecloud/apis/v1/BindENIPulblicIP
 	BindENIPublicIP(ctx context.Context, privateIP string, publicIP string, eniID string) error
+	// +cce:api:key=bcecloud/apis/v1/UnbindENIPulblicIP
 	UnBindENIPublicIP(ctx context.Context, publicIP string, eniID string) error
 	DirectEIP(ctx context.Context, eip string) error
 	UnDirectEIP(ctx context.Context, eip string) error
+	// +cce:api:metric=ListEIP
 	ListEIPs(ctx context.Context, args eip.ListEipArgs) ([]eip.EipModel, error)
+	CreateEIP(ctx context.Context, args *eip.CreateEipArgs) (string, error)
+	DeleteEIP(ctx context.Context, eip string) error
+	// EIPGroupMoveIn moves the EIPs into the shared bandwidth group
+	EIPGroupMoveIn(ctx context.Context, groupID string, eips []string) error
 
 	// BatchAddPrivateIpCrossSubnet
 	// Assign IP addresses to Eni across subnets.
 	// Note that this feature needs to initiate a work order in advance to
 	// enable the cross subnet IP allocation function
 	BatchAddPrivateIpCrossSubnet(ctx context.Context, eniID, subnetID string, privateIPs []string, count int, isIpv6 bool) ([]string, error)
+	// +cce:api:ratelimit=rate-limit:5/s,rate-burst:10,parallel-requests:5,max-wait-duration:30s,log:true
 	BatchAddPrivateIP(ctx context.Context, privateIPs []string, count int, eniID string, isIpv6 bool) ([]string, error)
+	// +cce:api:ratelimit=rate-limit:5/s,rate-burst:10,parallel-requests:5,max-wait-duration:30s,log:true
 	BatchDeletePrivateIP(ctx context.Context, privateIPs []string, eniID string, isIpv6 bool) error
+	// +cce:api:ratelimit=rate-limit:5/s,rate-burst:5,parallel-requests:5,max-wait-duration:30s,log:true
 	CreateENI(ctx context.Context, args *eni.CreateEniArgs) (string, error)
+	// +cce:api:ratelimit=rate-limit:1/s,rate-burst:1,parallel-requests:1,max-wait-duration:30s,log:true
 	DeleteENI(ctx context.Context, eniID string) error
+	// +cce:api:ratelimit=rate-limit:5/s,rate-burst:5,parallel-requests:5,max-wait-duration:30s,log:true
 	AttachENI(ctx context.Context, args *eni.EniInstance) error
 	DetachENI(ctx context.Context, args *eni.EniInstance) error
+	// +cce:api:ratelimit=rate-limit:5/s,rate-burst:10,parallel-requests:5,max-wait-duration:30s,log:false
 	StatENI(ctx context.Context, eniID string) (*eni.Eni, error)
+	// +cce:api:metric=GET /v1/eni/quota
 	GetENIQuota(ctx context.Context, instanceID string) (*eni.EniQuoteInfo, error)
 
+	// UpdateENISecurityGroup replaces the security groups of the ENI with the
+	// normal security groups
+	// +cce:api:ratelimit=rate-limit:5/s,rate-burst:5,parallel-requests:5,max-wait-duration:30s,log:true
+	UpdateENISecurityGroup(ctx context.Context, eniID string, securityGroupIDs []string) error
+	// UpdateENIEnterpriseSecurityGroup replaces the security groups of the ENI
+	// with the enterprise security groups
+	// +cce:api:ratelimit=rate-limit:5/s,rate-burst:5,parallel-requests:5,max-wait-duration:30s,log:true
+	UpdateENIEnterpriseSecurityGroup(ctx context.Context, eniID string, enterpriseSecurityGroupIDs []string) error
+
 	// ListBCCInstanceEni Query the list of BCC eni network interface.
 	// Unlike the VPC interface, this interface can query the primaty network interface of BCC/EBC
 	// However, the `ListENIs`` and `StatENI`` interfaces of VPC cannot retrieve relevant information
 	// about the primary network interface of BCC/EBC.
+	// +cce:api:key=bcecloud/bcc/apis/v2/eni/{instanceID}
+	// +cce:api:ratelimit=rate-limit:5/s,rate-burst:5,parallel-requests:5,max-wait-duration:30s,log:false
+	// +cce:api:metric=bcecloud/bcc/apis/v2/eni/{instanceID}
 	ListBCCInstanceEni(ctx context.Context, instanceID string) ([]bccapi.Eni, error)
 
 	// BCCBatchAddIP batch add secondary IP to primary interface of BCC/EBC
+	// +cce:api:key=bcecloud/bcc/apis/v2/instance/batchAddIp
+	// +cce:api:ratelimit=rate-limit:5/s,rate-burst:5,parallel-requests:5,max-wait-duration:30s,log:false
+	// +cce:api:metric=bcecloud/bcc/apis/v2/instance/batchAddIp
 	BCCBatchAddIP(ctx context.Context, args *bccapi.BatchAddIpArgs) (*bccapi.BatchAddIpResponse, error)
 
 	// BCCBatchDelIP batch delete secondary IP to primary interface of BCC/EBC
 	// Waring: Do not mistakenly delete the primary IP address of the main network card
+	// +cce:api:key=bcecloud/bcc/apis/v2/instance/batchDelIp
+	// +cce:api:ratelimit=rate-limit:5/s,rate-burst:5,parallel-requests:5,max-wait-duration:30s,log:false
+	// +cce:api:metric=bcecloud/bcc/apis/v2/instance/batchDelIp
 	BCCBatchDelIP(ctx context.Context, args *bccapi.BatchDelIpArgs) error
 
+	// +cce:api:ratelimit=rate-limit:1/s,rate-burst:1,parallel-requests:1,max-wait-duration:30s,log:false
 	ListRouteTable(ctx context.Context, vpcID, routeTableID string) ([]vpc.RouteRule, error)
+	// +cce:api:ratelimit=rate-limit:5/s,rate-burst:10,parallel-requests:5,max-wait-duration:30s,log:true
 	CreateRouteRule(ctx context.Context, args *vpc.CreateRouteRuleArgs) (string, error)
+	// +cce:api:ratelimit=rate-limit:5/s,rate-burst:10,parallel-requests:5,max-wait-duration:30s,log:true
 	DeleteRouteRule(ctx context.Context, routeID string) error
 
+	// +cce:api:ratelimit=rate-limit:5/s,rate-burst:10,parallel-requests:5,max-wait-duration:5s,log:false
 	DescribeSubnet(ctx context.Context, subnetID string) (*vpc.Subnet, error)
+	// +cce:api:ratelimit=rate-limit:1/s,rate-burst:1,parallel-requests:1,max-wait-duration:30s,log:false
 	ListSubnets(ctx context.Context, args *vpc.ListSubnetArgs) ([]vpc.Subnet, error)
 
 	ListSecurityGroup(ctx context.Context, vpcID, instanceID string) ([]bccapi.SecurityGroupModel, error)
+	// +cce:api:key=bcecloud/apis/v1/ListAcl
 	ListAclEntrys(ctx context.Context, vpcID string) ([]vpc.AclEntry, error)
 	ListEsg(ctx context.Context, instanceID string) ([]esg.EnterpriseSecurityGroup, error)
 
+	// +cce:api:ratelimit=rate-limit:5/s,rate-burst:10,parallel-requests:5,max-wait-duration:30s,log:false
 	GetBCCInstanceDetail(ctx context.Context, instanceID string) (*bccapi.InstanceModel, error)
 
+	// +cce:api:ratelimit=rate-limit:5/s,rate-burst:10,parallel-requests:5,max-wait-duration:30s,log:false
 	GetBBCInstanceDetail(ctx context.Context, instanceID string) (*bbc.InstanceModel, error)
 	GetBBCInstanceENI(ctx context.Context, instanceID string) (*bbc.GetInstanceEniResult, error)
+	// +cce:api:ratelimit=rate-limit:5/s,rate-burst:10,parallel-requests:5,max-wait-duration:30s,log:false
 	BBCBatchAddIP(ctx context.Context, args *bbc.BatchAddIpArgs) (*bbc.BatchAddIpResponse, error)
+	// +cce:api:ratelimit=rate-limit:5/s,rate-burst:10,parallel-requests:5,max-wait-duration:30s,log:false
 	BBCBatchDelIP(ctx context.Context, args *bbc.BatchDelIpArgs) error
+	// +cce:api:ratelimit=rate-limit:5/s,rate-burst:10,parallel-requests:5,max-wait-duration:30s,log:false
 	BBCBatchAddIPCrossSubnet(ctx context.Context, args *bbc.BatchAddIpCrossSubnetArgs) (*bbc.BatchAddIpResponse, error)
 
+	// +cce:api:ratelimit=rate-limit:5/s,rate-burst:10,parallel-requests:5,max-wait-duration:60s,log:true
 	GetHPCEniID(ctx context.Context, instanceID string) (*hpc.EniList, error)
+	// +cce:api:ratelimit=rate-limit:5/s,rate-burst:10,parallel-requests:5,max-wait-duration:30s,log:false
 	BatchDeleteHpcEniPrivateIP(ctx context.Context, args *hpc.EniBatchDeleteIPArgs) error
+	// +cce:api:ratelimit=rate-limit:5/s,rate-burst:10,parallel-requests:5,max-wait-duration:30s,log:false
 	BatchAddHpcEniPrivateIP(ctx context.Context, args *hpc.EniBatchPrivateIPArgs) (*hpc.BatchAddPrivateIPResult, error)
+	// +cce:api:local
 	HPASWrapper(ctx context.Context) error
 }
 
@@ -13,6 +13,7 @@ import (
 	"github.com/baidubce/bce-sdk-go/services/vpc"
 	"github.com/sirupsen/logrus"
 
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/bce/bcesync"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/controller"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/ip"
 	ccev2alpha1 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2alpha1"
@@ -380,8 +381,6 @@ type SecurityValidator struct {
 	checkErrorCacheMap map[string]error
 	lock               sync.Mutex
 
-	lastErrorTime time.Time
-
 	haveInit bool
 }
 
@@ -503,14 +502,25 @@ func (bsv *SecurityValidator) ViolateSecurityRules(opts *SecurityCheckOpt) (err
 	return
 }
 
+// ValidatePodSecurityGroups checks the security groups bound to the ENI used
+// exclusively by a pod, it returns nil if the validator is not initialised.
+func (bsv *SecurityValidator) ValidatePodSecurityGroups(identifier string, ids []string) error {
+	err, _ := bsv.ViolateSecurityRules(&SecurityCheckOpt{
+		Indentifier: identifier,
+		Role:        ccev2alpha1.SecurityGroupUserRolesPod,
+		RuleIds:     ids,
+	})
+	return err
+}
+
+var _ bcesync.SecurityGroupValidator = &SecurityValidator{}
+
+// getErrorCache returns the result of the last check of the security groups,
+// the results are cleaned every checkInterval
 func (bsv *SecurityValidator) getErrorCache(ids []string) (error, bool) {
 	bsv.lock.Lock()
 	defer bsv.lock.Unlock()
 
-	if bsv.lastErrorTime.Add(bsv.checkInterval).Before(time.Now()) {
-		return nil, true
-	}
-
 	key := strings.Join(ids, ",")
 	if err, ok := bsv.checkErrorCacheMap[key]; ok {
 		return err, true
@@ -524,10 +534,6 @@ func (bsv *SecurityValidator) setErrorCache(ids []string, err error) {
 
 	key := strings.Join(ids, ",")
 	bsv.checkErrorCacheMap[key] = err
-
-	if err != nil {
-		bsv.lastErrorTime = time.Now()
-	}
 }
 
 func (bsv *SecurityValidator) invokeReuiredRules(role ccev2alpha1.SecurityGroupUserRoles, sgRules *sgRulesCollector) *SafetyConstraintsViolations {
@@ -135,6 +135,7 @@ type eniSyncher struct {
 
 	remoteSyncer  remoteEniSyncher
 	eventRecorder record.EventRecorder
+	sgValidator   SecurityGroupValidator
 }
 
 // Init initialise the sync manager.
@@ -248,6 +249,13 @@ func (es *eniSyncher) handleENIUpdate(resource *ccev2.ENI, scopeLog *logrus.Entr
 		}
 	}
 
+	if changed, sgErr := es.ensureSecurityGroups(ctx, newObj, scopeLog); sgErr != nil {
+		scopeLog.WithError(sgErr).Error("ensure security groups of eni failed")
+		return sgErr
+	} else if changed {
+		eniStatus = &newObj.Status
+	}
+
 	// When the Finalizer is null, only patching is allowed, updates will ignore changes.
 UpdateStatus:
 	if isNeedSkipUpdate {
@@ -0,0 +1,219 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+package bcesync
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s"
+	ccev2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2"
+)
+
+// SecurityGroupValidator validates the security groups before they are bound
+// to the ENI used exclusively by a pod
+type SecurityGroupValidator interface {
+	// ValidatePodSecurityGroups returns an error if the security groups
+	// violate the rules required by the pods
+	ValidatePodSecurityGroups(identifier string, ids []string) error
+}
+
+// SetSecurityGroupValidator sets the validator of the security groups
+// requested by the pods. The security groups are not validated if it is
+// not set.
+func (es *VPCENISyncerRouter) SetSecurityGroupValidator(validator SecurityGroupValidator) {
+	es.eni.sgValidator = validator
+}
+
+// ensureSecurityGroups binds the security groups requested by the endpoint
+// using the ENI in Primary use mode, and restores the security groups of the
+// node after the endpoint releases the ENI. The result is recorded in the
+// status of the ENI, it returns true if the status is changed.
+func (es *eniSyncher) ensureSecurityGroups(ctx context.Context, newObj *ccev2.ENI, scopeLog *logrus.Entry) (bool, error) {
+	if newObj.Spec.UseMode != ccev2.ENIUseModePrimaryIP ||
+		newObj.Spec.Type == ccev2.ENIForHPC || newObj.Spec.Type == ccev2.ENIForERI ||
+		newObj.Status.VPCStatus != ccev2.VPCENIStatusInuse ||
+		newObj.DeletionTimestamp != nil {
+		return false, nil
+	}
+
+	requested, endpointUID, err := requestedSecurityGroups(newObj)
+	if err != nil {
+		return false, err
+	}
+	if requested == nil {
+		// the ENIs which have never been bound to the security groups of
+		// endpoints keep the security groups they are created with
+		if newObj.Status.SecurityGroupBinding == nil {
+			return false, nil
+		}
+		nodeGroups, err := nodeSecurityGroups(newObj.Spec.NodeName)
+		if err != nil {
+			return false, err
+		}
+		if err = es.bindSecurityGroups(ctx, newObj, nodeGroups); err != nil {
+			return false, fmt.Errorf("failed to restore security groups of node to eni %s: %w", newObj.Name, err)
+		}
+		newObj.Status.SecurityGroupBinding = nil
+		scopeLog.WithField("securityGroups", nodeGroups).Info("restore security groups of node to eni")
+		return true, nil
+	}
+
+	scopeLog = scopeLog.WithFields(logrus.Fields{
+		"endpointUID":    endpointUID,
+		"securityGroups": requested,
+	})
+	binding := &ccev2.ENISecurityGroupBinding{
+		EndpointUID:            endpointUID,
+		EndpointSecurityGroups: *requested.DeepCopy(),
+	}
+	binding.Code = models.EndpointStatusChangeCodeOk
+	if es.sgValidator != nil {
+		if err := es.sgValidator.ValidatePodSecurityGroups(newObj.Name, securityGroupIDs(requested)); err != nil {
+			binding.Code = models.EndpointStatusChangeCodeFailed
+			binding.Message = fmt.Sprintf("security groups violate the rules required by pods: %v", err)
+		}
+	}
+	if binding.Code == models.EndpointStatusChangeCodeOk {
+		if err := es.bindSecurityGroups(ctx, newObj, requested); kerrors.IsConflict(err) {
+			return false, err
+		} else if err != nil {
+			binding.Code = models.EndpointStatusChangeCodeFailed
+			binding.Message = fmt.Sprintf("failed to bind security groups: %v", err)
+		}
+	}
+
+	// the failed binding is not updated again to avoid updating the status
+	// of the ENI repeatedly, it is retried when the ENI is resynced
+	old := newObj.Status.SecurityGroupBinding
+	if old != nil && old.EndpointUID == binding.EndpointUID && old.Code == binding.Code &&
+		old.Message == binding.Message && sameSecurityGroups(&old.EndpointSecurityGroups, requested) {
+		return false, nil
+	}
+	binding.Time = metav1.Now()
+	newObj.Status.SecurityGroupBinding = binding
+	if binding.Code != models.EndpointStatusChangeCodeOk {
+		es.eventRecorder.Event(newObj, corev1.EventTypeWarning, "FailedBindSecurityGroups", binding.Message)
+		scopeLog.Error(binding.Message)
+	} else {
+		es.eventRecorder.Eventf(newObj, corev1.EventTypeNormal, "BindSecurityGroups", "bind security groups %v of endpoint to eni", securityGroupIDs(requested))
+		scopeLog.Info("bind security groups of endpoint to eni")
+	}
+	return true, nil
+}
+
+// bindSecurityGroups replaces the security groups of the ENI on the cloud and
+// updates the spec of the ENI
+func (es *eniSyncher) bindSecurityGroups(ctx context.Context, newObj *ccev2.ENI, groups *ccev2.EndpointSecurityGroups) error {
+	current := &ccev2.EndpointSecurityGroups{
+		SecurityGroupIDs:           newObj.Spec.ENI.SecurityGroupIds,
+		EnterpriseSecurityGroupIDs: newObj.Spec.ENI.EnterpriseSecurityGroupIds,
+	}
+	if sameSecurityGroups(current, groups) {
+		return nil
+	}
+
+	var err error
+	if len(groups.EnterpriseSecurityGroupIDs) != 0 {
+		err = es.bceclient.UpdateENIEnterpriseSecurityGroup(ctx, newObj.Spec.ENI.ID, groups.EnterpriseSecurityGroupIDs)
+	} else {
+		err = es.bceclient.UpdateENISecurityGroup(ctx, newObj.Spec.ENI.ID, groups.SecurityGroupIDs)
+	}
+	if err != nil {
+		return err
+	}
+
+	newObj.Spec.ENI.SecurityGroupIds = groups.SecurityGroupIDs
+	newObj.Spec.ENI.EnterpriseSecurityGroupIds = groups.EnterpriseSecurityGroupIDs
+	updated, err := es.updater.Update(newObj)
+	if err != nil {
+		return fmt.Errorf("update eni spec failed: %w", err)
+	}
+	newObj.ObjectMeta = updated.ObjectMeta
+	return nil
+}
+
+// requestedSecurityGroups returns the security groups requested by the
+// endpoint using the ENI and the UID of the endpoint. It returns nil if the
+// ENI is not used or the endpoint does not request any.
+func requestedSecurityGroups(resource *ccev2.ENI) (*ccev2.EndpointSecurityGroups, string, error) {
+	ref := resource.Status.EndpointReference
+	if ref == nil {
+		return nil, "", nil
+	}
+	cep, err := k8s.CCEClient().Informers.Cce().V2().CCEEndpoints().Lister().CCEEndpoints(ref.Namespace).Get(ref.Name)
+	if kerrors.IsNotFound(err) {
+		return nil, "", nil
+	} else if err != nil {
+		return nil, "", err
+	}
+	// the endpoint is recreated and has not been bound to the ENI yet
+	if string(cep.UID) != ref.UID || cep.Spec.Network.SecurityGroups == nil {
+		return nil, "", nil
+	}
+	return cep.Spec.Network.SecurityGroups, string(cep.UID), nil
+}
+
+// nodeSecurityGroups returns the security groups of the ENIs created for the node
+func nodeSecurityGroups(nodeName string) (*ccev2.EndpointSecurityGroups, error) {
+	nrs, err := k8s.CCEClient().Informers.Cce().V2().NetResourceSets().Lister().Get(nodeName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nrs %s: %w", nodeName, err)
+	}
+	if nrs.Spec.ENI == nil || (len(nrs.Spec.ENI.SecurityGroups) == 0 && len(nrs.Spec.ENI.EnterpriseSecurityGroupList) == 0) {
+		return nil, fmt.Errorf("no security group is found on nrs %s", nodeName)
+	}
+	return &ccev2.EndpointSecurityGroups{
+		SecurityGroupIDs:           nrs.Spec.ENI.SecurityGroups,
+		EnterpriseSecurityGroupIDs: nrs.Spec.ENI.EnterpriseSecurityGroupList,
+	}, nil
+}
+
+func securityGroupIDs(groups *ccev2.EndpointSecurityGroups) []string {
+	if len(groups.EnterpriseSecurityGroupIDs) != 0 {
+		return groups.EnterpriseSecurityGroupIDs
+	}
+	return groups.SecurityGroupIDs
+}
+
+// sameSecurityGroups compares the security groups regardless of the order
+func sameSecurityGroups(a, b *ccev2.EndpointSecurityGroups) bool {
+	return sameStrings(a.SecurityGroupIDs, b.SecurityGroupIDs) &&
+		sameStrings(a.EnterpriseSecurityGroupIDs, b.EnterpriseSecurityGroupIDs)
+}
+
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append([]string(nil), a...)
+	b = append([]string(nil), b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,25 @@
+package bcesync
+
+import (
+	"testing"
+
+	ccev2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSameSecurityGroups(t *testing.T) {
+	groups := &ccev2.EndpointSecurityGroups{SecurityGroupIDs: []string{"g-1", "g-2"}}
+
+	assert.True(t, sameSecurityGroups(groups, &ccev2.EndpointSecurityGroups{SecurityGroupIDs: []string{"g-2", "g-1"}}))
+	assert.False(t, sameSecurityGroups(groups, &ccev2.EndpointSecurityGroups{SecurityGroupIDs: []string{"g-1"}}))
+	assert.False(t, sameSecurityGroups(groups, &ccev2.EndpointSecurityGroups{EnterpriseSecurityGroupIDs: []string{"esg-1"}}))
+	assert.True(t, sameSecurityGroups(&ccev2.EndpointSecurityGroups{}, &ccev2.EndpointSecurityGroups{SecurityGroupIDs: []string{}}))
+
+	// the security groups are not reordered
+	assert.Equal(t, []string{"g-1", "g-2"}, groups.SecurityGroupIDs)
+	assert.Equal(t, []string{"g-1", "g-2"}, securityGroupIDs(groups))
+	assert.Equal(t, []string{"esg-1"}, securityGroupIDs(&ccev2.EndpointSecurityGroups{
+		SecurityGroupIDs:           []string{"g-1"},
+		EnterpriseSecurityGroupIDs: []string{"esg-1"},
+	}))
+}
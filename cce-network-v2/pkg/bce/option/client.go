@@ -21,6 +21,7 @@ import (
 	operatorOption "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/operator/option"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/bce/api/cloud"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/bce/api/cloud/ccegateway"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/bce/api/cloud/simulator"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/option"
@@ -41,6 +42,35 @@ func BCEClient() cloud.Interface {
 	}
 	mutex.Unlock()
 
+	var (
+		c   cloud.Interface
+		err error
+	)
+	if path := operatorOption.Config.BCECloudSimulatorConfig; path != "" {
+		log.Warnf("[InitBCEClient] running against the in-memory cloud simulator %s, no BCE cloud resource will be changed", path)
+		c, err = simulator.NewFromFile(path)
+		if err != nil {
+			log.Fatalf("[InitBCEClient] failed to init cloud simulator %v", err)
+		}
+	} else {
+		c = newCloudClient()
+	}
+
+	c, err = cloud.NewFlowControlClient(cloud.NewMetricsClient(c),
+		operatorOption.Config.DefaultAPIQPSLimit,
+		operatorOption.Config.DefaultAPIBurst,
+		operatorOption.Config.DefaultAPITimeoutLimit)
+	if err != nil {
+		log.Fatalf("[InitBCEClient] failed to init bce client with flow control %v", err)
+	}
+
+	mutex.Lock()
+	defaultClient = c
+	mutex.Unlock()
+	return defaultClient
+}
+
+func newCloudClient() cloud.Interface {
 	if endpoint := operatorOption.Config.BCECloudBaseHost; endpoint != "" {
 		os.Setenv(ccegateway.EndpointOverrideEnv, endpoint)
 	}
@@ -65,16 +95,5 @@ func BCEClient() cloud.Interface {
 	if err != nil {
 		log.Fatalf("[InitBCEClient] failed to init bce client %v", err)
 	}
-	c, err = cloud.NewFlowControlClient(c,
-		operatorOption.Config.DefaultAPIQPSLimit,
-		operatorOption.Config.DefaultAPIBurst,
-		operatorOption.Config.DefaultAPITimeoutLimit)
-	if err != nil {
-		log.Fatalf("[InitBCEClient] failed to init bce client with flow control %v", err)
-	}
-
-	mutex.Lock()
-	defaultClient = c
-	mutex.Unlock()
-	return defaultClient
+	return c
 }
@@ -32,6 +32,7 @@ import (
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
 	operatorOption "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/operator/option"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/bce/api"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/bce/api/cloud"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/bce/bcesync"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/bce/rdma/client"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/ipam"
@@ -531,8 +532,8 @@ func (n *bceRDMANetResourceSet) AllocateIPs(ctx context.Context, allocation *ipa
 			partialIPv4Result, partialIPv6Result, err := n.real.allocateIPs(ctx, scopedLog, iaasClient, allocation, ipv4PaticalToAllocate, ipv6PaticalToAllocate)
 			if err != nil {
 				allocateLog.WithError(err).Error("failed to allocate ips from eni")
-				err = fmt.Errorf("allocate RDMA %d IP(s) on NRS %s failed, error: %v", ipv4PaticalToAllocate, n.k8sObj.Name, err) // IPv6 is not supported
-				n.appendAllocatedIPError(allocation.InterfaceID, ccev2.NewErrorStatusChange(err.Error()))
+				err = fmt.Errorf("allocate RDMA %d IP(s) on NRS %s failed, error: %w", ipv4PaticalToAllocate, n.k8sObj.Name, err) // IPv6 is not supported
+				n.appendAllocatedIPError(allocation.InterfaceID, cloud.NewErrorStatusChange(err, err.Error()))
 				if !isPartialSuccess {
 					return err
 				} else {
@@ -38,6 +38,7 @@ import (
 	operatorOption "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/operator/option"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/operator/watchers"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/bce/api"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/bce/api/cloud"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/bce/api/metadata"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/bce/bcesync"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/defaults"
@@ -980,7 +981,7 @@ func (n *bceNetworkResourceSet) AllocateIPs(ctx context.Context, allocation *ipa
 			if err != nil {
 				// append error to eni status
 				n.appendAllocatedIPError(allocation.InterfaceID,
-					ccev2.NewErrorStatusChange(
+					cloud.NewErrorStatusChange(err,
 						fmt.Sprintf("allocate ips from eni [%s] failed: %v", allocation.InterfaceID, err)))
 
 				allocateLog.WithError(err).Error("failed to allocate ips from eni")
@@ -1245,8 +1246,8 @@ func (n *bceNetworkResourceSet) ReleaseIPs(ctx context.Context, release *ipam.Re
 				err = n.real.releaseIPs(ctx, release, ipToRelease, []string{})
 			}
 			if err != nil && !strings.Contains(err.Error(), "PrivateIpInvalid") {
-				err = fmt.Errorf("release ip %s failed: %v", ipToRelease, err)
-				n.appendAllocatedIPError(release.InterfaceID, ccev2.NewErrorStatusChange(err.Error()))
+				err = fmt.Errorf("release ip %s failed: %w", ipToRelease, err)
+				n.appendAllocatedIPError(release.InterfaceID, cloud.NewErrorStatusChange(err, err.Error()))
 				if !isPartialSuccess {
 					return err
 				}
@@ -64,10 +64,14 @@ type VPCRouteOperator struct {
 	// realHandler is the real handler to handle node event
 	realHandler allocator.NetResourceSetEventHandler
 
-	bceClient     cloud.Interface
-	vpcRouteMap   map[string]*vpc.RouteRule
-	routeTableID  string
-	eventRecorder record.EventRecorder
+	bceClient cloud.Interface
+	// vpcRouteMap is the route rules of all the route tables, the key is routeRuleKey
+	vpcRouteMap map[string]*vpc.RouteRule
+	// routeTableID is the default route table of VPC
+	routeTableID string
+	// routeTableSelectors are the route tables selected by the labels of nodes
+	routeTableSelectors []routeTableSelector
+	eventRecorder       record.EventRecorder
 }
 
 func (operator *VPCRouteOperator) Init(ctx context.Context) error {
@@ -78,6 +82,12 @@ func (operator *VPCRouteOperator) Init(ctx context.Context) error {
 	operator.vpcRouteMap = make(map[string]*vpc.RouteRule)
 	operator.eventRecorder = k8s.EventBroadcaster().NewRecorder(scheme.Scheme, corev1.EventSource{Component: vpcRouteSubsys})
 
+	var err error
+	operator.routeTableSelectors, err = parseRouteTableSelectors(operatorOption.Config.VPCRouteTableSelectors)
+	if err != nil {
+		return err
+	}
+
 	routeDescription = fmt.Sprintf("%s:%s", routeDescription, operatorOption.Config.CCEClusterID)
 	return operator.allocator.Init(ctx)
 }
@@ -111,16 +121,37 @@ func (operator *VPCRouteOperator) doSyncVPCRouteRules(ctx context.Context) error
 	tmpMap := make(map[string]*vpc.RouteRule)
 	for i := range rules {
 		rule := &rules[i]
-		tmpMap[rule.NexthopId+rule.DestinationAddress] = rule
+		tmpMap[routeRuleKey(rule)] = rule
 		if operator.routeTableID == "" {
 			operator.mutex.Lock()
 			operator.routeTableID = rule.RouteTableId
 			operator.mutex.Unlock()
 		}
 	}
+
+	// the route tables selected by node labels
+	for _, rt := range operator.routeTableSelectors {
+		if rt.routeTableID == operator.routeTableID {
+			continue
+		}
+		rules, err := operator.bceClient.ListRouteTable(ctx, operatorOption.Config.BCECloudVPCID, rt.routeTableID)
+		if err != nil {
+			log.WithError(err).WithField("routeTableID", rt.routeTableID).Error("failed to list route table")
+			return err
+		}
+		for i := range rules {
+			rule := &rules[i]
+			if rule.RouteTableId == "" {
+				rule.RouteTableId = rt.routeTableID
+			}
+			tmpMap[routeRuleKey(rule)] = rule
+		}
+	}
+
 	operator.mutex.Lock()
 	operator.vpcRouteMap = tmpMap
 	operator.mutex.Unlock()
+	operator.updateRouteTableMetrics(operator.routeTableUsage())
 	return nil
 }
 
@@ -218,18 +249,20 @@ func (operator *VPCRouteOperator) updateVPCRouteStatus(scopedLog *logrus.Entry,
 	if len(newObj.Status.IPAM.VPCRouteCIDRs) == 0 {
 		newObj.Status.IPAM.VPCRouteCIDRs = make(ipamTypes.VPCRouteStatuMap)
 	}
-	if operator.routeTableID == "" {
+	routeTableIDs := operator.routeTablesForNode(newObj)
+	if len(routeTableIDs) == 0 {
 		err = operator.doSyncVPCRouteRules(context.TODO())
 		if err != nil {
 			return false, err
 		}
+		routeTableIDs = operator.routeTablesForNode(newObj)
 	}
-	if operator.routeTableID == "" {
+	if len(routeTableIDs) == 0 {
 		scopedLog.Warningf("failed to get route table for %s. retry later", name)
 		return false, nil
 	}
 
-	toAddCIDR, toDeleteCIDR := operator.determineNodeActions(newObj)
+	toAddCIDR, toDeleteCIDR := operator.determineNodeActions(newObj, routeTableIDs)
 
 	// to clean all vpc route via this node, this is equivalent to clearing
 	// all CIDR of the current node
@@ -242,7 +275,7 @@ func (operator *VPCRouteOperator) updateVPCRouteStatus(scopedLog *logrus.Entry,
 			continue
 		}
 		operator.mutex.Lock()
-		delete(operator.vpcRouteMap, rule.NexthopId+rule.DestinationAddress)
+		delete(operator.vpcRouteMap, routeRuleKey(rule))
 		operator.mutex.Unlock()
 		operator.eventRecorder.Eventf(newObj, corev1.EventTypeNormal, "RouteRuleDeleted", "delete vpc route rule %s success", ruleStr)
 	}
@@ -258,40 +291,56 @@ func (operator *VPCRouteOperator) updateVPCRouteStatus(scopedLog *logrus.Entry,
 			NexthopType:        rule.NexthopType,
 			NexthopId:          rule.NexthopId,
 			Description:        rule.Description,
+			IpVersion:          ipVersionOfRouteRule(rule),
 		}
 		ruleID, err := operator.bceClient.CreateRouteRule(ctx, arg)
 		if err != nil {
-			if cloud.IsErrorQuotaLimitExceeded(err) {
+			switch {
+			case cloud.IsErrorQuotaLimitExceeded(err) || cloud.IsErrorCreateRouteRuleExceededQuota(err):
 				operator.eventRecorder.Eventf(newObj, corev1.EventTypeWarning, "RouteRuleExceededQuota", "failed to create vpc route rule %s : %s", ruleStr, err)
-			} else {
+			case cloud.IsErrorRouteRuleRepeated(err):
+				// the rule was created after the route table was listed, resync to get its id
+				operator.eventRecorder.Eventf(newObj, corev1.EventTypeWarning, "CreateRouteRuleFailed", "failed to create vpc route rule %s : %s", ruleStr, err)
+				if syncErr := operator.doSyncVPCRouteRules(ctx); syncErr != nil {
+					scopedLog.WithError(syncErr).Warning("failed to resync vpc route rules")
+				}
+			default:
 				operator.eventRecorder.Eventf(newObj, corev1.EventTypeWarning, "CreateRouteRuleFailed", "failed to create vpc route rule %s : %s", ruleStr, err)
 			}
 			continue
 		}
 		rule.RouteRuleId = ruleID
 		operator.mutex.Lock()
-		operator.vpcRouteMap[rule.NexthopId+rule.DestinationAddress] = rule
+		operator.vpcRouteMap[routeRuleKey(rule)] = rule
 		operator.mutex.Unlock()
 		operator.eventRecorder.Eventf(newObj, corev1.EventTypeNormal, "RouteRuleCreated", "create vpc route rule %s success", ruleStr)
 	}
+	if len(toAddCIDR) != 0 {
+		operator.warnRouteTableQuota(newObj, routeTableIDs)
+	} else if len(toDeleteCIDR) != 0 {
+		operator.updateRouteTableMetrics(operator.routeTableUsage())
+	}
 
-	// update status if route table already exists
+	// update status if the cidr is routed in all the route tables of node
 	for _, cidr := range newObj.Spec.IPAM.PodCIDRs {
-		operator.mutex.Lock()
-		vpcRule := operator.vpcRouteMap[newObj.Spec.InstanceID+cidr]
-		operator.mutex.Unlock()
-		if vpcRule != nil {
+		if routedInAll(operator.routedTables(newObj.Spec.InstanceID, cidr), routeTableIDs) {
 			newObj.Status.IPAM.VPCRouteCIDRs[cidr] = ipamTypes.VPCRouteStatusInUse
 		}
 	}
 
 	// clean vpc route status if route table already delete on VPC
 	for target := range newObj.Status.IPAM.VPCRouteCIDRs {
+		routed := operator.routedTables(newObj.Spec.InstanceID, target)
+		// the deleting node is released only if all the route rules via it are deleted
+		released := !routedInAll(routed, routeTableIDs)
+		if newObj.DeletionTimestamp != nil {
+			released = len(routed) == 0
+		}
+
 		operator.mutex.Lock()
-		vpcRule := operator.vpcRouteMap[newObj.Spec.InstanceID+target]
+		synced := len(operator.vpcRouteMap) != 0
 		operator.mutex.Unlock()
-
-		if vpcRule == nil && len(operator.vpcRouteMap) != 0 &&
+		if released && synced &&
 			newObj.Status.IPAM.VPCRouteCIDRs[target] != ipamTypes.VPCRouteStatusReleased {
 			scopedLog.WithField("target", target).Info("released vpc route")
 			newObj.Status.IPAM.VPCRouteCIDRs[target] = ipamTypes.VPCRouteStatusReleased
@@ -315,31 +364,31 @@ func (operator *VPCRouteOperator) updateVPCRouteStatus(scopedLog *logrus.Entry,
 	return true, nil
 }
 
-// determineNodeActions returns the list of route rules to add and delete
-func (operator *VPCRouteOperator) determineNodeActions(netResourceSet *ccev2.NetResourceSet) (toAddCIDR, toDeleteCIDR []*vpc.RouteRule) {
+// determineNodeActions returns the list of route rules to add and delete, the pod cidrs
+// of node should be routed in all the route tables
+func (operator *VPCRouteOperator) determineNodeActions(netResourceSet *ccev2.NetResourceSet, routeTableIDs []string) (toAddCIDR, toDeleteCIDR []*vpc.RouteRule) {
 	operator.mutex.Lock()
 	defer operator.mutex.Unlock()
 	var (
 		specCIDRs map[string]bool = make(map[string]bool)
-		ccestatus                 = netResourceSet.Status.IPAM.VPCRouteCIDRs
+		inTables  map[string]bool = make(map[string]bool)
 	)
-	if ccestatus == nil {
-		ccestatus = make(ipamTypes.VPCRouteStatuMap)
+	for _, routeTableID := range routeTableIDs {
+		inTables[routeTableID] = true
 	}
 
 	if netResourceSet.DeletionTimestamp == nil {
 		for _, cidr := range netResourceSet.Spec.IPAM.PodCIDRs {
 			specCIDRs[cidr] = true
-			if ccestatu, ok := ccestatus[cidr]; ok && ccestatu == ipamTypes.VPCRouteStatusInUse {
-				continue
-			}
-			rule, err := cidrToVPCRouteRule(cidr, netResourceSet.Spec.InstanceID, operator.routeTableID)
-			if err != nil {
-				log.WithField("cidr", cidr).WithField("node", netResourceSet.Name).WithError(err).Error("failed to parse cidr")
-				continue
-			}
-			if _, ok := operator.vpcRouteMap[rule.NexthopId+rule.DestinationAddress]; !ok {
-				toAddCIDR = append(toAddCIDR, rule)
+			for _, routeTableID := range routeTableIDs {
+				rule, err := cidrToVPCRouteRule(cidr, netResourceSet.Spec.InstanceID, routeTableID)
+				if err != nil {
+					log.WithField("cidr", cidr).WithField("node", netResourceSet.Name).WithError(err).Error("failed to parse cidr")
+					break
+				}
+				if _, ok := operator.vpcRouteMap[routeRuleKey(rule)]; !ok {
+					toAddCIDR = append(toAddCIDR, rule)
+				}
 			}
 		}
 
@@ -349,7 +398,8 @@ func (operator *VPCRouteOperator) determineNodeActions(netResourceSet *ccev2.Net
 				continue
 			}
 			if _, inCIDRs := specCIDRs[rule.DestinationAddress]; inCIDRs {
-				if rule.NexthopId != netResourceSet.Spec.InstanceID {
+				// the cidr is routed to another node, or the node is moved out of the route table
+				if rule.NexthopId != netResourceSet.Spec.InstanceID || !inTables[rule.RouteTableId] {
 					toDeleteCIDR = append(toDeleteCIDR, rule)
 				}
 				continue
@@ -380,6 +430,16 @@ func (operator *VPCRouteOperator) ResourceType() string {
 
 var _ allocator.NetResourceSetEventHandler = &VPCRouteOperator{}
 
+// routedInAll returns true if all the route tables are routed
+func routedInAll(routed map[string]bool, routeTableIDs []string) bool {
+	for _, routeTableID := range routeTableIDs {
+		if !routed[routeTableID] {
+			return false
+		}
+	}
+	return true
+}
+
 func cidrToVPCRouteRule(cidrkey, instanceID, routeTableID string) (*vpc.RouteRule, error) {
 	cidrNet, err := cidr.ParseCIDR(cidrkey)
 	if err != nil {
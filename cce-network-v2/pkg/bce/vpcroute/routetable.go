@@ -0,0 +1,148 @@
+/*
+ * Copyright (c) 2024 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+package vpcroute
+
+import (
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/baidubce/bce-sdk-go/services/vpc"
+	corev1 "k8s.io/api/core/v1"
+	k8sLabels "k8s.io/apimachinery/pkg/labels"
+
+	operatorMetrics "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/operator/metrics"
+	operatorOption "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/operator/option"
+	ccev2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2"
+)
+
+// routeTableSelector selects the nodes whose routes are created in the route table
+type routeTableSelector struct {
+	routeTableID string
+	selector     k8sLabels.Selector
+}
+
+// parseRouteTableSelectors parses the label selectors of route tables, the
+// result is sorted by the route table id
+func parseRouteTableSelectors(selectors map[string]string) ([]routeTableSelector, error) {
+	var result []routeTableSelector
+	for routeTableID, str := range selectors {
+		selector, err := k8sLabels.Parse(str)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label selector %q of route table %s: %w", str, routeTableID, err)
+		}
+		result = append(result, routeTableSelector{routeTableID: routeTableID, selector: selector})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].routeTableID < result[j].routeTableID
+	})
+	return result, nil
+}
+
+// routeTablesForNode returns the route tables whose selectors match the labels of the node,
+// or the default route table of VPC if no selector matches. The default route table is
+// empty before it is synced from VPC.
+func (operator *VPCRouteOperator) routeTablesForNode(netResourceSet *ccev2.NetResourceSet) []string {
+	var routeTableIDs []string
+	for _, rt := range operator.routeTableSelectors {
+		if rt.selector.Matches(k8sLabels.Set(netResourceSet.Labels)) {
+			routeTableIDs = append(routeTableIDs, rt.routeTableID)
+		}
+	}
+	if len(routeTableIDs) != 0 {
+		return routeTableIDs
+	}
+
+	operator.mutex.Lock()
+	defer operator.mutex.Unlock()
+	if operator.routeTableID == "" {
+		return nil
+	}
+	return []string{operator.routeTableID}
+}
+
+// routedTables returns the route tables in which the cidr is routed to the instance
+func (operator *VPCRouteOperator) routedTables(instanceID, cidr string) map[string]bool {
+	operator.mutex.Lock()
+	defer operator.mutex.Unlock()
+	routeTableIDs := make(map[string]bool)
+	for _, rule := range operator.vpcRouteMap {
+		if rule.NexthopId == instanceID && rule.DestinationAddress == cidr {
+			routeTableIDs[rule.RouteTableId] = true
+		}
+	}
+	return routeTableIDs
+}
+
+// routeTableUsage counts the route rules of each route table, including the rules not created by cce
+func (operator *VPCRouteOperator) routeTableUsage() map[string]int {
+	operator.mutex.Lock()
+	defer operator.mutex.Unlock()
+	usage := make(map[string]int)
+	if operator.routeTableID != "" {
+		usage[operator.routeTableID] = 0
+	}
+	for _, rt := range operator.routeTableSelectors {
+		usage[rt.routeTableID] = 0
+	}
+	for _, rule := range operator.vpcRouteMap {
+		usage[rule.RouteTableId]++
+	}
+	return usage
+}
+
+// updateRouteTableMetrics exports the usage and quota of route tables
+func (operator *VPCRouteOperator) updateRouteTableMetrics(usage map[string]int) {
+	if !operatorOption.Config.EnableMetrics {
+		return
+	}
+	for routeTableID, used := range usage {
+		operatorMetrics.VPCRouteRules.WithLabelValues(routeTableID).Set(float64(used))
+		operatorMetrics.VPCRouteRuleQuota.WithLabelValues(routeTableID).Set(float64(operatorOption.Config.VPCRouteRuleQuota))
+	}
+}
+
+// warnRouteTableQuota records a warning event on the netresourceset if the route
+// rules of the route tables are about to exceed the quota
+func (operator *VPCRouteOperator) warnRouteTableQuota(netResourceSet *ccev2.NetResourceSet, routeTableIDs []string) {
+	quota := operatorOption.Config.VPCRouteRuleQuota
+	if quota <= 0 {
+		return
+	}
+	usage := operator.routeTableUsage()
+	operator.updateRouteTableMetrics(usage)
+	for _, routeTableID := range routeTableIDs {
+		used := usage[routeTableID]
+		if float64(used) < float64(quota)*operatorOption.Config.VPCRouteRuleQuotaWarningRatio {
+			continue
+		}
+		operator.eventRecorder.Eventf(netResourceSet, corev1.EventTypeWarning, "RouteRuleQuotaAlmostExceeded",
+			"route table %s has used %d of %d route rules, please apply for more quota", routeTableID, used, quota)
+	}
+}
+
+// routeRuleKey is the key of route rule in vpcRouteMap
+func routeRuleKey(rule *vpc.RouteRule) string {
+	return rule.RouteTableId + "/" + rule.NexthopId + "/" + rule.DestinationAddress
+}
+
+// ipVersionOfRouteRule returns the ip version of the route rule for the VPC API,
+// the IPv6 rules must be created with ip version 6
+func ipVersionOfRouteRule(rule *vpc.RouteRule) string {
+	if _, ipNet, err := net.ParseCIDR(rule.DestinationAddress); err == nil && ipNet.IP.To4() == nil {
+		return "6"
+	}
+	return "4"
+}
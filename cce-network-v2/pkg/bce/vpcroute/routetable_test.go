@@ -0,0 +1,124 @@
+/*
+ * Copyright (c) 2024 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+package vpcroute
+
+import (
+	"testing"
+
+	"github.com/baidubce/bce-sdk-go/services/vpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ccev2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/lock"
+)
+
+func newTestOperator(t *testing.T, selectors map[string]string, rules ...*vpc.RouteRule) *VPCRouteOperator {
+	routeTableSelectors, err := parseRouteTableSelectors(selectors)
+	require.NoError(t, err)
+	operator := &VPCRouteOperator{
+		mutex:               &lock.Mutex{},
+		vpcRouteMap:         make(map[string]*vpc.RouteRule),
+		routeTableID:        "rt-default",
+		routeTableSelectors: routeTableSelectors,
+	}
+	for _, rule := range rules {
+		operator.vpcRouteMap[routeRuleKey(rule)] = rule
+	}
+	return operator
+}
+
+func newTestNetResourceSet(labels map[string]string, podCIDRs ...string) *ccev2.NetResourceSet {
+	nrs := &ccev2.NetResourceSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "10.0.0.2", Labels: labels},
+	}
+	nrs.Spec.InstanceID = "i-node"
+	nrs.Spec.IPAM.PodCIDRs = podCIDRs
+	return nrs
+}
+
+func newTestRouteRule(t *testing.T, cidr, instanceID, routeTableID string) *vpc.RouteRule {
+	rule, err := cidrToVPCRouteRule(cidr, instanceID, routeTableID)
+	require.NoError(t, err)
+	rule.RouteRuleId = "rr-" + routeTableID + "-" + cidr
+	return rule
+}
+
+func TestParseRouteTableSelectors(t *testing.T) {
+	_, err := parseRouteTableSelectors(map[string]string{"rt-a": "zone in (a"})
+	assert.Error(t, err)
+
+	selectors, err := parseRouteTableSelectors(map[string]string{
+		"rt-b": "topology.kubernetes.io/zone=zoneB",
+		"rt-a": "topology.kubernetes.io/zone in (zoneA,zoneB)",
+	})
+	require.NoError(t, err)
+	require.Len(t, selectors, 2)
+	assert.Equal(t, "rt-a", selectors[0].routeTableID)
+	assert.Equal(t, "rt-b", selectors[1].routeTableID)
+}
+
+func TestRouteTablesForNode(t *testing.T) {
+	operator := newTestOperator(t, map[string]string{
+		"rt-b": "topology.kubernetes.io/zone=zoneB",
+		"rt-a": "topology.kubernetes.io/zone in (zoneA,zoneB)",
+	})
+
+	nrs := newTestNetResourceSet(map[string]string{"topology.kubernetes.io/zone": "zoneB"})
+	assert.Equal(t, []string{"rt-a", "rt-b"}, operator.routeTablesForNode(nrs))
+	nrs = newTestNetResourceSet(map[string]string{"topology.kubernetes.io/zone": "zoneA"})
+	assert.Equal(t, []string{"rt-a"}, operator.routeTablesForNode(nrs))
+	nrs = newTestNetResourceSet(map[string]string{"topology.kubernetes.io/zone": "zoneC"})
+	assert.Equal(t, []string{"rt-default"}, operator.routeTablesForNode(nrs))
+
+	operator.routeTableID = ""
+	assert.Empty(t, operator.routeTablesForNode(nrs), "default route table is not synced")
+}
+
+func TestDetermineNodeActions(t *testing.T) {
+	var (
+		inDefault = newTestRouteRule(t, "172.16.0.0/24", "i-node", "rt-default")
+		inA       = newTestRouteRule(t, "172.16.1.0/24", "i-node", "rt-a")
+		otherNode = newTestRouteRule(t, "fd00::/80", "i-other", "rt-a")
+		notCCE    = &vpc.RouteRule{RouteRuleId: "rr-user", RouteTableId: "rt-default", DestinationAddress: "192.168.0.0/24", NexthopId: "i-node"}
+	)
+	operator := newTestOperator(t, map[string]string{"rt-a": "zone=a"}, inDefault, inA, otherNode, notCCE)
+
+	// the node moves from the default route table to rt-a
+	nrs := newTestNetResourceSet(map[string]string{"zone": "a"}, "172.16.0.0/24", "172.16.1.0/24", "fd00::/80")
+	routeTableIDs := operator.routeTablesForNode(nrs)
+	toAdd, toDelete := operator.determineNodeActions(nrs, routeTableIDs)
+	if assert.Len(t, toAdd, 2) {
+		assert.Equal(t, "rt-a", toAdd[0].RouteTableId)
+		assert.Equal(t, "172.16.0.0/24", toAdd[0].DestinationAddress)
+		assert.Equal(t, "4", ipVersionOfRouteRule(toAdd[0]))
+		assert.Equal(t, "rt-a", toAdd[1].RouteTableId)
+		assert.Equal(t, "fd00::/80", toAdd[1].DestinationAddress)
+		assert.Equal(t, "::/0", toAdd[1].SourceAddress)
+		assert.Equal(t, "6", ipVersionOfRouteRule(toAdd[1]))
+	}
+	assert.ElementsMatch(t, []*vpc.RouteRule{inDefault, otherNode}, toDelete)
+
+	assert.True(t, routedInAll(operator.routedTables("i-node", "172.16.1.0/24"), routeTableIDs))
+	assert.False(t, routedInAll(operator.routedTables("i-node", "172.16.0.0/24"), routeTableIDs))
+
+	// the deleting node only deletes the route rules created by cce
+	now := metav1.Now()
+	nrs.DeletionTimestamp = &now
+	toAdd, toDelete = operator.determineNodeActions(nrs, routeTableIDs)
+	assert.Empty(t, toAdd)
+	assert.ElementsMatch(t, []*vpc.RouteRule{inDefault, inA}, toDelete)
+}
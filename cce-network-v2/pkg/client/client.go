@@ -32,6 +32,7 @@ import (
 	clientapi "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/client"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/defaults"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/tracing"
 )
 
 type Client struct {
@@ -135,7 +136,8 @@ func NewClient(host string) (*Client, error) {
 	}
 
 	transport := configureTransport(nil, tmp[0], host)
-	httpClient := &http.Client{Transport: transport}
+	// the trace context of the requests is carried by the headers
+	httpClient := &http.Client{Transport: tracing.NewTransport(transport)}
 	clientTrans := runtime_client.NewWithClient(tmp[1], clientapi.DefaultBasePath,
 		clientapi.DefaultSchemes, httpClient)
 	return &Client{*clientapi.New(clientTrans, strfmt.Default)}, nil
@@ -0,0 +1,59 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+package client
+
+import (
+	"fmt"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/client/daemon"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/client/metrics"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/api"
+)
+
+// Healthz returns the status of the agent with the results of its health
+// checks. The error is not nil if a critical health check failed, the status
+// is returned with it.
+func (c *Client) Healthz() (*models.StatusResponse, error) {
+	params := daemon.NewGetHealthzParams().WithTimeout(api.ClientTimeout)
+	resp, err := c.Daemon.GetHealthz(params)
+	if err != nil {
+		if failure, ok := err.(*daemon.GetHealthzFailure); ok && failure.Payload != nil {
+			return failure.Payload, fmt.Errorf("critical health checks of the agent failed")
+		}
+		return nil, Hint(err)
+	}
+	return resp.Payload, nil
+}
+
+// ConfigGet returns the configuration of the agent which is in effect.
+func (c *Client) ConfigGet() (*models.DaemonConfigurationStatus, error) {
+	params := daemon.NewGetConfigParams().WithTimeout(api.ClientTimeout)
+	resp, err := c.Daemon.GetConfig(params)
+	if err != nil {
+		return nil, Hint(err)
+	}
+	return resp.Payload, nil
+}
+
+// MetricsList returns the metrics of the agent.
+func (c *Client) MetricsList() ([]*models.Metric, error) {
+	params := metrics.NewGetMetricsParams().WithTimeout(api.ClientTimeout)
+	resp, err := c.Metrics.GetMetrics(params)
+	if err != nil {
+		return nil, Hint(err)
+	}
+	return resp.Payload, nil
+}
@@ -0,0 +1,31 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+package client
+
+import (
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/client/eni"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/api"
+)
+
+// ENIList returns the ENIs of the local node.
+func (c *Client) ENIList() ([]*models.LocalENI, error) {
+	params := eni.NewGetEniParams().WithTimeout(api.ClientTimeout)
+	resp, err := c.Eni.GetEni(params)
+	if err != nil {
+		return nil, Hint(err)
+	}
+	return resp.Payload, nil
+}
@@ -16,6 +16,8 @@
 package client
 
 import (
+	"context"
+
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/client/ipam"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/client/rdmaipam"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
@@ -28,8 +30,9 @@ const (
 )
 
 // IPAMCNIAllocate allocates an IP address out of address family specific pool.
-func (c *Client) IPAMCNIAllocate(family, owner, containerID, netns string) (*models.IPAMResponse, error) {
-	params := ipam.NewPostIpamParams().WithTimeout(api.ClientTimeout)
+// The span in ctx is the parent of the spans of the agent.
+func (c *Client) IPAMCNIAllocate(ctx context.Context, family, owner, containerID, netns string) (*models.IPAMResponse, error) {
+	params := ipam.NewPostIpamParamsWithContext(ctx).WithTimeout(api.ClientTimeout)
 
 	if family != "" {
 		params.SetFamily(&family)
@@ -52,6 +55,16 @@ func (c *Client) IPAMCNIAllocate(family, owner, containerID, netns string) (*mod
 	return resp.Payload, nil
 }
 
+// IPAMGet returns the IP addresses allocated by the agent.
+func (c *Client) IPAMGet() (*models.IPAMStatus, error) {
+	params := ipam.NewGetIpamParams().WithTimeout(api.ClientTimeout)
+	resp, err := c.Ipam.GetIpam(params)
+	if err != nil {
+		return nil, Hint(err)
+	}
+	return resp.Payload, nil
+}
+
 // IPAMAllocate allocates an IP address out of address family specific pool.
 func (c *Client) IPAMAllocate(family, owner string, expiration bool) (*models.IPAMResponse, error) {
 	params := ipam.NewPostIpamParams().WithTimeout(api.ClientTimeout)
@@ -134,3 +147,32 @@ func (c *Client) IPAMReleaseIP(ip string) error {
 	_, err := c.Ipam.DeleteIpamIP(params)
 	return Hint(err)
 }
+
+// IPAMReleaseOwnedIP releases the IP address allocated to the owner and the
+// container, just like what CNI DEL does.
+func (c *Client) IPAMReleaseOwnedIP(ip, owner, containerID string) error {
+	params := ipam.NewDeleteIpamIPParams().WithIP(ip).WithOwner(&owner).WithContainerID(&containerID).WithTimeout(api.ClientTimeout)
+	_, err := c.Ipam.DeleteIpamIP(params)
+	return Hint(err)
+}
+
+// IPAMCNIAllocateNetworks allocates the IP addresses of the secondary
+// interfaces requested by the annotation of the pod
+func (c *Client) IPAMCNIAllocateNetworks(owner, containerID, netns string) ([]*models.NetworkAttachmentResponse, error) {
+	params := ipam.NewPostNetworksParams().WithOwner(&owner).WithContainerID(&containerID).WithTimeout(api.ClientTimeout)
+	if netns != "" {
+		params.SetNetns(&netns)
+	}
+	resp, err := c.Ipam.PostNetworks(params)
+	if err != nil {
+		return nil, Hint(err)
+	}
+	return resp.Payload, nil
+}
+
+// IPAMCNIReleaseNetworks releases the IP addresses of the secondary interfaces of the pod
+func (c *Client) IPAMCNIReleaseNetworks(owner, containerID string) error {
+	params := ipam.NewDeleteNetworksParams().WithOwner(&owner).WithContainerID(&containerID).WithTimeout(api.ClientTimeout)
+	_, err := c.Ipam.DeleteNetworks(params)
+	return Hint(err)
+}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/bce/api"
 	ipamTypes "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/ipam/types"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/tracing"
 )
 
 // NetConf is the CCE specific CNI network configuration
@@ -35,6 +36,9 @@ type NetConf struct {
 	MTU  int  `json:"mtu"`
 	Args Args `json:"args"`
 	IPAM IPAM `json:"ipam,omitempty"` // Shadows the JSON field "ipam" in cniTypes.NetConf.
+
+	// Tracing is the configuration of the OpenTelemetry exporter
+	Tracing *tracing.Config `json:"tracing,omitempty"`
 }
 
 // IPAM is the CCE specific CNI IPAM configuration
@@ -1,21 +1,28 @@
 package bandwidth
 
 import (
+	"context"
 	"fmt"
+	"net"
+	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"unicode"
 
 	"github.com/cilium/ebpf"
 	"github.com/cilium/ebpf/asm"
 	"github.com/cilium/ebpf/features"
 	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/controller"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/datapath/link"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/endpoint/event"
 	ccev2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/watchers"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/option"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/sysctl"
@@ -27,6 +34,10 @@ const (
 	AnnotaionPodIngressBandwidth = "kubernetes.io/ingress-bandwidth"
 	AnnotaionPodEgressBandwidth  = "kubernetes.io/egress-bandwidth"
 	AnnotaionPodBindwidthMode    = "kubernetes.io/bindwidth-mode"
+
+	// BindwidthModeUnsupported is reported in the status when the bandwidth
+	// of the endpoint can not be shaped
+	BindwidthModeUnsupported = "unsupported"
 )
 
 var (
@@ -49,15 +60,19 @@ func GetPodBandwidth(podAnnotation map[string]string, cniDriver string) (*ccev2.
 			opt.Mode = ccev2.BindwidthModeTC
 		}
 	}
+	// fall back to tc if edt is not available on this node
+	if opt.Mode == ccev2.BindwidthModeEDT && GlobalManager.edt == nil {
+		opt.Mode = ccev2.BindwidthModeTC
+	}
 	if ingressBandwidth, ok := podAnnotation[AnnotaionPodIngressBandwidth]; ok {
-		if ingress, err := parseBandwidth(ingressBandwidth); err == nil {
+		if ingress, err := ParseBandwidth(ingressBandwidth); err == nil {
 			opt.Ingress = ingress
 		} else {
 			return nil, fmt.Errorf("failed to parse annotaion ingress bandwidth %s", ingressBandwidth)
 		}
 	}
 	if egressBandwidth, ok := podAnnotation[AnnotaionPodEgressBandwidth]; ok {
-		if egress, err := parseBandwidth(egressBandwidth); err == nil {
+		if egress, err := ParseBandwidth(egressBandwidth); err == nil {
 			opt.Egress = egress
 		} else {
 			return nil, fmt.Errorf("failed to parse annotaion egress bandwidth %s", egressBandwidth)
@@ -75,7 +90,9 @@ const (
 	TERABYTE
 )
 
-func parseBandwidth(s string) (int64, error) {
+// ParseBandwidth parses the bandwidth of the pod annotation, such as "100M"
+// or "1G", to bytes. The bandwidth without unit is in bytes.
+func ParseBandwidth(s string) (int64, error) {
 	// when bandwidth is "", return
 	if len(s) == 0 {
 		return 0, fmt.Errorf("invalid bandwidth %s", s)
@@ -85,6 +102,9 @@ func parseBandwidth(s string) (int64, error) {
 	s = strings.ToUpper(s)
 
 	i := strings.IndexFunc(s, unicode.IsLetter)
+	if i < 0 {
+		i = len(s)
+	}
 
 	bytesString, multiple := s[:i], s[i:]
 	bytes, err := strconv.ParseFloat(bytesString, 64)
@@ -110,6 +130,14 @@ func parseBandwidth(s string) (int64, error) {
 
 type BandwidthManager struct {
 	Mode ccev2.BindwidthMode
+
+	// edt is nil if the kernel does not support edt
+	edt *edtDatapath
+
+	lock sync.Mutex
+	// nodeDevices devices which the egress traffic of pods will pass through
+	// KEY: link index
+	nodeDevices map[int]netlink.Link
 }
 
 // AcceptType implements event.EndpointProbeEventHandler.
@@ -146,28 +174,32 @@ func (manager *BandwidthManager) Handle(event *event.EndpointProbeEvent) (*ccev2
 	})
 	scopeLog.Info("bandwidth manager received event")
 
-	cctx, err := link.NewContainerContext(event.Obj.Spec.ExternalIdentifiers.ContainerID, event.Obj.Spec.ExternalIdentifiers.Netns)
-	if err != nil {
-		bandwidthStatus.Msg = fmt.Sprintf("failed to get container context: %v", err)
-		scopeLog.WithError(err).Error("failed to get container context")
-		return bandwidthStatus, nil
-	}
-	defer cctx.Close()
-
 	switch opt.Mode {
 	case ccev2.BindwidthModeEDT:
-		managerLog.Warn("bandwidth mode edt have not been implemented yet")
-		return bandwidthStatus, nil
+		err := manager.setEDT(event.Obj, opt)
+		if err != nil {
+			bandwidthStatus.Msg = fmt.Sprintf("failed to set edt: %v", err)
+			return bandwidthStatus, err
+		}
 	default:
-		switch cctx.Driver {
-		case string(models.DatapathModeVeth), "":
-			err = manager.setVethTC(cctx, opt)
-			if err != nil {
-				bandwidthStatus.Msg = fmt.Sprintf("failed to set veth tc: %v", err)
-				return bandwidthStatus, err
-			}
+		cctx, err := link.NewContainerContext(event.Obj.Spec.ExternalIdentifiers.ContainerID, event.Obj.Spec.ExternalIdentifiers.Netns)
+		if err != nil {
+			bandwidthStatus.Msg = fmt.Sprintf("failed to get container context: %v", err)
+			scopeLog.WithError(err).Error("failed to get container context")
+			return bandwidthStatus, nil
 		}
+		defer cctx.Close()
 
+		if !IsDriverSupportTC(cctx.Driver) {
+			bandwidthStatus.Data["mode"] = BindwidthModeUnsupported
+			bandwidthStatus.Msg = fmt.Sprintf("bandwidth is unsupported by driver %s", cctx.Driver)
+			return bandwidthStatus, nil
+		}
+		err = manager.setVethTC(cctx, opt)
+		if err != nil {
+			bandwidthStatus.Msg = fmt.Sprintf("failed to set %s tc: %v", cctx.Driver, err)
+			return bandwidthStatus, err
+		}
 	}
 	bandwidthStatus.Ready = true
 	return bandwidthStatus, nil
@@ -175,13 +207,23 @@ func (manager *BandwidthManager) Handle(event *event.EndpointProbeEvent) (*ccev2
 
 func InitBandwidthManager() {
 	if !option.Config.EnableBandwidthManager {
+		teardownEDT()
 		return
 	}
-	GlobalManager = &BandwidthManager{}
+	GlobalManager = &BandwidthManager{
+		nodeDevices: make(map[int]netlink.Link),
+	}
 	ProbeBandwidthManager()
 }
 
 func ProbeBandwidthManager() {
+	// we can use TC to implement bandwidth
+	GlobalManager.Mode = ccev2.BindwidthModeTC
+
+	defaultLink, err := link.DetectDefaultRouteInterface()
+	if err != nil {
+		managerLog.WithError(err).Warn("failed to detect default route interface")
+	}
 
 	// We at least need 5.1 kernel for native TCP EDT integration
 	// and writable queue_mapping that we use. Below helper is
@@ -190,20 +232,192 @@ func ProbeBandwidthManager() {
 
 	if _, err := sysctl.Read("net.core.default_qdisc"); err != nil {
 		managerLog.WithError(err).Warn("BPF bandwidth manager could not read procfs. Disabling the feature.")
-		GlobalManager.Mode = ccev2.BindwidthModeTC
+		teardownEDT()
 		return
 	}
 	if !kernelGood {
 		managerLog.Warn("BPF bandwidth manager needs kernel 5.1 or newer. Disabling the feature.")
-		GlobalManager.Mode = ccev2.BindwidthModeTC
+		teardownEDT()
+		return
+	}
+
+	edt, err := newEDTDatapath(edtMapPinPath())
+	if err != nil {
+		managerLog.WithError(err).Warn("failed to load edt bpf program. Disabling the feature.")
+		teardownEDT()
 		return
 	}
+	GlobalManager.edt = edt
+	managerLog.Info("bandwidth manager edt mode is available")
 
-	// TODO: check if we can use eBPF to implement EDT
-	// manager.Mode = ccev2.BindwidthModeEDT
+	if defaultLink != nil {
+		GlobalManager.addNodeDevice(defaultLink)
+	}
+}
 
-	// we can use TC to implement bandwidth
-	GlobalManager.Mode = ccev2.BindwidthModeTC
+// teardownEDT remove the edt programs, fq qdiscs and pinned maps which were
+// set up by last agent, the devices of both node and pods are cleaned up
+func teardownEDT() {
+	links, err := netlink.LinkList()
+	if err != nil {
+		managerLog.WithError(err).Warn("failed to list links")
+		return
+	}
+	for _, dev := range links {
+		if !hasEDTProgram(dev) {
+			continue
+		}
+		if err = teardownEDTDevice(dev); err != nil {
+			managerLog.WithError(err).WithField("dev", dev.Attrs().Name).Warn("failed to teardown edt")
+		}
+	}
+	if err = os.RemoveAll(edtPinPath); err != nil {
+		managerLog.WithError(err).Warn("failed to remove pinned edt maps")
+	}
+}
+
+// Start starts the controller to keep the edt maps consistent with the endpoints on this node
+func (manager *BandwidthManager) Start(cceEndpointClient *watchers.CCEEndpointClient) {
+	if manager == nil || manager.edt == nil {
+		return
+	}
+	controller.NewManager().UpdateController(bandwidthSys, controller.ControllerParams{
+		RunInterval: option.Config.ResourceResyncInterval,
+		DoFunc: func(ctx context.Context) error {
+			cepList, err := cceEndpointClient.List()
+			if err != nil {
+				return err
+			}
+			return manager.syncEDT(cepList)
+		},
+	})
+}
+
+// syncEDT set rate of all endpoints using edt mode, and remove the rate of ips
+// which are not used by edt endpoints any more
+func (manager *BandwidthManager) syncEDT(cepList []*ccev2.CCEEndpoint) error {
+	var expected = make(map[string]bool)
+	for _, cep := range cepList {
+		opt := cep.Spec.Network.Bindwidth
+		if opt == nil || opt.Mode != ccev2.BindwidthModeEDT ||
+			cep.Status.Networking == nil {
+			continue
+		}
+		for _, addr := range cep.Status.Networking.Addressing {
+			ip := net.ParseIP(addr.IP)
+			if ip == nil || ip.To4() == nil {
+				continue
+			}
+			expected[ip.String()] = true
+			if err := manager.edt.update(ip, opt.Ingress, opt.Egress); err != nil {
+				managerLog.WithError(err).WithField("endpoint", cep.Namespace+"/"+cep.Name).Warn("failed to update edt rate")
+			}
+		}
+	}
+
+	for _, ip := range manager.edt.listIPs() {
+		if expected[ip.String()] {
+			continue
+		}
+		if err := manager.edt.delete(ip); err != nil {
+			managerLog.WithError(err).WithField("ip", ip.String()).Warn("failed to delete edt rate")
+			continue
+		}
+		managerLog.WithField("ip", ip.String()).Info("deleted edt rate of ip")
+	}
+
+	// the node devices are attached again when the next edt endpoint is created
+	if len(expected) == 0 {
+		manager.edt.detachNodeDevices()
+	}
+	return nil
+}
+
+// ENIUpdateEventHandler record the ENI device as the egress device of pods
+func (manager *BandwidthManager) ENIUpdateEventHandler(eni *ccev2.ENI) {
+	if manager == nil || manager.edt == nil || eni.Status.InterfaceIndex == 0 {
+		return
+	}
+	dev, err := netlink.LinkByIndex(eni.Status.InterfaceIndex)
+	if err != nil {
+		managerLog.WithError(err).WithField("eni", eni.Spec.ID).Warn("failed to get link by index")
+		return
+	}
+	manager.addNodeDevice(dev)
+}
+
+// addNodeDevice record the node device, the edt program will be attached to
+// it lazily when the first edt endpoint is created. If the device has been
+// attached by last agent, the program is replaced immediately.
+func (manager *BandwidthManager) addNodeDevice(dev netlink.Link) {
+	manager.lock.Lock()
+	manager.nodeDevices[dev.Attrs().Index] = dev
+	manager.lock.Unlock()
+
+	if hasEDTProgram(dev) {
+		if err := manager.edt.attachNodeDevice(dev); err != nil {
+			managerLog.WithError(err).WithField("dev", dev.Attrs().Name).Warn("failed to attach edt program")
+		}
+	}
+}
+
+// setEDT set the rate of endpoint to edt maps, and make sure the edt program
+// is attached to all node devices and the host device of the endpoint
+func (manager *BandwidthManager) setEDT(cep *ccev2.CCEEndpoint, opt *ccev2.BindwidthOption) error {
+	if manager.edt == nil {
+		return fmt.Errorf("edt is not available on this node")
+	}
+	switch cep.Spec.ExternalIdentifiers.Cnidriver {
+	case string(models.DatapathModeVeth), "":
+	default:
+		return fmt.Errorf("edt is not supported by driver %s", cep.Spec.ExternalIdentifiers.Cnidriver)
+	}
+	if cep.Status.Networking == nil {
+		return fmt.Errorf("endpoint has no address")
+	}
+
+	manager.lock.Lock()
+	var devices []netlink.Link
+	for _, dev := range manager.nodeDevices {
+		devices = append(devices, dev)
+	}
+	manager.lock.Unlock()
+	if opt.Egress > 0 {
+		for _, dev := range devices {
+			if err := manager.edt.attachNodeDevice(dev); err != nil {
+				return err
+			}
+		}
+	}
+
+	var applied bool
+	for _, addr := range cep.Status.Networking.Addressing {
+		ip := net.ParseIP(addr.IP)
+		if ip == nil || ip.To4() == nil {
+			continue
+		}
+		if opt.Ingress > 0 {
+			routes, err := netlink.RouteGet(ip)
+			if err != nil || len(routes) == 0 {
+				return fmt.Errorf("failed to find host device of %s: %v", addr.IP, err)
+			}
+			hostDev, err := netlink.LinkByIndex(routes[0].LinkIndex)
+			if err != nil {
+				return fmt.Errorf("failed to get host device of %s: %w", addr.IP, err)
+			}
+			if err = manager.edt.attachHostDevice(ip, hostDev); err != nil {
+				return err
+			}
+		}
+		if err := manager.edt.update(ip, opt.Ingress, opt.Egress); err != nil {
+			return err
+		}
+		applied = true
+	}
+	if !applied {
+		return fmt.Errorf("edt only support ipv4 address")
+	}
+	return nil
 }
 
 var _ event.EndpointProbeEventHandler = &BandwidthManager{}
@@ -0,0 +1,545 @@
+package bandwidth
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/asm"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/datapath/tc"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/sysctl"
+)
+
+const (
+	edtFilterName    = "cce-edt"
+	edtMapMaxEntries = 16384
+	// bpffsRoot is the mount point of bpffs on the node
+	bpffsRoot = "/sys/fs/bpf"
+	// edtPinPath the edt maps are pinned here, so that the programs attached
+	// by the last agent keep reading the rates updated by the new agent
+	edtPinPath = bpffsRoot + "/cce/edt"
+	// edtHorizonDrop packets scheduled later than now + horizon will be dropped
+	// to avoid unbounded queueing in the fq qdisc
+	edtHorizonDrop = 2 * time.Second
+
+	nsecPerSec = 1000000000
+
+	// offsets of fields in struct __sk_buff
+	skbLenOffset     = 0
+	skbDataOffset    = 76
+	skbDataEndOffset = 80
+	skbTstampOffset  = 152
+
+	ethHdrLen      = 14
+	ethProtoOffset = 12
+	ipv4HdrLen     = 20
+	ipv4SrcOffset  = ethHdrLen + 12
+	ipv4DstOffset  = ethHdrLen + 16
+	ethPIPv4       = 0x0008 // htons(ETH_P_IP) loaded as little endian half word
+
+	tcActOK   = 0
+	tcActShot = 2
+)
+
+// edtInfo is the value of edt map, it must be the same layout as the bpf program read
+type edtInfo struct {
+	// Bps rate limit in bytes per second
+	Bps uint64
+	// TLast departure time of the last packet in nanoseconds
+	TLast uint64
+	// THorizonDrop packets which departure time is later than now + THorizonDrop will be dropped
+	THorizonDrop uint64
+}
+
+// edtDatapath implements the earliest departure time bandwidth limiting.
+// The bpf program set skb->tstamp for every packet of the pod by the rate
+// of the pod, and the fq qdisc on the device send the packet not earlier
+// than skb->tstamp.
+//
+//   - egress: the program is attached to the tc egress of the node devices
+//     (default route device and ENIs), keyed by source IP of the packet
+//   - ingress: the program is attached to the tc egress of the host side veth
+//     of the pod, keyed by destination IP of the packet
+//
+// only IPv4 is supported now.
+type edtDatapath struct {
+	lock sync.Mutex
+
+	egressMap   *ebpf.Map
+	ingressMap  *ebpf.Map
+	egressProg  *ebpf.Program
+	ingressProg *ebpf.Program
+
+	// nodeDevices devices attached egress program, KEY: link index
+	nodeDevices map[int]netlink.Link
+	// hostDevices host side devices of pods attached ingress program, KEY: ip
+	hostDevices map[string]netlink.Link
+}
+
+// newEDTDatapath creates the edt maps and programs. The maps are pinned under
+// pinPath and reopened if they have been pinned by the last agent, an empty
+// pinPath means the maps are not pinned.
+func newEDTDatapath(pinPath string) (*edtDatapath, error) {
+	var (
+		err error
+		edt = &edtDatapath{
+			nodeDevices: make(map[int]netlink.Link),
+			hostDevices: make(map[string]netlink.Link),
+		}
+	)
+	defer func() {
+		if err != nil {
+			edt.close()
+		}
+	}()
+
+	newMap := func(name string) (*ebpf.Map, error) {
+		spec := &ebpf.MapSpec{
+			Name:       name,
+			Type:       ebpf.Hash,
+			KeySize:    net.IPv4len,
+			ValueSize:  24,
+			MaxEntries: edtMapMaxEntries,
+		}
+		if pinPath == "" {
+			return ebpf.NewMap(spec)
+		}
+		spec.Pinning = ebpf.PinByName
+		m, err := ebpf.NewMapWithOptions(spec, ebpf.MapOptions{PinPath: pinPath})
+		if errors.Is(err, ebpf.ErrMapIncompatible) {
+			// the layout of map was changed, the programs of the last agent
+			// will be replaced when the devices are attached again
+			managerLog.WithError(err).WithField("map", name).Warn("recreate incompatible pinned edt map")
+			if err = os.Remove(filepath.Join(pinPath, name)); err != nil {
+				return nil, err
+			}
+			m, err = ebpf.NewMapWithOptions(spec, ebpf.MapOptions{PinPath: pinPath})
+		}
+		return m, err
+	}
+	if edt.egressMap, err = newMap("cce_edt_egress"); err != nil {
+		return nil, fmt.Errorf("failed to create edt egress map: %w", err)
+	}
+	if edt.ingressMap, err = newMap("cce_edt_ingress"); err != nil {
+		return nil, fmt.Errorf("failed to create edt ingress map: %w", err)
+	}
+
+	newProg := func(name string, m *ebpf.Map, ipOffset int16) (*ebpf.Program, error) {
+		return ebpf.NewProgram(&ebpf.ProgramSpec{
+			Name:         name,
+			Type:         ebpf.SchedCLS,
+			License:      "Dual BSD/GPL",
+			Instructions: edtInstructions(m, ipOffset),
+		})
+	}
+	if edt.egressProg, err = newProg("cce_edt_egress", edt.egressMap, ipv4SrcOffset); err != nil {
+		return nil, fmt.Errorf("failed to load edt egress program: %w", err)
+	}
+	if edt.ingressProg, err = newProg("cce_edt_ingress", edt.ingressMap, ipv4DstOffset); err != nil {
+		return nil, fmt.Errorf("failed to load edt ingress program: %w", err)
+	}
+	return edt, nil
+}
+
+// edtInstructions build the edt program, the map is keyed by the IPv4
+// address at ipOffset of the packet
+//
+//	info = map_lookup(ip)
+//	delay = skb->len * NSEC_PER_SEC / info->bps
+//	t_next = info->t_last + delay
+//	if t_next <= now: info->t_last = now; pass
+//	if t_next - now >= info->t_horizon_drop: drop
+//	info->t_last = t_next; skb->tstamp = t_next; pass
+func edtInstructions(m *ebpf.Map, ipOffset int16) asm.Instructions {
+	return asm.Instructions{
+		asm.Mov.Reg(asm.R6, asm.R1),
+		asm.LoadMem(asm.R2, asm.R6, skbDataOffset, asm.Word),
+		asm.LoadMem(asm.R3, asm.R6, skbDataEndOffset, asm.Word),
+		asm.Mov.Reg(asm.R4, asm.R2),
+		asm.Add.Imm(asm.R4, ethHdrLen+ipv4HdrLen),
+		asm.JGT.Reg(asm.R4, asm.R3, "pass"),
+		asm.LoadMem(asm.R1, asm.R2, ethProtoOffset, asm.Half),
+		asm.JNE.Imm(asm.R1, ethPIPv4, "pass"),
+		asm.LoadMem(asm.R1, asm.R2, ipOffset, asm.Word),
+		asm.StoreMem(asm.RFP, -4, asm.R1, asm.Word),
+		asm.LoadMapPtr(asm.R1, m.FD()),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, -4),
+		asm.FnMapLookupElem.Call(),
+		asm.JEq.Imm(asm.R0, 0, "pass"),
+		asm.Mov.Reg(asm.R7, asm.R0),
+		asm.LoadMem(asm.R1, asm.R7, 0, asm.DWord),
+		asm.JEq.Imm(asm.R1, 0, "pass"),
+		asm.FnKtimeGetNs.Call(),
+		asm.Mov.Reg(asm.R8, asm.R0),
+		asm.LoadMem(asm.R2, asm.R6, skbLenOffset, asm.Word),
+		asm.Mul.Imm(asm.R2, nsecPerSec),
+		asm.LoadMem(asm.R1, asm.R7, 0, asm.DWord),
+		asm.Div.Reg(asm.R2, asm.R1),
+		asm.LoadMem(asm.R3, asm.R7, 8, asm.DWord),
+		asm.Add.Reg(asm.R3, asm.R2),
+		asm.JGT.Reg(asm.R3, asm.R8, "delay"),
+		asm.StoreMem(asm.R7, 8, asm.R8, asm.DWord),
+		asm.Ja.Label("pass"),
+		asm.Mov.Reg(asm.R4, asm.R3).WithSymbol("delay"),
+		asm.Sub.Reg(asm.R4, asm.R8),
+		asm.LoadMem(asm.R5, asm.R7, 16, asm.DWord),
+		asm.JGE.Reg(asm.R4, asm.R5, "drop"),
+		asm.StoreMem(asm.R7, 8, asm.R3, asm.DWord),
+		asm.StoreMem(asm.R6, skbTstampOffset, asm.R3, asm.DWord),
+		asm.Mov.Imm(asm.R0, tcActOK).WithSymbol("pass"),
+		asm.Return(),
+		asm.Mov.Imm(asm.R0, tcActShot).WithSymbol("drop"),
+		asm.Return(),
+	}
+}
+
+// attachNodeDevice attach the egress program to the node device, all
+// tx queues of the device will use fq qdisc
+func (edt *edtDatapath) attachNodeDevice(dev netlink.Link) error {
+	edt.lock.Lock()
+	defer edt.lock.Unlock()
+
+	if _, ok := edt.nodeDevices[dev.Attrs().Index]; ok {
+		return nil
+	}
+	if err := ensureFQQdisc(dev, true); err != nil {
+		return err
+	}
+	if err := attachEDTProgram(dev, edt.egressProg); err != nil {
+		return err
+	}
+	edt.nodeDevices[dev.Attrs().Index] = dev
+	return nil
+}
+
+// detachNodeDevices detach the egress program from all node devices and
+// restore their qdiscs, it is called when no endpoint uses edt any more
+func (edt *edtDatapath) detachNodeDevices() {
+	edt.lock.Lock()
+	defer edt.lock.Unlock()
+
+	for index, dev := range edt.nodeDevices {
+		if err := teardownEDTDevice(dev); err != nil {
+			managerLog.WithError(err).WithField("dev", dev.Attrs().Name).Warn("failed to teardown edt on node device")
+			continue
+		}
+		delete(edt.nodeDevices, index)
+	}
+}
+
+// attachHostDevice attach the ingress program to the host side device of the pod
+func (edt *edtDatapath) attachHostDevice(ip net.IP, dev netlink.Link) error {
+	if err := ensureFQQdisc(dev, false); err != nil {
+		return err
+	}
+	if err := attachEDTProgram(dev, edt.ingressProg); err != nil {
+		return err
+	}
+	edt.lock.Lock()
+	edt.hostDevices[ip.String()] = dev
+	edt.lock.Unlock()
+	return nil
+}
+
+// update set the rate of the ip, 0 means no limit
+func (edt *edtDatapath) update(ip net.IP, ingress, egress int64) error {
+	key, ok := edtKey(ip)
+	if !ok {
+		return fmt.Errorf("edt only support ipv4 address, got %s", ip)
+	}
+	for _, item := range []struct {
+		m    *ebpf.Map
+		rate int64
+	}{{edt.egressMap, egress}, {edt.ingressMap, ingress}} {
+		if item.rate <= 0 {
+			if err := item.m.Delete(key); err != nil && !errors.Is(err, ebpf.ErrKeyNotExist) {
+				return err
+			}
+			continue
+		}
+		info := edtInfo{Bps: uint64(item.rate), THorizonDrop: uint64(edtHorizonDrop.Nanoseconds())}
+		var old edtInfo
+		if err := item.m.Lookup(key, &old); err == nil && old.Bps == info.Bps {
+			continue
+		}
+		if err := item.m.Put(key, &info); err != nil {
+			return fmt.Errorf("failed to update edt map for %s: %w", ip, err)
+		}
+	}
+	return nil
+}
+
+// delete remove the rate of the ip, and teardown the host device of the ip
+// if the device is still there
+func (edt *edtDatapath) delete(ip net.IP) error {
+	key, ok := edtKey(ip)
+	if !ok {
+		return nil
+	}
+	for _, m := range []*ebpf.Map{edt.egressMap, edt.ingressMap} {
+		if err := m.Delete(key); err != nil && !errors.Is(err, ebpf.ErrKeyNotExist) {
+			return err
+		}
+	}
+
+	edt.lock.Lock()
+	dev, ok := edt.hostDevices[ip.String()]
+	delete(edt.hostDevices, ip.String())
+	edt.lock.Unlock()
+	if !ok {
+		return nil
+	}
+	// the device has been removed with the pod
+	if _, err := netlink.LinkByIndex(dev.Attrs().Index); err != nil {
+		return nil
+	}
+	return teardownEDTDevice(dev)
+}
+
+func edtKey(ip net.IP) (key [net.IPv4len]byte, ok bool) {
+	ip4 := ip.To4()
+	if ip4 == nil {
+		return key, false
+	}
+	copy(key[:], ip4)
+	return key, true
+}
+
+// listIPs list all ips which have rate limit
+func (edt *edtDatapath) listIPs() []net.IP {
+	var (
+		result []net.IP
+		seen   = make(map[string]bool)
+	)
+	for _, m := range []*ebpf.Map{edt.egressMap, edt.ingressMap} {
+		var (
+			key  [net.IPv4len]byte
+			info edtInfo
+		)
+		iter := m.Iterate()
+		for iter.Next(&key, &info) {
+			ip := net.IP(append([]byte{}, key[:]...))
+			if !seen[ip.String()] {
+				seen[ip.String()] = true
+				result = append(result, ip)
+			}
+		}
+	}
+	return result
+}
+
+// close detach the program from node devices and release the bpf objects.
+// The pinned maps are kept for the next agent.
+func (edt *edtDatapath) close() {
+	edt.lock.Lock()
+	for _, dev := range edt.nodeDevices {
+		if err := detachEDTProgram(dev); err != nil {
+			managerLog.WithError(err).WithField("dev", dev.Attrs().Name).Warn("failed to detach edt program")
+		}
+	}
+	edt.nodeDevices = make(map[int]netlink.Link)
+	edt.lock.Unlock()
+
+	if edt.egressProg != nil {
+		_ = edt.egressProg.Close()
+	}
+	if edt.ingressProg != nil {
+		_ = edt.ingressProg.Close()
+	}
+	if edt.egressMap != nil {
+		_ = edt.egressMap.Close()
+	}
+	if edt.ingressMap != nil {
+		_ = edt.ingressMap.Close()
+	}
+}
+
+// ensureFQQdisc make sure the device use fq qdisc.
+// For multi queue device, fq is the child qdisc of mq.
+func ensureFQQdisc(dev netlink.Link, multiQueue bool) error {
+	newFq := func(parent uint32) *netlink.Fq {
+		fq := netlink.NewFq(netlink.QdiscAttrs{
+			LinkIndex: dev.Attrs().Index,
+			Parent:    parent,
+		})
+		fq.Pacing = 1
+		return fq
+	}
+
+	qdiscs, err := netlink.QdiscList(dev)
+	if err != nil {
+		return fmt.Errorf("list qdisc for dev %s error, %w", dev.Attrs().Name, err)
+	}
+
+	if !multiQueue || dev.Attrs().NumTxQueues <= 1 {
+		for _, qdisc := range qdiscs {
+			if qdisc.Attrs().Parent == netlink.HANDLE_ROOT && qdisc.Type() == "fq" {
+				return nil
+			}
+		}
+		fq := newFq(netlink.HANDLE_ROOT)
+		fq.Handle = netlink.MakeHandle(1, 0)
+		return tc.QdiscReplace(fq)
+	}
+
+	if err = tc.EnsureMQQdisc(dev); err != nil {
+		return err
+	}
+	if qdiscs, err = netlink.QdiscList(dev); err != nil {
+		return fmt.Errorf("list qdisc for dev %s error, %w", dev.Attrs().Name, err)
+	}
+	for _, qdisc := range qdiscs {
+		major, minor := netlink.MajorMinor(qdisc.Attrs().Parent)
+		if major != 1 || minor == 0 || qdisc.Type() == "fq" {
+			continue
+		}
+		// the queue is used by egress priority manager
+		if qdisc.Type() == "prio" {
+			managerLog.WithField("dev", dev.Attrs().Name).WithField("parent", netlink.HandleStr(qdisc.Attrs().Parent)).
+				Warn("skip replace prio qdisc with fq, edt will not take effect on this queue")
+			continue
+		}
+		if err = tc.QdiscReplace(newFq(qdisc.Attrs().Parent)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func attachEDTProgram(dev netlink.Link, prog *ebpf.Program) error {
+	err := netlink.QdiscReplace(&netlink.Clsact{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: dev.Attrs().Index,
+			Handle:    netlink.MakeHandle(0xffff, 0),
+			Parent:    netlink.HANDLE_CLSACT,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to replace clsact qdisc on %s: %w", dev.Attrs().Name, err)
+	}
+
+	filter := &netlink.BpfFilter{
+		FilterAttrs: netlink.FilterAttrs{
+			LinkIndex: dev.Attrs().Index,
+			Parent:    netlink.HANDLE_MIN_EGRESS,
+			Handle:    netlink.MakeHandle(0, 1),
+			Protocol:  unix.ETH_P_ALL,
+			Priority:  1,
+		},
+		Fd:           prog.FD(),
+		Name:         edtFilterName,
+		DirectAction: true,
+	}
+	if err = netlink.FilterReplace(filter); err != nil {
+		return fmt.Errorf("failed to replace edt filter on %s: %w", dev.Attrs().Name, err)
+	}
+	return nil
+}
+
+// hasEDTProgram check if the edt filter is attached to tc egress of the device
+func hasEDTProgram(dev netlink.Link) bool {
+	filters, err := netlink.FilterList(dev, netlink.HANDLE_MIN_EGRESS)
+	if err != nil {
+		return false
+	}
+	for _, filter := range filters {
+		if bpfFilter, ok := filter.(*netlink.BpfFilter); ok && bpfFilter.Name == edtFilterName {
+			return true
+		}
+	}
+	return false
+}
+
+// teardownEDTDevice remove the edt filters and the fq qdiscs from the device
+func teardownEDTDevice(dev netlink.Link) error {
+	if err := detachEDTProgram(dev); err != nil {
+		return err
+	}
+	return restoreDefaultQdisc(dev)
+}
+
+// restoreDefaultQdisc replace the fq qdiscs set up by ensureFQQdisc with the
+// default qdisc of the node
+func restoreDefaultQdisc(dev netlink.Link) error {
+	defaultQdisc, err := sysctl.Read("net.core.default_qdisc")
+	if err != nil || defaultQdisc == "" {
+		defaultQdisc = "pfifo_fast"
+	}
+	if defaultQdisc == "fq" {
+		return nil
+	}
+
+	qdiscs, err := netlink.QdiscList(dev)
+	if err != nil {
+		return fmt.Errorf("list qdisc for dev %s error, %w", dev.Attrs().Name, err)
+	}
+	for _, qdisc := range qdiscs {
+		if qdisc.Type() != "fq" {
+			continue
+		}
+		if qdisc.Attrs().Parent == netlink.HANDLE_ROOT {
+			// the kernel attaches the default qdisc after the root qdisc is deleted
+			if err = tc.QdiscDel(qdisc); err != nil {
+				return err
+			}
+			continue
+		}
+		// the child of mq qdisc can not be deleted, otherwise the queue
+		// would be grafted with noop qdisc
+		major, minor := netlink.MajorMinor(qdisc.Attrs().Parent)
+		if major != 1 || minor == 0 {
+			continue
+		}
+		err = tc.QdiscReplace(&netlink.GenericQdisc{
+			QdiscAttrs: netlink.QdiscAttrs{
+				LinkIndex: dev.Attrs().Index,
+				Parent:    qdisc.Attrs().Parent,
+			},
+			QdiscType: defaultQdisc,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// edtMapPinPath returns the path to pin the edt maps, an empty path is
+// returned if bpffs is not mounted on the node
+func edtMapPinPath() string {
+	var statfs unix.Statfs_t
+	if err := unix.Statfs(bpffsRoot, &statfs); err != nil || statfs.Type != unix.BPF_FS_MAGIC {
+		managerLog.WithError(err).Warnf("bpffs is not mounted at %s, edt maps will not survive agent restart", bpffsRoot)
+		return ""
+	}
+	if err := os.MkdirAll(edtPinPath, 0755); err != nil {
+		managerLog.WithError(err).Warnf("failed to create %s, edt maps will not survive agent restart", edtPinPath)
+		return ""
+	}
+	return edtPinPath
+}
+
+// detachEDTProgram remove the edt filters from tc egress of the device
+func detachEDTProgram(dev netlink.Link) error {
+	filters, err := netlink.FilterList(dev, netlink.HANDLE_MIN_EGRESS)
+	if err != nil {
+		return fmt.Errorf("failed to list filters of %s: %w", dev.Attrs().Name, err)
+	}
+	for _, filter := range filters {
+		if bpfFilter, ok := filter.(*netlink.BpfFilter); ok && bpfFilter.Name == edtFilterName {
+			if err = tc.FilterDel(filter); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package bandwidth
+
+import (
+	"errors"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/sysctl"
+)
+
+// newTestEDTDatapath creates the edt datapath, the test is skipped if the
+// bpf objects can not be created in the environment
+func newTestEDTDatapath(t *testing.T, pinPath string) *edtDatapath {
+	edt, err := newEDTDatapath(pinPath)
+	if err != nil {
+		t.Skipf("edt datapath is not supported: %v", err)
+	}
+	return edt
+}
+
+func TestEDTKey(t *testing.T) {
+	key, ok := edtKey(net.ParseIP("10.0.0.1"))
+	assert.True(t, ok)
+	assert.Equal(t, [net.IPv4len]byte{10, 0, 0, 1}, key)
+
+	_, ok = edtKey(net.ParseIP("fd00::1"))
+	assert.False(t, ok)
+}
+
+func TestEDTDatapathUpdate(t *testing.T) {
+	edt := newTestEDTDatapath(t, "")
+	defer edt.close()
+
+	ip := net.ParseIP("10.0.0.1")
+	require.NoError(t, edt.update(ip, 1000, 2000))
+
+	key, _ := edtKey(ip)
+	var info edtInfo
+	require.NoError(t, edt.ingressMap.Lookup(key, &info))
+	assert.Equal(t, uint64(1000), info.Bps)
+	assert.Equal(t, uint64(edtHorizonDrop.Nanoseconds()), info.THorizonDrop)
+	require.NoError(t, edt.egressMap.Lookup(key, &info))
+	assert.Equal(t, uint64(2000), info.Bps)
+	assert.Equal(t, []net.IP{ip.To4()}, edt.listIPs())
+
+	// the ingress rate is removed, the egress rate is kept
+	require.NoError(t, edt.update(ip, 0, 2000))
+	assert.Error(t, edt.ingressMap.Lookup(key, &info))
+	assert.NoError(t, edt.egressMap.Lookup(key, &info))
+
+	assert.Error(t, edt.update(net.ParseIP("fd00::1"), 1000, 1000))
+
+	require.NoError(t, edt.delete(ip))
+	assert.Empty(t, edt.listIPs())
+	// deleting the ip without rate is not an error
+	assert.NoError(t, edt.delete(ip))
+}
+
+func TestEDTDatapathPinnedMaps(t *testing.T) {
+	var statfs unix.Statfs_t
+	if err := unix.Statfs(bpffsRoot, &statfs); err != nil || statfs.Type != unix.BPF_FS_MAGIC {
+		t.Skipf("bpffs is not mounted at %s", bpffsRoot)
+	}
+	pinPath, err := os.MkdirTemp(bpffsRoot, "cce-edt-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(pinPath)
+
+	ip := net.ParseIP("10.0.0.1")
+	edt := newTestEDTDatapath(t, pinPath)
+	require.NoError(t, edt.update(ip, 1000, 2000))
+	edt.close()
+
+	// the rates set by the last agent are read from the pinned maps
+	edt = newTestEDTDatapath(t, pinPath)
+	defer edt.close()
+	assert.Equal(t, []net.IP{ip.To4()}, edt.listIPs())
+}
+
+func TestTeardownEDTDevice(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("creating netns requires root")
+	}
+	defaultQdisc, err := sysctl.Read("net.core.default_qdisc")
+	if err != nil || defaultQdisc == "fq" {
+		t.Skip("fq is the default qdisc")
+	}
+
+	netns, err := testutils.NewNS()
+	require.NoError(t, err)
+	defer testutils.UnmountNS(netns)
+	defer netns.Close()
+
+	hasFQ := func(dev netlink.Link) bool {
+		qdiscs, err := netlink.QdiscList(dev)
+		require.NoError(t, err)
+		for _, qdisc := range qdiscs {
+			if qdisc.Type() == "fq" {
+				return true
+			}
+		}
+		return false
+	}
+
+	// the loopback device of netns stands for the host device of pod
+	var dev netlink.Link
+	err = netns.Do(func(_ ns.NetNS) error {
+		if dev, err = netlink.LinkByName("lo"); err != nil {
+			return err
+		}
+		if err = netlink.LinkSetUp(dev); err != nil {
+			return err
+		}
+		return ensureFQQdisc(dev, false)
+	})
+	if errors.Is(err, unix.ENOENT) {
+		t.Skip("fq qdisc is not supported by the kernel")
+	}
+	require.NoError(t, err)
+
+	err = netns.Do(func(_ ns.NetNS) error {
+		assert.True(t, hasFQ(dev))
+		if err := teardownEDTDevice(dev); err != nil {
+			return err
+		}
+		assert.False(t, hasFQ(dev))
+		assert.False(t, hasEDTProgram(dev))
+		return nil
+	})
+	require.NoError(t, err)
+}
@@ -1,30 +1,262 @@
 package bandwidth
 
 import (
+	"fmt"
 	"math"
 
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/datapath/link"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/datapath/tc"
 	ccev2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2"
+	"github.com/containernetworking/plugins/pkg/ns"
 	"github.com/pkg/errors"
 	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
 )
 
 const (
+	// linkTypeDevice is the link type of the physical device moved into container netns
+	linkTypeDevice = "device"
+
 	latencyInMillis   = 25
 	hardwareHeaderLen = 1500
 	milliSeconds      = 1000
 )
 
 func (manager *BandwidthManager) setVethTC(cctx *link.ContainerContext, opt *ccev2.BindwidthOption) error {
-	err := SetupTBFQdisc(cctx.HostDev, uint64(opt.Ingress))
+	return SetupContainerTC(cctx, opt)
+}
+
+// IsDriverSupportTC check if the bandwidth of the pod with the driver can be shaped by tc
+func IsDriverSupportTC(driver string) bool {
+	switch driver {
+	case string(models.DatapathModeVeth), "",
+		string(models.DatapathModeIpvlan), linkTypeDevice:
+		return true
+	}
+	return false
+}
+
+// SetupContainerTC set up tbf qdisc for the container by the driver of container device
+//   - veth: ingress is shaped on the host side veth, egress is shaped on the container side veth
+//   - exclusive device and ipvlan: the device is in the container netns, egress is shaped
+//     on the device, and ingress traffic is redirected to an ifb device to be shaped
+func SetupContainerTC(cctx *link.ContainerContext, opt *ccev2.BindwidthOption) error {
+	if opt == nil {
+		return nil
+	}
+	switch cctx.Driver {
+	case string(models.DatapathModeVeth), "":
+		if opt.Ingress > 0 {
+			err := SetupTBFQdisc(cctx.HostDev, uint64(opt.Ingress))
+			if err != nil {
+				return errors.Wrapf(err, "can not setup tbf qdisc on host device %s", cctx.HostDev.Attrs().Name)
+			}
+		}
+		if opt.Egress > 0 {
+			err := cctx.ContainerNetns.Do(func(_ ns.NetNS) error {
+				return SetupTBFQdisc(cctx.ContainerDev, uint64(opt.Egress))
+			})
+			if err != nil {
+				return errors.Wrapf(err, "can not setup tbf qdisc on container device %s", cctx.ContainerDev.Attrs().Name)
+			}
+		}
+	case string(models.DatapathModeIpvlan), linkTypeDevice:
+		return cctx.ContainerNetns.Do(func(_ ns.NetNS) error {
+			if opt.Egress > 0 {
+				err := SetupTBFQdisc(cctx.ContainerDev, uint64(opt.Egress))
+				if err != nil {
+					return errors.Wrapf(err, "can not setup tbf qdisc on container device %s", cctx.ContainerDev.Attrs().Name)
+				}
+			}
+			if opt.Ingress > 0 {
+				err := SetupIngressIFB(cctx.ContainerDev, uint64(opt.Ingress))
+				if err != nil {
+					return errors.Wrapf(err, "can not setup ingress ifb for container device %s", cctx.ContainerDev.Attrs().Name)
+				}
+			}
+			return nil
+		})
+	default:
+		return fmt.Errorf("bandwidth is unsupported by driver %s", cctx.Driver)
+	}
+	return nil
+}
+
+// SetupIngressIFB redirect the ingress traffic of dev to an ifb device, and
+// shape the traffic with tbf qdisc on the ifb device.
+// This function must be called in the netns of dev.
+func SetupIngressIFB(dev netlink.Link, bandwidthInBytes uint64) error {
+	ifbName := ifbDeviceName(dev.Attrs().Name)
+	ifb, err := netlink.LinkByName(ifbName)
+	if err != nil {
+		err = netlink.LinkAdd(&netlink.Ifb{
+			LinkAttrs: netlink.LinkAttrs{
+				Name:   ifbName,
+				MTU:    dev.Attrs().MTU,
+				TxQLen: 1000,
+			},
+		})
+		if err != nil {
+			return errors.Wrapf(err, "can not create ifb device %s", ifbName)
+		}
+		ifb, err = netlink.LinkByName(ifbName)
+		if err != nil {
+			return errors.Wrapf(err, "can not find ifb device %s", ifbName)
+		}
+	}
+	if err = netlink.LinkSetUp(ifb); err != nil {
+		return errors.Wrapf(err, "can not set ifb device %s up", ifbName)
+	}
+
+	ingress := &netlink.Ingress{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: dev.Attrs().Index,
+			Handle:    netlink.MakeHandle(0xffff, 0),
+			Parent:    netlink.HANDLE_INGRESS,
+		},
+	}
+	if err = tc.QdiscReplace(ingress); err != nil {
+		return err
+	}
+
+	filter := &netlink.U32{
+		FilterAttrs: netlink.FilterAttrs{
+			LinkIndex: dev.Attrs().Index,
+			Parent:    ingress.Handle,
+			Priority:  1,
+			Protocol:  unix.ETH_P_ALL,
+		},
+		ClassId:    netlink.MakeHandle(1, 1),
+		RedirIndex: ifb.Attrs().Index,
+		Actions:    []netlink.Action{netlink.NewMirredAction(ifb.Attrs().Index)},
+	}
+	if err = netlink.FilterReplace(filter); err != nil {
+		return errors.Wrapf(err, "can not redirect ingress of %s to %s", dev.Attrs().Name, ifbName)
+	}
+
+	return SetupTBFQdisc(ifb, bandwidthInBytes)
+}
+
+// ifbDeviceName the ifb device is in the container netns, so the name only
+// needs to be unique in the netns
+func ifbDeviceName(devName string) string {
+	name := "ifb-" + devName
+	if len(name) > unix.IFNAMSIZ-1 {
+		name = name[:unix.IFNAMSIZ-1]
+	}
+	return name
+}
+
+// CheckContainerTC checks the qdiscs set up by SetupContainerTC are still in
+// place, and returns the drift found.
+func CheckContainerTC(cctx *link.ContainerContext, opt *ccev2.BindwidthOption) ([]string, error) {
+	if opt == nil {
+		return nil, nil
+	}
+	var drifts []string
+	switch cctx.Driver {
+	case string(models.DatapathModeVeth), "":
+		if opt.Ingress > 0 {
+			drift, err := checkTBFQdisc(cctx.HostDev, uint64(opt.Ingress))
+			if err != nil {
+				return nil, err
+			}
+			drifts = append(drifts, drift...)
+		}
+		if opt.Egress > 0 {
+			err := cctx.ContainerNetns.Do(func(_ ns.NetNS) error {
+				drift, err := checkTBFQdisc(cctx.ContainerDev, uint64(opt.Egress))
+				drifts = append(drifts, drift...)
+				return err
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	case string(models.DatapathModeIpvlan), linkTypeDevice:
+		err := cctx.ContainerNetns.Do(func(_ ns.NetNS) error {
+			if opt.Egress > 0 {
+				drift, err := checkTBFQdisc(cctx.ContainerDev, uint64(opt.Egress))
+				if err != nil {
+					return err
+				}
+				drifts = append(drifts, drift...)
+			}
+			if opt.Ingress > 0 {
+				drift, err := checkIngressIFB(cctx.ContainerDev, uint64(opt.Ingress))
+				if err != nil {
+					return err
+				}
+				drifts = append(drifts, drift...)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("bandwidth is unsupported by driver %s", cctx.Driver)
+	}
+	return drifts, nil
+}
+
+// checkIngressIFB checks the ingress traffic of dev is still redirected to the
+// ifb device and shaped there, as set up by SetupIngressIFB.
+// This function must be called in the netns of dev.
+func checkIngressIFB(dev netlink.Link, bandwidthInBytes uint64) ([]string, error) {
+	ifbName := ifbDeviceName(dev.Attrs().Name)
+	ifb, err := netlink.LinkByName(ifbName)
 	if err != nil {
-		return errors.Wrapf(err, "can not setup tbf qdisc on host device %s", cctx.HostDev.Attrs().Name)
+		if _, ok := err.(netlink.LinkNotFoundError); ok {
+			return []string{fmt.Sprintf("ifb device %s not found", ifbName)}, nil
+		}
+		return nil, errors.Wrapf(err, "can not find ifb device %s", ifbName)
 	}
-	err = SetupTBFQdisc(cctx.ContainerDev, uint64(opt.Egress))
+
+	filters, err := netlink.FilterList(dev, netlink.MakeHandle(0xffff, 0))
 	if err != nil {
-		return errors.Wrapf(err, "can not setup tbf qdisc on container device %s", cctx.ContainerDev.Attrs().Name)
+		return nil, errors.Wrapf(err, "can not list ingress filters of %s", dev.Attrs().Name)
 	}
-	return nil
+	redirected := false
+	for _, f := range filters {
+		u32, ok := f.(*netlink.U32)
+		if !ok {
+			continue
+		}
+		for _, action := range u32.Actions {
+			if mirred, ok := action.(*netlink.MirredAction); ok && mirred.Ifindex == ifb.Attrs().Index {
+				redirected = true
+			}
+		}
+	}
+	if !redirected {
+		return []string{fmt.Sprintf("ingress of %s is not redirected to %s", dev.Attrs().Name, ifbName)}, nil
+	}
+
+	return checkTBFQdisc(ifb, bandwidthInBytes)
+}
+
+// checkTBFQdisc checks the root qdisc of dev is the tbf qdisc with the rate
+func checkTBFQdisc(dev netlink.Link, bandwidthInBytes uint64) ([]string, error) {
+	if dev == nil {
+		return nil, nil
+	}
+	qdiscs, err := netlink.QdiscList(dev)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can not list qdiscs of %s", dev.Attrs().Name)
+	}
+	for _, qdisc := range qdiscs {
+		tbf, ok := qdisc.(*netlink.Tbf)
+		if !ok || tbf.Attrs().Parent != netlink.HANDLE_ROOT {
+			continue
+		}
+		if tbf.Rate != bandwidthInBytes {
+			return []string{fmt.Sprintf("rate of tbf qdisc on %s is %d, expected %d", dev.Attrs().Name, tbf.Rate, bandwidthInBytes)}, nil
+		}
+		return nil, nil
+	}
+	return []string{fmt.Sprintf("tbf qdisc not found on %s", dev.Attrs().Name)}, nil
 }
 
 func SetupTBFQdisc(dev netlink.Link, bandwidthInBytes uint64) error {
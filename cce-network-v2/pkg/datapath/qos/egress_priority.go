@@ -265,6 +265,45 @@ func (elr *engressLinkRule) ensureEgressRule(classID, dscp uint32, ipNet *net.IP
 	})
 }
 
+// CheckEgressRule checks the u32 filters set up by ensureEgressRule are still
+// in place under every prio qdisc of link, and returns the drift found.
+func CheckEgressRule(link netlink.Link, classID uint32, ipNets []*net.IPNet) ([]string, error) {
+	var (
+		drifts   []string
+		prioNums int
+	)
+	err := foreachPrioQdisc(link, func(q netlink.Qdisc) error {
+		prioNums++
+		major, _ := netlink.MajorMinor(q.Attrs().Handle)
+		expectedClassID := netlink.MakeHandle(major, uint16(classID))
+		for _, ipNet := range ipNets {
+			filters, err := tc.FilterListBySrcIP(link, q.Attrs().Handle, []*net.IPNet{ipNet})
+			if err != nil {
+				return fmt.Errorf("failed to list filters of dev %s: %w", link.Attrs().Name, err)
+			}
+			found := false
+			for _, f := range filters {
+				if f.ClassId == expectedClassID {
+					found = true
+					break
+				}
+			}
+			if !found {
+				drifts = append(drifts, fmt.Sprintf("filter from %s to class %s not found under qdisc %s of dev %s",
+					ipNet, netlink.HandleStr(expectedClassID), netlink.HandleStr(q.Attrs().Handle), link.Attrs().Name))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if prioNums == 0 {
+		drifts = append(drifts, fmt.Sprintf("prio qdisc not found on dev %s", link.Attrs().Name))
+	}
+	return drifts, nil
+}
+
 // cleanEgressRule will remove all the rules
 func (elr *engressLinkRule) cleanOtherEgressRuleBySrcIP(ipset []*net.IPNet) (int, error) {
 	link := elr.link
@@ -26,6 +26,9 @@ const (
 	// ClusterHealthPort is the default value for option.ClusterHealthPort
 	ClusterHealthPort = 4240
 
+	// ClusterHealthProbeInterval is the default value for option.ClusterHealthProbeInterval
+	ClusterHealthProbeInterval = 60 * time.Second
+
 	// GopsPortAgent is the default value for option.GopsPort in the agent
 	GopsPortAgent = 9890
 
@@ -63,6 +66,10 @@ const (
 	// API to clients locally.
 	HubbleSockPath = RuntimePath + "/hubble.sock"
 
+	// HealthSockPath is the path to the UNIX domain socket exposing the
+	// connectivity health API to clients locally.
+	HealthSockPath = RuntimePath + "/health.sock"
+
 	// HubbleSockPathEnv is the environment variable to overwrite
 	// HubbleSockPath.
 	HubbleSockPathEnv = "HUBBLE_SOCK"
@@ -164,6 +171,9 @@ const (
 	// EnableRDMA is the default value for RDMA enablement
 	EnableRDMA = false
 
+	// EnableMultiNetwork is the default value for the secondary interfaces of the pods
+	EnableMultiNetwork = false
+
 	// EnableL7Proxy is the default value for L7 proxy enablement
 	EnableL7Proxy = true
 
@@ -208,6 +218,28 @@ const (
 	// DatapathMode is the default value for the datapath mode.
 	DatapathMode = "veth"
 
+	// IpvlanMode is the default mode of the ipvlan slaves in the ipvlan datapath
+	IpvlanMode = "l2"
+
+	// PluginVerifyMode is the default mode of the connectivity verification
+	// of the pods
+	PluginVerifyMode = "enforce"
+
+	// PluginVerifyRetries is the default number of ICMP packets sent to each
+	// target of the connectivity verification
+	PluginVerifyRetries = 3
+
+	// PluginVerifyTimeout is the default timeout of waiting for each ICMP reply
+	PluginVerifyTimeout = 500 * time.Millisecond
+
+	// TracingEndpoint is the default OTLP/HTTP collector, it is expected to
+	// be deployed on each node
+	TracingEndpoint = "localhost:4318"
+
+	// TracingSampleRatio is the default ratio of the pod network allocations
+	// traced
+	TracingSampleRatio = 1.0
+
 	// EnableBPFTProxy is the default value for EnableBPFTProxy
 	EnableBPFTProxy = false
 
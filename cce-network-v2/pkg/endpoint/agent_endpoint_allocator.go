@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -46,7 +47,9 @@ import (
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging/logfields"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/node"
 	nodeTypes "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/node/types"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/option"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/pststrategy"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/tracing"
 )
 
 var (
@@ -114,6 +117,10 @@ func (e *EndpointAllocator) Dump() (allocv4 map[string]string, allocv6 map[strin
 	return e.dynamicIPAM.Dump()
 }
 
+func (e *EndpointAllocator) ExpirationTimers() map[string]string {
+	return e.dynamicIPAM.ExpirationTimers()
+}
+
 func (e *EndpointAllocator) DebugStatus() string {
 	return e.dynamicIPAM.DebugStatus()
 }
@@ -199,7 +206,7 @@ func wantRoce(pod *corev1.Pod) bool {
 }
 
 // ADD allocates an IP for the given owner and returns the allocated IP.
-func (e *EndpointAllocator) ADD(family, owner, containerID, netns string) (ipv4Result, ipv6Result *ipam.AllocationResult, err error) {
+func (e *EndpointAllocator) ADD(ctx context.Context, family, owner, containerID, netns string) (ipv4Result, ipv6Result *ipam.AllocationResult, err error) {
 	var name string
 	namespace, podName, err := cache.SplitMetaNamespaceKey(owner)
 	if err != nil {
@@ -210,9 +217,14 @@ func (e *EndpointAllocator) ADD(family, owner, containerID, netns string) (ipv4R
 	// cep name is like "podname"
 	name = bceutils.GetCEPNameFromPodName(e.rdmaAttr.isRdmaEndpointAllocator, podName, e.rdmaAttr.primaryMacAddress)
 
+	ctx, span := tracing.Start(ctx, "agent/endpoint-add", attribute.String("endpoint", namespace+"/"+name))
+	defer func() {
+		tracing.End(span, err)
+	}()
+	ctx, cancelFun := context.WithTimeout(logfields.WithtTraceID(ctx), e.c.GetFixedIPTimeout())
+
 	var (
-		ctx, cancelFun = context.WithTimeout(logfields.NewContext(), e.c.GetFixedIPTimeout())
-		logEntry       = allocatorLog.WithFields(logrus.Fields{
+		logEntry = allocatorLog.WithFields(logrus.Fields{
 			"namespace":   namespace,
 			"name":        name,
 			"module":      "AllocateNext",
@@ -296,6 +308,7 @@ func (e *EndpointAllocator) allocateIP(ctx context.Context, logEntry *logrus.Ent
 	}
 
 	newEP := NewEndpointTemplate(containerID, netns, pod)
+	setTraceContext(ctx, newEP)
 	ipTTLSeconds := k8s.ExtractFixedIPTTLSeconds(pod)
 	if ipTTLSeconds != 0 {
 		newEP.Spec.Network.IPAllocation.TTLSecondsAfterDeleted = &ipTTLSeconds
@@ -441,6 +454,11 @@ func (e *EndpointAllocator) Restore() {
 		}
 	}
 
+	// restore health endpoint ip before the ips of pods
+	if option.Config.HealthEndpointEnabled() {
+		e.restoreHealthIPs()
+	}
+
 	// restore dynamic ip if need
 	logEntry := allocatorLog.WithField("module", "Restore")
 	eps, err := e.cceEndpointClient.List()
@@ -471,7 +489,8 @@ func (e *EndpointAllocator) Restore() {
 			_, err = e.dynamicIPAM.AllocateIPWithoutSyncUpstream(net.ParseIP(ip), ep.Namespace+"/"+ep.Name)
 			if err != nil {
 				epLog.WithError(err).Warnf("failed to restore ip %s, strict inspection mode will be activated", ip)
-				pod, err := e.podClient.Get(ep.Namespace, ep.Name)
+				_, podName := GetPodNameFromCEP(ep)
+				pod, err := e.podClient.Get(ep.Namespace, podName)
 				if err == nil {
 					if pod.Status.Phase != corev1.PodRunning && pod.Status.Phase != corev1.PodPending {
 						epLog.Infof("pod is not running or pending, try to delete expired endpoint")
@@ -518,7 +537,8 @@ func (e *EndpointAllocator) extractEndpointIPs(ep *ccev2.CCEEndpoint) ([]string,
 		}
 	} else {
 		// Demote to directly recycle the pod ip
-		pod, _ := e.podClient.Get(ep.Namespace, ep.Name)
+		_, podName := GetPodNameFromCEP(ep)
+		pod, _ := e.podClient.Get(ep.Namespace, podName)
 		if pod != nil {
 			for _, ip := range pod.Status.PodIPs {
 				if ip.String() != "" {
@@ -537,6 +557,11 @@ func (e *EndpointAllocator) extractEndpointIPs(ep *ccev2.CCEEndpoint) ([]string,
 // If the status of the endpoint does not complete the IP allocation until the context timeout,
 // it is considered that the IP allocation has failed and is returned directly.
 func (e *EndpointAllocator) waitEndpointIPAllocated(ctx context.Context, newEP *ccev2.CCEEndpoint) (ipv4Result, ipv6Result *ipam.AllocationResult, err error) {
+	ctx, span := tracing.Start(ctx, "agent/wait-endpoint-ip-allocated")
+	defer func() {
+		tracing.End(span, err)
+	}()
+
 	err = wait.PollImmediateUntilWithContext(ctx, time.Second/2, func(context.Context) (done bool, err error) {
 		ep, err := e.cceEndpointClient.Get(newEP.Namespace, newEP.Name)
 		if err != nil {
@@ -659,12 +684,13 @@ func (e *EndpointAllocator) createReuseIPEndpoint(ctx context.Context, newEP, ol
 		}
 	} else {
 		// update endpoint spec, Waiting for status updates
+		oldEP = oldEP.DeepCopy()
 		if !reflect.DeepEqual(newEP.Spec, oldEP.Spec) {
-			oldEP = oldEP.DeepCopy()
 			oldEP.Labels = newEP.Labels
 			oldEP.Spec = newEP.Spec
 			oldEP.Finalizers = newEP.Finalizers
 		}
+		setTraceContext(ctx, oldEP)
 		AppendEndpointStatus(&oldEP.Status, models.EndpointStateRestoring, models.EndpointStatusChangeCodeOk)
 		ep, err = e.cceEndpointClient.CCEEndpoints(newEP.Namespace).Update(ctx, oldEP, metav1.UpdateOptions{})
 		if err != nil {
@@ -0,0 +1,178 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+package endpoint
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/ipam"
+	ipamOption "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/ipam/option"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s"
+	ccev2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging/logfields"
+)
+
+// The secondary interfaces of a pod are requested by the annotation
+// k8s.AnnotationPodNetworks. Each of them has its own CCEEndpoint named
+// "podname-net-interface", whose IPs are allocated by the operator from the
+// subnets of the PSTS of the interface, just like the endpoints of the pods
+// using PSTS.
+
+// ADDNetworks implements ipam.CNIIPAMServer
+func (e *EndpointAllocator) ADDNetworks(owner, containerID, netns string) (results []*ipam.NetworkAttachmentResult, err error) {
+	namespace, podName, err := cache.SplitMetaNamespaceKey(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		ctx, cancelFun = context.WithTimeout(logfields.NewContext(), e.c.GetFixedIPTimeout())
+		logEntry       = allocatorLog.WithFields(logrus.Fields{
+			"namespace":   namespace,
+			"name":        podName,
+			"module":      "AllocateNetworks",
+			"containerID": containerID,
+		}).WithContext(ctx)
+
+		created []*ccev2.CCEEndpoint
+	)
+	defer cancelFun()
+	logEntry.Debug("start cni ADD networks")
+
+	defer func() {
+		if err != nil {
+			logEntry.WithError(err).Error("cni ADD networks error")
+			// release the interfaces allocated already, the pod will be
+			// retried by kubelet with all of them
+			for _, ep := range created {
+				if delErr := e.tryDeleteEndpointAfterPodDeleted(ep, false, logEntry.WithField("scope", "rollback")); delErr != nil {
+					logEntry.WithError(delErr).Warnf("failed to rollback endpoint %s", ep.Name)
+				}
+			}
+			results = nil
+			return
+		}
+		logEntry.Infof("cni ADD networks success")
+	}()
+
+	pod, err := e.podClient.Get(namespace, podName)
+	if err != nil {
+		return nil, fmt.Errorf("get pod (%s/%s) error %w", namespace, podName, err)
+	}
+	attachments, err := k8s.ExtractPodNetworkAttachments(pod)
+	if err != nil {
+		return nil, err
+	}
+	if len(attachments) == 0 {
+		return nil, nil
+	}
+	if e.isRDMAMode() || e.c.IPAMMode() != ipamOption.IPAMVpcEni {
+		return nil, fmt.Errorf("secondary interfaces are only supported in %s mode", ipamOption.IPAMVpcEni)
+	}
+
+	for i := range attachments {
+		attachment := attachments[i]
+		attachLog := logEntry.WithFields(logrus.Fields{
+			"interface": attachment.Interface,
+			"psts":      attachment.PSTSName,
+		})
+
+		psts, err := e.pstsLister.PodSubnetTopologySpreads(namespace).Get(attachment.PSTSName)
+		if err != nil {
+			return nil, fmt.Errorf("get psts %s of interface %s error %w", attachment.PSTSName, attachment.Interface, err)
+		}
+
+		epName := GetAttachmentEndpointName(pod.Name, attachment.Interface)
+		oldEP, err := e.getEndpoint(namespace, epName)
+		if err != nil {
+			return nil, err
+		}
+
+		newEP := NewEndpointTemplate(containerID, netns, pod)
+		newEP.Name = epName
+		newEP.Spec.Network.Attachment = &attachment
+		k8s.FinalizerAddRemoteIP(newEP)
+
+		newEP, err = e.createDelegateEndpoint(ctx, psts, newEP, oldEP)
+		if err != nil {
+			return nil, fmt.Errorf("create endpoint of interface %s error %w", attachment.Interface, err)
+		}
+		created = append(created, newEP)
+
+		ipv4Result, ipv6Result, err := e.waitEndpointIPAllocated(ctx, newEP)
+		if err != nil {
+			return nil, fmt.Errorf("wait ip of interface %s allocated error %w", attachment.Interface, err)
+		}
+		attachLog.Info("allocate ip for interface success")
+
+		results = append(results, &ipam.NetworkAttachmentResult{
+			Interface: attachment.Interface,
+			IPv4:      ipv4Result,
+			IPv6:      ipv6Result,
+			Routes:    attachment.Routes,
+		})
+	}
+	return results, nil
+}
+
+// DELNetworks implements ipam.CNIIPAMServer
+func (e *EndpointAllocator) DELNetworks(owner, containerID string) (err error) {
+	namespace, podName, err := cache.SplitMetaNamespaceKey(owner)
+	if err != nil {
+		return err
+	}
+	logEntry := allocatorLog.WithFields(logrus.Fields{
+		"namespace":   namespace,
+		"name":        podName,
+		"module":      "ReleaseNetworks",
+		"containerID": containerID,
+	})
+
+	eps, err := e.cceEndpointClient.List()
+	if err != nil {
+		return err
+	}
+	for _, ep := range eps {
+		if ep.Namespace != namespace || ep.Spec.Network.Attachment == nil || !isSameContainerID(ep, containerID) {
+			continue
+		}
+		if _, name := GetPodNameFromCEP(ep); name != podName {
+			continue
+		}
+		if err := e.tryDeleteEndpointAfterPodDeleted(ep, false, logEntry.WithField("interface", ep.Spec.Network.Attachment.Interface)); err != nil {
+			return fmt.Errorf("release endpoint %s error %w", ep.Name, err)
+		}
+	}
+	return nil
+}
+
+// getEndpoint gets the endpoint from the cache, and falls back to the
+// kube-apiserver if it is not found. It returns nil if the endpoint does not exist.
+func (e *EndpointAllocator) getEndpoint(namespace, name string) (*ccev2.CCEEndpoint, error) {
+	ep, err := e.cceEndpointClient.Get(namespace, name)
+	if kerrors.IsNotFound(err) {
+		ep, err = e.cceEndpointClient.CCEEndpoints(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+	}
+	return ep, err
+}
@@ -121,8 +121,9 @@ func (gcer *agentGCer) dynamicUsedIPsGC() {
 				"step":  "dynamicUsedIPsGC",
 			})
 
-			// skip the router ip used by cce and cilium
-			if strings.HasSuffix(owner, ipamOption.IPAMVpcRoute) {
+			// skip the router ip used by cce and cilium, and the ip of
+			// the health endpoint
+			if strings.HasSuffix(owner, ipamOption.IPAMVpcRoute) || strings.HasSuffix(owner, ipamOption.IPAMHealthEndpoint) {
 				delete(gcer.expiredIPMap, addr)
 				continue
 			}
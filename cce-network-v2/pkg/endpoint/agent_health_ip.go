@@ -0,0 +1,100 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+package endpoint
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/defaults"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/ipam"
+	ipamOption "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/ipam/option"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/node"
+)
+
+// healthIPOwner returns the owner of the health endpoint IP of the family
+func healthIPOwner(family ipam.Family) string {
+	return string(family) + "-" + ipamOption.IPAMHealthEndpoint
+}
+
+// restoreHealthIPs allocates the IPs of the health endpoint published in the
+// NetResourceSet again, so that they will not be allocated to pods after the
+// agent restarted
+func (e *EndpointAllocator) restoreHealthIPs() {
+	logEntry := allocatorLog.WithField("module", "restoreHealthIPs")
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaults.ClientConnectTimeout)
+	defer cancel()
+	nrs, err := k8s.CCEClient().CceV2().NetResourceSets().Get(ctx, e.nrsName, metav1.GetOptions{})
+	if err != nil {
+		logEntry.WithError(err).Warning("get NetResourceSet error, health endpoint will use new ips")
+		return
+	}
+
+	restore := func(addr string, family ipam.Family, setter func(net.IP)) {
+		ip := net.ParseIP(addr)
+		if ip == nil {
+			return
+		}
+		scopedLog := logEntry.WithFields(logrus.Fields{
+			"ip":     addr,
+			"family": family,
+		})
+		if err := e.dynamicIPAM.AllocateIPString(addr, healthIPOwner(family)); err != nil {
+			scopedLog.WithError(err).Warning("restore health endpoint ip error, health endpoint will use a new ip")
+			return
+		}
+		setter(ip)
+		scopedLog.Info("restore health endpoint ip success")
+	}
+	if e.c.IPv4Enabled() {
+		restore(nrs.Spec.HealthAddressing.IPv4, ipam.IPv4, node.SetEndpointHealthIPv4)
+	}
+	if e.c.IPv6Enabled() {
+		restore(nrs.Spec.HealthAddressing.IPv6, ipam.IPv6, node.SetEndpointHealthIPv6)
+	}
+}
+
+// AllocateHealthIPs implements ipam.CNIIPAMServer
+func (e *EndpointAllocator) AllocateHealthIPs() (ipv4, ipv6 net.IP, err error) {
+	ipv4, ipv6 = node.GetEndpointHealthIPv4(), node.GetEndpointHealthIPv6()
+
+	if e.c.IPv4Enabled() && ipv4 == nil {
+		result, _, err := e.dynamicIPAM.AllocateNext(string(ipam.IPv4), healthIPOwner(ipam.IPv4))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to allocate ipv4 for health endpoint: %w", err)
+		}
+		ipv4 = result.IP
+		node.SetEndpointHealthIPv4(ipv4)
+		allocatorLog.WithField("ip", ipv4.String()).Info("allocate health endpoint ipv4 success")
+	}
+
+	if e.c.IPv6Enabled() && ipv6 == nil {
+		_, result, err := e.dynamicIPAM.AllocateNext(string(ipam.IPv6), healthIPOwner(ipam.IPv6))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to allocate ipv6 for health endpoint: %w", err)
+		}
+		ipv6 = result.IP
+		node.SetEndpointHealthIPv6(ipv6)
+		allocatorLog.WithField("ip", ipv6.String()).Info("allocate health endpoint ipv6 success")
+	}
+	return ipv4, ipv6, nil
+}
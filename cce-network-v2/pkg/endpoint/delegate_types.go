@@ -33,6 +33,8 @@ type CCEEndpointGetterUpdater interface {
 	UpdateStatus(newResource *ccev2.CCEEndpoint) (*ccev2.CCEEndpoint, error)
 	Delete(namespace, name string) error
 	Lister() ccelister.CCEEndpointLister
+	// GetByPSTSSubnet returns the endpoints allocated from the subnet by the psts
+	GetByPSTSSubnet(namespace, pstsName, subnetID string) ([]*ccev2.CCEEndpoint, error)
 }
 
 type DirectIPAction struct {
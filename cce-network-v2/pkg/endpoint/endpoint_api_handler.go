@@ -81,20 +81,28 @@ func (handler *EndpointAPIHandler) GetEndpointExtpluginStatus(ctx context.Contex
 		}
 
 		// check extFeatureStatus
-		if len(cep.Spec.ExtFeatureGates) == 0 {
-			return true, nil
-		}
-		if len(cep.Spec.ExtFeatureGates) != len(cep.Status.ExtFeatureStatus) {
-			return false, nil
-		}
-
+		// the status may contain the features reported by the plugins which are
+		// not gated, only the gated features are waited for
 		for _, extFeature := range cep.Spec.ExtFeatureGates {
-			if extStatus, ok := cep.Status.ExtFeatureStatus[extFeature]; ok {
-				if !extStatus.Ready || extStatus.ContainerID != containerID {
-					return false, nil
-				}
-				extFeatureData[extFeature] = extStatus.Data
+			extStatus, ok := cep.Status.ExtFeatureStatus[extFeature]
+			if !ok || extStatus == nil {
+				return false, nil
 			}
+			if !extStatus.Ready || extStatus.ContainerID != containerID {
+				return false, nil
+			}
+			extFeatureData[extFeature] = extStatus.Data
+		}
+		// the features applied by agent such as bandwidth and egress priority
+		// are never gated, return them as they are so that CHECK can verify them
+		for extFeature, extStatus := range cep.Status.ExtFeatureStatus {
+			if _, ok := extFeatureData[extFeature]; ok || extStatus == nil {
+				continue
+			}
+			if extStatus.ContainerID != containerID {
+				continue
+			}
+			extFeatureData[extFeature] = extStatus.Data
 		}
 		return true, nil
 	})
@@ -154,6 +162,51 @@ func (handler *EndpointAPIHandler) PutEndpointProbe(ctx context.Context, namespa
 	return result, err
 }
 
+// PutEndpointExtpluginStatus records the status of the feature reported by the
+// external plugin to the endpoint. The status is dropped if the endpoint
+// belongs to another container.
+func (handler *EndpointAPIHandler) PutEndpointExtpluginStatus(ctx context.Context, namespace, name, containerID, feature string, status *models.ExtFeatureStatus) error {
+	var (
+		err error
+		cep *ccev2.CCEEndpoint
+
+		log = apiHandlerLog.WithField("method", "PutEndpointExtpluginStatus")
+	)
+
+	err = wait.PollImmediateUntilWithContext(ctx, 500*time.Millisecond, func(ctx context.Context) (bool, error) {
+		cep, err = handler.cceEndpointClient.Get(namespace, name)
+		if err != nil {
+			return false, nil
+		}
+		if cep.Spec.ExternalIdentifiers == nil || cep.Spec.ExternalIdentifiers.ContainerID != containerID {
+			return false, nil
+		}
+
+		cep = cep.DeepCopy()
+		if cep.Status.ExtFeatureStatus == nil {
+			cep.Status.ExtFeatureStatus = make(map[string]*ccev2.ExtFeatureStatus)
+		}
+		now := metav1.Now()
+		cep.Status.ExtFeatureStatus[feature] = &ccev2.ExtFeatureStatus{
+			Ready:       status.Ready,
+			ContainerID: containerID,
+			Msg:         status.Msg,
+			UpdateTime:  &now,
+			Data:        status.Data,
+		}
+		_, err = handler.cceEndpointClient.CCEEndpoints(namespace).Update(ctx, cep, metav1.UpdateOptions{})
+		if err != nil {
+			log.WithError(err).Error("update endpoint failed")
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update status of feature %s: %v", feature, err)
+	}
+	return nil
+}
+
 func shouldUpdateSpec(resource *ccev2.CCEEndpoint) (bool, error) {
 	if resource.Status.ExtFeatureStatus == nil {
 		resource.Status.ExtFeatureStatus = make(map[string]*ccev2.ExtFeatureStatus)
@@ -171,13 +224,31 @@ func shouldUpdateSpec(resource *ccev2.CCEEndpoint) (bool, error) {
 			return false, fmt.Errorf("failed to get pod bandwidth: %v", err)
 		}
 
-		if bandWidthOpt.IsValid() {
+		if bandWidthOpt.IsValid() && bandWidthOpt.Mode == ccev2.BindwidthModeEDT {
+			// edt is implemented by agent, the status reports the result of agent.
+			// It is applied again only if the spec changed or the last result is
+			// not ready for the current container
+			if !reflect.DeepEqual(bandWidthOpt, resource.Spec.Network.Bindwidth) ||
+				!isExtFeatureReady(resource, event.EndpointProbeEventBandwidth) {
+				shouldUpdateSpec = true
+				resource.Spec.Network.Bindwidth = bandWidthOpt
+				bandwidthStatus, err := bandwidth.GlobalManager.Handle(&event.EndpointProbeEvent{
+					ID:   resource.Namespace + "/" + resource.Name,
+					Obj:  resource,
+					Type: event.EndpointProbeEventBandwidth,
+				})
+				if err != nil {
+					return false, fmt.Errorf("handle edt bandwidth failed: %v", err)
+				}
+				resource.Status.ExtFeatureStatus[event.EndpointProbeEventBandwidth] = bandwidthStatus
+			}
+		} else if bandWidthOpt.IsValid() {
 			if !reflect.DeepEqual(bandWidthOpt, resource.Spec.Network.Bindwidth) {
 				shouldUpdateSpec = true
 				// this feature will implement by cni
 				resource.Spec.Network.Bindwidth = bandWidthOpt
 				now := metav1.Now()
-				resource.Status.ExtFeatureStatus[event.EndpointProbeEventBandwidth] = &ccev2.ExtFeatureStatus{
+				bandwidthStatus := &ccev2.ExtFeatureStatus{
 					Ready:       true,
 					ContainerID: resource.Spec.ExternalIdentifiers.ContainerID,
 					UpdateTime:  &now,
@@ -187,6 +258,12 @@ func shouldUpdateSpec(resource *ccev2.CCEEndpoint) (bool, error) {
 						"egress":  strconv.Itoa(int(bandWidthOpt.Egress)),
 					},
 				}
+				if driver := resource.Spec.ExternalIdentifiers.Cnidriver; !bandwidth.IsDriverSupportTC(driver) {
+					bandwidthStatus.Ready = false
+					bandwidthStatus.Data["mode"] = bandwidth.BindwidthModeUnsupported
+					bandwidthStatus.Msg = fmt.Sprintf("bandwidth is unsupported by driver %s", driver)
+				}
+				resource.Status.ExtFeatureStatus[event.EndpointProbeEventBandwidth] = bandwidthStatus
 			}
 		}
 	}
@@ -208,3 +285,11 @@ func shouldUpdateSpec(resource *ccev2.CCEEndpoint) (bool, error) {
 	}
 	return shouldUpdateSpec, nil
 }
+
+// isExtFeatureReady returns true if the feature has been applied to the
+// current container of endpoint
+func isExtFeatureReady(resource *ccev2.CCEEndpoint, feature string) bool {
+	status, ok := resource.Status.ExtFeatureStatus[feature]
+	return ok && status != nil && status.Ready &&
+		status.ContainerID == resource.Spec.ExternalIdentifiers.ContainerID
+}
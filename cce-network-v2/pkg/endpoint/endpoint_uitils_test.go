@@ -1,6 +1,7 @@
 package endpoint
 
 import (
+	"context"
 	"errors"
 	"net"
 	"testing"
@@ -11,11 +12,13 @@ import (
 	"github.com/vishvananda/netlink"
 
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s"
 	ccev2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging/logfields"
 	mock_netlinkwrapper "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/netlinkwrapper/mocks"
 	mock_nswrapper "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/nswrapper/mocks"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/tracing"
 )
 
 // mockLink is a mock implementation of netlink.Link
@@ -361,3 +364,30 @@ func TestIsThisCEPReadyForDelete(t *testing.T) {
 		t.Error("Expected isThisCEPReadyForDelete to return false when IP is empty")
 	}
 }
+
+func TestSetTraceContext(t *testing.T) {
+	const traceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	podAnnotations := map[string]string{"foo": "bar"}
+	ep := &ccev2.CCEEndpoint{}
+	ep.Annotations = podAnnotations
+
+	setTraceContext(tracing.WithTraceParent(context.Background(), traceParent), ep)
+	if ep.Annotations[k8s.AnnotationTraceContext] != traceParent || ep.Annotations["foo"] != "bar" {
+		t.Errorf("unexpected annotations %v", ep.Annotations)
+	}
+	if _, ok := podAnnotations[k8s.AnnotationTraceContext]; ok {
+		t.Error("the annotations shared with the pod must not be modified")
+	}
+	if got := tracing.TraceParent(ContextWithTraceOfEndpoint(context.Background(), ep)); got != traceParent {
+		t.Errorf("expected trace context %s, got %s", traceParent, got)
+	}
+
+	// the stale trace context is removed if the request is not traced
+	setTraceContext(context.Background(), ep)
+	if _, ok := ep.Annotations[k8s.AnnotationTraceContext]; ok || ep.Annotations["foo"] != "bar" {
+		t.Errorf("unexpected annotations %v", ep.Annotations)
+	}
+	if got := tracing.TraceParent(ContextWithTraceOfEndpoint(context.Background(), ep)); got != "" {
+		t.Errorf("expected no trace context, got %s", got)
+	}
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/watchers"
 	netlinkwrapper "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/netlinkwrapper"
 	nodeTypes "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/node/types"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/tracing"
 	pluginManager "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/plugins/pluginmanager"
 )
 
@@ -92,6 +93,33 @@ func NewEndpointTemplate(containerID, netnsPath string, pod *corev1.Pod) *ccev2.
 	return newEP
 }
 
+// setTraceContext records the trace context of ctx in the annotation of the
+// endpoint, so the IP allocation by the operator is traced as the child of the
+// CNI request. The stale trace context of the last request is removed.
+func setTraceContext(ctx context.Context, ep *ccev2.CCEEndpoint) {
+	traceParent := tracing.TraceParent(ctx)
+	if ep.Annotations[k8s.AnnotationTraceContext] == traceParent {
+		return
+	}
+	// the annotations may be shared with the pod in the informer cache
+	annotations := make(map[string]string, len(ep.Annotations)+1)
+	for k, v := range ep.Annotations {
+		annotations[k] = v
+	}
+	if traceParent == "" {
+		delete(annotations, k8s.AnnotationTraceContext)
+	} else {
+		annotations[k8s.AnnotationTraceContext] = traceParent
+	}
+	ep.Annotations = annotations
+}
+
+// ContextWithTraceOfEndpoint returns a context with the span of the CNI
+// request which created the endpoint as the parent
+func ContextWithTraceOfEndpoint(ctx context.Context, ep *ccev2.CCEEndpoint) context.Context {
+	return tracing.WithTraceParent(ctx, ep.Annotations[k8s.AnnotationTraceContext])
+}
+
 // IsSameContainerID checks if two container ids are the same or not
 func IsSameContainerID(ep *ccev2.CCEEndpoint, containerID string) bool {
 	return ep != nil &&
@@ -101,11 +129,18 @@ func IsSameContainerID(ep *ccev2.CCEEndpoint, containerID string) bool {
 
 // AppendEndpointStatus appends node status to given pod endpoint status
 func AppendEndpointStatus(newStatus *ccev2.EndpointStatus, status models.EndpointState, code string) bool {
+	return AppendEndpointStatusWithMessage(newStatus, status, code, "")
+}
+
+// AppendEndpointStatusWithMessage appends node status to given pod endpoint status
+// the message is used to record the reason of the status change
+func AppendEndpointStatusWithMessage(newStatus *ccev2.EndpointStatus, status models.EndpointState, code, message string) bool {
 	update := newStatus.State != string(status)
 	// update state and log
 	newStatus.State = string(status)
 	newLog := &models.EndpointStatusChange{
 		Code:      code,
+		Message:   message,
 		State:     status,
 		Timestamp: time.Now().Format(time.RFC3339),
 	}
@@ -169,6 +204,12 @@ func ConverteIPAllocation2EndpointAddress(result *ipam.AllocationResult, family
 	}
 }
 
+// GetAttachmentEndpointName returns the name of the endpoint of the secondary
+// interface of the pod, which is like "podname-net-eth1"
+func GetAttachmentEndpointName(podName, iface string) string {
+	return podName + "-net-" + iface
+}
+
 func GetPodNameFromCEP(cep *ccev2.CCEEndpoint) (namesapce, name string) {
 	if cep == nil {
 		return "", ""
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -42,6 +43,7 @@ import (
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging/logfields"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/metrics"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/tracing"
 )
 
 var (
@@ -178,6 +180,19 @@ func (manager *EndpointManager) Update(resource *ccev2.CCEEndpoint) error {
 
 	logEntry.Info("received to update delegate cep")
 
+	// trace the IP allocation as the child of the CNI request which created the cep
+	ctx, span := tracing.Start(ContextWithTraceOfEndpoint(ctx, resource), "operator/endpoint-allocate-ip",
+		attribute.String("endpoint", resource.Namespace+"/"+resource.Name),
+		attribute.String("node", newNodeName),
+		attribute.String("psts", resource.Spec.Network.IPAllocation.PSTSName))
+	defer func() {
+		if err != nil {
+			tracing.End(span, err)
+		} else {
+			tracing.End(span, apiError)
+		}
+	}()
+
 	if newStatus.Networking == nil {
 		newStatus.Networking = &ccev2.EndpointNetworking{
 			NodeIP: newNodeName,
@@ -190,7 +205,11 @@ func (manager *EndpointManager) Update(resource *ccev2.CCEEndpoint) error {
 	}
 
 	if err != nil || newStatus.Networking == nil || len(newStatus.Networking.Addressing) == 0 {
-		AppendEndpointStatus(newStatus, models.EndpointStateInvalid, models.EndpointStatusChangeCodeFailed)
+		var message string
+		if err != nil {
+			message = err.Error()
+		}
+		AppendEndpointStatusWithMessage(newStatus, models.EndpointStateInvalid, models.EndpointStatusChangeCodeFailed, message)
 		goto update
 	}
 
@@ -479,7 +498,9 @@ func (manager *EndpointManager) repairEPState(old *ccev2.CCEEndpoint, log *logru
 	})
 	needUpdate := false
 	newStatus := models.EndpointStateIPAllocated
-	pod, err := operatorWatchers.PodClient.Lister().Pods(old.Namespace).Get(old.Name)
+	// the endpoints of the secondary interfaces are not named after the pod
+	namespace, podName := GetPodNameFromCEP(old)
+	pod, err := operatorWatchers.PodClient.Lister().Pods(namespace).Get(podName)
 	if err == nil && pod != nil {
 		if pod.DeletionTimestamp != nil {
 			return false
@@ -84,6 +84,10 @@ func (provider *pstsAllocatorProvider) AllocateIP(ctx context.Context, log *logr
 		if err != nil {
 			return err
 		}
+		subnets, err = spreadSubnets(psts, provider, log, resource, subnets)
+		if err != nil {
+			return err
+		}
 		if pststrategy.EnableReuseIPPSTS(psts) {
 			// allocate ip from local pool
 			localAllocator := &localAllocator{
@@ -163,6 +167,48 @@ func filterAvailableSubnet(psts *ccev2.PodSubnetTopologySpread, provider *pstsAl
 	return subnets, nil
 }
 
+// spreadSubnets reorders the available subnets by the number of pods matched by psts,
+// so that the allocation keeps the skew between subnets within psts.Spec.MaxSkew
+func spreadSubnets(psts *ccev2.PodSubnetTopologySpread, provider *pstsAllocatorProvider, log *logrus.Entry, resource *ccev2.CCEEndpoint, subnets []*ccev1.Subnet) ([]*ccev1.Subnet, error) {
+	if psts.Spec.MaxSkew <= 0 {
+		return subnets, nil
+	}
+
+	var (
+		candidates []string
+		subnetMap  = make(map[string]*ccev1.Subnet)
+		podCounts  = make(map[string]int32)
+	)
+	for _, sbn := range subnets {
+		candidates = append(candidates, sbn.Name)
+		subnetMap[sbn.Name] = sbn
+	}
+
+	// only the candidates are counted, the subnets which can not allocate IP
+	// for the endpoint are not eligible topology domains
+	for _, sbnID := range candidates {
+		ceps, err := provider.k8sAPI.GetByPSTSSubnet(psts.Namespace, psts.Name, sbnID)
+		if err != nil {
+			log.WithField("step", "spreadSubnets").WithError(err).Error("failed to get ceps by psts subnet")
+			return nil, err
+		}
+		for _, cep := range ceps {
+			if cep.Name != resource.Name {
+				podCounts[sbnID]++
+			}
+		}
+	}
+
+	ordered := pststrategy.SpreadSubnets(psts, candidates, podCounts)
+
+	var result []*ccev1.Subnet
+	for _, sbnID := range ordered {
+		result = append(result, subnetMap[sbnID])
+	}
+	log.WithField("step", "spreadSubnets").WithField("subnets", ordered).Debug("spread subnets by max skew")
+	return result, nil
+}
+
 type localAllocator struct {
 	localPool *localPool
 
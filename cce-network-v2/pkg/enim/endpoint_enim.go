@@ -33,6 +33,7 @@ import (
 	"github.com/sirupsen/logrus"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
 )
 
@@ -139,7 +140,8 @@ func (eem *eniEndpointAllocator) ADD(owner, containerID, netnsPath string) (
 // allocateENI task:
 //  1. build the endpoint template
 //  2. try to allocate eni, if error occured, try rollback eni status
-//  3. update eni status
+//  3. wait for the security groups of the endpoint bound to eni
+//  4. update eni status
 func (eem *eniEndpointAllocator) allocateENI(
 	ctx context.Context, logEntry *logrus.Entry,
 	namespace, name, containerID, netnsPath string) (
@@ -161,10 +163,16 @@ func (eem *eniEndpointAllocator) allocateENI(
 		}
 	}
 
+	securityGroups, err := k8s.ExtractPodSecurityGroups(pod)
+	if err != nil {
+		return
+	}
+
 	ep = endpoint.NewEndpointTemplate(containerID, netnsPath, pod)
 	ep.Finalizers = append(ep.Finalizers, FinalizerPrimaryENIEndpoint)
 	ep.Spec.Network.IPAllocation.Type = ccev2.IPAllocTypeENIPrimary
 	ep.Spec.Network.IPAllocation.ReleaseStrategy = ccev2.ReleaseStrategyTTL
+	ep.Spec.Network.SecurityGroups = securityGroups
 
 	logEntry.WithField(logfields.Step, "1").Debug("try to create endpoint on k8s")
 	ep, err = eem.cceEndpointClient.CCEEndpoints(ep.Namespace).Create(ctx, ep, metav1.CreateOptions{})
@@ -191,17 +199,56 @@ func (eem *eniEndpointAllocator) allocateENI(
 		}
 	}()
 
-	logEntry.WithField(logfields.Step, "3").Debug("try to fill endpoint by ENI")
+	logEntry.WithField(logfields.Step, "3").Debug("try to wait for security groups of ENI")
+	err = eem.waitSecurityGroups(ctx, eni.Name, ep)
+	if err != nil {
+		return
+	}
+
+	logEntry.WithField(logfields.Step, "4").Debug("try to fill endpoint by ENI")
 	ipv4Result, ipv6Result, err = eem.fillEndpintByENI(ctx, eni, ep)
 	if err != nil {
 		return
 	}
 
-	logEntry.WithField(logfields.Step, "4").Debug("try to create endpoint on k8s")
+	logEntry.WithField(logfields.Step, "5").Debug("try to create endpoint on k8s")
 	_, err = eem.cceEndpointClient.CCEEndpoints(ep.Namespace).Update(ctx, ep, metav1.UpdateOptions{})
 	return
 }
 
+// waitSecurityGroups waits until the operator binds the security groups
+// requested by the endpoint to the ENI. If the endpoint does not request any,
+// it waits until the security groups bound for the previous endpoint are
+// replaced by the ones of the node.
+func (eem *eniEndpointAllocator) waitSecurityGroups(ctx context.Context, eniName string, ep *ccev2.CCEEndpoint) error {
+	var bindErr error
+	err := wait.PollImmediateUntilWithContext(ctx, time.Second/2, func(context.Context) (bool, error) {
+		eni, err := eem.eniClient.Get(eniName)
+		if err != nil {
+			return false, nil
+		}
+		binding := eni.Status.SecurityGroupBinding
+		if ep.Spec.Network.SecurityGroups == nil {
+			return binding == nil, nil
+		}
+		if binding == nil || binding.EndpointUID != string(ep.UID) {
+			return false, nil
+		}
+		if binding.Code != models.EndpointStatusChangeCodeOk {
+			bindErr = fmt.Errorf("failed to bind security groups to eni %s: %s", eniName, binding.Message)
+			return false, bindErr
+		}
+		return true, nil
+	})
+	if bindErr != nil {
+		return bindErr
+	}
+	if err != nil {
+		return fmt.Errorf("timeout waiting for security groups of eni %s: %w", eniName, err)
+	}
+	return nil
+}
+
 // fillEndpintByENI build a endpoint by eni
 func (eem *eniEndpointAllocator) fillEndpintByENI(ctx context.Context, eni *ccev2.ENI, ep *ccev2.CCEEndpoint) (
 	ipv4Result, ipv6Result *models.IPAMAddressResponse,
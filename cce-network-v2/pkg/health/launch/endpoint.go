@@ -0,0 +1,302 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+// Package launch creates the health endpoint of the node. The health endpoint
+// is a network namespace connected to the node the same way as a pod, it
+// answers the probes of the health servers of the other nodes so that they
+// can check the connectivity to the pods of this node.
+package launch
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+	"golang.org/x/sys/unix"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/datapath/link"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/health/server"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging"
+)
+
+const (
+	// netNSName is the name of the network namespace of the health endpoint
+	netNSName = "cce-health"
+
+	// hostVethName is the name of the host side veth of the health endpoint
+	hostVethName = "cce-health"
+
+	// tmpVethName is the name of the endpoint side veth before it is moved
+	// into the network namespace
+	tmpVethName = "cce-health-tmp"
+
+	// containerVethName is the name of the veth in the network namespace
+	containerVethName = "eth0"
+)
+
+var (
+	log = logging.NewSubysLogger("health-endpoint")
+
+	// gatewayIPv4 and gatewayIPv6 are the gateways of the health endpoint,
+	// they are resolved to the host side veth by permanent neighbours as the
+	// gateway of the pods created by cptp
+	gatewayIPv4 = net.ParseIP("169.254.1.1")
+	gatewayIPv6 = net.ParseIP("fe80::1")
+)
+
+// Endpoint is the health endpoint of the node
+type Endpoint struct {
+	IPv4, IPv6 net.IP
+
+	responder *http.Server
+}
+
+// LaunchAsEndpoint creates the health endpoint with the addresses and starts
+// the responder listening on the port in it. The health endpoint left by the
+// previous agent is removed first.
+func LaunchAsEndpoint(ipv4, ipv6 net.IP, port, mtu int) (*Endpoint, error) {
+	CleanupEndpoint()
+
+	netNS, err := newNetNS()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create network namespace %s: %w", netNSName, err)
+	}
+	defer netNS.Close()
+
+	ep := &Endpoint{IPv4: ipv4, IPv6: ipv6}
+	if err := ep.setup(netNS, port, mtu); err != nil {
+		ep.Stop()
+		return nil, err
+	}
+	log.WithFields(logrus.Fields{
+		"ipv4": ipv4,
+		"ipv6": ipv6,
+	}).Info("health endpoint launched")
+	return ep, nil
+}
+
+func (e *Endpoint) setup(netNS ns.NetNS, port, mtu int) error {
+	veth := &netlink.Veth{
+		LinkAttrs: netlink.LinkAttrs{Name: hostVethName, MTU: mtu},
+		PeerName:  tmpVethName,
+	}
+	if err := netlink.LinkAdd(veth); err != nil {
+		return fmt.Errorf("failed to create veth %s: %w", hostVethName, err)
+	}
+	hostVeth, err := netlink.LinkByName(hostVethName)
+	if err != nil {
+		return err
+	}
+	peer, err := netlink.LinkByName(tmpVethName)
+	if err != nil {
+		return err
+	}
+	if err := netlink.LinkSetNsFd(peer, int(netNS.Fd())); err != nil {
+		return fmt.Errorf("failed to move veth %s to network namespace %s: %w", tmpVethName, netNSName, err)
+	}
+	if err := netlink.LinkSetUp(hostVeth); err != nil {
+		return fmt.Errorf("failed to set %s up: %w", hostVethName, err)
+	}
+	if err := link.DisableRpFilter(hostVethName); err != nil {
+		log.WithError(err).Warning("failed to disable rp_filter of health endpoint")
+	}
+
+	var containerMAC net.HardwareAddr
+	err = netNS.Do(func(ns.NetNS) error {
+		containerMAC, err = e.setupContainer(hostVeth.Attrs().HardwareAddr)
+		if err != nil {
+			return err
+		}
+
+		// the listener keeps receiving in the network namespace it is
+		// created in, even though it is served by the agent
+		listener, err := net.Listen("tcp", net.JoinHostPort("", strconv.Itoa(port)))
+		if err != nil {
+			return fmt.Errorf("failed to listen on health port %d: %w", port, err)
+		}
+		e.responder = server.NewResponder()
+		go func() {
+			if err := e.responder.Serve(listener); err != nil && err != http.ErrServerClosed {
+				log.WithError(err).Error("health endpoint responder stopped")
+			}
+		}()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, ip := range e.ips() {
+		if err := netlink.RouteReplace(&netlink.Route{
+			LinkIndex: hostVeth.Attrs().Index,
+			Scope:     netlink.SCOPE_LINK,
+			Dst:       hostNet(ip),
+		}); err != nil {
+			return fmt.Errorf("failed to add route to health endpoint %s: %w", ip, err)
+		}
+		if err := netlink.NeighSet(&netlink.Neigh{
+			LinkIndex:    hostVeth.Attrs().Index,
+			State:        netlink.NUD_PERMANENT,
+			IP:           ip,
+			HardwareAddr: containerMAC,
+		}); err != nil {
+			return fmt.Errorf("failed to add neighbour of health endpoint %s: %w", ip, err)
+		}
+	}
+	return nil
+}
+
+// setupContainer configures the veth in the network namespace and returns
+// its MAC address
+func (e *Endpoint) setupContainer(hostMAC net.HardwareAddr) (net.HardwareAddr, error) {
+	if lo, err := netlink.LinkByName("lo"); err == nil {
+		netlink.LinkSetUp(lo)
+	}
+
+	contVeth, err := netlink.LinkByName(tmpVethName)
+	if err != nil {
+		return nil, err
+	}
+	if err := netlink.LinkSetName(contVeth, containerVethName); err != nil {
+		return nil, fmt.Errorf("failed to rename %s to %s: %w", tmpVethName, containerVethName, err)
+	}
+	if err := netlink.LinkSetUp(contVeth); err != nil {
+		return nil, fmt.Errorf("failed to set %s up: %w", containerVethName, err)
+	}
+
+	for _, ip := range e.ips() {
+		gateway := gatewayIPv4
+		if ip.To4() == nil {
+			gateway = gatewayIPv6
+		}
+		if err := netlink.AddrAdd(contVeth, &netlink.Addr{IPNet: hostNet(ip), Flags: unix.IFA_F_NODAD}); err != nil {
+			return nil, fmt.Errorf("failed to add address %s: %w", ip, err)
+		}
+		if err := netlink.NeighSet(&netlink.Neigh{
+			LinkIndex:    contVeth.Attrs().Index,
+			State:        netlink.NUD_PERMANENT,
+			IP:           gateway,
+			HardwareAddr: hostMAC,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to add neighbour of gateway %s: %w", gateway, err)
+		}
+		for _, route := range []*netlink.Route{
+			{
+				LinkIndex: contVeth.Attrs().Index,
+				Scope:     netlink.SCOPE_LINK,
+				Dst:       hostNet(gateway),
+				Src:       ip,
+			},
+			{
+				LinkIndex: contVeth.Attrs().Index,
+				Dst:       defaultNet(ip),
+				Gw:        gateway,
+				Src:       ip,
+			},
+		} {
+			if err := netlink.RouteReplace(route); err != nil {
+				return nil, fmt.Errorf("failed to add route %s: %w", route, err)
+			}
+		}
+	}
+	return contVeth.Attrs().HardwareAddr, nil
+}
+
+// Alive returns true if the veth of the health endpoint still exists
+func (e *Endpoint) Alive() bool {
+	_, err := netlink.LinkByName(hostVethName)
+	return err == nil
+}
+
+// Stop stops the responder and removes the health endpoint
+func (e *Endpoint) Stop() {
+	if e.responder != nil {
+		e.responder.Close()
+	}
+	CleanupEndpoint()
+}
+
+func (e *Endpoint) ips() []net.IP {
+	var ips []net.IP
+	for _, ip := range []net.IP{e.IPv4, e.IPv6} {
+		if ip != nil {
+			ips = append(ips, ip)
+		}
+	}
+	return ips
+}
+
+// CleanupEndpoint removes the veth and the network namespace of the health
+// endpoint if they exist
+func CleanupEndpoint() {
+	if l, err := netlink.LinkByName(hostVethName); err == nil {
+		if err := netlink.LinkDel(l); err != nil {
+			log.WithError(err).Warning("failed to delete veth of health endpoint")
+		}
+	}
+	if _, err := os.Stat(filepath.Join("/var/run/netns", netNSName)); err == nil {
+		if err := netns.DeleteNamed(netNSName); err != nil {
+			log.WithError(err).Warning("failed to delete network namespace of health endpoint")
+		}
+	}
+}
+
+// newNetNS creates the named network namespace of the health endpoint, the
+// network namespace of the calling thread is not changed
+func newNetNS() (ns.NetNS, error) {
+	runtime.LockOSThread()
+	origin, err := netns.Get()
+	if err != nil {
+		runtime.UnlockOSThread()
+		return nil, err
+	}
+	defer origin.Close()
+
+	handle, err := netns.NewNamed(netNSName)
+	if setErr := netns.Set(origin); setErr != nil {
+		// keep the thread locked, so that it is terminated instead of being
+		// reused in the wrong network namespace
+		return nil, setErr
+	}
+	runtime.UnlockOSThread()
+	if err != nil {
+		return nil, err
+	}
+	handle.Close()
+	return ns.GetNS(filepath.Join("/var/run/netns", netNSName))
+}
+
+func hostNet(ip net.IP) *net.IPNet {
+	bits := 128
+	if ip.To4() != nil {
+		bits = 32
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}
+}
+
+func defaultNet(ip net.IP) *net.IPNet {
+	if ip.To4() != nil {
+		return &net.IPNet{IP: net.IPv4zero, Mask: net.CIDRMask(0, 32)}
+	}
+	return &net.IPNet{IP: net.IPv6zero, Mask: net.CIDRMask(0, 128)}
+}
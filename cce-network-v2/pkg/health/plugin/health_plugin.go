@@ -1 +1,54 @@
 package plugin
+
+import (
+	"time"
+)
+
+// HealthPlugin implemented by each plugin
+type HealthPlugin interface {
+	Check() error
+}
+
+// Severity is how much a failing health check affects the agent
+type Severity string
+
+const (
+	// SeverityCritical is the severity of the checks the agent can not work
+	// without, the agent is restarted by the liveness probe if one fails
+	SeverityCritical Severity = "Critical"
+
+	// SeverityDegraded is the severity of the checks of optional features,
+	// the agent keeps working with the features degraded if one fails
+	SeverityDegraded Severity = "Degraded"
+)
+
+// Covers returns true if the failure of a check with severity s must be
+// reported when the failures of the severity failOn and above are reported
+func (s Severity) Covers(failOn Severity) bool {
+	return s == SeverityCritical || failOn == SeverityDegraded
+}
+
+// DefaultCheckTimeout is the timeout of a check if it is not configured
+const DefaultCheckTimeout = 10 * time.Second
+
+// CheckResult is the result of the last run of a health check
+type CheckResult struct {
+	Name     string
+	Severity Severity
+
+	// Healthy is true if the last run of the check succeeded
+	Healthy bool
+
+	// Message is the error of the last run if it failed
+	Message string
+
+	// LastCheck is the time the last run of the check finished
+	LastCheck time.Time
+
+	// LastSuccess is the time the last successful run of the check finished,
+	// it is zero if the check never succeeded
+	LastSuccess time.Time
+
+	// Duration is how long the last run of the check took
+	Duration time.Duration
+}
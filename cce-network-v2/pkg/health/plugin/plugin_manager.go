@@ -1,38 +1,212 @@
 package plugin
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/lock"
 )
 
-// HealthPlugin implemented by each plugin
-type HealthPlugin interface {
-	Check() error
+// Manager runs the registered health checks and remembers their results
+type Manager struct {
+	mutex  lock.RWMutex
+	checks map[string]*check
 }
 
-// HealthPluginManager manage plugin registration and delegate calls
-type healthPluginManager map[string]HealthPlugin
+// NewManager returns a manager without any check
+func NewManager() *Manager {
+	return &Manager{checks: make(map[string]*check)}
+}
 
-var globalHealthPluginManager healthPluginManager = make(healthPluginManager, 0)
+var globalHealthPluginManager = NewManager()
 
-// RegisterPlugin registers a plugin
+// RegisterPlugin registers a critical check with the default timeout
 func RegisterPlugin(name string, plugin HealthPlugin) {
-	globalHealthPluginManager[name] = plugin
+	RegisterCheck(name, plugin, SeverityCritical, DefaultCheckTimeout)
+}
+
+// RegisterCheck registers a check with the severity and the timeout, a check
+// registered with the same name before is replaced
+func RegisterCheck(name string, plugin HealthPlugin, severity Severity, timeout time.Duration) {
+	globalHealthPluginManager.Register(name, plugin, severity, timeout)
 }
 
-// GlobalHealthManager verifies plugins for health of the plugins
-func GlobalHealthManager() HealthPlugin {
+// GlobalHealthManager returns the manager of the checks registered by the
+// components of the agent
+func GlobalHealthManager() *Manager {
 	return globalHealthPluginManager
 }
 
-func (manager healthPluginManager) Check() error {
-	for name, plugin := range manager {
-		err := plugin.Check()
-		if err != nil {
-			return fmt.Errorf("%s's health plugin check failed:%v", name, err)
+// Register registers a check with the severity and the timeout, a check
+// registered with the same name before is replaced
+func (m *Manager) Register(name string, plugin HealthPlugin, severity Severity, timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = DefaultCheckTimeout
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.checks[name] = &check{
+		name:    name,
+		plugin:  plugin,
+		timeout: timeout,
+		result: CheckResult{
+			Name:     name,
+			Severity: severity,
+		},
+	}
+}
+
+// Run runs all checks concurrently and returns their results ordered by name
+func (m *Manager) Run(ctx context.Context) []CheckResult {
+	checks := m.sortedChecks()
+	results := make([]CheckResult, len(checks))
+
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, c *check) {
+			defer wg.Done()
+			results[i] = c.run(ctx)
+		}(i, c)
+	}
+	wg.Wait()
+	return results
+}
+
+// Results returns the results of the last run of all checks ordered by name,
+// the checks which never ran are unhealthy without LastCheck
+func (m *Manager) Results() []CheckResult {
+	checks := m.sortedChecks()
+	results := make([]CheckResult, 0, len(checks))
+	for _, c := range checks {
+		results = append(results, c.getResult())
+	}
+	return results
+}
+
+// Check runs all checks and returns an error naming the failed critical
+// checks. There is no error if no check is registered.
+func (m *Manager) Check() error {
+	return FailedError(m.Run(context.Background()), SeverityCritical)
+}
+
+func (m *Manager) sortedChecks() []*check {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	checks := make([]*check, 0, len(m.checks))
+	for _, c := range m.checks {
+		checks = append(checks, c)
+	}
+	sort.Slice(checks, func(i, j int) bool {
+		return checks[i].name < checks[j].name
+	})
+	return checks
+}
+
+// Failed returns the failed results whose severity is covered by failOn
+func Failed(results []CheckResult, failOn Severity) []CheckResult {
+	var failed []CheckResult
+	for _, r := range results {
+		if !r.Healthy && r.Severity.Covers(failOn) {
+			failed = append(failed, r)
 		}
 	}
-	if len(manager) == 0 {
-		return fmt.Errorf("no health plugin found")
+	return failed
+}
+
+// FailedError returns an error naming the failed results whose severity is
+// covered by failOn, it is nil if there is none
+func FailedError(results []CheckResult, failOn Severity) error {
+	failed := Failed(results, failOn)
+	if len(failed) == 0 {
+		return nil
+	}
+	msgs := make([]string, 0, len(failed))
+	for _, r := range failed {
+		msgs = append(msgs, fmt.Sprintf("%s's health plugin check failed:%s", r.Name, r.Message))
 	}
-	return nil
+	return errors.New(strings.Join(msgs, "; "))
+}
+
+type check struct {
+	name    string
+	plugin  HealthPlugin
+	timeout time.Duration
+
+	mutex lock.Mutex
+	// inflight is the run of the plugin which did not return yet. A plugin
+	// which hangs is not run again until it returns, so that the timed out
+	// runs do not pile up.
+	inflight *pluginRun
+	result   CheckResult
+}
+
+type pluginRun struct {
+	done chan struct{}
+	err  error
+}
+
+// start returns the run of the plugin in flight, a new run is started if
+// there is none
+func (c *check) start() *pluginRun {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.inflight == nil {
+		r := &pluginRun{done: make(chan struct{})}
+		c.inflight = r
+		go func() {
+			r.err = c.plugin.Check()
+
+			c.mutex.Lock()
+			c.inflight = nil
+			c.mutex.Unlock()
+			close(r.done)
+		}()
+	}
+	return c.inflight
+}
+
+func (c *check) run(ctx context.Context) CheckResult {
+	start := time.Now()
+	r := c.start()
+
+	timer := time.NewTimer(c.timeout)
+	defer timer.Stop()
+
+	var err error
+	select {
+	case <-r.done:
+		err = r.err
+	case <-timer.C:
+		err = fmt.Errorf("check timed out after %s", c.timeout)
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	now := time.Now()
+	c.result.LastCheck = now
+	c.result.Duration = now.Sub(start)
+	c.result.Healthy = err == nil
+	c.result.Message = ""
+	if err != nil {
+		c.result.Message = err.Error()
+	} else {
+		c.result.LastSuccess = now
+	}
+	return c.result
+}
+
+func (c *check) getResult() CheckResult {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.result
 }
@@ -0,0 +1,91 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePlugin struct {
+	err   error
+	block chan struct{}
+	calls int32
+}
+
+func (f *fakePlugin) Check() error {
+	atomic.AddInt32(&f.calls, 1)
+	if f.block != nil {
+		<-f.block
+	}
+	return f.err
+}
+
+func TestManagerNoChecks(t *testing.T) {
+	m := NewManager()
+	assert.NoError(t, m.Check())
+	assert.Empty(t, m.Run(context.Background()))
+}
+
+func TestManagerRun(t *testing.T) {
+	m := NewManager()
+	m.Register("rdma", &fakePlugin{err: errors.New("no rdma device")}, SeverityDegraded, 0)
+	m.Register("eni", &fakePlugin{}, SeverityCritical, 0)
+	m.Register("cluster-pool", &fakePlugin{}, SeverityCritical, 0)
+
+	results := m.Run(context.Background())
+	require.Len(t, results, 3)
+	assert.Equal(t, "cluster-pool", results[0].Name)
+	assert.Equal(t, "eni", results[1].Name)
+	assert.Equal(t, "rdma", results[2].Name)
+
+	assert.True(t, results[0].Healthy)
+	assert.False(t, results[0].LastSuccess.IsZero())
+	assert.False(t, results[2].Healthy)
+	assert.Equal(t, "no rdma device", results[2].Message)
+	assert.True(t, results[2].LastSuccess.IsZero())
+	assert.Equal(t, results, m.Results())
+
+	// the failed degraded check only fails the agent when degraded checks
+	// count
+	assert.NoError(t, m.Check())
+	assert.Empty(t, Failed(results, SeverityCritical))
+	assert.Len(t, Failed(results, SeverityDegraded), 1)
+	assert.EqualError(t, FailedError(results, SeverityDegraded), "rdma's health plugin check failed:no rdma device")
+}
+
+func TestManagerKeepsLastSuccess(t *testing.T) {
+	m := NewManager()
+	p := &fakePlugin{}
+	m.Register("eni", p, SeverityCritical, 0)
+
+	lastSuccess := m.Run(context.Background())[0].LastSuccess
+	p.err = errors.New("no eni")
+	result := m.Run(context.Background())[0]
+	assert.False(t, result.Healthy)
+	assert.Equal(t, lastSuccess, result.LastSuccess)
+	assert.Error(t, m.Check())
+}
+
+func TestManagerTimeout(t *testing.T) {
+	m := NewManager()
+	p := &fakePlugin{block: make(chan struct{})}
+	m.Register("hang", p, SeverityCritical, 10*time.Millisecond)
+
+	result := m.Run(context.Background())[0]
+	assert.False(t, result.Healthy)
+	assert.Contains(t, result.Message, "timed out")
+
+	// the plugin in flight is not started again
+	m.Run(context.Background())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&p.calls))
+
+	close(p.block)
+	assert.Eventually(t, func() bool {
+		return m.Run(context.Background())[0].Healthy
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+package server
+
+import (
+	"strings"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/health/models"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/lock"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/metrics"
+)
+
+// gaugeTracker remembers the label values of a gauge vector set in the last
+// round, so that the series of the removed nodes and addresses are deleted
+type gaugeTracker struct {
+	vec     func() metrics.GaugeVec
+	last    map[string][]string
+	current map[string][]string
+}
+
+func newGaugeTracker(vec func() metrics.GaugeVec) *gaugeTracker {
+	return &gaugeTracker{
+		vec:     vec,
+		last:    make(map[string][]string),
+		current: make(map[string][]string),
+	}
+}
+
+func (g *gaugeTracker) set(value float64, labels ...string) {
+	g.vec().WithLabelValues(labels...).Set(value)
+	g.current[strings.Join(labels, "\x00")] = labels
+}
+
+// flush deletes the series which were not set since the last flush
+func (g *gaugeTracker) flush() {
+	for key, labels := range g.last {
+		if _, ok := g.current[key]; !ok {
+			g.vec().DeleteLabelValues(labels...)
+		}
+	}
+	g.last, g.current = g.current, make(map[string][]string)
+}
+
+// connectivityMetrics exports the results of the prober as metrics
+type connectivityMetrics struct {
+	cluster   string
+	localNode string
+
+	mutex   lock.Mutex
+	status  *gaugeTracker
+	latency *gaugeTracker
+}
+
+func newConnectivityMetrics(cluster, localNode string) *connectivityMetrics {
+	return &connectivityMetrics{
+		cluster:   cluster,
+		localNode: localNode,
+		status: newGaugeTracker(func() metrics.GaugeVec {
+			return metrics.NodeConnectivityStatus
+		}),
+		latency: newGaugeTracker(func() metrics.GaugeVec {
+			return metrics.NodeConnectivityLatency
+		}),
+	}
+}
+
+// update sets the metrics to the connectivity status of the nodes. The status
+// of a peer is 1 only if all its addresses are reachable by both ICMP and
+// HTTP, the latency is only exported for the reachable addresses.
+func (m *connectivityMetrics) update(nodes []*models.NodeStatus) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, node := range nodes {
+		if node.Host != nil {
+			m.updatePeer(node.Name, metrics.LabelPeerNode, node.Host.PrimaryAddress, node.Host.SecondaryAddresses)
+		}
+		if node.HealthEndpoint != nil {
+			m.updatePeer(node.Name, metrics.LabelPeerEndpoint, node.HealthEndpoint.PrimaryAddress, node.HealthEndpoint.SecondaryAddresses)
+		}
+	}
+	m.status.flush()
+	m.latency.flush()
+}
+
+func (m *connectivityMetrics) updatePeer(nodeName, peerType string, primary *models.PathStatus, secondaries []*models.PathStatus) {
+	reachable := m.updatePath(nodeName, peerType, metrics.LabelAddressTypePrimary, primary)
+	for _, path := range secondaries {
+		reachable = m.updatePath(nodeName, peerType, metrics.LabelAddressTypeSecondary, path) && reachable
+	}
+
+	status := 0.0
+	if reachable {
+		status = 1
+	}
+	m.status.set(status, m.cluster, m.localNode, m.cluster, nodeName, metrics.LabelLocationRemoteIntraCluster, peerType)
+}
+
+func (m *connectivityMetrics) updatePath(nodeName, peerType, addressType string, path *models.PathStatus) bool {
+	if path == nil {
+		return false
+	}
+
+	reachable := true
+	for protocol, status := range map[string]*models.ConnectivityStatus{
+		metrics.LabelTrafficICMP: path.Icmp,
+		metrics.LabelTrafficHTTP: path.HTTP,
+	} {
+		if status == nil || status.Status != "" {
+			reachable = false
+			continue
+		}
+		m.latency.set(float64(status.Latency)/1e9, m.cluster, m.localNode, m.cluster, nodeName, path.IP,
+			metrics.LabelLocationRemoteIntraCluster, peerType, protocol, addressType)
+	}
+	return reachable
+}
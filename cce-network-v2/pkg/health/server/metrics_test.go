@@ -0,0 +1,51 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/metrics"
+)
+
+type recordingGaugeVec struct {
+	metrics.GaugeVec
+	deleted [][]string
+}
+
+func (r *recordingGaugeVec) DeleteLabelValues(lvs ...string) bool {
+	r.deleted = append(r.deleted, lvs)
+	return true
+}
+
+func TestGaugeTracker(t *testing.T) {
+	vec := &recordingGaugeVec{GaugeVec: metrics.NoOpGaugeVec}
+	g := newGaugeTracker(func() metrics.GaugeVec { return vec })
+
+	g.set(1, "node-a", "node")
+	g.set(1, "node-b", "node")
+	g.flush()
+	assert.Empty(t, vec.deleted)
+
+	g.set(0, "node-a", "node")
+	g.flush()
+	assert.Equal(t, [][]string{{"node-b", "node"}}, vec.deleted)
+
+	g.flush()
+	assert.Equal(t, [][]string{{"node-b", "node"}, {"node-a", "node"}}, vec.deleted)
+}
@@ -0,0 +1,157 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+package server
+
+import (
+	"net"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/tools/cache"
+
+	bceutils "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/bce/utils"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s"
+	ccev2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/informer"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/node/addressing"
+	nodeTypes "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/node/types"
+)
+
+// healthNode is a remote node to probe
+type healthNode struct {
+	Name string
+
+	// PrimaryAddress is the address the node is reached by by default
+	PrimaryAddress net.IP
+
+	// SecondaryAddresses are the other addresses of the node
+	SecondaryAddresses []net.IP
+
+	// HealthAddresses are the addresses of the health endpoint of the node,
+	// the first one is the primary address
+	HealthAddresses []net.IP
+}
+
+// nodeLister lists the remote nodes to probe
+type nodeLister interface {
+	ListNodes() []*healthNode
+}
+
+// netResourceSetLister lists the remote nodes from the NetResourceSets of
+// the cluster
+type netResourceSetLister struct {
+	localNodeName string
+	store         cache.Store
+	hasSynced     cache.InformerSynced
+}
+
+// newNetResourceSetLister starts the informer of all NetResourceSets. The
+// agent only watches the NetResourceSet of its own node, so the health server
+// keeps its own cache which contains nothing but the addresses of the nodes.
+func newNetResourceSetLister(localNodeName string, stop <-chan struct{}) *netResourceSetLister {
+	store, controller := informer.NewInformer(
+		cache.NewListWatchFromClient(k8s.CCEClient().CceV2().RESTClient(),
+			ccev2.NRSPluralName, metav1.NamespaceAll, fields.Everything()),
+		&ccev2.NetResourceSet{},
+		0,
+		cache.ResourceEventHandlerFuncs{},
+		slimNetResourceSet,
+	)
+	go controller.Run(stop)
+
+	return &netResourceSetLister{
+		localNodeName: localNodeName,
+		store:         store,
+		hasSynced:     controller.HasSynced,
+	}
+}
+
+// ListNodes implements nodeLister
+func (l *netResourceSetLister) ListNodes() []*healthNode {
+	var nodes []*healthNode
+	for _, obj := range l.store.List() {
+		nrs, ok := obj.(*ccev2.NetResourceSet)
+		if !ok || nrs.Name == l.localNodeName || bceutils.IsCCERdmaNetRourceSetName(nrs.Name) {
+			continue
+		}
+		if node := toHealthNode(nrs); node.PrimaryAddress != nil || len(node.HealthAddresses) > 0 {
+			nodes = append(nodes, node)
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+	return nodes
+}
+
+// slimNetResourceSet keeps only the fields of the NetResourceSet which the
+// health server needs in the cache
+func slimNetResourceSet(obj interface{}) interface{} {
+	switch concreteObj := obj.(type) {
+	case *ccev2.NetResourceSet:
+		return &ccev2.NetResourceSet{
+			TypeMeta: concreteObj.TypeMeta,
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            concreteObj.Name,
+				ResourceVersion: concreteObj.ResourceVersion,
+			},
+			Spec: ccev2.NetResourceSpec{
+				Addresses:        concreteObj.Spec.Addresses,
+				HealthAddressing: concreteObj.Spec.HealthAddressing,
+			},
+		}
+	case cache.DeletedFinalStateUnknown:
+		nrs, ok := concreteObj.Obj.(*ccev2.NetResourceSet)
+		if !ok {
+			return obj
+		}
+		return cache.DeletedFinalStateUnknown{
+			Key: concreteObj.Key,
+			Obj: slimNetResourceSet(nrs),
+		}
+	default:
+		return obj
+	}
+}
+
+// toHealthNode returns the addresses of the node to probe
+func toHealthNode(nrs *ccev2.NetResourceSet) *healthNode {
+	n := nodeTypes.ParseNetResourceSet(nrs)
+	node := &healthNode{
+		Name:           nrs.Name,
+		PrimaryAddress: n.GetNodeIP(false),
+	}
+	if node.PrimaryAddress == nil {
+		node.PrimaryAddress = n.GetNodeIP(true)
+	}
+
+	known := map[string]bool{node.PrimaryAddress.String(): true}
+	for _, addr := range n.IPAddresses {
+		if addr.Type == addressing.NodeCCEInternalIP || known[addr.IP.String()] {
+			continue
+		}
+		known[addr.IP.String()] = true
+		node.SecondaryAddresses = append(node.SecondaryAddresses, addr.IP)
+	}
+
+	for _, addr := range []string{nrs.Spec.HealthAddressing.IPv4, nrs.Spec.HealthAddressing.IPv6} {
+		if ip := net.ParseIP(addr); ip != nil {
+			node.HealthAddresses = append(node.HealthAddresses, ip)
+		}
+	}
+	return node
+}
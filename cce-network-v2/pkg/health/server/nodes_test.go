@@ -0,0 +1,100 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+package server
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	ccev2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/node/addressing"
+)
+
+func newNetResourceSet(name string, addresses ...ccev2.NodeAddress) *ccev2.NetResourceSet {
+	return &ccev2.NetResourceSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: ccev2.NetResourceSpec{
+			Addresses: addresses,
+		},
+	}
+}
+
+func TestToHealthNode(t *testing.T) {
+	nrs := newNetResourceSet("node-a",
+		ccev2.NodeAddress{Type: addressing.NodeCCEInternalIP, IP: "10.0.0.100"},
+		ccev2.NodeAddress{Type: addressing.NodeExternalIP, IP: "1.1.1.1"},
+		ccev2.NodeAddress{Type: addressing.NodeInternalIP, IP: "10.0.0.1"},
+		ccev2.NodeAddress{Type: addressing.NodeInternalIP, IP: "fd00::1"},
+	)
+	nrs.Spec.HealthAddressing = ccev2.HealthAddressingSpec{IPv4: "172.16.0.1", IPv6: "fd01::1"}
+
+	node := toHealthNode(nrs)
+	assert.Equal(t, "node-a", node.Name)
+	assert.Equal(t, net.ParseIP("10.0.0.1"), node.PrimaryAddress)
+	assert.Equal(t, []net.IP{net.ParseIP("1.1.1.1"), net.ParseIP("fd00::1")}, node.SecondaryAddresses)
+	assert.Equal(t, []net.IP{net.ParseIP("172.16.0.1"), net.ParseIP("fd01::1")}, node.HealthAddresses)
+}
+
+func TestToHealthNodeIPv6Only(t *testing.T) {
+	node := toHealthNode(newNetResourceSet("node-a",
+		ccev2.NodeAddress{Type: addressing.NodeInternalIP, IP: "fd00::1"},
+	))
+	assert.Equal(t, net.ParseIP("fd00::1"), node.PrimaryAddress)
+	assert.Empty(t, node.SecondaryAddresses)
+	assert.Empty(t, node.HealthAddresses)
+}
+
+func TestSlimNetResourceSet(t *testing.T) {
+	nrs := newNetResourceSet("node-a", ccev2.NodeAddress{Type: addressing.NodeInternalIP, IP: "10.0.0.1"})
+	nrs.Labels = map[string]string{"foo": "bar"}
+	nrs.Spec.InstanceID = "i-xxx"
+	nrs.Spec.HealthAddressing.IPv4 = "172.16.0.1"
+
+	slim := slimNetResourceSet(nrs).(*ccev2.NetResourceSet)
+	assert.Equal(t, "node-a", slim.Name)
+	assert.Empty(t, slim.Labels)
+	assert.Empty(t, slim.Spec.InstanceID)
+	assert.Equal(t, nrs.Spec.Addresses, slim.Spec.Addresses)
+	assert.Equal(t, nrs.Spec.HealthAddressing, slim.Spec.HealthAddressing)
+
+	deleted := slimNetResourceSet(cache.DeletedFinalStateUnknown{Key: "node-a", Obj: nrs}).(cache.DeletedFinalStateUnknown)
+	assert.Equal(t, "node-a", deleted.Key)
+	assert.Equal(t, slim, deleted.Obj)
+}
+
+func TestNetResourceSetListerListNodes(t *testing.T) {
+	store := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	for _, nrs := range []*ccev2.NetResourceSet{
+		newNetResourceSet("node-b", ccev2.NodeAddress{Type: addressing.NodeInternalIP, IP: "10.0.0.2"}),
+		newNetResourceSet("node-a", ccev2.NodeAddress{Type: addressing.NodeInternalIP, IP: "10.0.0.1"}),
+		newNetResourceSet("local", ccev2.NodeAddress{Type: addressing.NodeInternalIP, IP: "10.0.0.3"}),
+		newNetResourceSet("node-c-fa2700f75004-elasticrdma", ccev2.NodeAddress{Type: addressing.NodeInternalIP, IP: "10.0.0.4"}),
+		newNetResourceSet("node-d"),
+	} {
+		store.Add(nrs)
+	}
+
+	lister := &netResourceSetLister{localNodeName: "local", store: store}
+	var names []string
+	for _, node := range lister.ListNodes() {
+		names = append(names, node.Name)
+	}
+	assert.Equal(t, []string{"node-a", "node-b"}, names)
+}
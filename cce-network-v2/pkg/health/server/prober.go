@@ -0,0 +1,279 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/health/models"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/lock"
+)
+
+const (
+	// maxConcurrentProbes is the maximum number of addresses probed at the
+	// same time
+	maxConcurrentProbes = 32
+
+	protocolICMPv4 = 1
+	protocolICMPv6 = 58
+)
+
+// probeFunc sends one probe to the address and returns the round trip time
+type probeFunc func(ctx context.Context, ip net.IP) (time.Duration, error)
+
+// prober probes all addresses of the remote nodes with ICMP and HTTP and
+// keeps the results of the last run
+type prober struct {
+	nodes nodeLister
+
+	icmpProbe probeFunc
+	httpProbe probeFunc
+
+	// probeMutex serializes the runs of the periodic and the on-demand probes
+	probeMutex lock.Mutex
+
+	mutex lock.RWMutex
+	// lastNodes are the nodes probed in the last run
+	lastNodes []*healthNode
+	// results are the results of the last run keyed by the address
+	results map[string]*models.PathStatus
+	// lastProbe is the time the last run completed
+	lastProbe time.Time
+}
+
+func newProber(nodes nodeLister, port int, timeout time.Duration) *prober {
+	client := &http.Client{
+		Timeout:   timeout,
+		Transport: &http.Transport{DisableKeepAlives: true},
+	}
+	return &prober{
+		nodes: nodes,
+		icmpProbe: func(ctx context.Context, ip net.IP) (time.Duration, error) {
+			return icmpPing(ctx, ip, timeout)
+		},
+		httpProbe: func(ctx context.Context, ip net.IP) (time.Duration, error) {
+			return httpGet(ctx, client, ip, port)
+		},
+		results: make(map[string]*models.PathStatus),
+	}
+}
+
+// probe probes all addresses of the remote nodes and stores the results
+func (p *prober) probe(ctx context.Context) {
+	p.probeMutex.Lock()
+	defer p.probeMutex.Unlock()
+
+	nodes := p.nodes.ListNodes()
+
+	var (
+		wg      sync.WaitGroup
+		mutex   lock.Mutex
+		sem     = make(chan struct{}, maxConcurrentProbes)
+		seen    = make(map[string]bool)
+		results = make(map[string]*models.PathStatus)
+	)
+	for _, node := range nodes {
+		for _, ip := range nodeAddresses(node) {
+			if seen[ip.String()] {
+				continue
+			}
+			seen[ip.String()] = true
+
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(ip net.IP) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				status := p.probeAddress(ctx, ip)
+
+				mutex.Lock()
+				results[ip.String()] = status
+				mutex.Unlock()
+			}(ip)
+		}
+	}
+	wg.Wait()
+
+	p.mutex.Lock()
+	p.lastNodes = nodes
+	p.results = results
+	p.lastProbe = time.Now()
+	p.mutex.Unlock()
+
+	log.WithField("nodes", len(nodes)).Debug("probe connectivity to remote nodes finished")
+}
+
+// probeAddress probes the address with both ICMP and HTTP
+func (p *prober) probeAddress(ctx context.Context, ip net.IP) *models.PathStatus {
+	return &models.PathStatus{
+		IP:   ip.String(),
+		Icmp: connectivityStatus(p.icmpProbe(ctx, ip)),
+		HTTP: connectivityStatus(p.httpProbe(ctx, ip)),
+	}
+}
+
+// getStatus returns the connectivity status of the last run
+func (p *prober) getStatus() ([]*models.NodeStatus, time.Time) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	var nodes []*models.NodeStatus
+	for _, node := range p.lastNodes {
+		nodes = append(nodes, nodeStatus(node, p.results))
+	}
+	return nodes, p.lastProbe
+}
+
+// nodeAddresses returns all addresses of the node to probe
+func nodeAddresses(node *healthNode) []net.IP {
+	var addrs []net.IP
+	if node.PrimaryAddress != nil {
+		addrs = append(addrs, node.PrimaryAddress)
+	}
+	addrs = append(addrs, node.SecondaryAddresses...)
+	return append(addrs, node.HealthAddresses...)
+}
+
+func nodeStatus(node *healthNode, results map[string]*models.PathStatus) *models.NodeStatus {
+	status := &models.NodeStatus{Name: node.Name}
+	if node.PrimaryAddress != nil {
+		status.Host = &models.HostStatus{
+			PrimaryAddress: results[node.PrimaryAddress.String()],
+		}
+		for _, ip := range node.SecondaryAddresses {
+			status.Host.SecondaryAddresses = append(status.Host.SecondaryAddresses, results[ip.String()])
+		}
+	}
+
+	if len(node.HealthAddresses) > 0 {
+		status.HealthEndpoint = &models.EndpointStatus{
+			PrimaryAddress: results[node.HealthAddresses[0].String()],
+		}
+		for _, ip := range node.HealthAddresses[1:] {
+			status.HealthEndpoint.SecondaryAddresses = append(status.HealthEndpoint.SecondaryAddresses, results[ip.String()])
+		}
+		status.Endpoint = status.HealthEndpoint.PrimaryAddress
+	}
+	return status
+}
+
+func connectivityStatus(rtt time.Duration, err error) *models.ConnectivityStatus {
+	if err != nil {
+		return &models.ConnectivityStatus{Status: err.Error()}
+	}
+	return &models.ConnectivityStatus{Latency: rtt.Nanoseconds()}
+}
+
+// icmpSeq is the sequence number of the last ICMP echo request, it tells the
+// replies of the probes running at the same time apart
+var icmpSeq uint32
+
+// icmpPing sends an ICMP echo request to the address and waits for the reply
+func icmpPing(ctx context.Context, ip net.IP, timeout time.Duration) (time.Duration, error) {
+	var (
+		network, listenAddr           = "ip4:icmp", "0.0.0.0"
+		protocol                      = protocolICMPv4
+		requestType         icmp.Type = ipv4.ICMPTypeEcho
+		replyType           icmp.Type = ipv4.ICMPTypeEchoReply
+	)
+	if ip.To4() == nil {
+		network, listenAddr = "ip6:ipv6-icmp", "::"
+		protocol = protocolICMPv6
+		requestType, replyType = ipv6.ICMPTypeEchoRequest, ipv6.ICMPTypeEchoReply
+	}
+
+	conn, err := icmp.ListenPacket(network, listenAddr)
+	if err != nil {
+		return 0, fmt.Errorf("failed to listen icmp: %w", err)
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return 0, err
+	}
+
+	id := os.Getpid() & 0xffff
+	seq := int(atomic.AddUint32(&icmpSeq, 1) & 0xffff)
+	request, err := (&icmp.Message{
+		Type: requestType,
+		Body: &icmp.Echo{ID: id, Seq: seq, Data: []byte("cce-health")},
+	}).Marshal(nil)
+	if err != nil {
+		return 0, err
+	}
+
+	start := time.Now()
+	if _, err := conn.WriteTo(request, &net.IPAddr{IP: ip}); err != nil {
+		return 0, fmt.Errorf("failed to send icmp echo request: %w", err)
+	}
+
+	reply := make([]byte, 1500)
+	for {
+		n, peer, err := conn.ReadFrom(reply)
+		if err != nil {
+			return 0, fmt.Errorf("failed to receive icmp echo reply: %w", err)
+		}
+		if addr, ok := peer.(*net.IPAddr); !ok || !addr.IP.Equal(ip) {
+			continue
+		}
+		msg, err := icmp.ParseMessage(protocol, reply[:n])
+		if err != nil || msg.Type != replyType {
+			continue
+		}
+		if echo, ok := msg.Body.(*icmp.Echo); ok && echo.ID == id && echo.Seq == seq {
+			return time.Since(start), nil
+		}
+	}
+}
+
+// httpGet requests the responder of the health server listening on the
+// address
+func httpGet(ctx context.Context, client *http.Client, ip net.IP, port int) (time.Duration, error) {
+	url := "http://" + net.JoinHostPort(ip.String(), strconv.Itoa(port)) + responderPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return time.Since(start), nil
+}
@@ -0,0 +1,116 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/health/models"
+)
+
+type fakeNodeLister []*healthNode
+
+func (f fakeNodeLister) ListNodes() []*healthNode {
+	return f
+}
+
+func TestHTTPGet(t *testing.T) {
+	responder := httptest.NewServer(NewResponder().Handler)
+	defer responder.Close()
+
+	host, portString, err := net.SplitHostPort(responder.Listener.Addr().String())
+	require.NoError(t, err)
+	port, _ := strconv.Atoi(portString)
+
+	client := &http.Client{Timeout: time.Second}
+	_, err = httpGet(context.Background(), client, net.ParseIP(host), port)
+	assert.NoError(t, err)
+
+	responder.Close()
+	_, err = httpGet(context.Background(), client, net.ParseIP(host), port)
+	assert.Error(t, err)
+}
+
+func TestProbe(t *testing.T) {
+	unreachable := net.ParseIP("10.0.0.3")
+	nodes := fakeNodeLister{
+		{
+			Name:               "node-a",
+			PrimaryAddress:     net.ParseIP("10.0.0.1"),
+			SecondaryAddresses: []net.IP{net.ParseIP("fd00::1")},
+			HealthAddresses:    []net.IP{net.ParseIP("172.16.0.1")},
+		},
+		{
+			Name:           "node-b",
+			PrimaryAddress: net.ParseIP("10.0.0.2"),
+			HealthAddresses: []net.IP{
+				net.ParseIP("172.16.0.2"),
+				unreachable,
+			},
+		},
+	}
+
+	p := newProber(nodes, 0, time.Second)
+	p.icmpProbe = func(_ context.Context, ip net.IP) (time.Duration, error) {
+		return time.Millisecond, nil
+	}
+	p.httpProbe = func(_ context.Context, ip net.IP) (time.Duration, error) {
+		if ip.Equal(unreachable) {
+			return 0, errors.New("connection refused")
+		}
+		return 2 * time.Millisecond, nil
+	}
+	p.probe(context.Background())
+
+	status, lastProbe := p.getStatus()
+	assert.False(t, lastProbe.IsZero())
+	require.Len(t, status, 2)
+
+	a := status[0]
+	assert.Equal(t, "node-a", a.Name)
+	assert.Equal(t, "10.0.0.1", a.Host.PrimaryAddress.IP)
+	assert.Equal(t, time.Millisecond.Nanoseconds(), a.Host.PrimaryAddress.Icmp.Latency)
+	assert.Equal(t, (2 * time.Millisecond).Nanoseconds(), a.Host.PrimaryAddress.HTTP.Latency)
+	require.Len(t, a.Host.SecondaryAddresses, 1)
+	assert.Equal(t, "fd00::1", a.Host.SecondaryAddresses[0].IP)
+	assert.Equal(t, "172.16.0.1", a.HealthEndpoint.PrimaryAddress.IP)
+	assert.Empty(t, a.HealthEndpoint.SecondaryAddresses)
+	assert.Equal(t, a.HealthEndpoint.PrimaryAddress, a.Endpoint)
+
+	b := status[1]
+	require.Len(t, b.HealthEndpoint.SecondaryAddresses, 1)
+	assert.Equal(t, &models.ConnectivityStatus{Status: "connection refused"}, b.HealthEndpoint.SecondaryAddresses[0].HTTP)
+	assert.Equal(t, time.Millisecond.Nanoseconds(), b.HealthEndpoint.SecondaryAddresses[0].Icmp.Latency)
+}
+
+func TestGetStatusBeforeProbe(t *testing.T) {
+	s := newServer(Config{LocalNodeName: "local"}, fakeNodeLister{{Name: "node-a"}})
+
+	status := s.getStatus()
+	assert.Equal(t, "local", status.Local.Name)
+	assert.Empty(t, status.Nodes)
+	assert.Empty(t, status.Timestamp)
+}
@@ -0,0 +1,248 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+// Package server implements the cluster connectivity health server. It probes
+// all other nodes and their health endpoints periodically, answers the probes
+// of the other nodes and serves the results through the health API.
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/loads"
+	"github.com/go-openapi/runtime/middleware"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/health/models"
+	healthApi "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/health/server"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/health/server/restapi"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/health/server/restapi/connectivity"
+	cceModels "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging"
+)
+
+var log = logging.NewSubysLogger("health-server")
+
+// defaultProbeTimeout is the timeout of a single probe if it is not configured
+const defaultProbeTimeout = 5 * time.Second
+
+// Config is the configuration of the health server
+type Config struct {
+	// ClusterID is the ID of the cluster used in the metrics
+	ClusterID string
+
+	// LocalNodeName is the name of the local node, it is not probed
+	LocalNodeName string
+
+	// HTTPPort is the port the responders of all nodes listen on
+	HTTPPort int
+
+	// SocketPath is the path of the unix socket the health API is served on
+	SocketPath string
+
+	// ProbeInterval is the interval between the probes of all nodes
+	ProbeInterval time.Duration
+
+	// ProbeTimeout is the timeout of a single ICMP or HTTP probe, it
+	// defaults to defaultProbeTimeout
+	ProbeTimeout time.Duration
+
+	// DaemonStatus returns the status of the local agent reported by
+	// GET /healthz, it may be nil
+	DaemonStatus func() cceModels.StatusResponse
+}
+
+// Server is the cluster connectivity health server
+type Server struct {
+	Config
+
+	startTime time.Time
+	prober    *prober
+	metrics   *connectivityMetrics
+	hasSynced cache.InformerSynced
+}
+
+// NewServer returns the health server which probes the nodes in the
+// NetResourceSets of the cluster
+func NewServer(config Config, stop <-chan struct{}) *Server {
+	lister := newNetResourceSetLister(config.LocalNodeName, stop)
+	s := newServer(config, lister)
+	s.hasSynced = lister.hasSynced
+	return s
+}
+
+func newServer(config Config, nodes nodeLister) *Server {
+	if config.ProbeTimeout == 0 {
+		config.ProbeTimeout = defaultProbeTimeout
+	}
+	return &Server{
+		Config:    config,
+		startTime: time.Now(),
+		prober:    newProber(nodes, config.HTTPPort, config.ProbeTimeout),
+		metrics:   newConnectivityMetrics(config.ClusterID, config.LocalNodeName),
+		hasSynced: func() bool { return true },
+	}
+}
+
+// Serve starts the responder on the node and the health API, then probes
+// all nodes periodically until the context is done
+func (s *Server) Serve(ctx context.Context) error {
+	responder := NewResponder()
+	listener, err := net.Listen("tcp", net.JoinHostPort("", strconv.Itoa(s.HTTPPort)))
+	if err != nil {
+		return fmt.Errorf("failed to listen on health port %d: %w", s.HTTPPort, err)
+	}
+	go func() {
+		if err := responder.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.WithError(err).Error("health responder stopped")
+		}
+	}()
+	defer responder.Close()
+
+	apiServer, err := s.newAPIServer()
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := apiServer.Serve(); err != nil {
+			log.WithError(err).Error("health api server stopped")
+		}
+	}()
+	defer apiServer.Shutdown()
+
+	if !cache.WaitForCacheSync(ctx.Done(), s.hasSynced) {
+		return ctx.Err()
+	}
+	log.WithField("interval", s.ProbeInterval).Info("health server started")
+
+	ticker := time.NewTicker(s.ProbeInterval)
+	defer ticker.Stop()
+	for {
+		s.probe(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// probe probes all nodes and updates the metrics
+func (s *Server) probe(ctx context.Context) {
+	s.prober.probe(ctx)
+	nodes, _ := s.prober.getStatus()
+	s.metrics.update(nodes)
+}
+
+// getStatus returns the connectivity status of the last probe
+func (s *Server) getStatus() *models.HealthStatusResponse {
+	nodes, lastProbe := s.prober.getStatus()
+	status := &models.HealthStatusResponse{
+		Local: &models.SelfStatus{Name: s.LocalNodeName},
+		Nodes: nodes,
+	}
+	if !lastProbe.IsZero() {
+		status.Timestamp = lastProbe.Format(time.RFC3339)
+	}
+	return status
+}
+
+func (s *Server) newAPIServer() (*healthApi.Server, error) {
+	swaggerSpec, err := loads.Analyzed(healthApi.SwaggerJSON, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load health swagger spec: %w", err)
+	}
+
+	api := restapi.NewCCEHealthAPIAPI(swaggerSpec)
+	api.Logger = log.Infof
+	api.GetHealthzHandler = &getHealthz{s}
+	api.ConnectivityGetStatusHandler = &getStatus{s}
+	api.ConnectivityPutStatusProbeHandler = &putStatusProbe{s}
+
+	if err := os.MkdirAll(filepath.Dir(s.SocketPath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory of health socket: %w", err)
+	}
+	if err := os.Remove(s.SocketPath); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to remove stale health socket: %w", err)
+	}
+
+	srv := healthApi.NewServer(api)
+	srv.EnabledListeners = []string{"unix"}
+	srv.SocketPath = s.SocketPath
+	srv.ConfigureAPI()
+	return srv, nil
+}
+
+type getHealthz struct {
+	*Server
+}
+
+// Handle implements restapi.GetHealthzHandler
+func (h *getHealthz) Handle(restapi.GetHealthzParams) middleware.Responder {
+	resp := &models.HealthResponse{
+		SystemLoad: loadAverage(),
+		Uptime:     time.Since(h.startTime).String(),
+	}
+	if h.DaemonStatus != nil {
+		resp.CCE = h.DaemonStatus()
+	}
+	return restapi.NewGetHealthzOK().WithPayload(resp)
+}
+
+type getStatus struct {
+	*Server
+}
+
+// Handle implements connectivity.GetStatusHandler
+func (h *getStatus) Handle(connectivity.GetStatusParams) middleware.Responder {
+	return connectivity.NewGetStatusOK().WithPayload(h.getStatus())
+}
+
+type putStatusProbe struct {
+	*Server
+}
+
+// Handle implements connectivity.PutStatusProbeHandler, it probes all nodes
+// immediately and returns the new results
+func (h *putStatusProbe) Handle(params connectivity.PutStatusProbeParams) middleware.Responder {
+	h.probe(params.HTTPRequest.Context())
+	return connectivity.NewPutStatusProbeOK().WithPayload(h.getStatus())
+}
+
+// loadAverage returns the load average of the node, it is nil if the load
+// average can not be read
+func loadAverage() *models.LoadResponse {
+	data, err := os.ReadFile("/proc/loadavg")
+	if err != nil {
+		return nil
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) < 3 {
+		return nil
+	}
+	return &models.LoadResponse{
+		Last1min:  fields[0],
+		Last5min:  fields[1],
+		Last15min: fields[2],
+	}
+}
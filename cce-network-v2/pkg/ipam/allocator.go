@@ -16,6 +16,7 @@
 package ipam
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net"
@@ -53,8 +54,19 @@ type CNIIPAMServer interface {
 	debug.StatusObject
 	RestoreTrigger
 	DEL(owner, containerID string) (err error)
-	ADD(family, owner, containerID, netns string) (ipv4Result, ipv6Result *AllocationResult, err error)
+	// ADD allocates the IPs of the pod, ctx carries the trace context of the
+	// CNI request
+	ADD(ctx context.Context, family, owner, containerID, netns string) (ipv4Result, ipv6Result *AllocationResult, err error)
 	Dump() (allocv4 map[string]string, allocv6 map[string]string, status string)
+	ExpirationTimers() map[string]string
+	// AllocateHealthIPs allocates the IPs of the health endpoint of the node
+	// if they have not been allocated yet
+	AllocateHealthIPs() (ipv4, ipv6 net.IP, err error)
+	// ADDNetworks allocates the IPs of the secondary interfaces requested by
+	// the annotation of the pod
+	ADDNetworks(owner, containerID, netns string) ([]*NetworkAttachmentResult, error)
+	// DELNetworks releases the IPs of the secondary interfaces of the pod
+	DELNetworks(owner, containerID string) error
 }
 type IPAMAllocator interface {
 	debug.StatusObject
@@ -66,6 +78,7 @@ type IPAMAllocator interface {
 	AllocateNext(family, owner string) (ipv4Result, ipv6Result *AllocationResult, err error)
 	AllocateNextFamilyWithoutSyncUpstream(family Family, owner string) (result *AllocationResult, err error)
 	Dump() (allocv4 map[string]string, allocv6 map[string]string, status string)
+	ExpirationTimers() map[string]string
 }
 
 func (ipam *IPAM) lookupIPsByOwner(owner string) (ips []net.IP) {
@@ -388,6 +401,18 @@ func (ipam *IPAM) Dump() (allocv4 map[string]string, allocv6 map[string]string,
 	return
 }
 
+// ExpirationTimers returns the UUID of the running expiration timers by IP
+func (ipam *IPAM) ExpirationTimers() map[string]string {
+	ipam.allocatorMutex.RLock()
+	defer ipam.allocatorMutex.RUnlock()
+
+	timers := make(map[string]string, len(ipam.expirationTimers))
+	for ip, allocationUUID := range ipam.expirationTimers {
+		timers[ip] = allocationUUID
+	}
+	return timers
+}
+
 // StartExpirationTimer installs an expiration timer for a previously allocated
 // IP. Unless StopExpirationTimer is called in time, the IP will be released
 // again after expiration of the specified timeout. The function will return a
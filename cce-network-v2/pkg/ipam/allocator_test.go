@@ -108,9 +108,11 @@ func (s *IPAMSuite) TestExpirationTimer(c *C) {
 	// attempt to stop with an invalid uuid, must fail
 	err = ipam.StopExpirationTimer(ip, "unknown-uuid")
 	c.Assert(err, Not(IsNil))
+	c.Assert(ipam.ExpirationTimers(), DeepEquals, map[string]string{ip.String(): uuid})
 	// stop expiration with valid uuid
 	err = ipam.StopExpirationTimer(ip, uuid)
 	c.Assert(err, IsNil)
+	c.Assert(ipam.ExpirationTimers(), HasLen, 0)
 	// Let expiration timer expire
 	time.Sleep(2 * timeout)
 	// must fail as IP is properly in use now
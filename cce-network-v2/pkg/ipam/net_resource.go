@@ -244,6 +244,25 @@ func (n *NetResource) getMinAllocate() int {
 	return n.resource.Spec.IPAM.MinAllocate
 }
 
+// isReleaseExcessIPs returns whether excess IPs of the node should be
+// released. The setting in the spec of the node overrides the setting of
+// cce-operator.
+//
+// n.mutex must be held when calling this function
+func (n *NetResource) isReleaseExcessIPs() bool {
+	if n.resource != nil && n.resource.Spec.IPAM.ReleaseExcessIPs != nil {
+		return *n.resource.Spec.IPAM.ReleaseExcessIPs
+	}
+	return n.manager.releaseExcessIPs
+}
+
+// releaseExcessIPs is the locked version of isReleaseExcessIPs
+func (n *NetResource) releaseExcessIPs() bool {
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	return n.isReleaseExcessIPs()
+}
+
 func (n *NetResource) getBurstableENIs() int {
 	return n.resource.Spec.ENI.BurstableMehrfachENI
 }
@@ -274,7 +293,7 @@ func (n *NetResource) GetNeededAddresses() int {
 	if stats.NeededIPs > 0 {
 		return stats.NeededIPs
 	}
-	if n.manager.releaseExcessIPs && stats.ExcessIPs > 0 {
+	if n.releaseExcessIPs() && stats.ExcessIPs > 0 {
 		// Nodes are sorted by needed addresses, return negative values of excessIPs
 		// so that nodes with IP deficit are resolved first
 		return stats.ExcessIPs * -1
@@ -487,7 +506,7 @@ func (n *NetResource) allocationNeeded() (needed bool) {
 // releaseNeeded returns true if this node requires IPs to be released
 func (n *NetResource) releaseNeeded() (needed bool) {
 	n.mutex.RLock()
-	needed = n.manager.releaseExcessIPs && !n.waitingForPoolMaintenance && n.resyncNeeded.IsZero() && n.stats.ExcessIPs > 0
+	needed = n.isReleaseExcessIPs() && !n.waitingForPoolMaintenance && n.resyncNeeded.IsZero() && n.stats.ExcessIPs > 0
 	if n.resource != nil {
 		releaseInProgress := len(n.resource.Status.IPAM.ReleaseIPs) > 0
 		needed = needed || releaseInProgress
@@ -624,7 +643,7 @@ func (n *NetResource) determineMaintenanceAction() (*maintenanceAction, error) {
 	// request may have been resolved in the meantime.
 	// we will disable the release of excess IPs for burstable ENI mode.
 	// getMaxIPBurstableIPCount() == 0 meanes that we are not in burstable ENI mode.
-	if n.manager.releaseExcessIPs && stats.ExcessIPs > 0 && n.getMaxIPBurstableIPCount() == 0 {
+	if n.releaseExcessIPs() && stats.ExcessIPs > 0 && n.getMaxIPBurstableIPCount() == 0 {
 		a.release = n.ops.PrepareIPRelease(stats.ExcessIPs, scopedLog)
 		return a, nil
 	}
@@ -899,7 +918,7 @@ func (n *NetResource) handleIPAllocation(ctx context.Context, a *maintenanceActi
 // returns instanceMutated which tracks if state changed with the cloud provider and is used
 // to determine if IPAM pool maintainer trigger func needs to be invoked.
 func (n *NetResource) maintainIPPool(ctx context.Context) (instanceMutated bool, err error) {
-	if n.manager.releaseExcessIPs {
+	if n.releaseExcessIPs() {
 		n.removeStaleReleaseIPs()
 	}
 
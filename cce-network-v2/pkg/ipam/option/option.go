@@ -47,6 +47,10 @@ const (
 	IPAMDelegatedPlugin = "delegated-plugin"
 )
 
+// IPAMHealthEndpoint is the suffix of the owner of the IPs allocated to the
+// health endpoint of the node
+const IPAMHealthEndpoint = "cce-health"
+
 const (
 	IPAMMarkForRelease  = "marked-for-release"
 	IPAMReadyForRelease = "ready-for-release"
@@ -56,6 +56,19 @@ type AllocationResult struct {
 	InterfaceNumber string
 }
 
+// NetworkAttachmentResult is the result of the allocation of a secondary
+// interface of a pod
+type NetworkAttachmentResult struct {
+	// Interface is the name of the interface in the pod
+	Interface string
+
+	IPv4 *AllocationResult
+	IPv6 *AllocationResult
+
+	// Routes are the CIDRs routed through the interface in the pod
+	Routes []string
+}
+
 // Allocator is the interface for an IP allocator implementation
 type Allocator interface {
 	// Allocate allocates a specific IP or fails
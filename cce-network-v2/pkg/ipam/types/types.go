@@ -141,6 +141,13 @@ type IPAMSpec struct {
 	//
 	// +kubebuilder:validation:Minimum=0
 	PodCIDRReleaseThreshold int `json:"pod-cidr-release-threshold,omitempty"`
+
+	// ReleaseExcessIPs overrides the release-excess-ips setting of
+	// cce-operator for this node. When it is nil, the setting of
+	// cce-operator is used.
+	//
+	// +optional
+	ReleaseExcessIPs *bool `json:"release-excess-ips,omitempty"`
 }
 
 // IPReleaseStatus  defines the valid states in IP release handshake
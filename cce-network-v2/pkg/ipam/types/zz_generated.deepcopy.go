@@ -73,6 +73,11 @@ func (in *IPAMSpec) DeepCopyInto(out *IPAMSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReleaseExcessIPs != nil {
+		in, out := &in.ReleaseExcessIPs, &out.ReleaseExcessIPs
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
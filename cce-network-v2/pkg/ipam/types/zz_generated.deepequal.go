@@ -116,6 +116,13 @@ func (in *IPAMSpec) DeepEqual(other *IPAMSpec) bool {
 	if in.PodCIDRReleaseThreshold != other.PodCIDRReleaseThreshold {
 		return false
 	}
+	if (in.ReleaseExcessIPs == nil) != (other.ReleaseExcessIPs == nil) {
+		return false
+	} else if in.ReleaseExcessIPs != nil {
+		if *in.ReleaseExcessIPs != *other.ReleaseExcessIPs {
+			return false
+		}
+	}
 
 	return true
 }
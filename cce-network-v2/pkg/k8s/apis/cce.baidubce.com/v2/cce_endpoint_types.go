@@ -48,6 +48,37 @@ type EndpointNetworkSpec struct {
 	IPAllocation   *IPAllocation      `json:"ipAllocation,omitempty"`
 	Bindwidth      *BindwidthOption   `json:"bindwidth,omitempty"`
 	EgressPriority *EgressPriorityOpt `json:"egressPriority,omitempty"`
+
+	// Attachment is set on the endpoints of the secondary interfaces of a pod,
+	// which are requested by the network attachment annotation of the pod.
+	// The endpoint of the primary interface of the pod does not have it.
+	Attachment *NetworkAttachment `json:"attachment,omitempty"`
+
+	// SecurityGroups are the security groups bound to the ENI used exclusively
+	// by the endpoint, which are requested by the security group annotations
+	// of the pod. They only take effect in the Primary use mode of ENI.
+	SecurityGroups *EndpointSecurityGroups `json:"securityGroups,omitempty"`
+}
+
+// EndpointSecurityGroups are the security groups of an endpoint. Only one of
+// normal and enterprise security groups can be set.
+type EndpointSecurityGroups struct {
+	// SecurityGroupIDs are the IDs of the normal security groups
+	SecurityGroupIDs []string `json:"securityGroupIds,omitempty"`
+	// EnterpriseSecurityGroupIDs are the IDs of the enterprise security groups
+	EnterpriseSecurityGroupIDs []string `json:"enterpriseSecurityGroupIds,omitempty"`
+}
+
+// NetworkAttachment is a secondary interface of a pod. The IPs of the
+// interface are allocated from the subnets of the PSTS.
+type NetworkAttachment struct {
+	// Interface is the name of the interface in the pod, such as eth1
+	Interface string `json:"interface"`
+	// PSTSName is the name of the PSTS in the namespace of the pod which
+	// the IPs of the interface are allocated from
+	PSTSName string `json:"psts"`
+	// Routes are the CIDRs routed through the interface in the pod
+	Routes []string `json:"routes,omitempty"`
 }
 
 // EndpointStatus is the status of a CCE endpoint.
@@ -120,6 +120,14 @@ type ENIStatus struct {
 	// ENI devices
 	EndpointReference *ObjectReference `json:"endpointReference,omitempty"`
 
+	// SecurityGroupBinding is the result of binding the security groups
+	// requested by the endpoint to the ENI in Primary use mode. It is removed
+	// after the security groups of the node are restored to the ENI when the
+	// endpoint releases the ENI.
+	//
+	// +optional
+	SecurityGroupBinding *ENISecurityGroupBinding `json:"securityGroupBinding,omitempty"`
+
 	// +kubebuilder:default:=Pending
 	CCEStatus CCEENIStatus `json:"CCEStatus"`
 	// +kubebuilder:validation:MaxItems=20
@@ -136,6 +144,17 @@ type ENIStatus struct {
 	LendBorrowedIPCount int `json:"lendBorrowedIPCount,omitempty"`
 }
 
+// ENISecurityGroupBinding is the security groups of the endpoint bound to
+// the ENI
+type ENISecurityGroupBinding struct {
+	StatusChange `json:",inline"`
+
+	// EndpointUID is the UID of the endpoint requesting the security groups
+	EndpointUID string `json:"endpointUID"`
+
+	EndpointSecurityGroups `json:",inline"`
+}
+
 // ENIStatusChange history of ENIStatus. This is used to track changes
 type ENIStatusChange struct {
 	StatusChange `json:",inline"`
@@ -125,6 +125,13 @@ type NetResourceSpec struct {
 	//
 	// +kubebuilder:validation:Optional
 	ENI *api.ENISpec `json:"eni,omitempty"`
+
+	// HealthAddressing is the addressing information of the health endpoint
+	// of the node, it is used by the agents of other nodes to check the
+	// connectivity to the pods of this node.
+	//
+	// +kubebuilder:validation:Optional
+	HealthAddressing HealthAddressingSpec `json:"health,omitempty"`
 }
 
 // HealthAddressingSpec is the addressing information required to do
@@ -135,7 +142,7 @@ type HealthAddressingSpec struct {
 	// +kubebuilder:validation:Optional
 	IPv4 string `json:"ipv4,omitempty"`
 
-	// IPv6 is the IPv6 address of the IPv4 health endpoint.
+	// IPv6 is the IPv6 address of the IPv6 health endpoint.
 	//
 	// +kubebuilder:validation:Optional
 	IPv6 string `json:"ipv6,omitempty"`
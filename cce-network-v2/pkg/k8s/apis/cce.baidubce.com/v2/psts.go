@@ -88,12 +88,12 @@ type PodSubnetTopologySpreadSpec struct {
 	// It's a required field. Default value is 1 and 0 is not allowed.
 	MaxSkew int32 `json:"maxSkew,omitempty"`
 
-	// +kubebuilder:default:=DoNotSchedule
+	// +kubebuilder:default:=ScheduleAnyway
 
 	// WhenUnsatisfiable indicates how to deal with a pod if it doesn't satisfy
 	// the spread constraint.
-	// - DoNotSchedule (default) tells the scheduler not to schedule it
-	// - ScheduleAnyway tells the scheduler to still schedule it
+	// - DoNotSchedule tells the scheduler not to schedule it
+	// - ScheduleAnyway (default) tells the scheduler to still schedule it
 	// It's considered as "Unsatisfiable" if and only if placing incoming pod on any
 	// topology violates "MaxSkew".
 	// For example, in a 3-zone cluster, MaxSkew is set to 1, and pods with the same
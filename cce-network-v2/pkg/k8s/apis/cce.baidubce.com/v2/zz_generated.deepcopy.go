@@ -396,6 +396,24 @@ func (in *ENIList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ENISecurityGroupBinding) DeepCopyInto(out *ENISecurityGroupBinding) {
+	*out = *in
+	in.StatusChange.DeepCopyInto(&out.StatusChange)
+	in.EndpointSecurityGroups.DeepCopyInto(&out.EndpointSecurityGroups)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ENISecurityGroupBinding.
+func (in *ENISecurityGroupBinding) DeepCopy() *ENISecurityGroupBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(ENISecurityGroupBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ENISpec) DeepCopyInto(out *ENISpec) {
 	*out = *in
@@ -421,6 +439,11 @@ func (in *ENIStatus) DeepCopyInto(out *ENIStatus) {
 		*out = new(ObjectReference)
 		**out = **in
 	}
+	if in.SecurityGroupBinding != nil {
+		in, out := &in.SecurityGroupBinding, &out.SecurityGroupBinding
+		*out = new(ENISecurityGroupBinding)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CCEStatusChangeLog != nil {
 		in, out := &in.CCEStatusChangeLog, &out.CCEStatusChangeLog
 		*out = make([]ENIStatusChange, len(*in))
@@ -515,6 +538,16 @@ func (in *EndpointNetworkSpec) DeepCopyInto(out *EndpointNetworkSpec) {
 		*out = new(EgressPriorityOpt)
 		**out = **in
 	}
+	if in.Attachment != nil {
+		in, out := &in.Attachment, &out.Attachment
+		*out = new(NetworkAttachment)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityGroups != nil {
+		in, out := &in.SecurityGroups, &out.SecurityGroups
+		*out = new(EndpointSecurityGroups)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -555,6 +588,32 @@ func (in *EndpointNetworking) DeepCopy() *EndpointNetworking {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointSecurityGroups) DeepCopyInto(out *EndpointSecurityGroups) {
+	*out = *in
+	if in.SecurityGroupIDs != nil {
+		in, out := &in.SecurityGroupIDs, &out.SecurityGroupIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EnterpriseSecurityGroupIDs != nil {
+		in, out := &in.EnterpriseSecurityGroupIDs, &out.EnterpriseSecurityGroupIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointSecurityGroups.
+func (in *EndpointSecurityGroups) DeepCopy() *EndpointSecurityGroups {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointSecurityGroups)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EndpointSpec) DeepCopyInto(out *EndpointSpec) {
 	*out = *in
@@ -804,6 +863,7 @@ func (in *NetResourceSpec) DeepCopyInto(out *NetResourceSpec) {
 		*out = new(api.ENISpec)
 		(*in).DeepCopyInto(*out)
 	}
+	out.HealthAddressing = in.HealthAddressing
 	return
 }
 
@@ -846,6 +906,27 @@ func (in *NetResourceStatus) DeepCopy() *NetResourceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkAttachment) DeepCopyInto(out *NetworkAttachment) {
+	*out = *in
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkAttachment.
+func (in *NetworkAttachment) DeepCopy() *NetworkAttachment {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkAttachment)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeAddress) DeepCopyInto(out *NodeAddress) {
 	*out = *in
@@ -14,6 +14,8 @@ const (
 	BurstableMehrfachENIConfigKey           = "burstable-mehrfach-eni"
 	ReleaseExcessIPsConfigKey               = "release-excess-ips"
 	ExtCNIPluginsConfigKey                  = "ext-cni-plugins"
+	UsePrimaryAddressConfigKey              = "use-eni-primary-address"
+	RouteTableOffsetConfigKey               = "route-table-offset"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -32,6 +34,11 @@ type NetResourceConfigSetList struct {
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:categories={cce},singular="netresourceconfigset",path="netresourceconfigsets",scope="Cluster",shortName={nrcs}
 // +kubebuilder:storageversion
+//
+// +kubebuilder:printcolumn:JSONPath=".spec.priority",description="priority of nrcs",name="Priority",type=integer
+// +kubebuilder:printcolumn:JSONPath=".status.nodeCount",description="number of nodes selected by nrcs",name="Nodes",type=integer
+// +kubebuilder:printcolumn:JSONPath=".status.effectiveNodeCount",description="number of nodes resolved to nrcs",name="Effective",type=integer
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",name="Age",type=date
 
 // NetResourceConfigSet describes how to distribute network resources configuration to nodes.
 type NetResourceConfigSet struct {
@@ -52,7 +59,8 @@ type NetResourceConfigSetSpec struct {
 	// Priority describes which object the target pod should use when multiple
 	// objects affect a pod at the same time. The higher the priority value,
 	// the earlier the object is configured. When multiple objects have the same
-	// priority value, only the configuration of the first object is taken.
+	// priority value, only the configuration of the object with the smallest
+	// name is taken.
 	Priority int32 `json:"priority,omitempty"`
 
 	// Agent is the configuration of the agent.
@@ -63,12 +71,28 @@ type NetResourceConfigSetSpec struct {
 
 type NetResourceConfigSetStatus struct {
 	// AgentVersion is the config version of agent.
-	// key is name of node
-	// value is resiversion of nrcs
+	// key is name of node which resolved to this NRCS
+	// value is resiversion of nrcs which has been applied by the agent,
+	// it is empty if the agent has not applied this NRCS yet
 	AgentVersion map[string]string `json:"agentVersion,omitempty"`
 
+	// AgentVersionCount is the number of agents which have applied each
+	// version of this NRCS.
+	// key is resiversion of nrcs
+	AgentVersionCount map[string]int32 `json:"agentVersionCount,omitempty"`
+
 	// NodeCount is the number of nodes was selected by this NRCS.
 	NodeCount int32 `json:"nodeCount,omitempty"`
+
+	// EffectiveNodeCount is the number of nodes which resolved to this NRCS.
+	// A node selected by multiple NRCS only resolves to one of them.
+	EffectiveNodeCount int32 `json:"effectiveNodeCount,omitempty"`
+
+	// PriorityConflicts lists the nodes which are selected by this NRCS and
+	// another NRCS with the same priority.
+	// key is name of node
+	// value is name of the NRCS which the node resolved to
+	PriorityConflicts map[string]string `json:"priorityConflicts,omitempty"`
 }
 
 type AgentConfig struct {
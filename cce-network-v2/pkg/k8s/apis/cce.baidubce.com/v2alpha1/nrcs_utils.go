@@ -0,0 +1,53 @@
+package v2alpha1
+
+import (
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// SelectNode returns true if the selector of the NRCS matches the labels of node
+func (nrcs *NetResourceConfigSet) SelectNode(nodeLabels map[string]string) (bool, error) {
+	selector, err := metav1.LabelSelectorAsSelector(nrcs.Spec.Selector)
+	if err != nil {
+		return false, err
+	}
+	return selector.Matches(labels.Set(nodeLabels)), nil
+}
+
+// ResolveNodeNrcs find the NetResourceConfigSet which takes effect on the node.
+// The NRCS with the highest priority is taken. When multiple NRCS have the same
+// priority, the one with the smallest name is taken, so that agent and operator
+// always resolve the same NRCS for a node.
+// NRCS with invalid selector are ignored.
+//
+// return:
+//   - matched: the NRCS which takes effect on the node, nil if no NRCS selects the node
+//   - ties: other NRCS which select the node with the same priority as matched
+func ResolveNodeNrcs(nrcsList []*NetResourceConfigSet, nodeLabels map[string]string) (matched *NetResourceConfigSet, ties []*NetResourceConfigSet) {
+	var selected []*NetResourceConfigSet
+	for _, nrcs := range nrcsList {
+		if ok, err := nrcs.SelectNode(nodeLabels); err == nil && ok {
+			selected = append(selected, nrcs)
+		}
+	}
+	if len(selected) == 0 {
+		return nil, nil
+	}
+
+	sort.Slice(selected, func(i, j int) bool {
+		if selected[i].Spec.Priority != selected[j].Spec.Priority {
+			return selected[i].Spec.Priority > selected[j].Spec.Priority
+		}
+		return selected[i].Name < selected[j].Name
+	})
+	matched = selected[0]
+	for _, nrcs := range selected[1:] {
+		if nrcs.Spec.Priority != matched.Spec.Priority {
+			break
+		}
+		ties = append(ties, nrcs)
+	}
+	return matched, ties
+}
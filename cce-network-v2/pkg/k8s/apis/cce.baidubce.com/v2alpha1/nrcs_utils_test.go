@@ -0,0 +1,88 @@
+package v2alpha1
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestNrcs(name string, priority int32, matchLabels map[string]string) *NetResourceConfigSet {
+	return &NetResourceConfigSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: NetResourceConfigSetSpec{
+			Priority: priority,
+			Selector: &metav1.LabelSelector{MatchLabels: matchLabels},
+		},
+	}
+}
+
+func TestResolveNodeNrcs(t *testing.T) {
+	nodeLabels := map[string]string{"pool": "a", "zone": "zoneA"}
+	tests := []struct {
+		name        string
+		nrcsList    []*NetResourceConfigSet
+		wantMatched string
+		wantTies    []string
+	}{
+		{
+			name:     "no nrcs selects node",
+			nrcsList: []*NetResourceConfigSet{newTestNrcs("b", 0, map[string]string{"pool": "b"})},
+		},
+		{
+			name: "highest priority wins",
+			nrcsList: []*NetResourceConfigSet{
+				newTestNrcs("low", 1, map[string]string{"pool": "a"}),
+				newTestNrcs("high", 10, map[string]string{"zone": "zoneA"}),
+				newTestNrcs("other", 100, map[string]string{"pool": "b"}),
+			},
+			wantMatched: "high",
+		},
+		{
+			name: "same priority resolves to smallest name",
+			nrcsList: []*NetResourceConfigSet{
+				newTestNrcs("nrcs-c", 5, map[string]string{"pool": "a"}),
+				newTestNrcs("nrcs-a", 5, map[string]string{"zone": "zoneA"}),
+				newTestNrcs("nrcs-b", 5, nil),
+				newTestNrcs("nrcs-0", 1, nil),
+			},
+			wantMatched: "nrcs-a",
+			wantTies:    []string{"nrcs-b", "nrcs-c"},
+		},
+		{
+			name: "invalid selector is ignored",
+			nrcsList: []*NetResourceConfigSet{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "invalid"},
+					Spec: NetResourceConfigSetSpec{
+						Priority: 10,
+						Selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "pool", Operator: "bad"},
+						}},
+					},
+				},
+				newTestNrcs("valid", 0, map[string]string{"pool": "a"}),
+			},
+			wantMatched: "valid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matched, ties := ResolveNodeNrcs(tt.nrcsList, nodeLabels)
+			var matchedName string
+			if matched != nil {
+				matchedName = matched.Name
+			}
+			if matchedName != tt.wantMatched {
+				t.Errorf("ResolveNodeNrcs() matched = %q, want %q", matchedName, tt.wantMatched)
+			}
+			if len(ties) != len(tt.wantTies) {
+				t.Fatalf("ResolveNodeNrcs() ties = %d, want %d", len(ties), len(tt.wantTies))
+			}
+			for i := range ties {
+				if ties[i].Name != tt.wantTies[i] {
+					t.Errorf("ResolveNodeNrcs() ties[%d] = %q, want %q", i, ties[i].Name, tt.wantTies[i])
+				}
+			}
+		})
+	}
+}
@@ -319,6 +319,20 @@ func (in *NetResourceConfigSetStatus) DeepCopyInto(out *NetResourceConfigSetStat
 			(*out)[key] = val
 		}
 	}
+	if in.AgentVersionCount != nil {
+		in, out := &in.AgentVersionCount, &out.AgentVersionCount
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PriorityConflicts != nil {
+		in, out := &in.PriorityConflicts, &out.PriorityConflicts
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -16,11 +16,16 @@
 package k8s
 
 import (
+	"encoding/json"
+	"fmt"
+	"net"
 	"strconv"
+	"strings"
 
 	ccev2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 const (
@@ -76,6 +81,13 @@ const (
 	// VPCIDLabel is the label used to store the VPC ID of the node.
 	VPCIDLabel = "cce.baidubce.com/vpc-id"
 
+	// AnnotationNrcsName is the annotation used to store the name of NetResourceConfigSet
+	// which has been applied to the NetResourceSet by agent.
+	AnnotationNrcsName = "network.cce.baidubce.com/nrcs"
+	// AnnotationNrcsVersion is the annotation used to store the resource version of
+	// NetResourceConfigSet which has been applied to the NetResourceSet by agent.
+	AnnotationNrcsVersion = "network.cce.baidubce.com/nrcs-version"
+
 	// AnnotationIPResourceCapacitySynced is the annotation used to store the ip resource capacity synced status of the node.
 	AnnotationIPResourceCapacitySynced = "cce.baidubce.com/ip-resource-capacity-synced"
 
@@ -96,6 +108,18 @@ var (
 	// annotation for PodSubnetTopologySpread
 	AnnotationPodSubnetTopologySpread = CCEPrefix + "PodSubnetTopologySpread"
 
+	// AnnotationPodNetworks is the annotation of the pod requesting the secondary
+	// interfaces, the value is a json list like
+	// [{"interface":"eth1","psts":"psts-storage","routes":["10.10.0.0/16"]}]
+	AnnotationPodNetworks = CCEPrefix + "networks"
+
+	// AnnotationPodSecurityGroupIDs and AnnotationPodEnterpriseSecurityGroupIDs
+	// are the annotations of the pod choosing the normal or enterprise security
+	// groups of the ENI used exclusively by the pod, the value is a comma
+	// separated list of security group IDs like "g-xxx,g-yyy"
+	AnnotationPodSecurityGroupIDs           = CCEPrefix + "security-group-ids"
+	AnnotationPodEnterpriseSecurityGroupIDs = CCEPrefix + "enterprise-security-group-ids"
+
 	// cce defined k8s resource name
 	ResourceIPForNode      = corev1.ResourceName(CCEPrefix + "ip")
 	ResourceENIForNode     = corev1.ResourceName(CCEPrefix + "eni")
@@ -119,6 +143,10 @@ var (
 	// cce defined net resource set annotations in k8s
 	AnnotationRDMAInfoMacAddress  = CCEPrefix + "rdma-mac-address"
 	AnnotationRDMAInfoVifFeatures = CCEPrefix + "rdma-vif-features"
+
+	// AnnotationTraceContext is the W3C traceparent of the CNI request which
+	// created the CCEEndpoint, the operator traces the IP allocation with it
+	AnnotationTraceContext = CCEPrefix + "trace-context"
 )
 
 // crossvpc labels
@@ -170,6 +198,92 @@ func ExtractFixedIPTTLSeconds(pod *corev1.Pod) int64 {
 	return 0
 }
 
+// maxInterfaceNameLen is the max length of the name of a link, IFNAMSIZ - 1
+const maxInterfaceNameLen = 15
+
+// ExtractPodNetworkAttachments extracts the secondary interfaces requested by
+// the annotation of the pod. It returns nil if the pod does not request any.
+func ExtractPodNetworkAttachments(pod *corev1.Pod) ([]ccev2.NetworkAttachment, error) {
+	v, ok := pod.Annotations[AnnotationPodNetworks]
+	if !ok || strings.TrimSpace(v) == "" {
+		return nil, nil
+	}
+
+	var attachments []ccev2.NetworkAttachment
+	if err := json.Unmarshal([]byte(v), &attachments); err != nil {
+		return nil, fmt.Errorf("invalid annotation %s: %w", AnnotationPodNetworks, err)
+	}
+
+	interfaces := make(map[string]bool)
+	for _, attachment := range attachments {
+		name := attachment.Interface
+		if errs := validation.IsDNS1123Label(name); len(errs) != 0 || len(name) > maxInterfaceNameLen {
+			return nil, fmt.Errorf("invalid interface name %q in annotation %s", name, AnnotationPodNetworks)
+		}
+		if name == "eth0" || name == "lo" {
+			return nil, fmt.Errorf("interface %s in annotation %s is reserved", name, AnnotationPodNetworks)
+		}
+		if interfaces[name] {
+			return nil, fmt.Errorf("duplicate interface %s in annotation %s", name, AnnotationPodNetworks)
+		}
+		interfaces[name] = true
+
+		if attachment.PSTSName == "" {
+			return nil, fmt.Errorf("psts of interface %s in annotation %s is empty", name, AnnotationPodNetworks)
+		}
+		for _, route := range attachment.Routes {
+			if _, _, err := net.ParseCIDR(route); err != nil {
+				return nil, fmt.Errorf("invalid route %q of interface %s in annotation %s", route, name, AnnotationPodNetworks)
+			}
+		}
+	}
+	return attachments, nil
+}
+
+// ExtractPodSecurityGroups extracts the security groups requested by the
+// annotations of the pod. It returns nil if the pod does not request any.
+func ExtractPodSecurityGroups(pod *corev1.Pod) (*ccev2.EndpointSecurityGroups, error) {
+	sgs, err := splitSecurityGroupIDs(pod.Annotations[AnnotationPodSecurityGroupIDs], AnnotationPodSecurityGroupIDs)
+	if err != nil {
+		return nil, err
+	}
+	esgs, err := splitSecurityGroupIDs(pod.Annotations[AnnotationPodEnterpriseSecurityGroupIDs], AnnotationPodEnterpriseSecurityGroupIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(sgs) == 0 && len(esgs) == 0 {
+		return nil, nil
+	}
+	// an ENI is bound to either normal or enterprise security groups
+	if len(sgs) != 0 && len(esgs) != 0 {
+		return nil, fmt.Errorf("annotations %s and %s can not be used together", AnnotationPodSecurityGroupIDs, AnnotationPodEnterpriseSecurityGroupIDs)
+	}
+	return &ccev2.EndpointSecurityGroups{
+		SecurityGroupIDs:           sgs,
+		EnterpriseSecurityGroupIDs: esgs,
+	}, nil
+}
+
+func splitSecurityGroupIDs(v, annotation string) ([]string, error) {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, id := range strings.Split(v, ",") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		if strings.ContainsAny(id, " \t") {
+			return nil, fmt.Errorf("invalid security group id %q in annotation %s", id, annotation)
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("duplicate security group id %s in annotation %s", id, annotation)
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
 func HaveFixedIPLabel(obj metav1.Object) bool {
 	if obj == nil {
 		return false
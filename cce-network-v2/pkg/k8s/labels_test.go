@@ -5,6 +5,7 @@ import (
 
 	ccev2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func TestMatchResourceType(t *testing.T) {
@@ -58,3 +59,127 @@ func TestMatchResourceType(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractPodNetworkAttachments(t *testing.T) {
+	tests := []struct {
+		name       string
+		annotation string
+		expected   []ccev2.NetworkAttachment
+		wantErr    bool
+	}{
+		{
+			name: "no annotation",
+		},
+		{
+			name:       "two interfaces",
+			annotation: `[{"interface":"eth1","psts":"psts-storage","routes":["10.10.0.0/16"]},{"interface":"eth2","psts":"psts-mgmt"}]`,
+			expected: []ccev2.NetworkAttachment{
+				{Interface: "eth1", PSTSName: "psts-storage", Routes: []string{"10.10.0.0/16"}},
+				{Interface: "eth2", PSTSName: "psts-mgmt"},
+			},
+		},
+		{
+			name:       "invalid json",
+			annotation: `{"interface":"eth1"}`,
+			wantErr:    true,
+		},
+		{
+			name:       "primary interface",
+			annotation: `[{"interface":"eth0","psts":"psts-storage"}]`,
+			wantErr:    true,
+		},
+		{
+			name:       "invalid interface name",
+			annotation: `[{"interface":"storage-interface","psts":"psts-storage"}]`,
+			wantErr:    true,
+		},
+		{
+			name:       "duplicate interface",
+			annotation: `[{"interface":"eth1","psts":"psts-storage"},{"interface":"eth1","psts":"psts-mgmt"}]`,
+			wantErr:    true,
+		},
+		{
+			name:       "empty psts",
+			annotation: `[{"interface":"eth1"}]`,
+			wantErr:    true,
+		},
+		{
+			name:       "invalid route",
+			annotation: `[{"interface":"eth1","psts":"psts-storage","routes":["10.10.0.0"]}]`,
+			wantErr:    true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pod := &corev1.Pod{}
+			if test.annotation != "" {
+				pod.Annotations = map[string]string{AnnotationPodNetworks: test.annotation}
+			}
+			attachments, err := ExtractPodNetworkAttachments(pod)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, attachments)
+		})
+	}
+}
+
+func TestExtractPodSecurityGroups(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    *ccev2.EndpointSecurityGroups
+		wantErr     bool
+	}{
+		{
+			name: "no annotation",
+		},
+		{
+			name:        "normal security groups",
+			annotations: map[string]string{AnnotationPodSecurityGroupIDs: "g-a, g-b,"},
+			expected:    &ccev2.EndpointSecurityGroups{SecurityGroupIDs: []string{"g-a", "g-b"}},
+		},
+		{
+			name:        "enterprise security groups",
+			annotations: map[string]string{AnnotationPodEnterpriseSecurityGroupIDs: "esg-a"},
+			expected:    &ccev2.EndpointSecurityGroups{EnterpriseSecurityGroupIDs: []string{"esg-a"}},
+		},
+		{
+			name:        "empty value",
+			annotations: map[string]string{AnnotationPodSecurityGroupIDs: " "},
+		},
+		{
+			name: "both kinds",
+			annotations: map[string]string{
+				AnnotationPodSecurityGroupIDs:           "g-a",
+				AnnotationPodEnterpriseSecurityGroupIDs: "esg-a",
+			},
+			wantErr: true,
+		},
+		{
+			name:        "duplicate id",
+			annotations: map[string]string{AnnotationPodSecurityGroupIDs: "g-a,g-a"},
+			wantErr:     true,
+		},
+		{
+			name:        "invalid id",
+			annotations: map[string]string{AnnotationPodSecurityGroupIDs: "g-a g-b"},
+			wantErr:     true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pod := &corev1.Pod{}
+			pod.Annotations = test.annotations
+			sgs, err := ExtractPodSecurityGroups(pod)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, sgs)
+		})
+	}
+}
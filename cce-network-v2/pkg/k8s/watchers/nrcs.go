@@ -5,21 +5,51 @@ import (
 	"fmt"
 	"sync"
 
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
 
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2alpha1"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/watchers/subscriber"
 )
 
 func (k *K8sWatcher) initNRCS(cceClient *k8s.K8sCCEClient, asyncControllers *sync.WaitGroup) {
 	apiGroup := k8sAPIGroupNRCSV2Alpha1
 	informer := cceClient.Informers.Cce().V2alpha1().NetResourceConfigSets().Informer()
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if nrcs, ok := obj.(*v2alpha1.NetResourceConfigSet); ok {
+				k.NetResourceConfigSetChain.OnAddNetResourceConfigSet(nrcs)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldNrcs, oldOk := oldObj.(*v2alpha1.NetResourceConfigSet)
+			newNrcs, newOk := newObj.(*v2alpha1.NetResourceConfigSet)
+			if oldOk && newOk && oldNrcs.ResourceVersion != newNrcs.ResourceVersion {
+				k.NetResourceConfigSetChain.OnUpdateNetResourceConfigSet(oldNrcs, newNrcs)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if nrcs, ok := obj.(*v2alpha1.NetResourceConfigSet); ok {
+				k.NetResourceConfigSetChain.OnDeleteNetResourceConfigSet(nrcs)
+			}
+		},
+	})
 	k.blockWaitGroupToSyncResources(k.stop, nil, informer.HasSynced, apiGroup)
 	go informer.Run(k.stop)
 	asyncControllers.Done()
 }
 
+// RegisterNetResourceConfigSetSubscriber allows registration of subscriber.NetResourceConfigSet implementations.
+// On NetResourceConfigSet events all registered subscriber.NetResourceConfigSet implementations will
+// have their event handling methods called in order of registration.
+func (k *K8sWatcher) RegisterNetResourceConfigSetSubscriber(s subscriber.NetResourceConfigSet) {
+	k.NetResourceConfigSetChain.Register(s)
+}
+
 // GetNodeNrcs find 1st priority matched NetResourceConfigSet which matches own node
 func (k *K8sWatcher) GetNodeNrcs(nodeName string) (*v2alpha1.NetResourceConfigSet, error) {
 	nrcsList, err := k8s.CCEClient().Informers.Cce().V2alpha1().NetResourceConfigSets().Lister().List(labels.Everything())
@@ -35,21 +65,14 @@ func (k *K8sWatcher) GetNodeNrcs(nodeName string) (*v2alpha1.NetResourceConfigSe
 		return nil, fmt.Errorf("failed to get k8s node %w", err)
 	}
 
-	var matched *v2alpha1.NetResourceConfigSet
-
-	for _, nrcs := range nrcsList {
-		selector, err := metav1.LabelSelectorAsSelector(nrcs.Spec.Selector)
-		if err != nil {
-			log.WithError(err).Warnf("invalid label selector in NetResourceConfigSet %q", nrcs.Name)
-			continue
-		}
-		if selector.Matches(labels.Set(k8sNode.Labels)) {
-			if matched == nil {
-				matched = nrcs
-			} else if matched.Spec.Priority < nrcs.Spec.Priority {
-				matched = nrcs
-			}
+	matched, ties := v2alpha1.ResolveNodeNrcs(nrcsList, k8sNode.Labels)
+	if matched != nil && len(ties) > 0 {
+		var tieNames []string
+		for _, tie := range ties {
+			tieNames = append(tieNames, tie.Name)
 		}
+		log.Warnf("NetResourceConfigSet %v have the same priority %d as %q, only %q takes effect",
+			tieNames, matched.Spec.Priority, matched.Name, matched.Name)
 	}
 	return matched, nil
 }
@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+package subscriber
+
+import (
+	"fmt"
+
+	ccev2alpha1 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2alpha1"
+)
+
+var _ NetResourceConfigSet = (*NetResourceConfigSetChain)(nil)
+
+// NetResourceConfigSet is implemented by event handlers responding to NetResourceConfigSet events.
+type NetResourceConfigSet interface {
+	OnAddNetResourceConfigSet(nrcs *ccev2alpha1.NetResourceConfigSet) error
+	OnUpdateNetResourceConfigSet(oldObj, newObj *ccev2alpha1.NetResourceConfigSet) error
+	OnDeleteNetResourceConfigSet(nrcs *ccev2alpha1.NetResourceConfigSet) error
+}
+
+// NetResourceConfigSetChain holds the subsciber.NetResourceConfigSet implementations
+// that are notified when reacting to NetResourceConfigSet resource / object changes
+// in the K8s watchers.
+//
+// NetResourceConfigSetChain itself is an implementation of subscriber.NetResourceConfigSet
+// with an additional Register method for attaching children subscribers to the
+// chain.
+type NetResourceConfigSetChain struct {
+	list
+
+	subs []NetResourceConfigSet
+}
+
+// NewNetResourceConfigSetChain creates a NetResourceConfigSetChain ready for its
+// Register method to be called.
+func NewNetResourceConfigSetChain() *NetResourceConfigSetChain {
+	return &NetResourceConfigSetChain{}
+}
+
+// Register registers s as a subscriber for reacting to NetResourceConfigSet objects
+// into the list.
+func (l *NetResourceConfigSetChain) Register(s NetResourceConfigSet) {
+	l.Lock()
+	l.subs = append(l.subs, s)
+	l.Unlock()
+}
+
+// OnAddNetResourceConfigSet notifies all the subscribers of an add event to a NetResourceConfigSet.
+func (l *NetResourceConfigSetChain) OnAddNetResourceConfigSet(nrcs *ccev2alpha1.NetResourceConfigSet) error {
+	l.RLock()
+	defer l.RUnlock()
+	errs := []error{}
+	for _, s := range l.subs {
+		if err := s.OnAddNetResourceConfigSet(nrcs); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("Errors: %v", errs)
+	}
+	return nil
+}
+
+// OnUpdateNetResourceConfigSet notifies all the subscribers of an update event to a NetResourceConfigSet.
+func (l *NetResourceConfigSetChain) OnUpdateNetResourceConfigSet(oldObj, newObj *ccev2alpha1.NetResourceConfigSet) error {
+	l.RLock()
+	defer l.RUnlock()
+	errs := []error{}
+	for _, s := range l.subs {
+		if err := s.OnUpdateNetResourceConfigSet(oldObj, newObj); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("Errors: %v", errs)
+	}
+	return nil
+}
+
+// OnDeleteNetResourceConfigSet notifies all the subscribers of a delete event to a NetResourceConfigSet.
+func (l *NetResourceConfigSetChain) OnDeleteNetResourceConfigSet(nrcs *ccev2alpha1.NetResourceConfigSet) error {
+	l.RLock()
+	defer l.RUnlock()
+	errs := []error{}
+	for _, s := range l.subs {
+		if err := s.OnDeleteNetResourceConfigSet(nrcs); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("Errors: %v", errs)
+	}
+	return nil
+}
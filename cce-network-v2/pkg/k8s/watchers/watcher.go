@@ -159,6 +159,12 @@ type K8sWatcher struct {
 	// have their event handling methods called in order of registration.
 	ENIChain *subscriber.ENIChain
 	eniStore cache.Store
+
+	// NetResourceConfigSetChain is the root of a notification chain for NetResourceConfigSet events.
+	// This NetResourceConfigSetChain allows registration of subscriber.NetResourceConfigSet implementations.
+	// On NetResourceConfigSet events all registered subscriber.NetResourceConfigSet implementations will
+	// have their event handling methods called in order of registration.
+	NetResourceConfigSetChain *subscriber.NetResourceConfigSetChain
 }
 
 func NewK8sWatcher() *K8sWatcher {
@@ -170,6 +176,8 @@ func NewK8sWatcher() *K8sWatcher {
 		NetResourceSetChain: subscriber.NewNetResourceSetChain(),
 		CCEEndpointChain:    subscriber.NewCCEEndpointChain(),
 		ENIChain:            subscriber.NewENIChain(),
+
+		NetResourceConfigSetChain: subscriber.NewNetResourceConfigSetChain(),
 	}
 }
 
@@ -35,6 +35,7 @@ type CounterVec interface {
 
 type GaugeVec interface {
 	WithLabelValues(lvls ...string) prometheus.Gauge
+	DeleteLabelValues(lvs ...string) bool
 	prometheus.Collector
 }
 
@@ -140,3 +141,5 @@ type gaugeVec struct {
 func (gv *gaugeVec) WithLabelValues(lvls ...string) prometheus.Gauge {
 	return NoOpGauge
 }
+
+func (gv *gaugeVec) DeleteLabelValues(...string) bool { return false }
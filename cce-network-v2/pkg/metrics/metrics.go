@@ -335,6 +335,10 @@ var (
 	// adjustment factor that was applied
 	APILimiterAdjustmentFactor = NoOpGaugeVec
 
+	// APILimiterThrottleFactor is the gauge representing the factor
+	// applied to the limits after the server throttled API requests
+	APILimiterThrottleFactor = NoOpGaugeVec
+
 	// APILimiterProcessedRequests is the counter of the number of
 	// processed (successful and failed) requests
 	APILimiterProcessedRequests = NoOpCounterVec
@@ -391,6 +395,7 @@ type Configuration struct {
 	APILimiterRequestsInFlight           bool
 	APILimiterRateLimit                  bool
 	APILimiterAdjustmentFactor           bool
+	APILimiterThrottleFactor             bool
 	APILimiterProcessedRequests          bool
 	CloudAPIRequestDurationMillisesconds bool
 
@@ -421,6 +426,7 @@ func DefaultMetrics() map[string]struct{} {
 		Namespace + "_" + SubsystemAPILimiter + "_requests_in_flight":                   {},
 		Namespace + "_" + SubsystemAPILimiter + "_rate_limit":                           {},
 		Namespace + "_" + SubsystemAPILimiter + "_adjustment_factor":                    {},
+		Namespace + "_" + SubsystemAPILimiter + "_throttle_factor":                      {},
 		Namespace + "_" + SubsystemAPILimiter + "_processed_requests_total":             {},
 		Namespace + "_cloud_api_request_duration_milliseconds":                          {},
 		Namespace + "_work_queue_len":                                                   {},
@@ -662,6 +668,17 @@ func CreateConfiguration(metricsEnabled []string) (Configuration, []prometheus.C
 			collectors = append(collectors, APILimiterAdjustmentFactor)
 			c.APILimiterAdjustmentFactor = true
 
+		case Namespace + "_" + SubsystemAPILimiter + "_throttle_factor":
+			APILimiterThrottleFactor = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Subsystem: SubsystemAPILimiter,
+				Name:      "throttle_factor",
+				Help:      "Current factor applied to the rate limits after throttling responses",
+			}, []string{"api_call"})
+
+			collectors = append(collectors, APILimiterThrottleFactor)
+			c.APILimiterThrottleFactor = true
+
 		case Namespace + "_" + SubsystemAPILimiter + "_processed_requests_total":
 			APILimiterProcessedRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
 				Namespace: Namespace,
@@ -722,6 +739,7 @@ func CreateConfiguration(metricsEnabled []string) (Configuration, []prometheus.C
 					LabelPath,
 					LabelError,
 					LabelAPIReturnCode,
+					LabelErrorReason,
 				},
 			)
 		case Namespace + "_work_queue_len":
@@ -33,6 +33,10 @@ func (*NodeDiscovery) OnDeleteNode(*corev1.Node, *lock.StoppableWaitGroup) error
 
 // OnUpdateNode implements subscriber.Node.
 func (u *NodeDiscovery) OnUpdateNode(oldObj *corev1.Node, newObj *corev1.Node, swg *lock.StoppableWaitGroup) error {
+	// labels of node decide which NRCS is used
+	if oldObj != nil && !comparator.MapStringEquals(oldObj.Labels, newObj.Labels) {
+		u.triggerNrcsSync()
+	}
 	return u.updateNetResourceSet(newObj)
 }
 
@@ -81,6 +81,13 @@ type NodeDiscovery struct {
 	localNodeLock         lock.Mutex
 	localNode             nodeTypes.Node
 	eventRecorder         record.EventRecorder
+
+	// defaultAgentConfig is the agent wide config from flags, it is used
+	// when the NRCS of local node does not set the config
+	defaultAgentConfig agentConfig
+	// appliedNrcs is the name and resource version of NRCS which has been
+	// applied to the agent
+	appliedNrcs appliedNrcs
 }
 
 func enableLocalNodeRoute() bool {
@@ -111,12 +118,16 @@ func NewNodeDiscovery(manager *nodemanager.Manager, mtuConfig mtu.Configuration,
 		localStateInitialized: make(chan struct{}),
 		NetConf:               netConf,
 		eventRecorder:         k8s.EventBroadcaster().NewRecorder(scheme.Scheme, corev1.EventSource{Component: nodeDiscoverySubsys}),
+		defaultAgentConfig: agentConfig{
+			mtu:           option.Config.MTU,
+			extCNIPlugins: option.Config.ExtCNIPluginsList,
+		},
 	}
 }
 
 // NewNodeDiscovery returns a pointer to new node discovery object
 func NewRdmaDiscovery(manager *nodemanager.Manager, mtuConfig mtu.Configuration, netConf *cnitypes.NetConf) *RdmaDiscovery {
-	return &RdmaDiscovery{NewNodeDiscovery(manager, mtuConfig, netConf)}
+	return &RdmaDiscovery{NodeDiscovery: NewNodeDiscovery(manager, mtuConfig, netConf)}
 }
 
 // StartDiscovery start configures the local node and starts node discovery. This is called on
@@ -260,7 +271,26 @@ func (n *NodeDiscovery) UpdateNetResourceSetResource() {
 	}
 
 	log.WithField(logfields.Node, nodeTypes.GetName()).Info("Creating or updating NetResourceSet resource")
+	if err := n.updateNetResourceSetResource(); err != nil {
+		log.WithError(err).Fatal("Could not create or update NetResourceSet resource")
+	}
+}
+
+// UpdateHealthAddressing publishes the addresses of the local health endpoint
+// in the NetResourceSet resource
+func (n *NodeDiscovery) UpdateHealthAddressing() error {
+	if !option.Config.AutoCreateNetResourceSetResource {
+		return nil
+	}
+
+	n.localNodeLock.Lock()
+	defer n.localNodeLock.Unlock()
+	return n.updateNetResourceSetResource()
+}
 
+// updateNetResourceSetResource creates or updates the NetResourceSet resource
+// of local node with retries on conflict
+func (n *NodeDiscovery) updateNetResourceSetResource() error {
 	cceClient := k8s.CCEClient()
 
 	performGet := true
@@ -298,9 +328,9 @@ func (n *NodeDiscovery) UpdateNetResourceSetResource() {
 					log.WithError(err).Warn("Unable to update NetResourceSet resource, will retry")
 					continue
 				}
-				log.WithError(err).Fatal("Unable to update NetResourceSet resource")
+				return fmt.Errorf("unable to update NetResourceSet resource: %w", err)
 			} else {
-				return
+				return nil
 			}
 		} else {
 			if _, err := cceClient.CceV2().NetResourceSets().Create(context.TODO(), nodeResource, metav1.CreateOptions{}); err != nil {
@@ -308,14 +338,14 @@ func (n *NodeDiscovery) UpdateNetResourceSetResource() {
 					log.WithError(err).Warn("Unable to create NetResourceSet resource, will retry")
 					continue
 				}
-				log.WithError(err).Fatal("Unable to create NetResourceSet resource")
+				return fmt.Errorf("unable to create NetResourceSet resource: %w", err)
 			} else {
 				log.Info("Successfully created NetResourceSet resource")
-				return
+				return nil
 			}
 		}
 	}
-	log.Fatal("Could not create or update NetResourceSet resource, despite retries")
+	return fmt.Errorf("could not create or update NetResourceSet resource, despite %d retries", maxRetryCount)
 }
 
 func (n *NodeDiscovery) mutateNodeResource(nodeResource *ccev2.NetResourceSet) error {
@@ -408,9 +438,24 @@ func (n *NodeDiscovery) mutateNodeResource(nodeResource *ccev2.NetResourceSet) e
 	}
 	nodeResource.Spec.InstanceID = instanceID
 
+	// the health addressing is kept until the health endpoint has been
+	// restored, so that it can reuse the ips after restart
+	if option.Config.HealthEndpointEnabled() {
+		if ip := node.GetEndpointHealthIPv4(); ip != nil {
+			nodeResource.Spec.HealthAddressing.IPv4 = ip.String()
+		}
+		if ip := node.GetEndpointHealthIPv6(); ip != nil {
+			nodeResource.Spec.HealthAddressing.IPv6 = ip.String()
+		}
+	} else {
+		nodeResource.Spec.HealthAddressing = ccev2.HealthAddressingSpec{}
+	}
+
 	nrcs, err := n.nrcsNodeGetter.GetNodeNrcs(nodeTypes.GetName())
 	if err != nil {
 		log.WithError(err).Warning("get node NRCs failed")
+	} else {
+		setNrcsAnnotations(nodeResource, nrcs)
 	}
 	switch option.Config.IPAM {
 	case ipamOption.IPAMClusterPool:
@@ -483,6 +528,7 @@ func (n *NodeDiscovery) refreshVpcENIConfiguration(nodeResource *ccev2.NetResour
 
 		eniUseMode        string
 		usePrimaryAddress bool
+		releaseExcessIPs  *bool
 	)
 
 	if mode, ok := nodeResource.Labels[k8s.LabelENIUseMode]; ok {
@@ -495,7 +541,7 @@ func (n *NodeDiscovery) refreshVpcENIConfiguration(nodeResource *ccev2.NetResour
 				"nrcs": nrcs.Name,
 				"spec": logfields.Repr(nrcs.Spec),
 			},
-		)
+		).Info("apply nrcs to nrs")
 		if nrcs.Spec.AgentConfig.IPPoolPreAllocateENI != nil {
 			preAllocateENI = *nrcs.Spec.AgentConfig.IPPoolPreAllocateENI
 		}
@@ -517,9 +563,6 @@ func (n *NodeDiscovery) refreshVpcENIConfiguration(nodeResource *ccev2.NetResour
 		if nrcs.Spec.AgentConfig.EniUseMode != nil {
 			eniUseMode = *nrcs.Spec.AgentConfig.EniUseMode
 		}
-		if nrcs.Spec.AgentConfig.ExtCniPlugins != nil {
-			option.Config.ExtCNIPluginsList = nrcs.Spec.AgentConfig.ExtCniPlugins
-		}
 
 		if len(nrcs.Spec.AgentConfig.EniSubnetIDs) > 0 {
 			subnetIDs = nrcs.Spec.AgentConfig.EniSubnetIDs
@@ -533,6 +576,7 @@ func (n *NodeDiscovery) refreshVpcENIConfiguration(nodeResource *ccev2.NetResour
 		if nrcs.Spec.AgentConfig.UsePrimaryAddress != nil {
 			usePrimaryAddress = *nrcs.Spec.AgentConfig.UsePrimaryAddress
 		}
+		releaseExcessIPs = nrcs.Spec.AgentConfig.ReleaseExcessIPs
 	}
 
 	// create new nrs if it is not set
@@ -567,6 +611,9 @@ func (n *NodeDiscovery) refreshVpcENIConfiguration(nodeResource *ccev2.NetResour
 			nodeResource.Spec.IPAM.MaxAboveWatermark = maxAboveWatermark
 		}
 
+		// nil means the setting of operator is used
+		nodeResource.Spec.IPAM.ReleaseExcessIPs = releaseExcessIPs
+
 		podsNum := k8sNode.Status.Capacity[k8sTypes.ResourcePods]
 		if nums, ok := podsNum.AsInt64(); ok {
 			nodeResource.Spec.IPAM.MaxAllocate = int(nums)
@@ -0,0 +1,227 @@
+package nodediscovery
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/controller"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s"
+	ccev2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2"
+	ccev2alpha1 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2alpha1"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/watchers/subscriber"
+	nodeTypes "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/node/types"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/option"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/plugins/pluginmanager"
+)
+
+const nrcsSyncControllerName = "sync-nrcs-with-agent-config"
+
+var _ subscriber.NetResourceConfigSet = &NodeDiscovery{}
+
+// agentConfig is the agent wide config which can be overwritten by NRCS
+type agentConfig struct {
+	mtu           int
+	extCNIPlugins []string
+}
+
+type appliedNrcs struct {
+	name            string
+	resourceVersion string
+}
+
+func newAppliedNrcs(nrcs *ccev2alpha1.NetResourceConfigSet) appliedNrcs {
+	if nrcs == nil {
+		return appliedNrcs{}
+	}
+	return appliedNrcs{name: nrcs.Name, resourceVersion: nrcs.ResourceVersion}
+}
+
+// ApplyNrcsAgentConfig applies the agent wide config of the NRCS which selects the
+// local node to option.Config. These config are read when agent configures the
+// IPAM, RDMA and CNI plugins, so this must be called after the NRCS informer has
+// synced and before them.
+func (n *NodeDiscovery) ApplyNrcsAgentConfig() {
+	nrcs, err := n.nrcsNodeGetter.GetNodeNrcs(nodeTypes.GetName())
+	if err != nil {
+		log.WithError(err).Warning("get node NRCs failed")
+		return
+	}
+	if nrcs != nil && nrcs.Spec.AgentConfig.EnableRDMA != nil {
+		option.Config.EnableRDMA = *nrcs.Spec.AgentConfig.EnableRDMA
+	}
+	n.applyAgentConfig(nrcs)
+	n.appliedNrcs = newAppliedNrcs(nrcs)
+}
+
+// applyAgentConfig applies the agent wide config which can be changed at runtime.
+// The config from flags is used if the NRCS does not set it.
+func (n *NodeDiscovery) applyAgentConfig(nrcs *ccev2alpha1.NetResourceConfigSet) {
+	option.Config.MTU = n.defaultAgentConfig.mtu
+	option.Config.ExtCNIPluginsList = n.defaultAgentConfig.extCNIPlugins
+	if nrcs == nil {
+		return
+	}
+
+	agentConfig := nrcs.Spec.AgentConfig
+	if agentConfig.ManualMTU != nil {
+		if *agentConfig.ManualMTU < 0 {
+			log.WithField("nrcs", nrcs.Name).Warnf("ignore negative mtu %d", *agentConfig.ManualMTU)
+		} else {
+			option.Config.MTU = *agentConfig.ManualMTU
+		}
+	}
+	if agentConfig.ExtCniPlugins != nil {
+		option.Config.ExtCNIPluginsList = agentConfig.ExtCniPlugins
+	}
+	log.WithFields(logrus.Fields{
+		"nrcs":          nrcs.Name,
+		"enableRDMA":    option.Config.EnableRDMA,
+		"mtu":           option.Config.MTU,
+		"extCNIPlugins": option.Config.ExtCNIPluginsList,
+	}).Info("apply agent config of nrcs success")
+}
+
+// StartNrcsSync starts a controller to apply the NRCS of local node when the
+// NRCS or the labels of node changed. This must be called after the
+// NetResourceSet of local node has been created.
+func (n *NodeDiscovery) StartNrcsSync() {
+	k8sCM.UpdateController(nrcsSyncControllerName,
+		controller.ControllerParams{
+			RunInterval:            option.Config.ResourceResyncInterval,
+			ErrorRetryBaseDuration: time.Second * 30,
+			DoFunc:                 n.syncNrcs,
+		})
+}
+
+func (n *NodeDiscovery) triggerNrcsSync() {
+	k8sCM.TriggerController(nrcsSyncControllerName)
+}
+
+// syncNrcs applies the config of NRCS which can be changed at runtime, and
+// reports the config which can not be changed by an event on the node.
+func (n *NodeDiscovery) syncNrcs(ctx context.Context) error {
+	n.localNodeLock.Lock()
+	defer n.localNodeLock.Unlock()
+
+	nrcs, err := n.nrcsNodeGetter.GetNodeNrcs(nodeTypes.GetName())
+	if err != nil {
+		return fmt.Errorf("failed to get node NRCS: %w", err)
+	}
+	applied := newAppliedNrcs(nrcs)
+	if applied == n.appliedNrcs {
+		return nil
+	}
+	scopedLog := log.WithFields(logrus.Fields{
+		"oldNrcs":    n.appliedNrcs.name,
+		"oldVersion": n.appliedNrcs.resourceVersion,
+		"nrcs":       applied.name,
+		"version":    applied.resourceVersion,
+	})
+	scopedLog.Info("NRCS of local node changed, start to apply config")
+
+	k8sNode, err := n.k8sNodeGetter.GetK8sNode(ctx, nodeTypes.GetName())
+	if err != nil {
+		return fmt.Errorf("failed to get k8s node: %w", err)
+	}
+
+	nodeResource, err := k8s.CCEClient().CceV2().NetResourceSets().Get(ctx, nodeTypes.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get NetResourceSet: %w", err)
+	}
+	if unsafe := unsafeNrcsConfig(nodeResource, nrcs, option.Config.EnableRDMA); len(unsafe) > 0 {
+		n.eventRecorder.Eventf(k8sNode, corev1.EventTypeWarning, "NrcsUnsafeChange",
+			"config %v of nrcs %s can not be changed on a running node and is ignored", unsafe, applied.name)
+		scopedLog.Warnf("config %v of nrcs can not be changed on a running node and is ignored", unsafe)
+	}
+
+	n.applyAgentConfig(nrcs)
+	if option.Config.AutoCreateNetResourceSetResource {
+		if err := n.updateNetResourceSetResource(); err != nil {
+			return err
+		}
+	}
+	if path := option.Config.WriteCNIConfigurationWhenReady; path != "" {
+		if _, err := pluginmanager.OverwriteCNIConfigList(path); err != nil {
+			return fmt.Errorf("failed to overwrite cni config: %w", err)
+		}
+	}
+
+	n.appliedNrcs = applied
+	n.eventRecorder.Eventf(k8sNode, corev1.EventTypeNormal, "NrcsApplied", "applied nrcs %q version %q", applied.name, applied.resourceVersion)
+	scopedLog.Info("apply NRCS of local node success")
+	return nil
+}
+
+// unsafeNrcsConfig returns the keys of config in nrcs which differ from the running
+// node but can not be changed at runtime. eni-use-mode, use-eni-primary-address and
+// route-table-offset only take effect when the NetResourceSet is created, and
+// enable-rdma only takes effect when agent starts.
+func unsafeNrcsConfig(nodeResource *ccev2.NetResourceSet, nrcs *ccev2alpha1.NetResourceConfigSet, enableRDMA bool) []string {
+	if nrcs == nil {
+		return nil
+	}
+	var (
+		agentConfig = nrcs.Spec.AgentConfig
+		unsafe      []string
+	)
+	if agentConfig.EnableRDMA != nil && *agentConfig.EnableRDMA != enableRDMA {
+		unsafe = append(unsafe, ccev2alpha1.EnableRDMAConfigKey)
+	}
+
+	eni := nodeResource.Spec.ENI
+	if eni == nil {
+		return unsafe
+	}
+	if agentConfig.EniUseMode != nil && *agentConfig.EniUseMode != eni.UseMode {
+		unsafe = append(unsafe, ccev2alpha1.ElasticNetworkInterfaceUseModeConfigKey)
+	}
+	if agentConfig.UsePrimaryAddress != nil {
+		usePrimaryAddress := eni.UsePrimaryAddress != nil && *eni.UsePrimaryAddress
+		if *agentConfig.UsePrimaryAddress != usePrimaryAddress {
+			unsafe = append(unsafe, ccev2alpha1.UsePrimaryAddressConfigKey)
+		}
+	}
+	if agentConfig.RouteTableOffset != nil && *agentConfig.RouteTableOffset > 0 &&
+		*agentConfig.RouteTableOffset != eni.RouteTableOffset {
+		unsafe = append(unsafe, ccev2alpha1.RouteTableOffsetConfigKey)
+	}
+	return unsafe
+}
+
+// setNrcsAnnotations records the NRCS applied to the NetResourceSet, so that
+// operator can report the config version of agents in the status of NRCS
+func setNrcsAnnotations(nodeResource *ccev2.NetResourceSet, nrcs *ccev2alpha1.NetResourceConfigSet) {
+	if nrcs == nil {
+		delete(nodeResource.Annotations, k8s.AnnotationNrcsName)
+		delete(nodeResource.Annotations, k8s.AnnotationNrcsVersion)
+		return
+	}
+	if nodeResource.Annotations == nil {
+		nodeResource.Annotations = make(map[string]string)
+	}
+	nodeResource.Annotations[k8s.AnnotationNrcsName] = nrcs.Name
+	nodeResource.Annotations[k8s.AnnotationNrcsVersion] = nrcs.ResourceVersion
+}
+
+// OnAddNetResourceConfigSet implements subscriber.NetResourceConfigSet.
+func (n *NodeDiscovery) OnAddNetResourceConfigSet(*ccev2alpha1.NetResourceConfigSet) error {
+	n.triggerNrcsSync()
+	return nil
+}
+
+// OnUpdateNetResourceConfigSet implements subscriber.NetResourceConfigSet.
+func (n *NodeDiscovery) OnUpdateNetResourceConfigSet(_, _ *ccev2alpha1.NetResourceConfigSet) error {
+	n.triggerNrcsSync()
+	return nil
+}
+
+// OnDeleteNetResourceConfigSet implements subscriber.NetResourceConfigSet.
+func (n *NodeDiscovery) OnDeleteNetResourceConfigSet(*ccev2alpha1.NetResourceConfigSet) error {
+	n.triggerNrcsSync()
+	return nil
+}
@@ -31,9 +31,11 @@ import (
 	bceutils "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/bce/utils"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/cidr"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/defaults"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/health/plugin"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/ipam"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s"
 	ccev2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/lock"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging/logfields"
 	nodeTypes "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/node/types"
@@ -106,6 +108,24 @@ func canUseIPVlanOnRdmaNode() (isCan bool, err error) {
 // RdmaDiscovery represents a node discovery action
 type RdmaDiscovery struct {
 	*NodeDiscovery
+
+	// discoveryErr is the error of the last discovery of the RDMA interfaces
+	discoveryErrLock lock.Mutex
+	discoveryErr     error
+}
+
+// Check implements plugin.HealthPlugin, it fails if the RDMA interfaces of
+// the node could not be discovered last time
+func (rd *RdmaDiscovery) Check() error {
+	rd.discoveryErrLock.Lock()
+	defer rd.discoveryErrLock.Unlock()
+	return rd.discoveryErr
+}
+
+func (rd *RdmaDiscovery) setDiscoveryErr(err error) {
+	rd.discoveryErrLock.Lock()
+	defer rd.discoveryErrLock.Unlock()
+	rd.discoveryErr = err
 }
 
 // start configures the local node and starts node discovery. This is called on
@@ -122,26 +142,13 @@ func (rd *RdmaDiscovery) StartDiscovery() {
 		return
 	}
 
-	nrcs, err := rd.nrcsNodeGetter.GetNodeNrcs(nodeTypes.GetName())
-	if err != nil {
-		log.WithError(err).Warning("get node NRCs failed")
-	}
-	if nrcs != nil {
-		if nrcs.Spec.AgentConfig.EnableRDMA != nil {
-			option.Config.EnableRDMA = *nrcs.Spec.AgentConfig.EnableRDMA
-			log.WithFields(
-				logrus.Fields{
-					"enableRDMA": option.Config.EnableRDMA,
-					"nrcs":       nrcs.Name,
-				},
-			).Info("update enable rdma flag success")
-		}
-	}
-
 	if !option.Config.EnableRDMA {
 		return
 	}
 	rdLog.Info("RDMA is enabled, will be starting rdma node discovery")
+	// the RDMA pods are only a part of the node, so failed discoveries must
+	// not restart the agent
+	plugin.RegisterCheck("rdma-discovery", rd, plugin.SeverityDegraded, plugin.DefaultCheckTimeout)
 
 	isCan, err := canUseIPVlanOnRdmaNode()
 	if err != nil {
@@ -217,6 +224,7 @@ func (rd *RdmaDiscovery) UpdateNetResourceSetResource() {
 
 	nodeName := nodeTypes.GetName()
 	rdmaIFs, err := bceutils.GetRdmaIFsInfo(nodeName, rdLog)
+	rd.setDiscoveryErr(err)
 	if err != nil {
 		rdLog.WithError(err).WithField("nodeAddressing", nodeName).Warning("Failed to get rdma IFs info")
 		return
@@ -40,10 +40,12 @@ import (
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/defaults"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/ip"
 	ipamOption "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/ipam/option"
+	ccev2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/lock"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging/logfields"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/metrics"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/tracing"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/version"
 )
 
@@ -60,6 +62,16 @@ const (
 	// ClusterHealthPort is the TCP port for cluster-wide network connectivity health API
 	ClusterHealthPort = "cluster-health-port"
 
+	// ClusterHealthProbeInterval is the interval between the connectivity probes of all nodes
+	ClusterHealthProbeInterval = "cluster-health-probe-interval"
+
+	// DatapathMode is the datapath mode of the pods using the secondary IPs
+	// of the shared ENIs, veth or ipvlan
+	DatapathMode = "datapath-mode"
+
+	// IpvlanMode is the mode of the ipvlan slaves in the ipvlan datapath, l2 or l3
+	IpvlanMode = "ipvlan-mode"
+
 	// AgentLabels are additional labels to identify this agent
 	AgentLabels = "agent-labels"
 
@@ -186,6 +198,33 @@ const (
 	// PluginIpRetryTimes is Number of retries for the plugin to request agent IPAM allocation
 	PluginIpRetryTimes = "plugin-ip-request-retry-times"
 
+	// PluginVerifyMode is the mode of the connectivity verification of the
+	// pods after their network is set up, off, warn or enforce
+	PluginVerifyMode = "plugin-verify-mode"
+
+	// PluginVerifyTargets are the targets pinged in the connectivity
+	// verification, gateway, node or IP addresses
+	PluginVerifyTargets = "plugin-verify-targets"
+
+	// PluginVerifyRetries is the number of ICMP packets sent to each target
+	PluginVerifyRetries = "plugin-verify-retries"
+
+	// PluginVerifyTimeout is the timeout of waiting for each ICMP reply
+	PluginVerifyTimeout = "plugin-verify-timeout"
+
+	// EnableTracing enables exporting the OpenTelemetry spans of the pod
+	// network allocation by the agent and the CNI plugins
+	EnableTracing = "enable-tracing"
+
+	// TracingEndpoint is the host:port of the OTLP/HTTP collector
+	TracingEndpoint = "tracing-endpoint"
+
+	// TracingInsecure exports the spans over HTTP instead of HTTPS
+	TracingInsecure = "tracing-insecure"
+
+	// TracingSampleRatio is the ratio of the pod network allocations traced
+	TracingSampleRatio = "tracing-sample-ratio"
+
 	// HostServicesTCP is the name of EnableHostServicesTCP config
 	HostServicesTCP = "tcp"
 
@@ -241,6 +280,10 @@ const (
 	// EnableRDMAName is the name of the option to enable RDMA support
 	EnableRDMAName = "enable-rdma"
 
+	// EnableMultiNetworkName is the name of the option to enable the secondary
+	// interfaces of the pods requested by the network attachment annotation
+	EnableMultiNetworkName = "enable-multi-network"
+
 	// EnableIPv6NDPName is the name of the option to enable IPv6 NDP support
 	EnableIPv6NDPName = "enable-ipv6-ndp"
 
@@ -492,6 +535,28 @@ func LogRegisteredOptions(entry *logrus.Entry) {
 	}
 }
 
+const (
+	// IpvlanModeL2 attaches the ipvlan slaves in L2 mode, the pods resolve
+	// the gateway of the ENI subnet themselves
+	IpvlanModeL2 = "l2"
+
+	// IpvlanModeL3 attaches the ipvlan slaves in L3 mode, the master routes
+	// all packets of the pods
+	IpvlanModeL3 = "l3"
+)
+
+const (
+	// PluginVerifyModeOff disables the connectivity verification
+	PluginVerifyModeOff = "off"
+
+	// PluginVerifyModeWarn records the failure of the verification on the
+	// endpoint without failing the pod
+	PluginVerifyModeWarn = "warn"
+
+	// PluginVerifyModeEnforce retries another IP if the verification fails
+	PluginVerifyModeEnforce = "enforce"
+)
+
 // IpvlanConfig is the configuration used by Daemon when in ipvlan mode.
 type IpvlanConfig struct {
 	MasterDeviceIndex int
@@ -528,6 +593,19 @@ type DaemonConfig struct {
 	// AgentHealthPort is the TCP port for agent health status API
 	AgentHealthPort int
 
+	// ClusterHealthPort is the TCP port for cluster-wide network connectivity health API
+	ClusterHealthPort int
+
+	// ClusterHealthProbeInterval is the interval between the connectivity probes of all nodes
+	ClusterHealthProbeInterval time.Duration
+
+	// DatapathMode is the datapath mode of the pods using the secondary IPs
+	// of the shared ENIs, veth or ipvlan
+	DatapathMode string
+
+	// IpvlanMode is the mode of the ipvlan slaves in the ipvlan datapath, l2 or l3
+	IpvlanMode string
+
 	// AgentLabels contains additional labels to identify this agent in monitor events.
 	AgentLabels []string
 
@@ -560,6 +638,27 @@ type DaemonConfig struct {
 
 	PluginIpRequestRetryTimes int
 
+	// PluginVerifyMode is the mode of the connectivity verification of the pods
+	PluginVerifyMode string
+
+	// PluginVerifyTargets are the targets pinged in the connectivity verification
+	PluginVerifyTargets []string
+
+	// PluginVerifyRetries is the number of ICMP packets sent to each target
+	PluginVerifyRetries int
+
+	// PluginVerifyTimeout is the timeout of waiting for each ICMP reply
+	PluginVerifyTimeout time.Duration
+
+	// TracingEndpoint is the host:port of the OTLP/HTTP collector
+	TracingEndpoint string
+
+	// TracingInsecure exports the spans over HTTP instead of HTTPS
+	TracingInsecure bool
+
+	// TracingSampleRatio is the ratio of the pod network allocations traced
+	TracingSampleRatio float64
+
 	// EnableMonitor enables the monitor unix domain socket server
 	EnableMonitor bool
 
@@ -610,6 +709,10 @@ type DaemonConfig struct {
 	// EnableRDMA is true when RDMA is enabled
 	EnableRDMA bool
 
+	// EnableMultiNetwork is true when the pods can request the secondary
+	// interfaces from the subnets of PSTS
+	EnableMultiNetwork bool
+
 	// IPv6MCastDevice is the name of device that joins IPv6's solicitation multicast group
 	IPv6MCastDevice string
 	// MonitorQueueSize is the size of the monitor event queue
@@ -622,6 +725,8 @@ type DaemonConfig struct {
 	EnableTracing              bool
 	IPv4Range                  string
 	IPv6Range                  string
+	IPv4ServiceRange           string
+	IPv6ServiceRange           string
 	K8sAPIServer               string
 	K8sKubeConfigPath          string
 	K8sClientBurst             int
@@ -931,6 +1036,43 @@ func (c *DaemonConfig) HealthCheckingEnabled() bool {
 	return c.EnableHealthChecking
 }
 
+// HealthEndpointEnabled returns true if the health endpoint of the node is
+// enabled. Its IPs are allocated from the IP pool of the node, so it is not
+// supported if the pods own the whole ENI in primary IP mode.
+func (c *DaemonConfig) HealthEndpointEnabled() bool {
+	if !c.EnableHealthChecking || !c.EnableEndpointHealthChecking {
+		return false
+	}
+	switch c.IPAM {
+	case ipamOption.IPAMClusterPool, ipamOption.IPAMClusterPoolV2, ipamOption.IPAMVpcRoute:
+		return true
+	case ipamOption.IPAMVpcEni:
+		return c.ENI != nil && c.ENI.UseMode == string(ccev2.ENIUseModeSecondaryIP)
+	}
+	return false
+}
+
+// IpvlanDatapathEnabled returns true if the pods are attached to the shared
+// ENIs as ipvlan slaves. The ipvlan datapath is only supported if the pods use
+// the secondary IPs of the ENIs, the veth datapath is used otherwise.
+func (c *DaemonConfig) IpvlanDatapathEnabled() bool {
+	return c.DatapathMode == string(models.DatapathModeIpvlan) &&
+		c.IPAM == ipamOption.IPAMVpcEni &&
+		c.ENI != nil && c.ENI.UseMode == string(ccev2.ENIUseModeSecondaryIP)
+}
+
+// ServiceCIDRs returns the Kubernetes service CIDRs set by the options, the
+// ranges detected automatically are not included
+func (c *DaemonConfig) ServiceCIDRs() []string {
+	var cidrs []string
+	for _, r := range []string{c.IPv4ServiceRange, c.IPv6ServiceRange} {
+		if r != "" && r != "auto" {
+			cidrs = append(cidrs, r)
+		}
+	}
+	return cidrs
+}
+
 // IPAMMode returns the IPAM mode
 func (c *DaemonConfig) IPAMMode() string {
 	return strings.ToLower(c.IPAM)
@@ -942,6 +1084,17 @@ func (c *DaemonConfig) TracingEnabled() bool {
 	return c.Opts.IsEnabled(PolicyTracing)
 }
 
+// TracingConfig returns the configuration of the OpenTelemetry exporter of the
+// agent, it is also written into the CNI configuration for the plugins
+func (c *DaemonConfig) TracingConfig() *tracing.Config {
+	return &tracing.Config{
+		Enabled:     c.EnableTracing,
+		Endpoint:    c.TracingEndpoint,
+		Insecure:    c.TracingInsecure,
+		SampleRatio: c.TracingSampleRatio,
+	}
+}
+
 // UnreachableRoutesEnabled returns true if unreachable routes is enabled
 func (c *DaemonConfig) UnreachableRoutesEnabled() bool {
 	return c.EnableUnreachableRoutes
@@ -1040,6 +1193,63 @@ func (c *DaemonConfig) Validate() error {
 		return err
 	}
 
+	if err := c.validateDatapathMode(); err != nil {
+		return err
+	}
+
+	if c.EnableMultiNetwork && c.IPAM != ipamOption.IPAMVpcEni {
+		return fmt.Errorf("option --%s is only supported with --%s=%s",
+			EnableMultiNetworkName, IPAM, ipamOption.IPAMVpcEni)
+	}
+
+	switch c.PluginVerifyMode {
+	case "", PluginVerifyModeOff, PluginVerifyModeWarn, PluginVerifyModeEnforce:
+	default:
+		return fmt.Errorf("invalid value '%s' of option --%s, must be %s, %s or %s",
+			c.PluginVerifyMode, PluginVerifyMode, PluginVerifyModeOff, PluginVerifyModeWarn, PluginVerifyModeEnforce)
+	}
+	for _, target := range c.PluginVerifyTargets {
+		if target != "gateway" && target != "node" && net.ParseIP(target) == nil {
+			return fmt.Errorf("invalid value '%s' of option --%s, must be gateway, node or an IP address",
+				target, PluginVerifyTargets)
+		}
+	}
+	if c.PluginVerifyRetries < 0 {
+		return fmt.Errorf("invalid value '%d' of option --%s", c.PluginVerifyRetries, PluginVerifyRetries)
+	}
+	if c.PluginVerifyTimeout < 0 {
+		return fmt.Errorf("invalid value '%s' of option --%s", c.PluginVerifyTimeout, PluginVerifyTimeout)
+	}
+	if c.TracingSampleRatio < 0 || c.TracingSampleRatio > 1 {
+		return fmt.Errorf("invalid value '%v' of option --%s, must be in [0, 1]", c.TracingSampleRatio, TracingSampleRatio)
+	}
+
+	return nil
+}
+
+// validateDatapathMode validates the datapath of pods. The service CIDRs must
+// be set explicitly for the ipvlan datapath, as the ClusterIP traffic would
+// leave through the ENI without the routes to the cce-hook veth.
+func (c *DaemonConfig) validateDatapathMode() error {
+	switch c.DatapathMode {
+	case "", string(models.DatapathModeVeth), string(models.DatapathModeIpvlan):
+	default:
+		return fmt.Errorf("invalid value '%s' of option --%s, must be %s or %s",
+			c.DatapathMode, DatapathMode, models.DatapathModeVeth, models.DatapathModeIpvlan)
+	}
+	if c.DatapathMode != string(models.DatapathModeIpvlan) {
+		return nil
+	}
+	switch c.IpvlanMode {
+	case IpvlanModeL2, IpvlanModeL3:
+	default:
+		return fmt.Errorf("invalid value '%s' of option --%s, must be %s or %s",
+			c.IpvlanMode, IpvlanMode, IpvlanModeL2, IpvlanModeL3)
+	}
+	if len(c.ServiceCIDRs()) == 0 {
+		return fmt.Errorf("option --%s or --%s must be set to the service CIDR with --%s=%s",
+			IPv4ServiceRange, IPv6ServiceRange, DatapathMode, models.DatapathModeIpvlan)
+	}
 	return nil
 }
 
@@ -1124,6 +1334,10 @@ func (c *DaemonConfig) parseExcludedLocalAddresses(s []string) error {
 func (c *DaemonConfig) Populate() {
 	c.GopsPort = viper.GetInt(GopsPort)
 	c.AgentHealthPort = viper.GetInt(AgentHealthPort)
+	c.ClusterHealthPort = viper.GetInt(ClusterHealthPort)
+	c.ClusterHealthProbeInterval = viper.GetDuration(ClusterHealthProbeInterval)
+	c.DatapathMode = viper.GetString(DatapathMode)
+	c.IpvlanMode = viper.GetString(IpvlanMode)
 	c.AgentLabels = viper.GetStringSlice(AgentLabels)
 	c.AllowLocalhost = viper.GetString(AllowLocalhost)
 	c.AnnotateK8sNode = viper.GetBool(AnnotateK8sNode)
@@ -1136,14 +1350,18 @@ func (c *DaemonConfig) Populate() {
 	c.EnableIPv6 = viper.GetBool(EnableIPv6Name)
 	c.EnableIPv6NDP = viper.GetBool(EnableIPv6NDPName)
 	c.EnableRDMA = viper.GetBool(EnableRDMAName)
+	c.EnableMultiNetwork = viper.GetBool(EnableMultiNetworkName)
 	c.IPv6MCastDevice = viper.GetString(IPv6MCastDevice)
 	c.DisableCCEEndpointCRD = viper.GetBool(DisableCCEEndpointCRDName)
 	c.DisableENICRD = viper.GetBool(DisableENICRDName)
 	//c.EnableAutoDirectRouting = viper.GetBool(EnableAutoDirectRoutingName)
 	c.EnableEndpointRoutes = viper.GetBool(EnableEndpointRoutes)
 	c.EnableHealthChecking = viper.GetBool(EnableHealthChecking)
-	//c.EnableEndpointHealthChecking = viper.GetBool(EnableEndpointHealthChecking)
-	//c.EnableTracing = viper.GetBool(EnableTracing)
+	c.EnableEndpointHealthChecking = viper.GetBool(EnableEndpointHealthChecking)
+	c.EnableTracing = viper.GetBool(EnableTracing)
+	c.TracingEndpoint = viper.GetString(TracingEndpoint)
+	c.TracingInsecure = viper.GetBool(TracingInsecure)
+	c.TracingSampleRatio = viper.GetFloat64(TracingSampleRatio)
 	c.IPAM = viper.GetString(IPAM)
 	c.IPPoolMaxAboveWatermark = viper.GetInt(IPPoolMaxAboveWatermark)
 	c.IPPoolMinAllocateIPs = viper.GetInt(IPPoolMinAllocateIPs)
@@ -1156,6 +1374,8 @@ func (c *DaemonConfig) Populate() {
 	c.IPv4Range = viper.GetString(IPv4Range)
 	c.IPv6ClusterAllocCIDR = viper.GetString(IPv6ClusterAllocCIDRName)
 	c.IPv6Range = viper.GetString(IPv6Range)
+	c.IPv4ServiceRange = viper.GetString(IPv4ServiceRange)
+	c.IPv6ServiceRange = viper.GetString(IPv6ServiceRange)
 	c.K8sAPIServer = viper.GetString(K8sAPIServer)
 	c.K8sClientBurst = viper.GetInt(K8sClientBurst)
 	c.K8sClientQPSLimit = viper.GetFloat64(K8sClientQPSLimit)
@@ -1181,6 +1401,10 @@ func (c *DaemonConfig) Populate() {
 	c.SocketPath = viper.GetString(SocketPath)
 	c.MTU = viper.GetInt(MTUName)
 	c.PluginIpRequestRetryTimes = viper.GetInt(PluginIpRetryTimes)
+	c.PluginVerifyMode = viper.GetString(PluginVerifyMode)
+	c.PluginVerifyTargets = viper.GetStringSlice(PluginVerifyTargets)
+	c.PluginVerifyRetries = viper.GetInt(PluginVerifyRetries)
+	c.PluginVerifyTimeout = viper.GetDuration(PluginVerifyTimeout)
 	c.PProf = viper.GetBool(PProf)
 	c.PProfPort = viper.GetInt(PProfPort)
 	c.ProcFs = viper.GetString(ProcFs)
@@ -28,6 +28,7 @@ import (
 	"github.com/spf13/viper"
 	. "gopkg.in/check.v1"
 
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/defaults"
 	ipamOption "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/ipam/option"
 )
@@ -311,6 +312,65 @@ func TestCheckIPAMDelegatedPlugin(t *testing.T) {
 	}
 }
 
+func TestValidateDatapathMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		d       *DaemonConfig
+		wantErr bool
+	}{
+		{
+			name: "veth datapath with default service range",
+			d: &DaemonConfig{
+				DatapathMode:     string(models.DatapathModeVeth),
+				IPv4ServiceRange: "auto",
+				IPv6ServiceRange: "auto",
+			},
+		},
+		{
+			name: "ipvlan datapath with default service range",
+			d: &DaemonConfig{
+				DatapathMode:     string(models.DatapathModeIpvlan),
+				IpvlanMode:       IpvlanModeL2,
+				IPv4ServiceRange: "auto",
+				IPv6ServiceRange: "auto",
+			},
+			wantErr: true,
+		},
+		{
+			name: "ipvlan datapath with ipv4 service range",
+			d: &DaemonConfig{
+				DatapathMode:     string(models.DatapathModeIpvlan),
+				IpvlanMode:       IpvlanModeL3,
+				IPv4ServiceRange: "172.16.0.0/16",
+				IPv6ServiceRange: "auto",
+			},
+		},
+		{
+			name: "ipvlan datapath with invalid ipvlan mode",
+			d: &DaemonConfig{
+				DatapathMode:     string(models.DatapathModeIpvlan),
+				IpvlanMode:       "l3s",
+				IPv4ServiceRange: "172.16.0.0/16",
+			},
+			wantErr: true,
+		},
+		{
+			name:    "unknown datapath",
+			d:       &DaemonConfig{DatapathMode: "macvlan"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.d.validateDatapathMode()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateDatapathMode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func (s *OptionSuite) TestGetDefaultMonitorQueueSize(c *C) {
 	c.Assert(getDefaultMonitorQueueSize(4), Equals, 4*defaults.MonitorQueueSizePerCPU)
 	c.Assert(getDefaultMonitorQueueSize(1000), Equals, defaults.MonitorQueueSizePerCPUMaximum)
@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+package pststrategy
+
+import (
+	"sort"
+
+	ccev2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2"
+)
+
+// SpreadSubnets orders the candidate subnets so that the subnet with the fewest
+// matched pods comes first. If the psts asks for DoNotSchedule, the subnets
+// which would make the skew between candidates greater than psts.Spec.MaxSkew
+// are dropped as well.
+//
+// The topology domains are the candidates only. The subnets which can not
+// allocate IP for the endpoint, such as the exhausted ones, are not eligible
+// and never constrain the allocation. The spread across zones is done by the
+// scheduler with the TopologySpreadConstraint injected by webhook.
+//
+// candidates: subnets which can allocate IP for the endpoint on the target node
+// podCounts: number of pods matched by the psts in each subnet, KEY: subnet ID
+// return: the ordered candidates, the least crowded one is always kept
+func SpreadSubnets(psts *ccev2.PodSubnetTopologySpread, candidates []string, podCounts map[string]int32) []string {
+	if psts == nil || psts.Spec.MaxSkew <= 0 || len(candidates) == 0 {
+		return candidates
+	}
+
+	ordered := make([]string, len(candidates))
+	copy(ordered, candidates)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if podCounts[ordered[i]] != podCounts[ordered[j]] {
+			return podCounts[ordered[i]] < podCounts[ordered[j]]
+		}
+		return ordered[i] < ordered[j]
+	})
+	if psts.Spec.WhenUnsatisfiable != ccev2.DoNotSchedule {
+		return ordered
+	}
+
+	minCount := podCounts[ordered[0]]
+	var satisfied []string
+	for _, sbnID := range ordered {
+		if podCounts[sbnID]+1-minCount <= psts.Spec.MaxSkew {
+			satisfied = append(satisfied, sbnID)
+		}
+	}
+	return satisfied
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+package pststrategy
+
+import (
+	"reflect"
+	"testing"
+
+	ccev2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2"
+)
+
+func newSpreadPSTS(maxSkew int32, action ccev2.UnsatisfiableConstraintAction, zones map[string]string) *ccev2.PodSubnetTopologySpread {
+	psts := &ccev2.PodSubnetTopologySpread{}
+	psts.Spec.MaxSkew = maxSkew
+	psts.Spec.WhenUnsatisfiable = action
+	psts.Status.AvailableSubnets = make(map[string]ccev2.SubnetPodStatus)
+	for sbnID, zone := range zones {
+		psts.Status.AvailableSubnets[sbnID] = ccev2.SubnetPodStatus{
+			SubenetDetail: ccev2.SubenetDetail{ID: sbnID, AvailabilityZone: zone},
+		}
+	}
+	return psts
+}
+
+func TestSpreadSubnets(t *testing.T) {
+	zones := map[string]string{
+		"sbn-a": "zoneA",
+		"sbn-b": "zoneA",
+		"sbn-c": "zoneA",
+		"sbn-d": "zoneB",
+	}
+	tests := []struct {
+		name       string
+		psts       *ccev2.PodSubnetTopologySpread
+		candidates []string
+		podCounts  map[string]int32
+		want       []string
+	}{
+		{
+			name:       "max skew disabled keeps order",
+			psts:       newSpreadPSTS(0, ccev2.DoNotSchedule, zones),
+			candidates: []string{"sbn-b", "sbn-a"},
+			podCounts:  map[string]int32{"sbn-a": 0, "sbn-b": 5},
+			want:       []string{"sbn-b", "sbn-a"},
+		},
+		{
+			name:       "least crowded subnet first",
+			psts:       newSpreadPSTS(2, ccev2.DoNotSchedule, zones),
+			candidates: []string{"sbn-a", "sbn-b", "sbn-c"},
+			podCounts:  map[string]int32{"sbn-a": 2, "sbn-b": 1, "sbn-c": 1},
+			want:       []string{"sbn-b", "sbn-c", "sbn-a"},
+		},
+		{
+			name:       "subnet violating max skew is dropped",
+			psts:       newSpreadPSTS(1, ccev2.DoNotSchedule, zones),
+			candidates: []string{"sbn-a", "sbn-b"},
+			podCounts:  map[string]int32{"sbn-a": 2, "sbn-b": 1, "sbn-c": 1},
+			want:       []string{"sbn-b"},
+		},
+		{
+			name:       "exhausted subnet without candidate is not counted",
+			psts:       newSpreadPSTS(1, ccev2.DoNotSchedule, zones),
+			candidates: []string{"sbn-a", "sbn-b"},
+			podCounts:  map[string]int32{"sbn-a": 1, "sbn-b": 1, "sbn-c": 0},
+			want:       []string{"sbn-a", "sbn-b"},
+		},
+		{
+			name:       "subnet in other zone is ignored",
+			psts:       newSpreadPSTS(1, ccev2.DoNotSchedule, zones),
+			candidates: []string{"sbn-a", "sbn-b", "sbn-c"},
+			podCounts:  map[string]int32{"sbn-a": 3, "sbn-b": 3, "sbn-c": 3, "sbn-d": 0},
+			want:       []string{"sbn-a", "sbn-b", "sbn-c"},
+		},
+		{
+			name:       "schedule anyway only orders the subnets",
+			psts:       newSpreadPSTS(1, ccev2.ScheduleAnyway, zones),
+			candidates: []string{"sbn-a", "sbn-b"},
+			podCounts:  map[string]int32{"sbn-a": 3, "sbn-b": 1},
+			want:       []string{"sbn-b", "sbn-a"},
+		},
+		{
+			name:       "unset whenUnsatisfiable acts as schedule anyway",
+			psts:       newSpreadPSTS(1, "", zones),
+			candidates: []string{"sbn-a", "sbn-b"},
+			podCounts:  map[string]int32{"sbn-a": 3, "sbn-b": 1},
+			want:       []string{"sbn-b", "sbn-a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SpreadSubnets(tt.psts, tt.candidates, tt.podCounts)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SpreadSubnets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
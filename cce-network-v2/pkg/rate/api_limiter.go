@@ -39,6 +39,7 @@ const (
 	defaultMeanOver                = 10
 	defaultDelayedAdjustmentFactor = 0.50
 	defaultMaxAdjustmentFactor     = 100.0
+	defaultThrottleRecoveryStep    = 0.01
 
 	// waitSemaphoreWeight is the maximum resolution of the wait semaphore,
 	// the higher this value, the more accurate the ParallelRequests
@@ -86,6 +87,9 @@ const (
 	// logSkipped represents whether the rate limiter will skip rate-limiting
 	// this request. See APILimiterParameters.SkipInitial.
 	logSkipped = "rateLimiterSkipped"
+	// logThrottleFactor is the factor applied to the limits after the
+	// request has been throttled. See APILimiter.throttleFactor.
+	logThrottleFactor = "throttleFactor"
 )
 
 type outcome string
@@ -140,6 +144,16 @@ type APILimiter struct {
 	// meanProcessingDuration.
 	adjustmentFactor float64
 
+	// throttleFactor is the factor applied to the rate limit, burst and
+	// parallel requests as a result of throttling responses reported via
+	// Throttled(). It is decreased multiplicatively on every throttling
+	// response and recovers additively on every successful request until
+	// it reaches 1.0 again.
+	throttleFactor float64
+
+	// lastThrottled is the time the throttleFactor was last decreased
+	lastThrottled time.Time
+
 	// limiter is the rate limiter based on params.RateLimit and
 	// params.RateBurst.
 	limiter *rate.Limiter
@@ -228,6 +242,18 @@ type APILimiterParameters struct {
 	// MaxAdjustmentFactor is the maximum adjustment factor when AutoAdjust
 	// is enabled. Base values will not adjust more than by this factor.
 	MaxAdjustmentFactor float64
+
+	// ThrottleDecreaseFactor is the factor defined as a value between
+	// 0.0..1.0 by which RateLimit, RateBurst and ParallelRequests are
+	// multiplied each time the server rejects a request due to rate
+	// limiting. A value of 0.0 disables the adaptive throttling. The
+	// limits never decrease by more than MaxAdjustmentFactor.
+	ThrottleDecreaseFactor float64
+
+	// ThrottleRecoveryStep is the share of the limits defined as a value
+	// between 0.0..1.0 which is recovered on each successful request after
+	// the limits have been decreased by ThrottleDecreaseFactor.
+	ThrottleRecoveryStep float64
 }
 
 // MergeUserConfig merges the provided user configuration into the existing
@@ -263,10 +289,15 @@ func NewAPILimiter(name string, p APILimiterParameters, metrics MetricsObserver)
 		p.MaxAdjustmentFactor = defaultMaxAdjustmentFactor
 	}
 
+	if p.ThrottleRecoveryStep == 0.0 {
+		p.ThrottleRecoveryStep = defaultThrottleRecoveryStep
+	}
+
 	l := &APILimiter{
 		name:                  key,
 		params:                p,
 		parallelRequests:      p.ParallelRequests,
+		throttleFactor:        1.0,
 		parallelWaitSemaphore: semaphore.NewWeighted(waitSemaphoreResolution),
 		metrics:               metrics,
 	}
@@ -376,6 +407,18 @@ func (p *APILimiterParameters) mergeUserConfigKeyValue(key, value string) error
 			return err
 		}
 		p.SkipInitial = skipInitial
+	case "throttle-decrease-factor":
+		throttleDecreaseFactor, err := parseFactor(value)
+		if err != nil {
+			return err
+		}
+		p.ThrottleDecreaseFactor = throttleDecreaseFactor
+	case "throttle-recovery-step":
+		throttleRecoveryStep, err := parseFactor(value)
+		if err != nil {
+			return err
+		}
+		p.ThrottleRecoveryStep = throttleRecoveryStep
 	default:
 		return fmt.Errorf("unknown rate limiting option %q", key)
 	}
@@ -446,7 +489,84 @@ func (l *APILimiter) adjustedParallelRequests() int {
 	return int(l.adjustmentLimit(newParallelRequests, float64(l.params.ParallelRequests)))
 }
 
+// throttledLimit returns the value scaled down by the current throttle
+// factor, it never returns less than min
+func (l *APILimiter) throttledLimit(value, min float64) float64 {
+	return math.Max(min, value*l.throttleFactor)
+}
+
+// updateLimits applies the configured or auto adjusted limits scaled down by
+// the current throttle factor. The caller must hold the mutex.
+func (l *APILimiter) updateLimits() {
+	var (
+		limit    = l.params.RateLimit
+		burst    = l.params.RateBurst
+		parallel = l.params.ParallelRequests
+	)
+
+	if l.params.AutoAdjust && l.params.EstimatedProcessingDuration != 0 && l.adjustmentFactor != 0 {
+		limit = l.adjustedLimit()
+		burst = l.adjustedBurst()
+		parallel = l.adjustedParallelRequests()
+	}
+
+	if l.throttleFactor < 1.0 {
+		limit = rate.Limit(float64(limit) * l.throttleFactor)
+		burst = int(math.Round(l.throttledLimit(float64(burst), 1.0)))
+		if parallel > 0 {
+			parallel = int(math.Round(l.throttledLimit(float64(parallel), float64(l.params.MinParallelRequests))))
+		}
+	}
+
+	l.parallelRequests = parallel
+	if l.limiter != nil {
+		l.limiter.SetLimit(limit)
+		l.limiter.SetBurst(burst)
+	}
+}
+
+// ThrottleFactor returns the factor currently applied to the limits as a
+// result of throttling responses. A value of 1.0 means not throttled.
+func (l *APILimiter) ThrottleFactor() float64 {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	return l.throttleFactor
+}
+
+// throttle decreases the limits by params.ThrottleDecreaseFactor. Requests
+// which have been scheduled before the last decrease were sent with the
+// higher limits and do not decrease the limits a second time. The caller
+// must hold the mutex.
+func (l *APILimiter) throttle(r *limitedRequest) bool {
+	if l.params.ThrottleDecreaseFactor <= 0.0 || l.params.ThrottleDecreaseFactor >= 1.0 {
+		return false
+	}
+	if r.scheduleTime.Before(l.lastThrottled) {
+		return false
+	}
+
+	l.throttleFactor = math.Max(l.throttleFactor*l.params.ThrottleDecreaseFactor, 1.0/l.params.MaxAdjustmentFactor)
+	l.lastThrottled = time.Now()
+	l.updateLimits()
+	return true
+}
+
+// recover increases the limits by params.ThrottleRecoveryStep until the
+// throttle factor reaches 1.0 again. The caller must hold the mutex.
+func (l *APILimiter) recover() {
+	if l.throttleFactor >= 1.0 {
+		return
+	}
+
+	l.throttleFactor = math.Min(l.throttleFactor+l.params.ThrottleRecoveryStep, 1.0)
+	l.updateLimits()
+}
+
 func (l *APILimiter) requestFinished(r *limitedRequest, err error) {
+	l.requestProcessed(r, err, false)
+}
+
+func (l *APILimiter) requestProcessed(r *limitedRequest, err error, throttled bool) {
 	if r.finished {
 		return
 	}
@@ -505,15 +625,12 @@ func (l *APILimiter) requestFinished(r *limitedRequest, err error) {
 
 		if l.params.AutoAdjust && l.params.EstimatedProcessingDuration != 0 {
 			l.adjustmentFactor = l.calculateAdjustmentFactor()
-			l.parallelRequests = l.adjustedParallelRequests()
-
-			if l.limiter != nil {
-				l.limiter.SetLimit(l.adjustedLimit())
-
-				newBurst := l.adjustedBurst()
-				l.limiter.SetBurst(newBurst)
-			}
+			l.updateLimits()
 		}
+
+		l.recover()
+	} else if throttled && l.throttle(r) {
+		scopedLog.WithField(logThrottleFactor, l.throttleFactor).Warning("API call has been throttled, decreasing rate limit")
 	}
 
 	values := MetricsValues{
@@ -524,6 +641,7 @@ func (l *APILimiter) requestFinished(r *limitedRequest, err error) {
 		ParallelRequests:            l.parallelRequests,
 		CurrentRequestsInFlight:     l.currentRequestsInFlight,
 		AdjustmentFactor:            l.adjustmentFactor,
+		ThrottleFactor:              l.throttleFactor,
 		Error:                       err,
 		Outcome:                     string(r.outcome),
 	}
@@ -556,6 +674,7 @@ func calcMeanDuration(durations []time.Duration) float64 {
 type LimitedRequest interface {
 	Done()
 	Error(err error)
+	Throttled(err error)
 	WaitDuration() time.Duration
 }
 
@@ -585,6 +704,12 @@ func (l *limitedRequest) Error(err error) {
 	l.limiter.requestFinished(l, err)
 }
 
+// Throttled must be called instead of Error() when the API request has been
+// rejected by the server due to rate limiting
+func (l *limitedRequest) Throttled(err error) {
+	l.limiter.requestProcessed(l, err, true)
+}
+
 // Wait blocks until the next API call is allowed to be processed. If the
 // configured MaxWaitDuration is exceeded, an error is returned. On success, a
 // LimitedRequest is returned on which Done() must be called when the API call
@@ -818,6 +943,7 @@ type MetricsValues struct {
 	Burst                       int
 	CurrentRequestsInFlight     int
 	AdjustmentFactor            float64
+	ThrottleFactor              float64
 	Error                       error
 }
 
@@ -877,6 +1003,7 @@ type dummyRequest struct{}
 func (d dummyRequest) WaitDuration() time.Duration { return 0 }
 func (d dummyRequest) Done()                       {}
 func (d dummyRequest) Error(err error)             {}
+func (d dummyRequest) Throttled(err error)         {}
 
 // Wait invokes Wait() on the APILimiter with the given name. If the limiter
 // does not exist, a dummy limiter is used which will not impose any
@@ -891,6 +1018,19 @@ func (s *APILimiterSet) Wait(ctx context.Context, name string) (LimitedRequest,
 	return l.Wait(ctx)
 }
 
+// parseFactor parses value as a float between 0.0..1.0
+func parseFactor(value string) (float64, error) {
+	f, err := strconv.ParseFloat(value, 64)
+	switch {
+	case err != nil:
+		return 0, fmt.Errorf("unable to parse float %q: %w", value, err)
+	case f < 0.0 || f > 1.0:
+		return 0, fmt.Errorf("unable to parse factor %q: must be between 0.0 and 1.0", value)
+	default:
+		return f, nil
+	}
+}
+
 // parsePositiveInt parses value as an int. It returns an error if value cannot
 // be parsed or is negative.
 func parsePositiveInt(value string) (int, error) {
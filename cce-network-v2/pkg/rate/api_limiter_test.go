@@ -129,6 +129,65 @@ func (b *ControllerSuite) TestAutoAdjust(c *check.C) {
 	c.Assert(a.requestsProcessed, check.Equals, int64(3))
 }
 
+func (b *ControllerSuite) TestThrottled(c *check.C) {
+	// Test decrease of rate limiting parameters on throttling responses and
+	// slow recovery on successful requests
+	a := NewAPILimiter("foo", APILimiterParameters{
+		ParallelRequests:       8,
+		RateLimit:              rate.Limit(100.0),
+		RateBurst:              8,
+		ThrottleDecreaseFactor: 0.5,
+		ThrottleRecoveryStep:   0.25,
+	}, nil)
+
+	// Requests scheduled before the throttling response was received were
+	// sent with the higher limits and must not decrease the limits again
+	req1, err := a.Wait(context.Background())
+	c.Assert(err, check.IsNil)
+	req2, err := a.Wait(context.Background())
+	c.Assert(err, check.IsNil)
+
+	req1.Throttled(fmt.Errorf("RateLimit"))
+	c.Assert(a.ThrottleFactor(), check.Equals, 0.5)
+	c.Assert(a.parallelRequests, check.Equals, 4)
+	c.Assert(a.limiter.Limit(), check.Equals, rate.Limit(50.0))
+	c.Assert(a.limiter.Burst(), check.Equals, 4)
+
+	req2.Throttled(fmt.Errorf("RateLimit"))
+	c.Assert(a.ThrottleFactor(), check.Equals, 0.5)
+
+	req, err := a.Wait(context.Background())
+	c.Assert(err, check.IsNil)
+	req.Throttled(fmt.Errorf("RateLimit"))
+	c.Assert(a.ThrottleFactor(), check.Equals, 0.25)
+	c.Assert(a.parallelRequests, check.Equals, 2)
+	c.Assert(a.limiter.Limit(), check.Equals, rate.Limit(25.0))
+
+	for i := 0; i < 3; i++ {
+		req, err = a.Wait(context.Background())
+		c.Assert(err, check.IsNil)
+		req.Done()
+	}
+	c.Assert(a.ThrottleFactor(), check.Equals, 1.0)
+	c.Assert(a.parallelRequests, check.Equals, 8)
+	c.Assert(a.limiter.Limit(), check.Equals, rate.Limit(100.0))
+	c.Assert(a.limiter.Burst(), check.Equals, 8)
+	c.Assert(a.requestsProcessed, check.Equals, int64(6))
+}
+
+func (b *ControllerSuite) TestThrottledDisabled(c *check.C) {
+	a := NewAPILimiter("foo", APILimiterParameters{
+		RateLimit: rate.Limit(100.0),
+		RateBurst: 8,
+	}, nil)
+
+	req, err := a.Wait(context.Background())
+	c.Assert(err, check.IsNil)
+	req.Throttled(fmt.Errorf("RateLimit"))
+	c.Assert(a.ThrottleFactor(), check.Equals, 1.0)
+	c.Assert(a.limiter.Limit(), check.Equals, rate.Limit(100.0))
+}
+
 func (b *ControllerSuite) TestMeanProcessingDuration(c *check.C) {
 	// Simulate several requests and calculate the mean processing duration
 	// over fewer requests. Verify calculation of mean processing duration
@@ -595,6 +654,10 @@ func (b *ControllerSuite) TestParseUserConfigKeyValue(c *check.C) {
 	c.Assert(p.mergeUserConfigKeyValue("max-adjustment-factor", "foo"), check.Not(check.IsNil))
 	c.Assert(p.mergeUserConfigKeyValue("skip-initial", "2"), check.IsNil)
 	c.Assert(p.mergeUserConfigKeyValue("skip-initial", "foo"), check.Not(check.IsNil))
+	c.Assert(p.mergeUserConfigKeyValue("throttle-decrease-factor", "0.5"), check.IsNil)
+	c.Assert(p.mergeUserConfigKeyValue("throttle-decrease-factor", "1.5"), check.Not(check.IsNil))
+	c.Assert(p.mergeUserConfigKeyValue("throttle-recovery-step", "0.1"), check.IsNil)
+	c.Assert(p.mergeUserConfigKeyValue("throttle-recovery-step", "foo"), check.Not(check.IsNil))
 }
 
 func (b *ControllerSuite) TestParseUserConfig(c *check.C) {
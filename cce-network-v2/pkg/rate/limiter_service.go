@@ -16,6 +16,7 @@ package rate
 
 import (
 	"context"
+	"strings"
 	"sync"
 
 	metric "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/metrics"
@@ -49,16 +50,20 @@ func APILimiterSetWrapDefault(limiterSet *APILimiterSet, defaultParam *APILimite
 
 // Limiter returns the APILimiter with a given name
 func (s *DefaultAPILimiterSet) Limiter(name string) *APILimiter {
+	key := strings.ToLower(name)
 	s.RLock()
-	limiter, ok := s.limiters[name]
+	limiter, ok := s.limiters[key]
 	s.RUnlock()
 	if ok {
 		return limiter
 	}
 	s.Lock()
 	defer s.Unlock()
-	newLimiter := NewAPILimiter(name, *s.defaultParam, s.metrics)
-	s.limiters[name] = newLimiter
+	if limiter, ok = s.limiters[key]; ok {
+		return limiter
+	}
+	newLimiter := NewAPILimiter(key, *s.defaultParam, s.metrics)
+	s.limiters[key] = newLimiter
 	return newLimiter
 }
 
@@ -71,6 +76,7 @@ func (a *simpleMetricsObserver) ProcessedRequest(name string, v MetricsValues) {
 	metric.APILimiterRateLimit.WithLabelValues(name, "limit").Set(float64(v.Limit))
 	metric.APILimiterRateLimit.WithLabelValues(name, "burst").Set(float64(v.Burst))
 	metric.APILimiterAdjustmentFactor.WithLabelValues(name).Set(v.AdjustmentFactor)
+	metric.APILimiterThrottleFactor.WithLabelValues(name).Set(v.ThrottleFactor)
 
 	if v.Outcome == "" {
 		v.Outcome = metric.Error2Outcome(v.Error)
@@ -0,0 +1,173 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+package ipavailability
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	corev1 "k8s.io/api/core/v1"
+	extenderv1 "k8s.io/kube-scheduler/extender/v1"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging"
+)
+
+const (
+	// URLPrefix is the path prefix of the extender, the verbs are served under it
+	URLPrefix = "/scheduler"
+	// FilterPath is the path of the filter verb of the extender
+	FilterPath = URLPrefix + "/filter"
+	// PrioritizePath is the path of the prioritize verb of the extender
+	PrioritizePath = URLPrefix + "/prioritize"
+)
+
+var log = logging.NewSubysLogger("ip-availability-scheduler")
+
+// Extender serves the plugin to kube-scheduler as a scheduler extender, which
+// works with the stock kube-scheduler of the cluster instead of a rebuilt one.
+// It is served over TLS by the webhook server and configured by the extenders
+// of KubeSchedulerConfiguration:
+//
+//	extenders:
+//	- urlPrefix: https://<cluster IP of cce-network-v2 service>/scheduler
+//	  filterVerb: filter
+//	  prioritizeVerb: prioritize
+//	  weight: 1
+//	  nodeCacheCapable: true
+//	  ignorable: true
+//	  tlsConfig:
+//	    serverName: cce-network-v2.kube-system.svc
+//	    caData: <ca.crt of the webhook certs>
+type Extender struct {
+	plugin *IPAvailability
+}
+
+// NewExtender creates the extender of the plugin
+func NewExtender(plugin *IPAvailability) *Extender {
+	return &Extender{plugin: plugin}
+}
+
+// Register registers the verbs of the extender by register, such as the
+// Register of webhook server or the Handle of http.ServeMux
+func (e *Extender) Register(register func(path string, handler http.Handler)) {
+	register(FilterPath, http.HandlerFunc(e.handleFilter))
+	register(PrioritizePath, http.HandlerFunc(e.handlePrioritize))
+}
+
+func (e *Extender) handleFilter(w http.ResponseWriter, r *http.Request) {
+	args, err := decodeArgs(r)
+	if err != nil {
+		writeResponse(w, http.StatusBadRequest, &extenderv1.ExtenderFilterResult{Error: err.Error()})
+		return
+	}
+	writeResponse(w, http.StatusOK, e.Filter(args))
+}
+
+func (e *Extender) handlePrioritize(w http.ResponseWriter, r *http.Request) {
+	args, err := decodeArgs(r)
+	if err != nil {
+		writeResponse(w, http.StatusBadRequest, &extenderv1.HostPriorityList{})
+		return
+	}
+	writeResponse(w, http.StatusOK, e.Prioritize(args))
+}
+
+// Filter filters the nodes of the extender arguments
+func (e *Extender) Filter(args *extenderv1.ExtenderArgs) *extenderv1.ExtenderFilterResult {
+	passed, failed, err := e.plugin.Filter(args.Pod, nodeNamesOf(args))
+	if err != nil {
+		log.WithError(err).WithField("pod", podKey(args.Pod)).Error("failed to filter nodes")
+		return &extenderv1.ExtenderFilterResult{Error: err.Error()}
+	}
+	if len(failed) != 0 {
+		log.WithField("pod", podKey(args.Pod)).WithField("failedNodes", failed).Debug("filter out nodes without IP")
+	}
+
+	result := &extenderv1.ExtenderFilterResult{FailedNodes: failed}
+	if args.Nodes != nil {
+		passedSet := make(map[string]struct{}, len(passed))
+		for _, name := range passed {
+			passedSet[name] = struct{}{}
+		}
+		nodes := &corev1.NodeList{}
+		for _, node := range args.Nodes.Items {
+			if _, ok := passedSet[node.Name]; ok {
+				nodes.Items = append(nodes.Items, node)
+			}
+		}
+		result.Nodes = nodes
+	} else {
+		result.NodeNames = &passed
+	}
+	return result
+}
+
+// Prioritize scores the nodes of the extender arguments
+func (e *Extender) Prioritize(args *extenderv1.ExtenderArgs) *extenderv1.HostPriorityList {
+	nodeNames := nodeNamesOf(args)
+	scores, err := e.plugin.Score(args.Pod, nodeNames)
+	if err != nil {
+		log.WithError(err).WithField("pod", podKey(args.Pod)).Error("failed to score nodes")
+		scores = make(map[string]int64)
+	}
+	NormalizeScore(scores, extenderv1.MaxExtenderPriority)
+
+	result := make(extenderv1.HostPriorityList, 0, len(nodeNames))
+	for _, name := range nodeNames {
+		result = append(result, extenderv1.HostPriority{Host: name, Score: scores[name]})
+	}
+	return &result
+}
+
+func decodeArgs(r *http.Request) (*extenderv1.ExtenderArgs, error) {
+	if r.Method != http.MethodPost {
+		return nil, fmt.Errorf("unsupported method %s", r.Method)
+	}
+	args := &extenderv1.ExtenderArgs{}
+	if err := json.NewDecoder(r.Body).Decode(args); err != nil {
+		return nil, fmt.Errorf("failed to decode extender args: %w", err)
+	}
+	if args.Pod == nil {
+		return nil, fmt.Errorf("pod is missing in extender args")
+	}
+	return args, nil
+}
+
+func writeResponse(w http.ResponseWriter, code int, result interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		log.WithError(err).Error("failed to write extender response")
+	}
+}
+
+func nodeNamesOf(args *extenderv1.ExtenderArgs) []string {
+	if args.NodeNames != nil {
+		return *args.NodeNames
+	}
+	var names []string
+	if args.Nodes != nil {
+		for _, node := range args.Nodes.Items {
+			names = append(names, node.Name)
+		}
+	}
+	return names
+}
+
+func podKey(pod *corev1.Pod) string {
+	return pod.Namespace + "/" + pod.Name
+}
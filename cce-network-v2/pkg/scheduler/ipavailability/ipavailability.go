@@ -0,0 +1,327 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+// Package ipavailability filters and scores the nodes by the IPs which can be
+// allocated to the pod on the node. The extended resources injected by the
+// pod webhook only reflect the static capacity of the ENIs of the node, this
+// plugin takes the subnets of the node and the PSTS of the pod into account,
+// so that the pods are not scheduled to the nodes whose subnets have no more
+// IPs.
+package ipavailability
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/bce/api"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s"
+	ccev2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2"
+	listerv1 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/client/listers/cce.baidubce.com/v1"
+	listerv2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/client/listers/cce.baidubce.com/v2"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/pststrategy"
+)
+
+const (
+	// Name is the name of the plugin
+	Name = "CCEIPAvailability"
+
+	// unlimitedHeadroom is the headroom of the nodes which are not judged
+	// by the plugin, such as the pods using host network
+	unlimitedHeadroom = -1
+)
+
+// IPAvailability filters out the nodes which cannot allocate an IP for the
+// pod, and scores the nodes by the remaining IPs
+type IPAvailability struct {
+	nrsLister      listerv2.NetResourceSetLister
+	subnetLister   listerv1.SubnetLister
+	pstsLister     listerv2.PodSubnetTopologySpreadLister
+	endpointLister listerv2.CCEEndpointLister
+}
+
+// New creates the plugin reading the objects from the listers
+func New(nrsLister listerv2.NetResourceSetLister, subnetLister listerv1.SubnetLister,
+	pstsLister listerv2.PodSubnetTopologySpreadLister, endpointLister listerv2.CCEEndpointLister) *IPAvailability {
+	return &IPAvailability{
+		nrsLister:      nrsLister,
+		subnetLister:   subnetLister,
+		pstsLister:     pstsLister,
+		endpointLister: endpointLister,
+	}
+}
+
+// Name returns the name of the plugin
+func (p *IPAvailability) Name() string {
+	return Name
+}
+
+// podState is the network request of the pod shared by all the nodes
+type podState struct {
+	// skip is true if the pod is not judged by the plugin
+	skip       bool
+	primaryENI bool
+	psts       *ccev2.PodSubnetTopologySpread
+}
+
+// preFilter resolves the network request of the pod
+func (p *IPAvailability) preFilter(pod *corev1.Pod) (*podState, error) {
+	if pod.Spec.HostNetwork || p.hasReservedEndpoint(pod) {
+		return &podState{skip: true}, nil
+	}
+	if requestsResource(pod, k8s.ResourceENIForNode) {
+		return &podState{primaryENI: true}, nil
+	}
+	pstsList, err := p.pstsLister.PodSubnetTopologySpreads(pod.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("list PodSubnetTopologySpreads at namespace %s error %w", pod.Namespace, err)
+	}
+	return &podState{psts: pststrategy.SelectPriorityPSTS(pstsList, labels.Set(pod.Labels))}, nil
+}
+
+// Filter returns the nodes which can allocate an IP for the pod, and the
+// reasons of the nodes which cannot
+func (p *IPAvailability) Filter(pod *corev1.Pod, nodeNames []string) ([]string, map[string]string, error) {
+	state, err := p.preFilter(pod)
+	if err != nil {
+		return nil, nil, err
+	}
+	var (
+		passed []string
+		failed = make(map[string]string)
+	)
+	for _, nodeName := range nodeNames {
+		headroom, err := p.headroom(state, nodeName)
+		if err != nil {
+			failed[nodeName] = err.Error()
+			continue
+		}
+		if headroom == 0 {
+			failed[nodeName] = fmt.Sprintf("node %s has no more IP for the pod", nodeName)
+			continue
+		}
+		passed = append(passed, nodeName)
+	}
+	return passed, failed, nil
+}
+
+// Score returns the number of IPs which can be allocated to the pods like the
+// pod on the nodes, the nodes which are not judged by the plugin are scored 0
+func (p *IPAvailability) Score(pod *corev1.Pod, nodeNames []string) (map[string]int64, error) {
+	state, err := p.preFilter(pod)
+	if err != nil {
+		return nil, err
+	}
+	scores := make(map[string]int64, len(nodeNames))
+	for _, nodeName := range nodeNames {
+		headroom, err := p.headroom(state, nodeName)
+		if err != nil || headroom == unlimitedHeadroom {
+			scores[nodeName] = 0
+			continue
+		}
+		scores[nodeName] = int64(headroom)
+	}
+	return scores, nil
+}
+
+// NormalizeScore scales the scores to [0, maxScore] in proportion to the
+// highest score
+func NormalizeScore(scores map[string]int64, maxScore int64) {
+	var highest int64
+	for _, score := range scores {
+		if score > highest {
+			highest = score
+		}
+	}
+	for name, score := range scores {
+		if highest == 0 {
+			scores[name] = 0
+			continue
+		}
+		scores[name] = score * maxScore / highest
+	}
+}
+
+// headroom returns the number of IPs which can be allocated to the pods like
+// the pod on the node, or unlimitedHeadroom if the node is not judged
+func (p *IPAvailability) headroom(state *podState, nodeName string) (int, error) {
+	if state.skip {
+		return unlimitedHeadroom, nil
+	}
+
+	nrs, err := p.nrsLister.Get(nodeName)
+	if kerrors.IsNotFound(err) {
+		return 0, fmt.Errorf("NetResourceSet of node %s is not found", nodeName)
+	} else if err != nil {
+		return 0, err
+	}
+	// the IPs of the nodes not using ENI are not allocated from the subnets
+	if nrs.Spec.ENI == nil {
+		return unlimitedHeadroom, nil
+	}
+
+	switch {
+	case state.primaryENI:
+		return p.primaryENIHeadroom(nrs), nil
+	case state.psts != nil:
+		return p.pstsHeadroom(nrs, state.psts), nil
+	default:
+		return p.secondaryIPHeadroom(nrs), nil
+	}
+}
+
+// primaryENIHeadroom returns the number of ENIs which can be used exclusively
+// by the pods, an ENI is ready on the node or can be created in the subnets
+// of the node
+func (p *IPAvailability) primaryENIHeadroom(nrs *ccev2.NetResourceSet) int {
+	var ready int
+	for _, eni := range nrs.Status.ENIs {
+		if eni.CCEStatus == string(ccev2.ENIStatusReadyOnNode) {
+			ready++
+		}
+	}
+	newENIs := nrs.Spec.ENI.MaxAllocateENI - len(nrs.Status.ENIs)
+	if newENIs > 0 {
+		ready += min(newENIs, p.subnetsAvailableIPs(nodeSubnetIDs(nrs)))
+	}
+	return ready
+}
+
+// secondaryIPHeadroom returns the number of the secondary IPs which can be
+// allocated to the pods on the node. They are the IPs in the pool but not
+// used, the IPs can be added to the ENIs whose subnet has more IPs, and the
+// IPs of the new ENIs in the subnets of the node.
+func (p *IPAvailability) secondaryIPHeadroom(nrs *ccev2.NetResourceSet) int {
+	headroom := len(nrs.Spec.IPAM.Pool) - len(nrs.Status.IPAM.Used)
+	if headroom < 0 {
+		headroom = 0
+	}
+	for _, eni := range nrs.Status.ENIs {
+		if eni.IsMoreAvailableIPInSubnet {
+			headroom += eni.AvailableIPNum
+		}
+	}
+	newENIs := nrs.Spec.ENI.MaxAllocateENI - len(nrs.Status.ENIs)
+	if newENIs > 0 {
+		headroom += min(newENIs*nrs.Spec.ENI.MaxIPsPerENI, p.subnetsAvailableIPs(nodeSubnetIDs(nrs)))
+	}
+	return headroom
+}
+
+// pstsHeadroom returns the number of IPs of the subnets of the PSTS in the
+// zone of the node, which are limited by the IP capacity of the ENIs of the
+// node as the IPs are allocated across subnet on the ENIs
+func (p *IPAvailability) pstsHeadroom(nrs *ccev2.NetResourceSet, psts *ccev2.PodSubnetTopologySpread) int {
+	var subnetIDs []string
+	for sbnID := range psts.Spec.Subnets {
+		zone := p.subnetZone(sbnID, psts)
+		if zone == "" || sameZone(zone, nrs.Spec.ENI.AvailabilityZone) {
+			subnetIDs = append(subnetIDs, sbnID)
+		}
+	}
+	subnetIPs := p.subnetsAvailableIPs(subnetIDs)
+
+	var eniIPs int
+	for _, eni := range nrs.Status.ENIs {
+		eniIPs += eni.AvailableIPNum
+	}
+	if newENIs := nrs.Spec.ENI.MaxAllocateENI - len(nrs.Status.ENIs); newENIs > 0 {
+		eniIPs += newENIs * nrs.Spec.ENI.MaxIPsPerENI
+	}
+	return min(subnetIPs, eniIPs)
+}
+
+// subnetsAvailableIPs returns the number of available IPs of the subnets which
+// can be used for automatic IP allocation
+func (p *IPAvailability) subnetsAvailableIPs(subnetIDs []string) int {
+	var available int
+	for _, sbnID := range subnetIDs {
+		sbn, err := p.subnetLister.Get(sbnID)
+		if err != nil {
+			continue
+		}
+		if !sbn.Status.Enable || sbn.Status.HasNoMoreIP {
+			continue
+		}
+		available += sbn.Status.AvailableIPNum
+	}
+	return available
+}
+
+// subnetZone returns the zone of the subnet from the subnet object, or from the
+// status of the PSTS if the subnet object has not been synced yet
+func (p *IPAvailability) subnetZone(sbnID string, psts *ccev2.PodSubnetTopologySpread) string {
+	if sbn, err := p.subnetLister.Get(sbnID); err == nil {
+		return sbn.Spec.AvailabilityZone
+	}
+	if status, ok := psts.Status.AvailableSubnets[sbnID]; ok {
+		return status.AvailabilityZone
+	}
+	return ""
+}
+
+// hasReservedEndpoint returns true if the pod reuses the IP reserved by the
+// endpoint of the same name, the pod is pinned to the nodes which can use the
+// IP by the node affinity added by the pod webhook
+func (p *IPAvailability) hasReservedEndpoint(pod *corev1.Pod) bool {
+	ep, err := p.endpointLister.CCEEndpoints(pod.Namespace).Get(pod.Name)
+	if err != nil {
+		return false
+	}
+	return len(ep.Status.NodeSelectorRequirement) != 0
+}
+
+func nodeSubnetIDs(nrs *ccev2.NetResourceSet) []string {
+	if len(nrs.Status.IPAM.AvailableSubnetIDs) != 0 {
+		return nrs.Status.IPAM.AvailableSubnetIDs
+	}
+	return nrs.Spec.ENI.SubnetIDs
+}
+
+func requestsResource(pod *corev1.Pod, name corev1.ResourceName) bool {
+	for _, container := range pod.Spec.Containers {
+		if _, ok := container.Resources.Requests[name]; ok {
+			return true
+		}
+		if _, ok := container.Resources.Limits[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// sameZone compares the zones in the format of zone name (cn-bj-a) or
+// available zone (zoneA)
+func sameZone(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if za, err := api.TransZoneNameToAvailableZone(a); err == nil {
+		a = za
+	}
+	if zb, err := api.TransZoneNameToAvailableZone(b); err == nil {
+		b = zb
+	}
+	return a == b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,281 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+package ipavailability
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	extenderv1 "k8s.io/kube-scheduler/extender/v1"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/bce/api"
+	ipamTypes "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/ipam/types"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s"
+	ccev1 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v1"
+	ccev2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2"
+	listerv1 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/client/listers/cce.baidubce.com/v1"
+	listerv2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/client/listers/cce.baidubce.com/v2"
+)
+
+func newTestPlugin(t *testing.T, objs ...interface{}) *IPAvailability {
+	var (
+		nrsIndexer    = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		subnetIndexer = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		pstsIndexer   = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+		epIndexer     = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	)
+	for _, obj := range objs {
+		var err error
+		switch obj.(type) {
+		case *ccev2.NetResourceSet:
+			err = nrsIndexer.Add(obj)
+		case *ccev1.Subnet:
+			err = subnetIndexer.Add(obj)
+		case *ccev2.PodSubnetTopologySpread:
+			err = pstsIndexer.Add(obj)
+		case *ccev2.CCEEndpoint:
+			err = epIndexer.Add(obj)
+		}
+		require.NoError(t, err)
+	}
+	return New(listerv2.NewNetResourceSetLister(nrsIndexer), listerv1.NewSubnetLister(subnetIndexer),
+		listerv2.NewPodSubnetTopologySpreadLister(pstsIndexer), listerv2.NewCCEEndpointLister(epIndexer))
+}
+
+func newTestNRS(name string, maxENI int, enis map[string]ccev2.SimpleENIStatus) *ccev2.NetResourceSet {
+	return &ccev2.NetResourceSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: ccev2.NetResourceSpec{
+			ENI: &api.ENISpec{
+				MaxAllocateENI:   maxENI,
+				MaxIPsPerENI:     8,
+				SubnetIDs:        []string{"sbn-node"},
+				AvailabilityZone: "cn-bj-a",
+			},
+		},
+		Status: ccev2.NetResourceStatus{ENIs: enis},
+	}
+}
+
+func newTestSubnet(id, zone string, available int) *ccev1.Subnet {
+	return &ccev1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{Name: id},
+		Spec:       ccev1.SubnetSpec{ID: id, AvailabilityZone: zone},
+		Status:     ccev1.SubnetStatus{Enable: true, AvailableIPNum: available},
+	}
+}
+
+func newTestPod(labels map[string]string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx", Labels: labels},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "nginx"}}},
+	}
+}
+
+func TestFilterSecondaryIP(t *testing.T) {
+	full := newTestNRS("full", 1, map[string]ccev2.SimpleENIStatus{
+		"eni-1": {ID: "eni-1", AvailableIPNum: 5, IsMoreAvailableIPInSubnet: false},
+	})
+	pool := newTestNRS("pool", 1, map[string]ccev2.SimpleENIStatus{
+		"eni-2": {ID: "eni-2"},
+	})
+	pool.Spec.IPAM.Pool = ipamTypes.AllocationMap{"10.0.0.2": {}, "10.0.0.3": {}}
+	pool.Status.IPAM.Used = ipamTypes.AllocationMap{"10.0.0.2": {}}
+	newENI := newTestNRS("new-eni", 2, map[string]ccev2.SimpleENIStatus{
+		"eni-3": {ID: "eni-3", AvailableIPNum: 2, IsMoreAvailableIPInSubnet: true},
+	})
+	noENI := &ccev2.NetResourceSet{ObjectMeta: metav1.ObjectMeta{Name: "vpc-route"}}
+
+	p := newTestPlugin(t, full, pool, newENI, noENI, newTestSubnet("sbn-node", "cn-bj-a", 100))
+	passed, failed, err := p.Filter(newTestPod(nil), []string{"full", "pool", "new-eni", "vpc-route", "missing"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"pool", "new-eni", "vpc-route"}, passed)
+	assert.Contains(t, failed, "full")
+	assert.Contains(t, failed, "missing")
+
+	scores, err := p.Score(newTestPod(nil), []string{"full", "pool", "new-eni", "vpc-route"})
+	require.NoError(t, err)
+	// 2 IPs on the ENI and 8 IPs of a new ENI
+	assert.Equal(t, int64(10), scores["new-eni"])
+	assert.Equal(t, int64(1), scores["pool"])
+	assert.Equal(t, int64(0), scores["vpc-route"])
+}
+
+func TestFilterPrimaryENI(t *testing.T) {
+	ready := newTestNRS("ready", 1, map[string]ccev2.SimpleENIStatus{
+		"eni-1": {ID: "eni-1", CCEStatus: string(ccev2.ENIStatusReadyOnNode)},
+	})
+	used := newTestNRS("used", 1, map[string]ccev2.SimpleENIStatus{
+		"eni-2": {ID: "eni-2", CCEStatus: string(ccev2.ENIStatusUsingInPod)},
+	})
+	exhausted := newTestNRS("exhausted", 2, nil)
+	exhausted.Spec.ENI.SubnetIDs = []string{"sbn-exhausted"}
+	exhausted.Status.IPAM.AvailableSubnetIDs = []string{"sbn-exhausted"}
+	exhaustedSubnet := newTestSubnet("sbn-exhausted", "cn-bj-a", 0)
+	exhaustedSubnet.Status.HasNoMoreIP = true
+
+	pod := newTestPod(nil)
+	pod.Spec.Containers[0].Resources.Requests = corev1.ResourceList{k8s.ResourceENIForNode: resource.MustParse("1")}
+
+	p := newTestPlugin(t, ready, used, exhausted, exhaustedSubnet)
+	passed, failed, err := p.Filter(pod, []string{"ready", "used", "exhausted"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ready"}, passed)
+	assert.Len(t, failed, 2)
+}
+
+func TestFilterPSTS(t *testing.T) {
+	psts := &ccev2.PodSubnetTopologySpread{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "psts"},
+		Spec: ccev2.PodSubnetTopologySpreadSpec{
+			Subnets: map[string]ccev2.CustomAllocationList{
+				"sbn-a": {},
+				"sbn-b": {},
+			},
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nginx"}},
+		},
+	}
+	zoneA := newTestNRS("zone-a", 1, map[string]ccev2.SimpleENIStatus{
+		"eni-1": {ID: "eni-1", AvailableIPNum: 3},
+	})
+	zoneB := newTestNRS("zone-b", 1, map[string]ccev2.SimpleENIStatus{
+		"eni-2": {ID: "eni-2", AvailableIPNum: 3},
+	})
+	zoneB.Spec.ENI.AvailabilityZone = "zoneB"
+
+	p := newTestPlugin(t, psts, zoneA, zoneB,
+		newTestSubnet("sbn-a", "cn-bj-a", 0), newTestSubnet("sbn-b", "cn-bj-b", 50))
+	passed, failed, err := p.Filter(newTestPod(map[string]string{"app": "nginx"}), []string{"zone-a", "zone-b"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"zone-b"}, passed)
+	assert.Contains(t, failed, "zone-a")
+
+	// limited by the IP capacity of the ENIs of the node
+	scores, err := p.Score(newTestPod(map[string]string{"app": "nginx"}), []string{"zone-b"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), scores["zone-b"])
+
+	// the pods not matching the PSTS use the subnets of the node
+	passed, _, err = p.Filter(newTestPod(nil), []string{"zone-a", "zone-b"})
+	require.NoError(t, err)
+	assert.Empty(t, passed)
+}
+
+func TestFilterSkippedPods(t *testing.T) {
+	ep := &ccev2.CCEEndpoint{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx"},
+		Status: ccev2.EndpointStatus{
+			NodeSelectorRequirement: []corev1.NodeSelectorRequirement{{Key: k8s.TopologyKeyOfPod}},
+		},
+	}
+	p := newTestPlugin(t, ep)
+	passed, _, err := p.Filter(newTestPod(nil), []string{"missing"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"missing"}, passed)
+
+	pod := newTestPod(nil)
+	pod.Name = "host"
+	pod.Spec.HostNetwork = true
+	passed, _, err = p.Filter(pod, []string{"missing"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"missing"}, passed)
+}
+
+func TestNormalizeScore(t *testing.T) {
+	scores := map[string]int64{"a": 0, "b": 50, "c": 100}
+	NormalizeScore(scores, extenderv1.MaxExtenderPriority)
+	assert.Equal(t, map[string]int64{"a": 0, "b": 5, "c": 10}, scores)
+
+	scores = map[string]int64{"a": 0}
+	NormalizeScore(scores, extenderv1.MaxExtenderPriority)
+	assert.Equal(t, int64(0), scores["a"])
+}
+
+func TestExtender(t *testing.T) {
+	nrs := newTestNRS("node", 1, map[string]ccev2.SimpleENIStatus{
+		"eni-1": {ID: "eni-1", AvailableIPNum: 2, IsMoreAvailableIPInSubnet: true},
+	})
+	e := NewExtender(newTestPlugin(t, nrs))
+
+	nodeNames := []string{"node", "missing"}
+	result := e.Filter(&extenderv1.ExtenderArgs{Pod: newTestPod(nil), NodeNames: &nodeNames})
+	require.NotNil(t, result.NodeNames)
+	assert.Equal(t, []string{"node"}, *result.NodeNames)
+	assert.Contains(t, result.FailedNodes, "missing")
+
+	nodes := &corev1.NodeList{Items: []corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "missing"}},
+	}}
+	result = e.Filter(&extenderv1.ExtenderArgs{Pod: newTestPod(nil), Nodes: nodes})
+	require.NotNil(t, result.Nodes)
+	require.Len(t, result.Nodes.Items, 1)
+	assert.Equal(t, "node", result.Nodes.Items[0].Name)
+
+	priorities := e.Prioritize(&extenderv1.ExtenderArgs{Pod: newTestPod(nil), NodeNames: &nodeNames})
+	assert.Equal(t, extenderv1.HostPriorityList{
+		{Host: "node", Score: extenderv1.MaxExtenderPriority},
+		{Host: "missing", Score: 0},
+	}, *priorities)
+}
+
+func TestExtenderOverTLS(t *testing.T) {
+	nrs := newTestNRS("node", 1, map[string]ccev2.SimpleENIStatus{
+		"eni-1": {ID: "eni-1", AvailableIPNum: 2, IsMoreAvailableIPInSubnet: true},
+	})
+	mux := http.NewServeMux()
+	NewExtender(newTestPlugin(t, nrs)).Register(mux.Handle)
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+
+	nodeNames := []string{"node", "missing"}
+	body, err := json.Marshal(&extenderv1.ExtenderArgs{Pod: newTestPod(nil), NodeNames: &nodeNames})
+	require.NoError(t, err)
+
+	resp, err := server.Client().Post(server.URL+FilterPath, "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	result := &extenderv1.ExtenderFilterResult{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(result))
+	require.NotNil(t, result.NodeNames)
+	assert.Equal(t, []string{"node"}, *result.NodeNames)
+
+	resp, err = server.Client().Post(server.URL+PrioritizePath, "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	priorities := extenderv1.HostPriorityList{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&priorities))
+	assert.Equal(t, extenderv1.HostPriorityList{
+		{Host: "node", Score: extenderv1.MaxExtenderPriority},
+		{Host: "missing", Score: 0},
+	}, priorities)
+
+	resp, err = server.Client().Get(server.URL + FilterPath)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,210 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+// Package tracing traces the allocation of the pod network across the CNI
+// plugins, the agent, the operator and the cloud API with OpenTelemetry.
+//
+// The spans are exported to an OTLP/HTTP collector. The W3C trace context is
+// carried by the TRACEPARENT environment variable between the CNI plugins, by
+// the HTTP headers over the unix socket of the agent, and by the annotation of
+// CCEEndpoint from the agent to the operator.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// EnvTraceParent is the environment variable which carries the W3C
+	// traceparent from a CNI plugin to the delegated IPAM plugin
+	EnvTraceParent = "TRACEPARENT"
+
+	// DefaultEndpoint is the OTLP/HTTP endpoint of the local collector
+	DefaultEndpoint = "localhost:4318"
+
+	instrumentationName = "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2"
+
+	traceParentHeader = "traceparent"
+
+	// shutdownTimeout is the max time to flush the spans when exiting, the
+	// CNI plugins must not be blocked by an unreachable collector
+	shutdownTimeout = 2 * time.Second
+)
+
+var propagator = propagation.TraceContext{}
+
+// Config is the configuration of the exporter, it is also the "tracing"
+// section of the CNI network configuration
+type Config struct {
+	// Enabled enables exporting the spans
+	Enabled bool `json:"enabled"`
+	// Endpoint is the host:port of the OTLP/HTTP collector
+	Endpoint string `json:"endpoint,omitempty"`
+	// Insecure exports the spans over HTTP instead of HTTPS
+	Insecure bool `json:"insecure,omitempty"`
+	// SampleRatio is the ratio of the root spans sampled, the spans with a
+	// remote parent follow the sampling decision of the parent
+	SampleRatio float64 `json:"sample-ratio,omitempty"`
+}
+
+// Validate checks the configuration
+func (c *Config) Validate() error {
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return fmt.Errorf("sample ratio %v must be in [0, 1]", c.SampleRatio)
+	}
+	return nil
+}
+
+// Init installs the tracer provider exporting the spans of serviceName if
+// tracing is enabled. The returned shutdown flushes the spans and must be
+// called before the process exits.
+//
+// The trace context is propagated even if tracing is disabled, so the spans of
+// the other components are still linked together.
+func Init(serviceName string, cfg *Config) (shutdown func(), err error) {
+	otel.SetTextMapPropagator(propagator)
+	if cfg == nil || !cfg.Enabled {
+		return func() {}, nil
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(DefaultEndpoint)}
+	if cfg.Endpoint != "" {
+		opts[0] = otlptracehttp.WithEndpoint(cfg.Endpoint)
+	}
+	if cfg.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("create OTLP exporter error: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceNameKey.String(serviceName),
+		semconv.HostNameKey.String(hostname()),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("create resource error: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		_ = provider.Shutdown(ctx)
+	}, nil
+}
+
+func hostname() string {
+	name, _ := os.Hostname()
+	return name
+}
+
+// Start starts a span of the global tracer provider, it is a no-op span if
+// tracing is disabled
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records err on the span and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// TraceParent returns the W3C traceparent of the span in ctx, or an empty
+// string if there is no span
+func TraceParent(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	return carrier.Get(traceParentHeader)
+}
+
+// WithTraceParent returns a context with the remote span of the W3C
+// traceparent as the parent of the new spans
+func WithTraceParent(ctx context.Context, traceParent string) context.Context {
+	if traceParent == "" {
+		return ctx
+	}
+	return propagator.Extract(ctx, propagation.MapCarrier{traceParentHeader: traceParent})
+}
+
+// SetEnv sets TRACEPARENT to the span in ctx, so the plugins executed by the
+// current process are traced as its children
+func SetEnv(ctx context.Context) error {
+	traceParent := TraceParent(ctx)
+	if traceParent == "" {
+		return nil
+	}
+	return os.Setenv(EnvTraceParent, traceParent)
+}
+
+// FromEnv returns a context with the parent span from TRACEPARENT
+func FromEnv(ctx context.Context) context.Context {
+	return WithTraceParent(ctx, os.Getenv(EnvTraceParent))
+}
+
+// FromHTTPHeader returns a context with the parent span from the headers of
+// the HTTP request
+func FromHTTPHeader(ctx context.Context, header http.Header) context.Context {
+	return propagator.Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+// transport injects the trace context into the headers of the requests
+type transport struct {
+	base http.RoundTripper
+}
+
+// NewTransport returns a RoundTripper which injects the trace context of the
+// requests into their headers
+func NewTransport(base http.RoundTripper) http.RoundTripper {
+	return &transport{base: base}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !trace.SpanContextFromContext(req.Context()).IsValid() {
+		return t.base.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	propagator.Inject(req.Context(), propagation.HeaderCarrier(req.Header))
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestTraceParent(t *testing.T) {
+	assert.Empty(t, TraceParent(context.Background()))
+	assert.Equal(t, context.Background(), WithTraceParent(context.Background(), ""))
+
+	ctx := WithTraceParent(context.Background(), testTraceParent)
+	sc := trace.SpanContextFromContext(ctx)
+	assert.True(t, sc.IsValid())
+	assert.True(t, sc.IsRemote())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", sc.TraceID().String())
+	assert.Equal(t, testTraceParent, TraceParent(ctx))
+
+	// the invalid traceparent is ignored
+	ctx = WithTraceParent(context.Background(), "invalid")
+	assert.False(t, trace.SpanContextFromContext(ctx).IsValid())
+}
+
+func TestEnv(t *testing.T) {
+	t.Setenv(EnvTraceParent, "")
+	assert.NoError(t, SetEnv(context.Background()))
+	assert.False(t, trace.SpanContextFromContext(FromEnv(context.Background())).IsValid())
+
+	assert.NoError(t, SetEnv(WithTraceParent(context.Background(), testTraceParent)))
+	assert.Equal(t, testTraceParent, TraceParent(FromEnv(context.Background())))
+}
+
+func TestTransport(t *testing.T) {
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: NewTransport(http.DefaultTransport)}
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	resp, err := client.Do(req)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Empty(t, received.Get(traceParentHeader))
+
+	ctx := WithTraceParent(context.Background(), testTraceParent)
+	req, _ = http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	resp, err = client.Do(req)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, testTraceParent, received.Get(traceParentHeader))
+	// the request of the caller is not modified
+	assert.Empty(t, req.Header.Get(traceParentHeader))
+
+	assert.Equal(t, testTraceParent, TraceParent(FromHTTPHeader(context.Background(), received)))
+}
+
+func TestInit(t *testing.T) {
+	shutdown, err := Init("test", nil)
+	assert.NoError(t, err)
+	shutdown()
+
+	shutdown, err = Init("test", &Config{Enabled: false, SampleRatio: 2})
+	assert.NoError(t, err)
+	shutdown()
+
+	_, err = Init("test", &Config{Enabled: true, SampleRatio: 2})
+	assert.Error(t, err)
+
+	// the spans started without parent are sampled by the ratio, and the
+	// spans with remote parent follow the decision of the parent
+	shutdown, err = Init("test", &Config{Enabled: true, Endpoint: "127.0.0.1:1", Insecure: true, SampleRatio: 0})
+	assert.NoError(t, err)
+	defer shutdown()
+	_, span := Start(context.Background(), "root")
+	assert.False(t, span.SpanContext().IsSampled())
+	End(span, nil)
+
+	ctx, span := Start(WithTraceParent(context.Background(), testTraceParent), "child")
+	assert.True(t, span.SpanContext().IsSampled())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext().TraceID().String())
+	assert.NotEqual(t, testTraceParent, TraceParent(ctx))
+	End(span, assert.AnError)
+}
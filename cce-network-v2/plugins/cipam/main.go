@@ -26,11 +26,13 @@ import (
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging/logfields"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/netns"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/tracing"
 	"github.com/containernetworking/plugins/pkg/ns"
 	bv "github.com/containernetworking/plugins/pkg/utils/buildversion"
 	gops "github.com/google/gops/agent"
 	"github.com/sirupsen/logrus"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 
 	plugintypes "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/cni/types"
 	"github.com/containernetworking/cni/pkg/skel"
@@ -81,6 +83,18 @@ func cmdAdd(args *skel.CmdArgs) (err error) {
 		}
 	}
 
+	// tracing is best-effort, it never fails the CNI request
+	if shutdownTracing, traceErr := tracing.Init("cipam", n.Tracing); traceErr != nil {
+		logger.WithError(traceErr).Warn("Unable to initialize tracing")
+	} else {
+		defer shutdownTracing()
+	}
+	ctx, span := tracing.Start(tracing.FromEnv(context.Background()), "cni/cipam-add",
+		attribute.String("containerID", args.ContainerID))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
 	logger.WithField("netConf", logfields.Json(n)).Infof("Processing CNI ADD request %#v", args)
 
 	cniArgs := plugintypes.ArgsSpec{}
@@ -107,7 +121,7 @@ func cmdAdd(args *skel.CmdArgs) (err error) {
 		ns = args.Netns
 	}
 	var releaseIPsFunc func(context.Context)
-	ipam, releaseIPsFunc, err = allocateIPsWithCCEAgent(c, cniArgs, args.ContainerID, ns)
+	ipam, releaseIPsFunc, err = allocateIPsWithCCEAgent(ctx, c, cniArgs, args.ContainerID, ns)
 	// release addresses on failure
 	defer func() {
 		if err != nil && releaseIPsFunc != nil {
@@ -209,9 +223,9 @@ func cmdDel(args *skel.CmdArgs) (err error) {
 	return nil
 }
 
-func allocateIPsWithCCEAgent(client *client.Client, cniArgs plugintypes.ArgsSpec, containerID, netns string) (*models.IPAMResponse, func(context.Context), error) {
+func allocateIPsWithCCEAgent(ctx context.Context, client *client.Client, cniArgs plugintypes.ArgsSpec, containerID, netns string) (*models.IPAMResponse, func(context.Context), error) {
 	podName := string(cniArgs.K8S_POD_NAMESPACE) + "/" + string(cniArgs.K8S_POD_NAME)
-	ipam, err := client.IPAMCNIAllocate("", podName, containerID, netns)
+	ipam, err := client.IPAMCNIAllocate(ctx, "", podName, containerID, netns)
 	if err != nil {
 		return nil, nil, fmt.Errorf("unable to allocate IP via local cce agent: %w", err)
 	}
@@ -0,0 +1,385 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+package main
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net"
+
+	"github.com/containernetworking/cni/pkg/types"
+	current "github.com/containernetworking/cni/pkg/types/100"
+	"github.com/containernetworking/plugins/pkg/ip"
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/datapath/link"
+)
+
+// hookContainerName is the name of the container side of the hook
+const hookContainerName = "cce-hook"
+
+var (
+	// hookGatewayIPv4 and hookGatewayIPv6 are the gateways of the hook in the
+	// pod, they are resolved to the host side of the hook by permanent
+	// neighbours as the gateway of the pods created by cptp
+	hookGatewayIPv4 = net.ParseIP("169.254.1.1")
+	hookGatewayIPv6 = net.ParseIP("fe80::1")
+)
+
+// findENI returns the ENI owning the IPs allocated to the pod, the link of the
+// ENI must have been found by the agent
+func findENI(enis []*models.LocalENI, ips []*current.IPConfig) (*models.LocalENI, error) {
+	for _, ipc := range ips {
+		podIP := ipc.Address.IP.String()
+		for _, eni := range enis {
+			addrs := eni.IPV4
+			if ipc.Address.IP.To4() == nil {
+				addrs = eni.IPV6
+			}
+			for _, addr := range addrs {
+				if addr != podIP {
+					continue
+				}
+				if eni.LinkIndex <= 0 {
+					return nil, fmt.Errorf("link of ENI %s owning IP %s is not found on the node", eni.ID, podIP)
+				}
+				return eni, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("no ENI of the node owns the IPs of the pod")
+}
+
+// setupIpvlan creates the ipvlan slave of the master in the netns and
+// configures the IPs and the default routes of the pod on it
+func setupIpvlan(netns ns.NetNS, masterIndex int, ifName string, conf *NetConf, result *current.Result) (*current.Interface, error) {
+	mode, err := ipvlanMode(conf.Mode)
+	if err != nil {
+		return nil, err
+	}
+	master, err := netlink.LinkByIndex(masterIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup master %d: %v", masterIndex, err)
+	}
+	mtu := conf.MTU
+	if mtu <= 0 || mtu > master.Attrs().MTU {
+		mtu = master.Attrs().MTU
+	}
+
+	// the slave is created with a temporary name as ifName may conflict with
+	// a link in the host netns
+	tmpName, err := ip.RandomVethName()
+	if err != nil {
+		return nil, err
+	}
+	slave := &netlink.IPVlan{
+		LinkAttrs: netlink.LinkAttrs{
+			Name:        tmpName,
+			MTU:         mtu,
+			ParentIndex: masterIndex,
+			Namespace:   netlink.NsFd(int(netns.Fd())),
+		},
+		Mode: mode,
+	}
+	if err := netlink.LinkAdd(slave); err != nil {
+		return nil, fmt.Errorf("failed to create ipvlan slave: %v", err)
+	}
+
+	// in L3 mode the master routes the packets of the pod, there is no
+	// gateway in the pod
+	if mode == netlink.IPVLAN_MODE_L3 {
+		result.Routes = nil
+		for _, ipc := range result.IPs {
+			result.Routes = append(result.Routes, &types.Route{Dst: *defaultNet(ipc.Address.IP)})
+		}
+	}
+
+	contIface := &current.Interface{Name: ifName, Sandbox: netns.Path()}
+	err = netns.Do(func(_ ns.NetNS) error {
+		l, err := netlink.LinkByName(tmpName)
+		if err != nil {
+			return fmt.Errorf("failed to lookup ipvlan slave %s: %v", tmpName, err)
+		}
+		if err := netlink.LinkSetName(l, ifName); err != nil {
+			netlink.LinkDel(l)
+			return fmt.Errorf("failed to rename ipvlan slave to %s: %v", ifName, err)
+		}
+		if err := netlink.LinkSetUp(l); err != nil {
+			return fmt.Errorf("failed to set %s up: %v", ifName, err)
+		}
+		contIface.Mac = l.Attrs().HardwareAddr.String()
+
+		for _, ipc := range result.IPs {
+			addr := &net.IPNet{IP: ipc.Address.IP, Mask: ipc.Address.Mask}
+			if mode == netlink.IPVLAN_MODE_L3 {
+				addr = hostNet(ipc.Address.IP)
+			}
+			if err := netlink.AddrAdd(l, &netlink.Addr{IPNet: addr, Flags: unix.IFA_F_NODAD}); err != nil {
+				return fmt.Errorf("failed to add address %s to %s: %v", addr, ifName, err)
+			}
+		}
+		for _, route := range result.Routes {
+			r := &netlink.Route{
+				LinkIndex: l.Attrs().Index,
+				Dst:       &route.Dst,
+				Gw:        route.GW,
+			}
+			if route.GW == nil {
+				r.Scope = netlink.SCOPE_LINK
+			} else {
+				r.Flags = int(netlink.FLAG_ONLINK)
+			}
+			if err := netlink.RouteReplace(r); err != nil {
+				return fmt.Errorf("failed to add route %s: %v", r, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return contIface, nil
+}
+
+// setupHook creates the veth pair between the pod and the host. The services
+// and the host IPs are routed through it in the pod, and the IPs of the pod
+// are routed through it on the host.
+func setupHook(netns ns.NetNS, hookName string, conf *NetConf, result *current.Result) (*current.Interface, *current.Interface, error) {
+	// the routes to the host IPs must be collected in the host netns
+	hostAddrs, err := hostIPs()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list the IPs of the host: %v", err)
+	}
+
+	// the container side is created with a random name, as the pods are
+	// attached concurrently
+	tmpName, err := ip.RandomVethName()
+	if err != nil {
+		return nil, nil, err
+	}
+	veth := &netlink.Veth{
+		LinkAttrs: netlink.LinkAttrs{Name: hookName, MTU: conf.MTU},
+		PeerName:  tmpName,
+	}
+	if err := netlink.LinkAdd(veth); err != nil {
+		return nil, nil, fmt.Errorf("failed to create veth %s: %v", hookName, err)
+	}
+	hostVeth, err := netlink.LinkByName(hookName)
+	if err != nil {
+		return nil, nil, err
+	}
+	peer, err := netlink.LinkByName(tmpName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := netlink.LinkSetNsFd(peer, int(netns.Fd())); err != nil {
+		return nil, nil, fmt.Errorf("failed to move %s to netns: %v", tmpName, err)
+	}
+	if err := netlink.LinkSetUp(hostVeth); err != nil {
+		return nil, nil, fmt.Errorf("failed to set %s up: %v", hookName, err)
+	}
+	if err := link.DisableRpFilter(hookName); err != nil {
+		logger.WithError(err).Warning("failed to disable rp_filter of host hook")
+	}
+
+	hostIface := &current.Interface{Name: hookName, Mac: hostVeth.Attrs().HardwareAddr.String()}
+	contIface := &current.Interface{Name: hookContainerName, Sandbox: netns.Path()}
+	var contMAC net.HardwareAddr
+	err = netns.Do(func(_ ns.NetNS) error {
+		l, err := netlink.LinkByName(tmpName)
+		if err != nil {
+			return err
+		}
+		if err := netlink.LinkSetName(l, hookContainerName); err != nil {
+			return fmt.Errorf("failed to rename %s to %s: %v", tmpName, hookContainerName, err)
+		}
+		if err := netlink.LinkSetUp(l); err != nil {
+			return fmt.Errorf("failed to set %s up: %v", hookContainerName, err)
+		}
+		contMAC = l.Attrs().HardwareAddr
+		contIface.Mac = contMAC.String()
+
+		for _, ipc := range result.IPs {
+			gateway := hookGatewayIPv4
+			if ipc.Address.IP.To4() == nil {
+				gateway = hookGatewayIPv6
+			}
+			if err := netlink.NeighSet(&netlink.Neigh{
+				LinkIndex:    l.Attrs().Index,
+				State:        netlink.NUD_PERMANENT,
+				IP:           gateway,
+				HardwareAddr: hostVeth.Attrs().HardwareAddr,
+			}); err != nil {
+				return fmt.Errorf("failed to add neighbour of gateway %s: %v", gateway, err)
+			}
+			if err := netlink.RouteReplace(&netlink.Route{
+				LinkIndex: l.Attrs().Index,
+				Scope:     netlink.SCOPE_LINK,
+				Dst:       hostNet(gateway),
+				Src:       ipc.Address.IP,
+			}); err != nil {
+				return fmt.Errorf("failed to add route to gateway %s: %v", gateway, err)
+			}
+			for _, dst := range hookDsts(conf.ServiceCIDRs, hostAddrs, ipc.Address.IP) {
+				if err := netlink.RouteReplace(&netlink.Route{
+					LinkIndex: l.Attrs().Index,
+					Dst:       dst,
+					Gw:        gateway,
+					Src:       ipc.Address.IP,
+				}); err != nil {
+					return fmt.Errorf("failed to add route to %s through hook: %v", dst, err)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, ipc := range result.IPs {
+		if err := netlink.RouteReplace(&netlink.Route{
+			LinkIndex: hostVeth.Attrs().Index,
+			Scope:     netlink.SCOPE_LINK,
+			Dst:       hostNet(ipc.Address.IP),
+		}); err != nil {
+			return nil, nil, fmt.Errorf("failed to add route to pod %s: %v", ipc.Address.IP, err)
+		}
+		if err := netlink.NeighSet(&netlink.Neigh{
+			LinkIndex:    hostVeth.Attrs().Index,
+			State:        netlink.NUD_PERMANENT,
+			IP:           ipc.Address.IP,
+			HardwareAddr: contMAC,
+		}); err != nil {
+			return nil, nil, fmt.Errorf("failed to add neighbour of pod %s: %v", ipc.Address.IP, err)
+		}
+	}
+	return hostIface, contIface, nil
+}
+
+// hookDsts returns the destinations routed through the hook for the pod IP of
+// the same family: the service CIDRs and the host IPs
+func hookDsts(serviceCIDRs []string, hostAddrs []net.IP, podIP net.IP) []*net.IPNet {
+	isIPv4 := podIP.To4() != nil
+	var dsts []*net.IPNet
+	for _, cidr := range serviceCIDRs {
+		_, ipn, err := net.ParseCIDR(cidr)
+		if err != nil || (ipn.IP.To4() != nil) != isIPv4 {
+			continue
+		}
+		dsts = append(dsts, ipn)
+	}
+	for _, addr := range hostAddrs {
+		if (addr.To4() != nil) != isIPv4 || addr.Equal(podIP) {
+			continue
+		}
+		dsts = append(dsts, hostNet(addr))
+	}
+	return dsts
+}
+
+// hostIPs returns the global unicast IPs of the host
+func hostIPs() ([]net.IP, error) {
+	addrs, err := netlink.AddrList(nil, netlink.FAMILY_ALL)
+	if err != nil {
+		return nil, err
+	}
+	var ips []net.IP
+	for _, addr := range addrs {
+		if addr.IP.IsGlobalUnicast() {
+			ips = append(ips, addr.IP)
+		}
+	}
+	return ips, nil
+}
+
+// rollbackLinks deletes the ipvlan slave in the netns and the hook, the links
+// which do not exist are ignored. The netns may be nil if it is gone already.
+func rollbackLinks(netns ns.NetNS, ifName, hookName string) error {
+	var errs []error
+	if netns != nil {
+		err := netns.Do(func(_ ns.NetNS) error {
+			for _, name := range []string{ifName, hookContainerName} {
+				if _, err := ip.DelLinkByNameAddr(name); err != nil && err != ip.ErrLinkNotFound {
+					return fmt.Errorf("failed to delete %s in netns: %w", name, err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	// the host side of the hook is gone with the peer in the netns, it is
+	// deleted in case the netns is removed before
+	if l, err := netlink.LinkByName(hookName); err == nil {
+		if err := netlink.LinkDel(l); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete host hook %s: %w", hookName, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("rollback errors: %v", errs)
+	}
+	return nil
+}
+
+// validateIpvlan checks the container interface in prevResult is an ipvlan
+// slave of the mode
+func validateIpvlan(intf *current.Interface, mode netlink.IPVlanMode) error {
+	l, err := netlink.LinkByName(intf.Name)
+	if err != nil {
+		return fmt.Errorf("%s: container interface %s in prevResult not found", pluginName, intf.Name)
+	}
+	ipvlan, ok := l.(*netlink.IPVlan)
+	if !ok {
+		return fmt.Errorf("%s: container interface %s is not of type ipvlan", pluginName, intf.Name)
+	}
+	if ipvlan.Mode != mode {
+		return fmt.Errorf("%s: container interface %s is in ipvlan mode %d, expected %d", pluginName, intf.Name, ipvlan.Mode, mode)
+	}
+	if intf.Mac != "" && intf.Mac != l.Attrs().HardwareAddr.String() {
+		return fmt.Errorf("%s: interface %s Mac %s doesn't match container Mac: %s", pluginName, intf.Name, intf.Mac, l.Attrs().HardwareAddr)
+	}
+	return nil
+}
+
+// vethNameForPod return host-side veth name for pod
+// max veth length is 15
+func vethNameForPod(name, namespace, prefix string) string {
+	// A SHA1 is always 20 bytes long, and so is sufficient for generating the
+	// veth name and mac addr.
+	h := sha1.New()
+	h.Write([]byte(namespace + "." + name))
+	return fmt.Sprintf("%s%s", prefix, hex.EncodeToString(h.Sum(nil))[:11])
+}
+
+func hostNet(ip net.IP) *net.IPNet {
+	bits := 128
+	if ip.To4() != nil {
+		bits = 32
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}
+}
+
+func defaultNet(ip net.IP) *net.IPNet {
+	if ip.To4() != nil {
+		return &net.IPNet{IP: net.IPv4zero, Mask: net.CIDRMask(0, 32)}
+	}
+	return &net.IPNet{IP: net.IPv6zero, Mask: net.CIDRMask(0, 128)}
+}
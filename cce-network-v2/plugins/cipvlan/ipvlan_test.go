@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+package main
+
+import (
+	"net"
+	"testing"
+
+	current "github.com/containernetworking/cni/pkg/types/100"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
+)
+
+func ipConfig(cidr string) *current.IPConfig {
+	ip, ipn, _ := net.ParseCIDR(cidr)
+	return &current.IPConfig{Address: net.IPNet{IP: ip, Mask: ipn.Mask}}
+}
+
+func TestFindENI(t *testing.T) {
+	enis := []*models.LocalENI{
+		{ID: "eni-a", IPV4: []string{"10.0.0.2", "10.0.0.3"}, LinkIndex: 3, LinkName: "eth1"},
+		{ID: "eni-b", IPV4: []string{"10.0.1.2"}, IPV6: []string{"fd00::2"}, LinkIndex: 4, LinkName: "eth2"},
+		{ID: "eni-c", IPV4: []string{"10.0.2.2"}},
+	}
+
+	eni, err := findENI(enis, []*current.IPConfig{ipConfig("10.0.0.3/24")})
+	require.NoError(t, err)
+	assert.Equal(t, "eni-a", eni.ID)
+
+	eni, err = findENI(enis, []*current.IPConfig{ipConfig("fd00::2/64")})
+	require.NoError(t, err)
+	assert.Equal(t, "eni-b", eni.ID)
+
+	_, err = findENI(enis, []*current.IPConfig{ipConfig("10.0.2.2/24")})
+	assert.ErrorContains(t, err, "eni-c")
+
+	_, err = findENI(enis, []*current.IPConfig{ipConfig("10.0.9.2/24")})
+	assert.Error(t, err)
+}
+
+func TestHookDsts(t *testing.T) {
+	hostAddrs := []net.IP{net.ParseIP("192.168.0.10"), net.ParseIP("fd01::10")}
+	serviceCIDRs := []string{"10.96.0.0/12", "fd02::/108"}
+
+	dsts := hookDsts(serviceCIDRs, hostAddrs, net.ParseIP("10.0.0.3"))
+	require.Len(t, dsts, 2)
+	assert.Equal(t, "10.96.0.0/12", dsts[0].String())
+	assert.Equal(t, "192.168.0.10/32", dsts[1].String())
+
+	dsts = hookDsts(serviceCIDRs, hostAddrs, net.ParseIP("fd00::2"))
+	require.Len(t, dsts, 2)
+	assert.Equal(t, "fd02::/108", dsts[0].String())
+	assert.Equal(t, "fd01::10/128", dsts[1].String())
+}
+
+func TestLoadConf(t *testing.T) {
+	conf, err := loadConf([]byte(`{"name":"generic-veth","type":"cipvlan","ipam":{"type":"cipam"}}`))
+	require.NoError(t, err)
+	assert.Equal(t, modeL2, conf.Mode)
+
+	conf, err = loadConf([]byte(`{"type":"cipvlan","mode":"l3","serviceCIDRs":["10.96.0.0/12"]}`))
+	require.NoError(t, err)
+	assert.Equal(t, modeL3, conf.Mode)
+	assert.Equal(t, []string{"10.96.0.0/12"}, conf.ServiceCIDRs)
+
+	_, err = loadConf([]byte(`{"type":"cipvlan","mode":"l3s"}`))
+	assert.Error(t, err)
+
+	_, err = loadConf([]byte(`{"type":"cipvlan","serviceCIDRs":["10.96.0.0"]}`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,342 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+// cipvlan attaches the pods using the secondary IPs of the shared ENIs to the
+// link of the ENI owning the IP as ipvlan slaves. The packets of the pods are
+// sent to the VPC through the ENI directly, without the veth and the policy
+// routing on the host. As the ipvlan master can not reach its slaves, a veth
+// pair is added to the pod as the hook to the host, the services and the host
+// are accessed through it.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"runtime"
+
+	"github.com/containernetworking/cni/pkg/skel"
+	"github.com/containernetworking/cni/pkg/types"
+	current "github.com/containernetworking/cni/pkg/types/100"
+	"github.com/containernetworking/cni/pkg/version"
+	"github.com/containernetworking/plugins/pkg/ip"
+	"github.com/containernetworking/plugins/pkg/ipam"
+	"github.com/containernetworking/plugins/pkg/ns"
+	bv "github.com/containernetworking/plugins/pkg/utils/buildversion"
+	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/client"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/defaults"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging/logfields"
+)
+
+const (
+	pluginName = "cipvlan"
+
+	modeL2 = "l2"
+	modeL3 = "l3"
+)
+
+var logger *logrus.Entry
+
+func init() {
+	// this ensures that main runs only on main thread (thread group leader).
+	// since namespace ops (unshare, setns) are done for a single thread, we
+	// must ensure that the goroutine does not jump from OS thread to thread
+	runtime.LockOSThread()
+}
+
+// NetConf is the configuration of the cipvlan plugin
+type NetConf struct {
+	types.NetConf
+	MTU int `json:"mtu"`
+	// Mode is the mode of the ipvlan slave, l2 or l3, defaults to l2
+	Mode string `json:"mode,omitempty"`
+	// ServiceCIDRs are the CIDRs of the Kubernetes services, they are routed
+	// through the hook to the host
+	ServiceCIDRs []string `json:"serviceCIDRs,omitempty"`
+}
+
+// K8SArgs k8s pod args
+type K8SArgs struct {
+	types.CommonArgs `json:"commonArgs"`
+	// IP is pod's ip address
+	IP net.IP `json:"ip"`
+	// K8S_POD_NAME is pod's name
+	K8S_POD_NAME types.UnmarshallableString `json:"k8s_pod_name"`
+	// K8S_POD_NAMESPACE is pod's namespace
+	K8S_POD_NAMESPACE types.UnmarshallableString `json:"k8s_pod_namespace"`
+	// K8S_POD_INFRA_CONTAINER_ID is pod's container ID
+	K8S_POD_INFRA_CONTAINER_ID types.UnmarshallableString `json:"k8s_pod_infra_container_id"`
+}
+
+func loadConf(data []byte) (*NetConf, error) {
+	conf := &NetConf{}
+	if err := json.Unmarshal(data, conf); err != nil {
+		return nil, fmt.Errorf("failed to load netconf: %v", err)
+	}
+	if conf.Mode == "" {
+		conf.Mode = modeL2
+	}
+	if _, err := ipvlanMode(conf.Mode); err != nil {
+		return nil, err
+	}
+	for _, cidr := range conf.ServiceCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return nil, fmt.Errorf("invalid service CIDR %q: %v", cidr, err)
+		}
+	}
+	return conf, nil
+}
+
+func loadK8SArgs(envArgs string) (*K8SArgs, error) {
+	k8sArgs := K8SArgs{}
+	if envArgs != "" {
+		err := types.LoadArgs(envArgs, &k8sArgs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &k8sArgs, nil
+}
+
+func ipvlanMode(mode string) (netlink.IPVlanMode, error) {
+	switch mode {
+	case modeL2:
+		return netlink.IPVLAN_MODE_L2, nil
+	case modeL3:
+		return netlink.IPVLAN_MODE_L3, nil
+	}
+	return 0, fmt.Errorf("unknown ipvlan mode %q, must be %s or %s", mode, modeL2, modeL3)
+}
+
+func cmdAdd(args *skel.CmdArgs) (err error) {
+	logging.SetupCNILogging("cni", true)
+	logger = logging.DefaultLogger.WithFields(logrus.Fields{
+		"cmdArgs": logfields.Json(args),
+		"plugin":  pluginName,
+		"mod":     "ADD",
+	})
+	defer func() {
+		if err != nil {
+			logger.WithError(err).Error("failed to exec plugin")
+		} else {
+			logger.Info("successfully to exec plugin")
+		}
+	}()
+
+	k8sArgs, err := loadK8SArgs(args.Args)
+	if err != nil {
+		return fmt.Errorf("failed to load CNI_ARGS: %v", err)
+	}
+	conf, err := loadConf(args.StdinData)
+	if err != nil {
+		return err
+	}
+
+	netns, err := ns.GetNS(args.Netns)
+	if err != nil {
+		return fmt.Errorf("failed to open netns %q: %v", args.Netns, err)
+	}
+	defer netns.Close()
+
+	r, err := ipam.ExecAdd(conf.IPAM.Type, args.StdinData)
+	if err != nil {
+		return fmt.Errorf("failed to execute IPAM plugin: %w", err)
+	}
+	// release the IPs if the pod fails to be attached
+	defer func() {
+		if err != nil {
+			if delErr := ipam.ExecDel(conf.IPAM.Type, args.StdinData); delErr != nil {
+				err = fmt.Errorf("%v; rollback failed: %v", err, delErr)
+				logger.WithError(delErr).Error("failed to release IPAM resources")
+			}
+		}
+	}()
+
+	result, err := current.NewResultFromResult(r)
+	if err != nil {
+		return fmt.Errorf("could not convert result of IPAM plugin: %v", err)
+	}
+	logger.WithField("ipamResult", result).Infof("got result from IPAM")
+	if len(result.IPs) == 0 {
+		return errors.New("IPAM plugin returned missing IP config")
+	}
+
+	c, err := client.NewDefaultClientWithTimeout(defaults.ClientConnectTimeout)
+	if err != nil {
+		return fmt.Errorf("unable to connect to network-v2-agent: %s", client.Hint(err))
+	}
+	enis, err := c.ENIList()
+	if err != nil {
+		return fmt.Errorf("failed to list ENIs of the node: %w", err)
+	}
+	eni, err := findENI(enis, result.IPs)
+	if err != nil {
+		return err
+	}
+	logger = logger.WithFields(logrus.Fields{
+		"eni":    eni.ID,
+		"master": eni.LinkName,
+	})
+
+	if err := ip.EnableForward(result.IPs); err != nil {
+		return fmt.Errorf("could not enable IP forwarding: %v", err)
+	}
+
+	// the host side of the hook uses the same name as the host veth of cptp,
+	// so that the network policies matching the veth prefix keep working
+	hookName := vethNameForPod(string(k8sArgs.K8S_POD_NAME), string(k8sArgs.K8S_POD_NAMESPACE), "veth")
+	comment := hookComment(conf, args)
+	defer func() {
+		if err != nil {
+			teardownHookMasq(hookName, comment)
+			if rollbackErr := rollbackLinks(netns, args.IfName, hookName); rollbackErr != nil {
+				err = fmt.Errorf("%v; rollback failed: %v", err, rollbackErr)
+			}
+		}
+	}()
+
+	contIface, err := setupIpvlan(netns, int(eni.LinkIndex), args.IfName, conf, result)
+	if err != nil {
+		return fmt.Errorf("failed to setup ipvlan slave of %s: %w", eni.LinkName, err)
+	}
+	hostHook, contHook, err := setupHook(netns, hookName, conf, result)
+	if err != nil {
+		return fmt.Errorf("failed to setup host hook: %w", err)
+	}
+	if err = setupHookMasq(hookName, result, comment); err != nil {
+		return fmt.Errorf("failed to setup masquerade of host hook: %w", err)
+	}
+
+	result.Interfaces = []*current.Interface{contIface, hostHook, contHook}
+	for _, ipc := range result.IPs {
+		ipc.Interface = current.Int(0)
+	}
+
+	logger.WithField("result", logfields.Json(result)).Infof("success to exec plugin")
+	return types.PrintResult(result, conf.CNIVersion)
+}
+
+func cmdDel(args *skel.CmdArgs) (err error) {
+	logging.SetupCNILogging("cni", true)
+	logger = logging.DefaultLogger.WithFields(logrus.Fields{
+		"cmdArgs": logfields.Json(args),
+		"plugin":  pluginName,
+		"mod":     "DEL",
+	})
+	defer func() {
+		if err != nil {
+			logger.WithError(err).Error("failed to exec plugin")
+		} else {
+			logger.Info("successfully to exec plugin")
+		}
+	}()
+
+	k8sArgs, err := loadK8SArgs(args.Args)
+	if err != nil {
+		return fmt.Errorf("failed to load CNI_ARGS: %v", err)
+	}
+	conf, err := loadConf(args.StdinData)
+	if err != nil {
+		return err
+	}
+
+	if err := ipam.ExecDel(conf.IPAM.Type, args.StdinData); err != nil {
+		return fmt.Errorf("failed to exec ipam del: %v", err)
+	}
+	logger.Info("success to executing cipam DEL")
+
+	// the masquerade rules and the host side of the hook are removed even if
+	// the netns is gone already
+	hookName := vethNameForPod(string(k8sArgs.K8S_POD_NAME), string(k8sArgs.K8S_POD_NAMESPACE), "veth")
+	if err := teardownHookMasq(hookName, hookComment(conf, args)); err != nil {
+		logger.WithError(err).Error("failed to teardown masquerade of host hook")
+	}
+
+	var netns ns.NetNS
+	if args.Netns != "" {
+		netns, err = ns.GetNS(args.Netns)
+		if err != nil {
+			// Delete can be called multiple times, the netns may be removed
+			// by the runtime already
+			// https://github.com/kubernetes/kubernetes/issues/43014#issuecomment-287164444
+			if _, ok := err.(ns.NSPathNotExistErr); !ok {
+				return fmt.Errorf("failed to open netns %q: %v", args.Netns, err)
+			}
+			netns = nil
+		} else {
+			defer netns.Close()
+		}
+	}
+	return rollbackLinks(netns, args.IfName, hookName)
+}
+
+func cmdCheck(args *skel.CmdArgs) error {
+	conf, err := loadConf(args.StdinData)
+	if err != nil {
+		return err
+	}
+
+	netns, err := ns.GetNS(args.Netns)
+	if err != nil {
+		return fmt.Errorf("failed to open netns %q: %v", args.Netns, err)
+	}
+	defer netns.Close()
+
+	err = ipam.ExecCheck(conf.IPAM.Type, args.StdinData)
+	if err != nil {
+		return err
+	}
+	if conf.NetConf.RawPrevResult == nil {
+		return fmt.Errorf("%s: Required prevResult missing", pluginName)
+	}
+	if err := version.ParsePrevResult(&conf.NetConf); err != nil {
+		return err
+	}
+	result, err := current.NewResultFromResult(conf.PrevResult)
+	if err != nil {
+		return err
+	}
+
+	var contIface *current.Interface
+	for _, intf := range result.Interfaces {
+		if intf.Name == args.IfName && intf.Sandbox == args.Netns {
+			contIface = intf
+			break
+		}
+	}
+	if contIface == nil {
+		return fmt.Errorf("container interface %s in netns %s is missing in prevResult", args.IfName, args.Netns)
+	}
+
+	mode, _ := ipvlanMode(conf.Mode)
+	return netns.Do(func(_ ns.NetNS) error {
+		if err := validateIpvlan(contIface, mode); err != nil {
+			return err
+		}
+		if err := ip.ValidateExpectedInterfaceIPs(args.IfName, result.IPs); err != nil {
+			return err
+		}
+		return ip.ValidateExpectedRoute(result.Routes)
+	})
+}
+
+func main() {
+	skel.PluginMain(cmdAdd, cmdCheck, cmdDel, version.All, bv.BuildString(pluginName))
+}
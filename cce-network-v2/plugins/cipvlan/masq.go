@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+package main
+
+import (
+	"fmt"
+
+	"github.com/containernetworking/cni/pkg/skel"
+	current "github.com/containernetworking/cni/pkg/types/100"
+	"github.com/containernetworking/plugins/pkg/utils"
+	"github.com/coreos/go-iptables/iptables"
+)
+
+// The packets forwarded to the pod through the hook are masqueraded to the
+// host IP. The pod replies to the IPs out of the node through the ipvlan slave
+// directly, so the replies to the clients of the services, which were DNATed
+// on the host, would bypass the conntrack of the host without it.
+
+func hookComment(conf *NetConf, args *skel.CmdArgs) string {
+	return utils.FormatComment(conf.Name, args.ContainerID)
+}
+
+func hookMasqRule(hookName, comment string) []string {
+	return []string{"-o", hookName, "-m", "comment", "--comment", comment, "-j", "MASQUERADE"}
+}
+
+// setupHookMasq masquerades the packets forwarded through the hook for the
+// IP families of the pod
+func setupHookMasq(hookName string, result *current.Result, comment string) error {
+	for _, proto := range resultProtocols(result) {
+		ipt, err := iptables.NewWithProtocol(proto)
+		if err != nil {
+			return fmt.Errorf("failed to locate iptables: %v", err)
+		}
+		if err := ipt.AppendUnique("nat", "POSTROUTING", hookMasqRule(hookName, comment)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// teardownHookMasq removes the masquerade rules of the hook of both families
+func teardownHookMasq(hookName, comment string) error {
+	var lastErr error
+	for _, proto := range []iptables.Protocol{iptables.ProtocolIPv4, iptables.ProtocolIPv6} {
+		ipt, err := iptables.NewWithProtocol(proto)
+		if err != nil {
+			lastErr = fmt.Errorf("failed to locate iptables: %v", err)
+			continue
+		}
+		if err := ipt.DeleteIfExists("nat", "POSTROUTING", hookMasqRule(hookName, comment)...); err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+func resultProtocols(result *current.Result) []iptables.Protocol {
+	var hasIPv4, hasIPv6 bool
+	for _, ipc := range result.IPs {
+		if ipc.Address.IP.To4() != nil {
+			hasIPv4 = true
+		} else {
+			hasIPv6 = true
+		}
+	}
+	var protos []iptables.Protocol
+	if hasIPv4 {
+		protos = append(protos, iptables.ProtocolIPv4)
+	}
+	if hasIPv6 {
+		protos = append(protos, iptables.ProtocolIPv6)
+	}
+	return protos
+}
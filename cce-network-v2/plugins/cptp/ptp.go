@@ -15,6 +15,7 @@
 package main
 
 import (
+	"context"
 	"crypto/sha1"
 	"encoding/hex"
 	"encoding/json"
@@ -27,8 +28,10 @@ import (
 
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging/logfields"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/tracing"
 	"github.com/sirupsen/logrus"
 	"github.com/vishvananda/netlink"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/containernetworking/cni/pkg/skel"
 	"github.com/containernetworking/cni/pkg/types"
@@ -143,6 +146,11 @@ type NetConf struct {
 	MTU                       int    `json:"mtu"`
 	DefaultGW                 string `json:"defaultGW,omitempty"`
 	PluginIpRequestRetryTimes int    `json:"retryTimes,omitempty"`
+	// Verify is the connectivity verification after the network of the pod
+	// is set up
+	Verify *VerifyConf `json:"verify,omitempty"`
+	// Tracing is the configuration of the OpenTelemetry exporter
+	Tracing *tracing.Config `json:"tracing,omitempty"`
 }
 
 func setupContainerVethLegacy(podname string, namespace string, netns ns.NetNS, ifName string, mtu int, pr *current.Result) (*current.Interface, *current.Interface, error) {
@@ -319,6 +327,25 @@ func cmdAdd(args *skel.CmdArgs) (err error) {
 	if err := json.Unmarshal(args.StdinData, &conf); err != nil {
 		return fmt.Errorf("failed to load netconf: %v", err)
 	}
+	if conf.Verify, err = loadVerifyConf(conf.Verify); err != nil {
+		return err
+	}
+
+	// tracing is best-effort, it never fails the CNI request
+	if shutdownTracing, traceErr := tracing.Init("cptp", conf.Tracing); traceErr != nil {
+		logger.WithError(traceErr).Warn("failed to initialize tracing")
+	} else {
+		defer shutdownTracing()
+	}
+	ctx, span := tracing.Start(tracing.FromEnv(context.Background()), "cni/cptp-add",
+		attribute.String("containerID", args.ContainerID), attribute.String("pod", string(pK8Sargs.K8S_POD_NAMESPACE)+"/"+string(pK8Sargs.K8S_POD_NAME)))
+	defer func() {
+		tracing.End(span, err)
+	}()
+	// the IPAM plugin inherits the environment and traces as the child span
+	if err := tracing.SetEnv(ctx); err != nil {
+		logger.WithError(err).Warn("failed to set the trace context to the IPAM plugin")
+	}
 
 	netns, err := ns.GetNS(args.Netns)
 	if err != nil {
@@ -341,7 +368,7 @@ func cmdAdd(args *skel.CmdArgs) (err error) {
 	}()
 
 	for i := 0; i <= conf.PluginIpRequestRetryTimes; i++ {
-		result, err = configureNetworkWithIPAM(&conf, pK8Sargs, args, netns, &DefaultNetworkConfigurer{}, &DefalutNetworkChecker{}, &DefaultIPAM{}, &DefaultIPMasqManager{})
+		result, err = configureNetworkWithIPAM(&conf, pK8Sargs, args, netns, &DefaultNetworkConfigurer{}, NewNetworkChecker(conf.Verify), &DefaultIPAM{}, &DefaultIPMasqManager{})
 		if err != nil {
 			if isIPRelatedError(err) {
 				IpRetryErrorList = append(IpRetryErrorList, fmt.Errorf("retry %d failed: %v", i, err))
@@ -640,10 +667,8 @@ func configureNetworkWithIPAM(conf *NetConf, pK8Sargs *K8SArgs, args *skel.CmdAr
 
 	// After the container network is configured, verify the reliability of the
 	// IP provided by the IaasS by testing the connectivity between the container
-	// and the gateway.
-	err = VerifyNetworkConnectivity(result, netns, conf.MTU, networkChecker)
-	if err != nil {
-		logger.WithError(err).Error("Failed to verify network connectivity")
+	// and the targets.
+	if err = verifyConnectivity(conf, pK8Sargs, args, netns, result, networkChecker); err != nil {
 		return nil, err
 	}
 
@@ -651,6 +676,36 @@ func configureNetworkWithIPAM(conf *NetConf, pK8Sargs *K8SArgs, args *skel.CmdAr
 	return result, nil
 }
 
+// verifyConnectivity verifies the connectivity of the pod as the verify mode and
+// records the outcome on the endpoint. Only the failure in enforce mode is
+// returned.
+func verifyConnectivity(conf *NetConf, pK8Sargs *K8SArgs, args *skel.CmdArgs, netns ns.NetNS, result *current.Result, networkChecker NetworkChecker) error {
+	verifyConf, err := loadVerifyConf(conf.Verify)
+	if err != nil {
+		return err
+	}
+	if verifyConf.Mode == VerifyModeOff {
+		return nil
+	}
+
+	outcome, verifyErr := VerifyNetworkConnectivity(result, netns, conf.MTU, verifyConf, networkChecker)
+	if verifyErr != nil {
+		logger.WithError(verifyErr).WithField("mode", verifyConf.Mode).Error("Failed to verify network connectivity")
+	}
+
+	// the report is best-effort, the agent may be unavailable
+	owner := string(pK8Sargs.K8S_POD_NAMESPACE + "/" + pK8Sargs.K8S_POD_NAME)
+	status := verifyStatus(verifyConf, outcome, verifyErr)
+	if err := networkChecker.ReportConnectivityStatus(owner, args.ContainerID, status); err != nil {
+		logger.WithError(err).Warning("failed to report connectivity status")
+	}
+
+	if verifyConf.Mode == VerifyModeEnforce {
+		return verifyErr
+	}
+	return nil
+}
+
 // VethNameForPod return host-side veth name for pod
 // max veth length is 15
 func vethNameForPod(name, namespace, prefix string) string {
@@ -21,6 +21,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging/logfields"
 	"github.com/sirupsen/logrus"
@@ -53,11 +54,24 @@ func (m *MockNetworkUtils) GetDefaultGateway() (net.IP, error) {
 	return nil, args.Error(1)
 }
 
+func (m *MockNetworkUtils) GetNodeIP() (net.IP, error) {
+	args := m.Called()
+	if ip, ok := args.Get(0).(net.IP); ok {
+		return ip, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func (m *MockNetworkUtils) SendIcmpProbeInNetNS(netns ns.NetNS, srcIP net.IP, targetIP net.IP, mtu int) (bool, error) {
 	args := m.Called(netns, srcIP, targetIP, mtu)
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockNetworkUtils) ReportConnectivityStatus(owner, containerID string, status *models.ExtFeatureStatus) error {
+	args := m.Called(owner, containerID, status)
+	return args.Error(0)
+}
+
 type Net struct {
 	Name          string                 `json:"name"`
 	CNIVersion    string                 `json:"cniVersion"`
@@ -668,6 +682,7 @@ var _ = Describe("configureNetworkWithIPAM", func() {
 		mockIPAM = &MockIPAM{}
 		mockConfigurer = &MockNetworkConfigurer{}
 		mockChecker = &MockNetworkChecker{}
+		mockChecker.On("ReportConnectivityStatus", "default/test-pod", "dummy", mock.Anything).Return(nil)
 		mockIPMasqManager = &MockIPMasqManager{}
 	})
 
@@ -814,4 +829,62 @@ var _ = Describe("configureNetworkWithIPAM", func() {
 		Expect(err.Error()).To(ContainSubstring("failed to verify network connectivity"))
 		Expect(result).To(BeNil())
 	})
+
+	It("should not fail if network connectivity verification fails in warn mode", func() {
+		ipConfig := &types100.IPConfig{
+			Address: net.IPNet{
+				IP:   net.ParseIP("192.168.1.10"),
+				Mask: net.CIDRMask(24, 32),
+			},
+			Gateway: net.ParseIP("192.168.1.1"),
+		}
+		result = &types100.Result{
+			CNIVersion: "1.0.0",
+			IPs:        []*types100.IPConfig{ipConfig},
+		}
+		conf.Verify = &VerifyConf{Mode: VerifyModeWarn}
+
+		mockIPAM.On("ExecAdd", "", args.StdinData).Return(result, nil)
+		mockIPAM.On("ExecDel", mock.Anything, mock.Anything).Return(nil)
+		mockConfigurer.On("SetupContainerVeth", "test-pod", "default", netns, "eth0", 1500, result).Return(&types100.Interface{}, &types100.Interface{}, nil)
+		mockConfigurer.On("SetupHostVeth", mock.Anything, mock.Anything, result).Return(nil)
+		mockIPMasqManager.On("SetupIPMasq", &ipConfig.Address, mock.Anything, mock.Anything).Return(nil)
+		mockIPMasqManager.On("TeardownIPMasq", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mockChecker.On("SendIcmpProbeInNetNS", netns, net.ParseIP("192.168.1.10"), net.ParseIP("192.168.1.1"), 1500).Return(false, nil)
+
+		result, err = configureNetworkWithIPAM(conf, pK8Sargs, args, netns, mockConfigurer, mockChecker, mockIPAM, mockIPMasqManager)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).NotTo(BeNil())
+		mockChecker.AssertCalled(GinkgoT(), "ReportConnectivityStatus", "default/test-pod", "dummy", mock.MatchedBy(func(status *models.ExtFeatureStatus) bool {
+			return !status.Ready && status.Data["192.168.1.1"] == "unreachable"
+		}))
+	})
+
+	It("should skip network connectivity verification in off mode", func() {
+		ipConfig := &types100.IPConfig{
+			Address: net.IPNet{
+				IP:   net.ParseIP("192.168.1.10"),
+				Mask: net.CIDRMask(24, 32),
+			},
+			Gateway: net.ParseIP("192.168.1.1"),
+		}
+		result = &types100.Result{
+			CNIVersion: "1.0.0",
+			IPs:        []*types100.IPConfig{ipConfig},
+		}
+		conf.Verify = &VerifyConf{Mode: VerifyModeOff}
+
+		mockIPAM.On("ExecAdd", "", args.StdinData).Return(result, nil)
+		mockIPAM.On("ExecDel", mock.Anything, mock.Anything).Return(nil)
+		mockConfigurer.On("SetupContainerVeth", "test-pod", "default", netns, "eth0", 1500, result).Return(&types100.Interface{}, &types100.Interface{}, nil)
+		mockConfigurer.On("SetupHostVeth", mock.Anything, mock.Anything, result).Return(nil)
+		mockIPMasqManager.On("SetupIPMasq", &ipConfig.Address, mock.Anything, mock.Anything).Return(nil)
+		mockIPMasqManager.On("TeardownIPMasq", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		result, err = configureNetworkWithIPAM(conf, pK8Sargs, args, netns, mockConfigurer, mockChecker, mockIPAM, mockIPMasqManager)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).NotTo(BeNil())
+		mockChecker.AssertNotCalled(GinkgoT(), "SendIcmpProbeInNetNS", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockChecker.AssertNotCalled(GinkgoT(), "ReportConnectivityStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
 })
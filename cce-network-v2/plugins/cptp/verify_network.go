@@ -4,34 +4,155 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"strings"
 	"time"
 
 	current "github.com/containernetworking/cni/pkg/types/100"
 
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/client/endpoint"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/client"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/defaults"
 	"github.com/containernetworking/plugins/pkg/ns"
 	"github.com/vishvananda/netlink"
 	"golang.org/x/net/icmp"
 	"golang.org/x/net/ipv4"
 )
 
-// icmpProbeTimeout for sending ICMP packets
-var icmpProbeTimeout = 500 * time.Millisecond
+const (
+	// VerifyModeOff disables the connectivity verification
+	VerifyModeOff = "off"
+	// VerifyModeWarn verifies the connectivity and records the outcome, the
+	// failure does not fail the pod
+	VerifyModeWarn = "warn"
+	// VerifyModeEnforce verifies the connectivity and retries another IP if
+	// the verification fails
+	VerifyModeEnforce = "enforce"
+
+	// VerifyTargetGateway is the gateway of the pod, or the default gateway of
+	// the node in vpc route mode
+	VerifyTargetGateway = "gateway"
+	// VerifyTargetNode is the primary IP of the node
+	VerifyTargetNode = "node"
+
+	// extFeatureConnectivityVerify is the name of the external feature that
+	// the outcome of the verification is recorded as on the endpoint
+	extFeatureConnectivityVerify = "connectivity-verify"
+
+	defaultVerifyRetries = 3
+	// defaultVerifyTimeout for receiving the reply of an ICMP packet
+	defaultVerifyTimeout = 500 * time.Millisecond
+)
+
+// VerifyConf is the configuration of the connectivity verification after the
+// network of the pod is set up
+type VerifyConf struct {
+	// Mode is one of off, warn and enforce, defaults to enforce
+	Mode string `json:"mode,omitempty"`
+	// Targets are the targets to ping, gateway, node or IP addresses,
+	// defaults to gateway
+	Targets []string `json:"targets,omitempty"`
+	// Retries is the number of ICMP packets sent to each target, defaults to 3
+	Retries int `json:"retries,omitempty"`
+	// Timeout of waiting for the reply of each ICMP packet, defaults to 500ms
+	Timeout string `json:"timeout,omitempty"`
+
+	timeout time.Duration
+}
+
+// loadVerifyConf validates the verification configuration and fills the defaults
+func loadVerifyConf(conf *VerifyConf) (*VerifyConf, error) {
+	c := &VerifyConf{}
+	if conf != nil {
+		*c = *conf
+	}
+	switch c.Mode {
+	case "":
+		c.Mode = VerifyModeEnforce
+	case VerifyModeOff, VerifyModeWarn, VerifyModeEnforce:
+	default:
+		return nil, fmt.Errorf("unknown verify mode %q, must be one of %s, %s and %s", c.Mode, VerifyModeOff, VerifyModeWarn, VerifyModeEnforce)
+	}
+	if len(c.Targets) == 0 {
+		c.Targets = []string{VerifyTargetGateway}
+	}
+	for _, target := range c.Targets {
+		if target != VerifyTargetGateway && target != VerifyTargetNode && net.ParseIP(target) == nil {
+			return nil, fmt.Errorf("invalid verify target %q, must be %s, %s or an IP address", target, VerifyTargetGateway, VerifyTargetNode)
+		}
+	}
+	if c.Retries < 0 {
+		return nil, fmt.Errorf("invalid verify retries %d", c.Retries)
+	}
+	if c.Retries == 0 {
+		c.Retries = defaultVerifyRetries
+	}
+	c.timeout = defaultVerifyTimeout
+	if c.Timeout != "" {
+		timeout, err := time.ParseDuration(c.Timeout)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("invalid verify timeout %q", c.Timeout)
+		}
+		c.timeout = timeout
+	}
+	return c, nil
+}
 
 // NetworkChecker interface defines methods for network utilities
 type NetworkChecker interface {
 	GetDefaultGateway() (net.IP, error)
+	GetNodeIP() (net.IP, error)
 	SendIcmpProbeInNetNS(netns ns.NetNS, srcIP net.IP, targetIP net.IP, mtu int) (bool, error)
+	// ReportConnectivityStatus records the outcome of the verification on the
+	// endpoint of the pod
+	ReportConnectivityStatus(owner, containerID string, status *models.ExtFeatureStatus) error
 }
 
 // DefalutNetworkChecker implements the NetworkUtils interface
-type DefalutNetworkChecker struct{}
+type DefalutNetworkChecker struct {
+	// probes is the number of ICMP packets sent to a target
+	probes int
+	// timeout of waiting for the reply of an ICMP packet
+	timeout time.Duration
+}
+
+// NewNetworkChecker creates the network checker sending ICMP packets as the
+// verification configuration
+func NewNetworkChecker(conf *VerifyConf) *DefalutNetworkChecker {
+	return &DefalutNetworkChecker{probes: conf.Retries, timeout: conf.timeout}
+}
 
 func (d *DefalutNetworkChecker) GetDefaultGateway() (net.IP, error) {
 	return getDefaultGateway()
 }
 
+func (d *DefalutNetworkChecker) GetNodeIP() (net.IP, error) {
+	return getNodeIP()
+}
+
 func (d *DefalutNetworkChecker) SendIcmpProbeInNetNS(netns ns.NetNS, srcIP net.IP, targetIP net.IP, mtu int) (bool, error) {
-	return sendIcmpProbeInNetNS(netns, srcIP, targetIP, mtu)
+	probes, timeout := d.probes, d.timeout
+	if probes <= 0 {
+		probes = defaultVerifyRetries
+	}
+	if timeout <= 0 {
+		timeout = defaultVerifyTimeout
+	}
+	return sendIcmpProbeInNetNS(netns, srcIP, targetIP, mtu, probes, timeout)
+}
+
+func (d *DefalutNetworkChecker) ReportConnectivityStatus(owner, containerID string, status *models.ExtFeatureStatus) error {
+	c, err := client.NewDefaultClientWithTimeout(defaults.ClientConnectTimeout)
+	if err != nil {
+		return fmt.Errorf("unable to connect to cce-network-v2-agent: %s", client.Hint(err))
+	}
+	param := endpoint.NewPutEndpointExtpluginStatusParams().WithTimeout(defaults.ClientConnectTimeout).
+		WithOwner(&owner).WithContainerID(&containerID).WithFeature(extFeatureConnectivityVerify).WithStatus(status)
+	_, err = c.Endpoint.PutEndpointExtpluginStatus(param)
+	if err != nil {
+		return fmt.Errorf("unable to put endpoint extplugin status: %s", client.Hint(err))
+	}
+	return nil
 }
 
 // Get the default gateway for IPv4
@@ -55,11 +176,41 @@ func getDefaultGateway() (net.IP, error) {
 	return nil, fmt.Errorf("no default gateway found")
 }
 
+// Get the IPv4 address of the node on the link of the default route
+func getNodeIP() (net.IP, error) {
+	routes, err := netlink.RouteList(nil, netlink.FAMILY_V4)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get routes: %v", err)
+	}
+	for _, route := range routes {
+		if route.Dst != nil && !route.Dst.IP.Equal(net.IPv4zero) {
+			continue
+		}
+		if route.Src != nil {
+			return route.Src, nil
+		}
+		link, err := netlink.LinkByIndex(route.LinkIndex)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get link of default route: %v", err)
+		}
+		addrs, err := netlink.AddrList(link, netlink.FAMILY_V4)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list addresses of %s: %v", link.Attrs().Name, err)
+		}
+		for _, addr := range addrs {
+			if addr.IP.IsGlobalUnicast() {
+				return addr.IP, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("no node IP found")
+}
+
 // sendIcmpProbeInNetNS sends ICMP Echo Requests from the specified source IP to the target IP
 // within the given network namespace (netns). It sends multiple ICMP packets and returns
 // true if it receives at least one valid ICMP Echo Reply within the timeout period.
 // The function returns false if no reply is received or if an error occurs
-func sendIcmpProbeInNetNS(netns ns.NetNS, srcIP net.IP, targetIP net.IP, mtu int) (bool, error) {
+func sendIcmpProbeInNetNS(netns ns.NetNS, srcIP net.IP, targetIP net.IP, mtu int, numPings int, timeout time.Duration) (bool, error) {
 	reachable := false
 	var err error
 
@@ -82,7 +233,6 @@ func sendIcmpProbeInNetNS(netns ns.NetNS, srcIP net.IP, targetIP net.IP, mtu int
 
 		// Send multiple ICMP Echo Requests
 		targetAddr := &net.IPAddr{IP: targetIP}
-		numPings := 3 // Send 3 ICMP packets
 		for i := 0; i < numPings; i++ {
 			// Construct an ICMP Echo Request
 			msg := icmp.Message{
@@ -106,7 +256,7 @@ func sendIcmpProbeInNetNS(netns ns.NetNS, srcIP net.IP, targetIP net.IP, mtu int
 			}
 
 			// Set a timeout for receiving
-			if err := conn.SetReadDeadline(time.Now().Add(icmpProbeTimeout)); err != nil {
+			if err := conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
 				return fmt.Errorf("failed to set read deadline: %v", err)
 			}
 
@@ -141,9 +291,11 @@ func sendIcmpProbeInNetNS(netns ns.NetNS, srcIP net.IP, targetIP net.IP, mtu int
 }
 
 // VerifyNetworkConnectivity verifies network connectivity for a list of IP addresses.
-// It skips non-IPv4 addresses, determines the appropriate gateway, and pings the gateway
-// to ensure connectivity.
-func VerifyNetworkConnectivity(result *current.Result, netns ns.NetNS, mtu int, nc NetworkChecker) error {
+// It skips non-IPv4 addresses, determines the targets of each address, and pings
+// the targets to ensure connectivity. The reachability of the targets is returned
+// even if the verification fails.
+func VerifyNetworkConnectivity(result *current.Result, netns ns.NetNS, mtu int, conf *VerifyConf, nc NetworkChecker) (map[string]string, error) {
+	outcome := make(map[string]string)
 	for _, IP := range result.IPs {
 		// Skip non-IPv4 addresses
 		if IP.Address.IP.To4() == nil {
@@ -152,31 +304,75 @@ func VerifyNetworkConnectivity(result *current.Result, netns ns.NetNS, mtu int,
 		}
 		startTime := time.Now()
 
-		// Determine the gateway to use
-		gateway := IP.Gateway
-		maskSize, _ := IP.Address.Mask.Size()
+		for _, target := range conf.Targets {
+			targetIP, ok, err := verifyTargetIP(IP, target, nc)
+			if err != nil {
+				return outcome, err
+			}
+			if !ok {
+				continue
+			}
+
+			// Ping the target to verify network connectivity
+			reachable, err := nc.SendIcmpProbeInNetNS(netns, IP.Address.IP, targetIP, mtu)
+			if err != nil {
+				outcome[targetIP.String()] = "error"
+				return outcome, NewIPRelatedError(IP.Address.String(), fmt.Sprintf("failed to verify network connectivity: %v", err))
+			}
+			if !reachable {
+				outcome[targetIP.String()] = "unreachable"
+				return outcome, NewIPRelatedError(IP.Address.String(), fmt.Sprintf("failed to ping %s %s", target, targetIP))
+			}
+			outcome[targetIP.String()] = "reachable"
+		}
 
+		executionTime := time.Since(startTime)
+
+		logger.Infof("successfully verified network connectivity for IP %s, time cost %s", IP.Address, executionTime.String())
+	}
+	return outcome, nil
+}
+
+// verifyTargetIP returns the IP of the target to ping from the IP of the pod,
+// false is returned if the target is an address of another family.
+func verifyTargetIP(IP *current.IPConfig, target string, nc NetworkChecker) (net.IP, bool, error) {
+	switch target {
+	case VerifyTargetGateway:
 		// If the mask size is 32, the cni mode is vpc route
-		if maskSize == 32 {
+		if maskSize, _ := IP.Address.Mask.Size(); maskSize == 32 {
 			defaultGateway, err := nc.GetDefaultGateway()
 			if err != nil {
-				return fmt.Errorf("failed to get default gateway for vpc route mode: %w", err)
+				return nil, false, fmt.Errorf("failed to get default gateway for vpc route mode: %w", err)
 			}
-			gateway = defaultGateway
+			return defaultGateway, true, nil
 		}
-
-		// Ping the gateway to verify network connectivity
-		reachable, err := nc.SendIcmpProbeInNetNS(netns, IP.Address.IP, gateway, mtu)
+		return IP.Gateway, true, nil
+	case VerifyTargetNode:
+		nodeIP, err := nc.GetNodeIP()
 		if err != nil {
-			return NewIPRelatedError(IP.Address.String(), fmt.Sprintf("failed to verify network connectivity: %v", err))
+			return nil, false, fmt.Errorf("failed to get node IP: %w", err)
 		}
-		if !reachable {
-			return NewIPRelatedError(IP.Address.String(), fmt.Sprintf("failed to ping gateway %s", gateway))
-		}
-
-		executionTime := time.Since(startTime)
+		return nodeIP, true, nil
+	}
+	targetIP := net.ParseIP(target)
+	if targetIP == nil || targetIP.To4() == nil {
+		return nil, false, nil
+	}
+	return targetIP, true, nil
+}
 
-		logger.Infof("successfully verified network connectivity for IP %s, time cost %s", IP.Address, executionTime.String())
+// verifyStatus is the status of the external feature recorded on the endpoint
+// for the outcome of the verification
+func verifyStatus(conf *VerifyConf, outcome map[string]string, err error) *models.ExtFeatureStatus {
+	status := &models.ExtFeatureStatus{
+		Ready: err == nil,
+		Data:  map[string]string{"mode": conf.Mode, "targets": strings.Join(conf.Targets, ",")},
 	}
-	return nil
+	for target, reachability := range outcome {
+		status.Data[target] = reachability
+	}
+	if err != nil {
+		status.Msg = err.Error()
+	}
+	return status
 }
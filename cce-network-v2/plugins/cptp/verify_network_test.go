@@ -3,7 +3,9 @@ package main
 import (
 	"fmt"
 	"net"
+	"time"
 
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging/logfields"
 	types100 "github.com/containernetworking/cni/pkg/types/100"
@@ -28,16 +30,31 @@ func (m *MockNetworkChecker) GetDefaultGateway() (net.IP, error) {
 	return ip.(net.IP), args.Error(1)
 }
 
+func (m *MockNetworkChecker) GetNodeIP() (net.IP, error) {
+	args := m.Called()
+	ip := args.Get(0)
+	if ip == nil {
+		return nil, args.Error(1)
+	}
+	return ip.(net.IP), args.Error(1)
+}
+
 func (m *MockNetworkChecker) SendIcmpProbeInNetNS(netns ns.NetNS, srcIP, targetIP net.IP, mtu int) (bool, error) {
 	args := m.Called(netns, srcIP, targetIP, mtu)
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockNetworkChecker) ReportConnectivityStatus(owner, containerID string, status *models.ExtFeatureStatus) error {
+	args := m.Called(owner, containerID, status)
+	return args.Error(0)
+}
+
 var _ NetworkChecker = &MockNetworkChecker{}
 
 var _ = Describe("VerifyNetworkConnectivity", func() {
 	var originalNS, targetNS ns.NetNS
 	var mockUtils *MockNetworkUtils
+	var verifyConf *VerifyConf
 	logger = logging.DefaultLogger.WithFields(logrus.Fields{
 		"cmdArgs": logfields.Json("test"),
 		"plugin":  "cptp",
@@ -52,6 +69,8 @@ var _ = Describe("VerifyNetworkConnectivity", func() {
 		Expect(err).NotTo(HaveOccurred())
 
 		mockUtils = &MockNetworkUtils{}
+		verifyConf, err = loadVerifyConf(nil)
+		Expect(err).NotTo(HaveOccurred())
 	})
 
 	AfterEach(func() {
@@ -79,7 +98,7 @@ var _ = Describe("VerifyNetworkConnectivity", func() {
 		err := targetNS.Do(func(ns.NetNS) error {
 			defer GinkgoRecover()
 
-			err := VerifyNetworkConnectivity(result, targetNS, 1500, mockUtils)
+			_, err := VerifyNetworkConnectivity(result, targetNS, 1500, verifyConf, mockUtils)
 			Expect(err).NotTo(HaveOccurred())
 			return nil
 		})
@@ -102,7 +121,7 @@ var _ = Describe("VerifyNetworkConnectivity", func() {
 		err := targetNS.Do(func(ns.NetNS) error {
 			defer GinkgoRecover()
 
-			err := VerifyNetworkConnectivity(result, targetNS, 1500, mockUtils)
+			_, err := VerifyNetworkConnectivity(result, targetNS, 1500, verifyConf, mockUtils)
 			Expect(err).NotTo(HaveOccurred())
 			return nil
 		})
@@ -127,7 +146,7 @@ var _ = Describe("VerifyNetworkConnectivity", func() {
 		err := targetNS.Do(func(ns.NetNS) error {
 			defer GinkgoRecover()
 
-			err := VerifyNetworkConnectivity(result, targetNS, 1500, mockUtils)
+			_, err := VerifyNetworkConnectivity(result, targetNS, 1500, verifyConf, mockUtils)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("failed to get default gateway for vpc route mode"))
 			return nil
@@ -153,7 +172,7 @@ var _ = Describe("VerifyNetworkConnectivity", func() {
 		err := targetNS.Do(func(ns.NetNS) error {
 			defer GinkgoRecover()
 
-			err := VerifyNetworkConnectivity(result, targetNS, 1500, mockUtils)
+			_, err := VerifyNetworkConnectivity(result, targetNS, 1500, verifyConf, mockUtils)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("failed to verify network connectivity"))
 			return nil
@@ -179,11 +198,72 @@ var _ = Describe("VerifyNetworkConnectivity", func() {
 		err := targetNS.Do(func(ns.NetNS) error {
 			defer GinkgoRecover()
 
-			err := VerifyNetworkConnectivity(result, targetNS, 1500, mockUtils)
+			_, err := VerifyNetworkConnectivity(result, targetNS, 1500, verifyConf, mockUtils)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("failed to ping gateway"))
 			return nil
 		})
 		Expect(err).NotTo(HaveOccurred())
 	})
+
+	It("should verify the node and the custom targets", func() {
+		result := &types100.Result{
+			IPs: []*types100.IPConfig{
+				{
+					Address: net.IPNet{
+						IP:   net.ParseIP("192.168.1.10"),
+						Mask: net.CIDRMask(24, 32),
+					},
+					Gateway: net.ParseIP("192.168.1.1"),
+				},
+			},
+		}
+		verifyConf.Targets = []string{VerifyTargetNode, "10.0.0.2", "fd00::2"}
+
+		mockUtils.On("GetNodeIP").Return(net.ParseIP("172.16.0.5"), nil)
+		mockUtils.On("SendIcmpProbeInNetNS", targetNS, net.ParseIP("192.168.1.10"), net.ParseIP("172.16.0.5"), 1500).Return(true, nil)
+		mockUtils.On("SendIcmpProbeInNetNS", targetNS, net.ParseIP("192.168.1.10"), net.ParseIP("10.0.0.2"), 1500).Return(false, nil)
+
+		err := targetNS.Do(func(ns.NetNS) error {
+			defer GinkgoRecover()
+
+			outcome, err := VerifyNetworkConnectivity(result, targetNS, 1500, verifyConf, mockUtils)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed to ping 10.0.0.2"))
+			Expect(outcome).To(Equal(map[string]string{"172.16.0.5": "reachable", "10.0.0.2": "unreachable"}))
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
+
+var _ = Describe("loadVerifyConf", func() {
+	It("should fill the defaults", func() {
+		conf, err := loadVerifyConf(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf.Mode).To(Equal(VerifyModeEnforce))
+		Expect(conf.Targets).To(Equal([]string{VerifyTargetGateway}))
+		Expect(conf.Retries).To(Equal(defaultVerifyRetries))
+		Expect(conf.timeout).To(Equal(defaultVerifyTimeout))
+	})
+
+	It("should parse the configuration", func() {
+		conf, err := loadVerifyConf(&VerifyConf{Mode: VerifyModeWarn, Targets: []string{VerifyTargetNode, "10.0.0.2"}, Retries: 5, Timeout: "1s"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf.Mode).To(Equal(VerifyModeWarn))
+		Expect(conf.Targets).To(Equal([]string{VerifyTargetNode, "10.0.0.2"}))
+		Expect(conf.Retries).To(Equal(5))
+		Expect(conf.timeout).To(Equal(time.Second))
+	})
+
+	It("should reject the invalid configuration", func() {
+		_, err := loadVerifyConf(&VerifyConf{Mode: "strict"})
+		Expect(err).To(HaveOccurred())
+		_, err = loadVerifyConf(&VerifyConf{Targets: []string{"router"}})
+		Expect(err).To(HaveOccurred())
+		_, err = loadVerifyConf(&VerifyConf{Retries: -1})
+		Expect(err).To(HaveOccurred())
+		_, err = loadVerifyConf(&VerifyConf{Timeout: "500"})
+		Expect(err).To(HaveOccurred())
+	})
 })
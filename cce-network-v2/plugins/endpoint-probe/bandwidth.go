@@ -1,8 +1,8 @@
 package main
 
 import (
-	"github.com/containernetworking/plugins/pkg/ns"
-	"github.com/pkg/errors"
+	"fmt"
+	"strconv"
 
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/datapath/bandwidth"
@@ -16,28 +16,44 @@ func handleBandwidth(cctx *link.ContainerContext, opt *models.BandwidthOption) e
 	}
 	switch opt.Mode {
 	case ccev2.BindwidthModeEDT:
-		logger.Warn("bandwidth mode edt have not been implemented yet")
+		// edt maps and bpf programs are maintained by agent
+		logger.Info("bandwidth of edt mode have been applied by agent")
 		return nil
 	default:
-		switch cctx.Driver {
-		case string(models.DatapathModeVeth), "":
-			if opt.Ingress > 0 {
-				err := bandwidth.SetupTBFQdisc(cctx.HostDev, uint64(opt.Ingress))
-				if err != nil {
-					return errors.Wrapf(err, "can not setup tbf qdisc on host device %s", cctx.HostDev.Attrs().Name)
-				}
-			}
-
-			if opt.Egress > 0 {
-				err := cctx.ContainerNetns.Do(func(_ ns.NetNS) error {
-					return bandwidth.SetupTBFQdisc(cctx.ContainerDev, uint64(opt.Egress))
-				})
-				if err != nil {
-					return errors.Wrapf(err, "can not setup tbf qdisc on container device %s", cctx.ContainerDev.Attrs().Name)
-				}
-			}
+		if !bandwidth.IsDriverSupportTC(cctx.Driver) {
+			logger.Warnf("bandwidth is unsupported by driver %s", cctx.Driver)
+			return nil
 		}
+		return bandwidth.SetupContainerTC(cctx, &ccev2.BindwidthOption{
+			Mode:    ccev2.BindwidthMode(opt.Mode),
+			Ingress: opt.Ingress,
+			Egress:  opt.Egress,
+		})
+	}
+}
 
+// checkBandwidth checks the tc qdiscs recorded in the bandwidth status of
+// endpoint are still in place.
+func checkBandwidth(cctx *link.ContainerContext, data map[string]string) ([]string, error) {
+	switch data["mode"] {
+	case string(ccev2.BindwidthModeEDT):
+		// edt maps and bpf programs are maintained by agent
+		return nil, nil
+	case bandwidth.BindwidthModeUnsupported:
+		return nil, nil
+	}
+
+	ingress, err := strconv.ParseInt(data["ingress"], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ingress bandwidth %q: %v", data["ingress"], err)
+	}
+	egress, err := strconv.ParseInt(data["egress"], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid egress bandwidth %q: %v", data["egress"], err)
 	}
-	return nil
+	return bandwidth.CheckContainerTC(cctx, &ccev2.BindwidthOption{
+		Mode:    ccev2.BindwidthMode(data["mode"]),
+		Ingress: ingress,
+		Egress:  egress,
+	})
 }
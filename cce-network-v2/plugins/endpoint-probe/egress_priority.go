@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	current "github.com/containernetworking/cni/pkg/types/100"
+	"github.com/vishvananda/netlink"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/datapath/qos"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/ip"
+)
+
+// checkEgressPriority checks the filters recorded in the egress priority status
+// of endpoint are still in place on the device of node.
+func checkEgressPriority(result *current.Result, data map[string]string) ([]string, error) {
+	if data["devIndex"] == "" {
+		return nil, nil
+	}
+	devIndex, err := strconv.Atoi(data["devIndex"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid devIndex %q: %v", data["devIndex"], err)
+	}
+	bands, err := strconv.ParseUint(data["bands"], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bands %q: %v", data["bands"], err)
+	}
+
+	dev, err := netlink.LinkByIndex(devIndex)
+	if err != nil {
+		if _, ok := err.(netlink.LinkNotFoundError); ok {
+			return []string{fmt.Sprintf("dev with index %d of egress priority not found", devIndex)}, nil
+		}
+		return nil, fmt.Errorf("failed to get dev with index %d: %v", devIndex, err)
+	}
+
+	var ipNets []*net.IPNet
+	for _, ipCfg := range result.IPs {
+		ipNet := &net.IPNet{IP: ipCfg.Address.IP, Mask: ip.IPv4Mask32}
+		if ipCfg.Address.IP.To4() == nil {
+			ipNet.Mask = ip.IPv6Mask128
+		}
+		ipNets = append(ipNets, ipNet)
+	}
+	return qos.CheckEgressRule(dev, uint32(bands), ipNets)
+}
@@ -18,6 +18,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/client/endpoint"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
@@ -25,6 +26,7 @@ import (
 	plugintypes "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/cni/types"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/datapath/link"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/defaults"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/endpoint/event"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging/logfields"
 	"github.com/containernetworking/cni/pkg/skel"
@@ -185,6 +187,93 @@ func main() {
 	skel.PluginMain(cmdAdd, cmdCheck, cmdDel, version.All, bv.BuildString("sbr-eip"))
 }
 
-func cmdCheck(_ *skel.CmdArgs) error {
+// cmdCheck is called for CHECK requests, it verifies the external features
+// recorded in the status of endpoint are still in place on the devices.
+func cmdCheck(args *skel.CmdArgs) (err error) {
+	logging.SetupCNILogging("cni", true)
+	logger = logging.DefaultLogger.WithFields(logrus.Fields{
+		"cmdArgs": logfields.Json(args),
+		"plugin":  "endpoint-probe",
+		"mod":     "CHECK",
+	})
+	defer func() {
+		if err != nil {
+			logger.WithError(err).Errorf("cni plugin failed")
+		}
+	}()
+
+	conf, err := parseConfig(args.StdinData)
+	if err != nil {
+		return fmt.Errorf("endpoint-probe failed to parse config: %v", err)
+	}
+	if conf.PrevResult == nil {
+		return fmt.Errorf("this plugin must be called as chained plugin")
+	}
+
+	cctx, err := link.NewContainerContext(args.ContainerID, args.Netns)
+	if err != nil {
+		return fmt.Errorf("failed to create container context: %v", err)
+	}
+	defer cctx.Close()
+
+	extFeatureData, err := endpointFeatureStatus(args)
+	if err != nil {
+		return fmt.Errorf("failed to get endpoint feature status: %v", err)
+	}
+
+	err = checkEndpointFeatures(cctx, conf.PrevResult, extFeatureData)
+	if err != nil {
+		return err
+	}
+	logger.Info("success to exec plugin")
 	return nil
 }
+
+// checkEndpointFeatures verifies the datapath of the container still matches
+// the bandwidth and egress priority recorded in the status of endpoint
+func checkEndpointFeatures(cctx *link.ContainerContext, result *current.Result, extFeatureData models.ExtFeatureData) error {
+	var drifts []string
+	if data, ok := extFeatureData[event.EndpointProbeEventBandwidth]; ok {
+		drift, err := checkBandwidth(cctx, data)
+		if err != nil {
+			return fmt.Errorf("failed to check bandwidth: %v", err)
+		}
+		drifts = append(drifts, drift...)
+	}
+	if data, ok := extFeatureData[event.EndpointProbeEventEgressPriority]; ok {
+		drift, err := checkEgressPriority(result, data)
+		if err != nil {
+			return fmt.Errorf("failed to check egress priority: %v", err)
+		}
+		drifts = append(drifts, drift...)
+	}
+	if len(drifts) > 0 {
+		return types.NewError(types.ErrInternal, "endpoint features drifted", strings.Join(drifts, "; "))
+	}
+	return nil
+}
+
+// endpointFeatureStatus returns the data of external features recorded in the
+// status of endpoint, including the features applied by agent which are not
+// listed in the feature gates of endpoint
+func endpointFeatureStatus(args *skel.CmdArgs) (models.ExtFeatureData, error) {
+	cniArgs := plugintypes.ArgsSpec{}
+	if err := types.LoadArgs(args.Args, &cniArgs); err != nil {
+		return nil, fmt.Errorf("unable to extract CNI arguments: %s", err)
+	}
+	var (
+		owner       = string(cniArgs.K8S_POD_NAMESPACE + "/" + cniArgs.K8S_POD_NAME)
+		containerID = args.ContainerID
+	)
+
+	c, err := client.NewDefaultClientWithTimeout(defaults.ClientConnectTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to cce-network-v2-agent: %s", client.Hint(err))
+	}
+	param := endpoint.NewGetEndpointExtpluginStatusParams().WithOwner(&owner).WithContainerID(&containerID)
+	result, err := c.Endpoint.GetEndpointExtpluginStatus(param)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get endpoint extplugin status: %s", client.Hint(err))
+	}
+	return result.Payload, nil
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/datapath/bandwidth"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/datapath/link"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/endpoint/event"
+	ccev2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2"
+)
+
+func TestCheckEndpointFeaturesBandwidthDrift(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("creating netns requires root")
+	}
+	logger = logrus.NewEntry(logrus.New())
+
+	netns, err := testutils.NewNS()
+	require.NoError(t, err)
+	defer testutils.UnmountNS(netns)
+	defer netns.Close()
+
+	// the qdiscs are checked on the loopback device of netns which stands for
+	// the container device
+	var dev netlink.Link
+	err = netns.Do(func(_ ns.NetNS) error {
+		dev, err = netlink.LinkByName("lo")
+		if err != nil {
+			return err
+		}
+		return netlink.LinkSetUp(dev)
+	})
+	require.NoError(t, err)
+
+	cctx := &link.ContainerContext{
+		Driver:         string(models.DatapathModeVeth),
+		ContainerDev:   dev,
+		ContainerNetns: netns,
+	}
+	// the endpoint is limited to 1MB/s of egress traffic
+	extFeatureData := models.ExtFeatureData{
+		event.EndpointProbeEventBandwidth: {
+			"mode":    ccev2.BindwidthModeTC,
+			"ingress": "0",
+			"egress":  "1000000",
+		},
+	}
+
+	// the tbf qdisc was never set up
+	assert.Error(t, checkEndpointFeatures(cctx, nil, extFeatureData))
+
+	// the tbf qdisc was set up with another rate
+	err = netns.Do(func(_ ns.NetNS) error {
+		return bandwidth.SetupTBFQdisc(dev, 2000000)
+	})
+	require.NoError(t, err)
+	assert.Error(t, checkEndpointFeatures(cctx, nil, extFeatureData))
+
+	// the datapath matches the status of endpoint
+	err = netns.Do(func(_ ns.NetNS) error {
+		return bandwidth.SetupTBFQdisc(dev, 1000000)
+	})
+	require.NoError(t, err)
+	assert.NoError(t, checkEndpointFeatures(cctx, nil, extFeatureData))
+
+	// nothing is checked without the bandwidth status
+	assert.NoError(t, checkEndpointFeatures(cctx, nil, models.ExtFeatureData{}))
+}
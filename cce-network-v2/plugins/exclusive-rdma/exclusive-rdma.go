@@ -9,9 +9,8 @@ import (
 	"net"
 	"net/http"
 	"net/url"
-	"os"
-	"path/filepath"
 	"runtime"
+	"strings"
 
 	"github.com/containernetworking/cni/pkg/skel"
 	"github.com/containernetworking/cni/pkg/types"
@@ -23,10 +22,15 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/vishvananda/netlink"
 
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/client/endpoint"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/client"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/defaults"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging/logfields"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/plugins/exclusive-rdma/keymutex"
 	netlinkutils "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/plugins/exclusive-rdma/netlink"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/plugins/exclusive-rdma/topology"
 )
 
 var logger *logrus.Entry
@@ -38,6 +42,10 @@ const (
 	rpFilterSysctlTemplate = "net.ipv4.conf.%s.rp_filter"
 	noMoreDevice           = "no more exclusive-rdma device for pod"
 	udsSocketFilePath      = "/var/run/exclusive-rdma/exclusive-rdma-cni-plugin.sock"
+
+	// extFeatureRdmaTopology is the name of the external feature that the
+	// chosen devices and the reasons are recorded as on the endpoint
+	extFeatureRdmaTopology = "rdma-topology"
 )
 
 // K8SArgs k8s pod args
@@ -53,6 +61,19 @@ type K8SArgs struct {
 type NetConf struct {
 	types.NetConf
 	RdmaDriverName string `json:"driver,omitempty"`
+	// TopologyAware chooses the rdma devices closest to the GPUs or CPUs
+	// allocated to the pod, instead of all the free rdma devices
+	TopologyAware bool `json:"topologyAware,omitempty"`
+	// SysfsRoot is the mount point of sysfs, defaults to /sys
+	SysfsRoot string `json:"sysfsRoot,omitempty"`
+}
+
+// rdmaDevice is a free rdma device in host network namespace
+type rdmaDevice struct {
+	link  netlink.Link
+	addrs []netlink.Addr
+	// reason why the device is chosen for the pod
+	reason string
 }
 
 func init() {
@@ -132,38 +153,38 @@ func cmdAdd(args *skel.CmdArgs) (err error) {
 	}
 	defer l.Close()
 
-	// Move each RDMA network device to the corresponding pod's network namespace
-	exclusiveRdmaDeviceCount := 0
-	for {
-		exclusiveRdma, addrList, err := chooseExclusiveRdmaDevice(n)
-		// If the rdma network device cannot be found for the first time, it indicates a "no exclusive-rdma device" error.
-		// If it's not the first time, and the error is still "network device not found",
-		// It is assumed that the operation has been completed and all network devices have been moved in.
-		// Otherwise, an error is returned
-		if err != nil && exclusiveRdmaDeviceCount == 0 {
-			logger.Errorf("there is no exclusive-rdma device for pod(%s), error: %s", string(k8sArgs.K8S_POD_NAME), err.Error())
-			return fmt.Errorf("there is no exclusive-rdma device error: %s", err.Error())
-		} else if err == errors.New(noMoreDevice) {
-			break
-		} else if err != nil {
-			logger.Errorf("pod: %s get exclusive-rdma device error: %s", string(k8sArgs.K8S_POD_NAME), err.Error())
-			return fmt.Errorf("get exclusive-rdma device error: %s", err.Error())
-		}
+	devices, selectMsg, err := chooseExclusiveRdmaDevices(n, k8sArgs)
+	if err != nil {
+		logger.Errorf("there is no exclusive-rdma device for pod(%s), error: %s", string(k8sArgs.K8S_POD_NAME), err.Error())
+		return fmt.Errorf("there is no exclusive-rdma device error: %s", err.Error())
+	}
 
+	// Move each chosen RDMA network device to the corresponding pod's network namespace
+	reasons := make(map[string]string, len(devices))
+	for _, device := range devices {
+		exclusiveRdma := device.link
+		hostName := exclusiveRdma.Attrs().Name
 		// move exclusive-rdma device to pod's network namespace and set it up
-		err = acquireExclusiveRdma(exclusiveRdma, netns, addrList)
+		podLinkName, err := acquireExclusiveRdma(exclusiveRdma, netns, &device.addrs)
 		if err != nil {
 			logger.Errorf("set up exclusive-rdma error for pod(%s): %s", string(k8sArgs.K8S_POD_NAME), err.Error())
-			return fmt.Errorf("set up exclusive-rdma device for %s error: %s", exclusiveRdma.Attrs().Name, err.Error())
+			return fmt.Errorf("set up exclusive-rdma device for %s error: %s", hostName, err.Error())
 		}
 
 		// set up host veth in host network namespace
-		err = setUpHostVeth(addrList, netns)
+		err = setUpHostVeth(&device.addrs, netns)
 		if err != nil {
 			logger.Errorf("set up host veth error for pod(%s): %s", string(k8sArgs.K8S_POD_NAME), err.Error())
 			return fmt.Errorf("set up host veth error: %s", err.Error())
 		}
-		exclusiveRdmaDeviceCount++
+		logger.Infof("exclusive-rdma device %s is moved to pod as %s: %s", hostName, podLinkName, device.reason)
+		reasons[podLinkName] = fmt.Sprintf("host device %s: %s", hostName, device.reason)
+	}
+
+	// the report is best-effort, the agent may be unavailable
+	owner := string(k8sArgs.K8S_POD_NAMESPACE + "/" + k8sArgs.K8S_POD_NAME)
+	if err := reportSelectionStatus(owner, args.ContainerID, selectMsg, reasons); err != nil {
+		logger.WithError(err).Warning("failed to report exclusive-rdma selection status")
 	}
 	return types.PrintResult(n.PrevResult, n.CNIVersion)
 }
@@ -239,11 +260,11 @@ func probeLinkName(prefix string) (string, error) {
 	return "", errors.New("error get exclusive-rdma name in pod")
 }
 
-// choose an rdma device and return, together with its address list
-func chooseExclusiveRdmaDevice(n *NetConf) (netlink.Link, *[]netlink.Addr, error) {
+// list the free rdma devices in host network namespace, together with their address lists
+func listExclusiveRdmaDevices(n *NetConf, sysfs *topology.Sysfs) ([]*rdmaDevice, error) {
 	linkList, err := netlink.LinkList()
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	// in some case, the ethernet device, which is used to connect to cluster, is also probably using the same driver with rdma device
@@ -251,7 +272,7 @@ func chooseExclusiveRdmaDevice(n *NetConf) (netlink.Link, *[]netlink.Addr, error
 	var defaultLinkName string
 	routes, err := netlink.RouteList(nil, netlink.FAMILY_ALL)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	for _, route := range routes {
 		// default route is nil
@@ -259,67 +280,129 @@ func chooseExclusiveRdmaDevice(n *NetConf) (netlink.Link, *[]netlink.Addr, error
 			link, err := netlink.LinkByIndex(route.LinkIndex)
 			if err != nil {
 				err = fmt.Errorf("failed to get default route link: %v", err)
-				return nil, nil, err
+				return nil, err
 			}
 			defaultLinkName = link.Attrs().Name
 			break
 		}
 	}
 
+	var devices []*rdmaDevice
 	for _, link := range linkList {
 		linkName := link.Attrs().Name
 		// skip the default link
 		if linkName == defaultLinkName {
 			continue
 		}
-		driverPath := filepath.Join("/sys/class/net", linkName, "device/driver")
-		driver, err := os.Readlink(driverPath)
+		driver, err := sysfs.NetDeviceDriver(linkName)
 		// if the device is a virtual device, skip it
 		if err != nil {
 			continue
 		}
-		// if the device is a rdma device, get its addrlist and return it
-		if filepath.Base(driver) == n.RdmaDriverName {
+		// if the device is a rdma device, get its addrlist
+		if driver == n.RdmaDriverName {
 			addrs, err := netlink.AddrList(link, netlink.FAMILY_V4)
 			if err != nil || len(addrs) < 1 {
 				// mabye we should do somthing here
 				continue
 			}
-			return link, &addrs, nil
+			devices = append(devices, &rdmaDevice{link: link, addrs: addrs})
 		}
 	}
+	return devices, nil
+}
+
+// choose the rdma devices for the pod. Without topology awareness, all the free
+// rdma devices are chosen. Otherwise the devices closest to the GPUs or CPUs
+// allocated to the pod are chosen. The returned message describes how the
+// devices are chosen.
+func chooseExclusiveRdmaDevices(n *NetConf, k8sArgs *K8SArgs) ([]*rdmaDevice, string, error) {
+	sysfs := topology.NewSysfs(n.SysfsRoot)
+	devices, err := listExclusiveRdmaDevices(n, sysfs)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(devices) == 0 {
+		return nil, "", errors.New(noMoreDevice)
+	}
 
-	return nil, nil, errors.New(noMoreDevice)
+	chooseAll := func(reason string) ([]*rdmaDevice, string, error) {
+		for _, device := range devices {
+			device.reason = reason
+		}
+		return devices, fmt.Sprintf("all free devices are chosen: %s", reason), nil
+	}
+	if !n.TopologyAware {
+		return chooseAll("topology-aware selection is disabled")
+	}
+
+	podDevices, err := getPodDevices(string(k8sArgs.K8S_POD_NAMESPACE), string(k8sArgs.K8S_POD_NAME))
+	if err != nil {
+		logger.WithError(err).Warning("failed to get devices allocated to pod, choose all free exclusive-rdma devices")
+		return chooseAll(fmt.Sprintf("failed to get devices allocated to pod: %v", err))
+	}
+	targets := sysfs.Targets(podDevices)
+	if len(targets) == 0 {
+		return chooseAll("no device or exclusive cpu allocated to pod has known topology")
+	}
+
+	byName := make(map[string]*rdmaDevice, len(devices))
+	candidates := make([]topology.Candidate, 0, len(devices))
+	for _, device := range devices {
+		name := device.link.Attrs().Name
+		locality, err := sysfs.NetDevice(name)
+		if err != nil {
+			logger.WithError(err).Warningf("failed to get topology of exclusive-rdma device %s", name)
+			locality = &topology.Locality{NUMANode: -1}
+		}
+		byName[name] = device
+		candidates = append(candidates, topology.Candidate{Name: name, Locality: locality})
+	}
+
+	var chosen []*rdmaDevice
+	reasons := make(map[string][]string)
+	for _, selection := range topology.Select(candidates, targets) {
+		if _, ok := reasons[selection.Candidate]; !ok {
+			chosen = append(chosen, byName[selection.Candidate])
+		}
+		reasons[selection.Candidate] = append(reasons[selection.Candidate], selection.Reason())
+	}
+	for _, device := range chosen {
+		device.reason = strings.Join(reasons[device.link.Attrs().Name], "; ")
+	}
+	return chosen, fmt.Sprintf("%d of %d free devices are chosen by topology of %d targets", len(chosen), len(devices), len(targets)), nil
 }
 
-func acquireExclusiveRdma(l netlink.Link, netNS ns.NetNS, addrList *[]netlink.Addr) error {
+// move the rdma device to the pod's network namespace, and return its name in the pod
+func acquireExclusiveRdma(l netlink.Link, netNS ns.NetNS, addrList *[]netlink.Addr) (string, error) {
 	if l == nil {
-		return errors.New("exclusive-rdma device is nil, please check")
+		return "", errors.New("exclusive-rdma device is nil, please check")
 	}
 
 	newName, err := randomDeviceName()
 	if err != nil {
-		return fmt.Errorf("falied to get host random device name: %s", err.Error())
+		return "", fmt.Errorf("falied to get host random device name: %s", err.Error())
 	}
 
 	if err = netlink.LinkSetDown(l); err != nil {
-		return fmt.Errorf("failed to set %q down: %v", l.Attrs().Name, err)
+		return "", fmt.Errorf("failed to set %q down: %v", l.Attrs().Name, err)
 	}
 
 	err = netlink.LinkSetName(l, newName)
 	if err != nil {
-		return fmt.Errorf("failed to set host link name: %s", err.Error())
+		return "", fmt.Errorf("failed to set host link name: %s", err.Error())
 	}
 
 	if err = netlink.LinkSetUp(l); err != nil {
-		return fmt.Errorf("failed to set %q up: %v", l.Attrs().Name, err)
+		return "", fmt.Errorf("failed to set %q up: %v", l.Attrs().Name, err)
 	}
 
 	err = netlink.LinkSetNsFd(l, int(netNS.Fd()))
 	if err != nil {
-		return fmt.Errorf("failed to move exclusive-rdma device to pod : %s", err.Error())
+		return "", fmt.Errorf("failed to move exclusive-rdma device to pod : %s", err.Error())
 	}
 
+	var podLinkName string
 	err = netNS.Do(func(netNS ns.NetNS) error {
 		contlink, err := netlink.LinkByName(newName)
 		if err != nil {
@@ -339,6 +422,7 @@ func acquireExclusiveRdma(l netlink.Link, netNS ns.NetNS, addrList *[]netlink.Ad
 		if err != nil {
 			return fmt.Errorf("set link name error: %s", err.Error())
 		}
+		podLinkName = linkName
 
 		if err = netlink.LinkSetUp(contlink); err != nil {
 			return fmt.Errorf("failed to set %q up: %v", l.Attrs().Name, err)
@@ -370,7 +454,7 @@ func acquireExclusiveRdma(l netlink.Link, netNS ns.NetNS, addrList *[]netlink.Ad
 		return nil
 	})
 
-	return err
+	return podLinkName, err
 }
 
 func releaseExclusiveRdma(l netlink.Link) error {
@@ -391,18 +475,22 @@ func releaseExclusiveRdma(l netlink.Link) error {
 	return nil
 }
 
-// this func is used to judge whether the pod needs exclusive-rdma devices.
-// actually, it is sending an http request (using uds) to agent, then get and read response.
-func isNeededExclusiveRdma(podNs, podName string) (bool, error) {
-	// create a client to send http request using unix domain socket
+// create a client to send http request to exclusive-rdma-agent using unix domain socket
+func newAgentClient() *http.Client {
 	transport := &http.Transport{
 		Dial: func(_, _ string) (net.Conn, error) {
 			return net.Dial("unix", udsSocketFilePath)
 		},
 	}
-	client := &http.Client{
+	return &http.Client{
 		Transport: transport,
 	}
+}
+
+// this func is used to judge whether the pod needs exclusive-rdma devices.
+// actually, it is sending an http request (using uds) to agent, then get and read response.
+func isNeededExclusiveRdma(podNs, podName string) (bool, error) {
+	client := newAgentClient()
 	// pack infomation to url and send to agent
 	params := url.Values{}
 	params.Add("podNs", podNs)
@@ -428,6 +516,53 @@ func isNeededExclusiveRdma(podNs, podName string) (bool, error) {
 	}
 }
 
+// get the devices and cpus allocated to the pod from agent
+func getPodDevices(podNs, podName string) (*topology.PodDevices, error) {
+	client := newAgentClient()
+	params := url.Values{}
+	params.Add("podNs", podNs)
+	params.Add("podName", podName)
+
+	resp, err := client.Get("http://unix/devices?" + params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("send http request error: %s", err.Error())
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response body error: %s", err.Error())
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("agent returns an error: %s", strings.TrimSpace(string(body)))
+	}
+	podDevices := &topology.PodDevices{}
+	if err := json.Unmarshal(body, podDevices); err != nil {
+		return nil, fmt.Errorf("unmarshal pod devices error: %s", err.Error())
+	}
+	return podDevices, nil
+}
+
+// record the chosen devices and the reasons on the endpoint of the pod
+func reportSelectionStatus(owner, containerID, msg string, reasons map[string]string) error {
+	c, err := client.NewDefaultClientWithTimeout(defaults.ClientConnectTimeout)
+	if err != nil {
+		return fmt.Errorf("unable to connect to cce-network-v2-agent: %s", client.Hint(err))
+	}
+	status := &models.ExtFeatureStatus{
+		Ready: true,
+		Msg:   msg,
+		Data:  reasons,
+	}
+	param := endpoint.NewPutEndpointExtpluginStatusParams().WithTimeout(defaults.ClientConnectTimeout).
+		WithOwner(&owner).WithContainerID(&containerID).WithFeature(extFeatureRdmaTopology).WithStatus(status)
+	_, err = c.Endpoint.PutEndpointExtpluginStatus(param)
+	if err != nil {
+		return fmt.Errorf("unable to put endpoint extplugin status: %s", client.Hint(err))
+	}
+	return nil
+}
+
 func loadK8SArgs(envArgs string) (*K8SArgs, error) {
 	k8sArgs := K8SArgs{}
 	if envArgs != "" {
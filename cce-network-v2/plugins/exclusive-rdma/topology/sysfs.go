@@ -0,0 +1,183 @@
+/*
+ * Copyright (c) 2024 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+package topology
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultSysfsRoot is the mount point of sysfs on the node
+const DefaultSysfsRoot = "/sys"
+
+var (
+	pciAddressRegexp      = regexp.MustCompile(`^[0-9a-f]{4}:[0-9a-f]{2}:[0-9a-f]{2}\.[0-7]$`)
+	shortPCIAddressRegexp = regexp.MustCompile(`^[0-9a-f]{2}:[0-9a-f]{2}\.[0-7]$`)
+)
+
+// PodDevices are the devices and CPUs allocated to a pod, the exclusive-rdma
+// agent responds them to the exclusive-rdma plugin
+type PodDevices struct {
+	Devices []Device `json:"devices,omitempty"`
+	// CPUs are the exclusive CPUs allocated by the CPU manager
+	CPUs []int64 `json:"cpus,omitempty"`
+}
+
+// Device is a device allocated to the pod
+type Device struct {
+	// ResourceName is the extended resource of the device, such as nvidia.com/gpu
+	ResourceName string `json:"resourceName,omitempty"`
+	// ID is the device ID of the device plugin, it is used as the PCI address
+	// of the device if it is in the form of a PCI address
+	ID string `json:"id"`
+	// NUMANodes of the device reported by the device plugin
+	NUMANodes []int64 `json:"numaNodes,omitempty"`
+}
+
+// Sysfs reads the topology of the devices from the sysfs mounted at root
+type Sysfs struct {
+	root string
+}
+
+// NewSysfs returns the Sysfs mounted at root, or DefaultSysfsRoot if root is
+// empty
+func NewSysfs(root string) *Sysfs {
+	if root == "" {
+		root = DefaultSysfsRoot
+	}
+	return &Sysfs{root: root}
+}
+
+// NetDeviceDriver returns the name of the driver of the network device
+func (s *Sysfs) NetDeviceDriver(name string) (string, error) {
+	driver, err := os.Readlink(filepath.Join(s.root, "class/net", name, "device/driver"))
+	if err != nil {
+		return "", err
+	}
+	return filepath.Base(driver), nil
+}
+
+// NetDevice returns the locality of the network device
+func (s *Sysfs) NetDevice(name string) (*Locality, error) {
+	return s.locality(filepath.Join(s.root, "class/net", name, "device"))
+}
+
+// PCIDevice returns the locality of the PCI device, the address is either in
+// the form of 0000:3b:00.0 or 3b:00.0
+func (s *Sysfs) PCIDevice(address string) (*Locality, error) {
+	address, ok := NormalizePCIAddress(address)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a PCI address", address)
+	}
+	return s.locality(filepath.Join(s.root, "bus/pci/devices", address))
+}
+
+// CPUNUMANode returns the NUMA node of the CPU
+func (s *Sysfs) CPUNUMANode(cpu int64) (int, error) {
+	nodes, err := filepath.Glob(filepath.Join(s.root, "devices/system/cpu", fmt.Sprintf("cpu%d", cpu), "node[0-9]*"))
+	if err != nil {
+		return -1, err
+	}
+	if len(nodes) == 0 {
+		return -1, fmt.Errorf("NUMA node of cpu %d not found", cpu)
+	}
+	return strconv.Atoi(strings.TrimPrefix(filepath.Base(nodes[0]), "node"))
+}
+
+// locality resolves the sysfs device path to its PCIe hierarchy and NUMA node
+func (s *Sysfs) locality(devicePath string) (*Locality, error) {
+	resolved, err := filepath.EvalSymlinks(devicePath)
+	if err != nil {
+		return nil, err
+	}
+	devicesDir, err := filepath.EvalSymlinks(filepath.Join(s.root, "devices"))
+	if err != nil {
+		return nil, err
+	}
+	rel, err := filepath.Rel(devicesDir, resolved)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return nil, fmt.Errorf("device %s is not under %s", resolved, devicesDir)
+	}
+
+	locality := &Locality{NUMANode: -1}
+	for _, elem := range strings.Split(rel, string(filepath.Separator)) {
+		// the device may be a child of a PCI device, such as virtio0
+		if strings.HasPrefix(elem, "pci") || pciAddressRegexp.MatchString(elem) {
+			locality.PCIPath = append(locality.PCIPath, elem)
+		}
+	}
+
+	if data, err := os.ReadFile(filepath.Join(resolved, "numa_node")); err == nil {
+		if node, err := strconv.Atoi(strings.TrimSpace(string(data))); err == nil {
+			locality.NUMANode = node
+		}
+	}
+	return locality, nil
+}
+
+// Targets resolves the devices allocated to the pod to the targets of the
+// selection. The PCI devices are located by their PCI addresses, the others
+// by the NUMA nodes reported by the device plugins. The NUMA nodes of the
+// exclusive CPUs are the targets only if no device is located.
+func (s *Sysfs) Targets(podDevices *PodDevices) []Target {
+	var targets []Target
+	for _, device := range podDevices.Devices {
+		id := device.ID
+		if device.ResourceName != "" {
+			id = device.ResourceName + " " + device.ID
+		}
+		if locality, err := s.PCIDevice(device.ID); err == nil {
+			targets = append(targets, Target{ID: id, Locality: locality})
+			continue
+		}
+		for _, node := range device.NUMANodes {
+			targets = append(targets, Target{ID: id, Locality: &Locality{NUMANode: int(node)}})
+		}
+	}
+	if len(targets) > 0 {
+		return targets
+	}
+
+	nodes := make(map[int]bool)
+	for _, cpu := range podDevices.CPUs {
+		if node, err := s.CPUNUMANode(cpu); err == nil {
+			nodes[node] = true
+		}
+	}
+	var sortedNodes []int
+	for node := range nodes {
+		sortedNodes = append(sortedNodes, node)
+	}
+	sort.Ints(sortedNodes)
+	for _, node := range sortedNodes {
+		targets = append(targets, Target{ID: fmt.Sprintf("cpus on NUMA node %d", node), Locality: &Locality{NUMANode: node}})
+	}
+	return targets
+}
+
+// NormalizePCIAddress returns the PCI address in the form of 0000:3b:00.0
+func NormalizePCIAddress(address string) (string, bool) {
+	address = strings.ToLower(address)
+	if shortPCIAddressRegexp.MatchString(address) {
+		address = "0000:" + address
+	}
+	return address, pciAddressRegexp.MatchString(address)
+}
@@ -0,0 +1,184 @@
+/*
+ * Copyright (c) 2024 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+// Package topology chooses the RDMA devices which are closest to the GPUs or
+// CPUs allocated to a pod in the PCIe and NUMA topology of the node.
+package topology
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Level is the distance between two devices, the lower level is closer. The
+// names of the levels follow `nvidia-smi topo -m`.
+type Level int
+
+const (
+	// LevelPIX the devices share a PCIe switch or root port
+	LevelPIX Level = iota
+	// LevelPHB the devices share a PCIe host bridge
+	LevelPHB
+	// LevelNode the devices are on the same NUMA node
+	LevelNode
+	// LevelSys the devices are on different NUMA nodes, or the topology of
+	// either device is unknown
+	LevelSys
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelPIX:
+		return "PIX"
+	case LevelPHB:
+		return "PHB"
+	case LevelNode:
+		return "NODE"
+	default:
+		return "SYS"
+	}
+}
+
+// Description is the readable meaning of the level
+func (l Level) Description() string {
+	switch l {
+	case LevelPIX:
+		return "same PCIe switch"
+	case LevelPHB:
+		return "same PCIe host bridge"
+	case LevelNode:
+		return "same NUMA node"
+	default:
+		return "different NUMA node or unknown topology"
+	}
+}
+
+// Locality is the location of a device in the topology of the node
+type Locality struct {
+	// PCIPath is the PCIe hierarchy from the host bridge to the device, such
+	// as [pci0000:3a 0000:3a:00.0 0000:3b:00.0], it is empty if the device is
+	// not a PCI device
+	PCIPath []string
+	// NUMANode of the device, -1 if unknown
+	NUMANode int
+}
+
+// PCIAddress returns the PCI address of the device, or an empty string if the
+// device is not a PCI device
+func (l *Locality) PCIAddress() string {
+	if len(l.PCIPath) < 2 {
+		return ""
+	}
+	return l.PCIPath[len(l.PCIPath)-1]
+}
+
+// Distance returns the level between a and b, together with the length of the
+// PCIe hierarchy they share. The longer shared hierarchy is closer in a level.
+func Distance(a, b *Locality) (Level, int) {
+	common := 0
+	for common < len(a.PCIPath) && common < len(b.PCIPath) && a.PCIPath[common] == b.PCIPath[common] {
+		common++
+	}
+	switch {
+	case common >= 2:
+		return LevelPIX, common
+	case common == 1:
+		return LevelPHB, common
+	case a.NUMANode >= 0 && a.NUMANode == b.NUMANode:
+		return LevelNode, common
+	default:
+		return LevelSys, common
+	}
+}
+
+// Target is a device allocated to the pod, which the RDMA devices should be
+// close to
+type Target struct {
+	// ID is the readable identity of the target, such as the PCI address of
+	// the GPU
+	ID       string
+	Locality *Locality
+}
+
+// Candidate is a free RDMA device on the node
+type Candidate struct {
+	Name     string
+	Locality *Locality
+}
+
+// Selection is the RDMA device chosen for a target
+type Selection struct {
+	Candidate string
+	Target    string
+	Level     Level
+}
+
+// Reason explains why the candidate is chosen
+func (s *Selection) Reason() string {
+	return fmt.Sprintf("closest to %s (%s: %s)", s.Target, s.Level, s.Level.Description())
+}
+
+// Select chooses the closest candidate for each target in order.
+//
+// A candidate is chosen for at most one target unless no free candidate is as
+// close as an already chosen one, so the targets under the same PCIe switch
+// share the RDMA device of the switch rather than take a remote one, while the
+// targets under different switches get their own RDMA devices. The ties are
+// broken by the longer shared PCIe hierarchy and then by the name of the
+// candidate.
+func Select(candidates []Candidate, targets []Target) []Selection {
+	sorted := make([]Candidate, len(candidates))
+	copy(sorted, candidates)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	chosen := make(map[string]bool)
+	var selections []Selection
+	for _, target := range targets {
+		var bestFree, bestChosen *Selection
+		bestFreeCommon, bestChosenCommon := -1, -1
+		for _, candidate := range sorted {
+			level, common := Distance(candidate.Locality, target.Locality)
+			selection := &Selection{Candidate: candidate.Name, Target: target.ID, Level: level}
+			if chosen[candidate.Name] {
+				if closer(level, common, bestChosen, bestChosenCommon) {
+					bestChosen, bestChosenCommon = selection, common
+				}
+			} else if closer(level, common, bestFree, bestFreeCommon) {
+				bestFree, bestFreeCommon = selection, common
+			}
+		}
+
+		switch {
+		case bestFree != nil && (bestChosen == nil || bestFree.Level <= bestChosen.Level):
+			chosen[bestFree.Candidate] = true
+			selections = append(selections, *bestFree)
+		case bestChosen != nil:
+			selections = append(selections, *bestChosen)
+		}
+	}
+	return selections
+}
+
+func closer(level Level, common int, best *Selection, bestCommon int) bool {
+	if best == nil {
+		return true
+	}
+	if level != best.Level {
+		return level < best.Level
+	}
+	return common > bestCommon
+}
@@ -0,0 +1,229 @@
+/*
+ * Copyright (c) 2024 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+package topology
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSysfs builds a sysfs tree of a node with two NUMA nodes:
+//
+//	NUMA 0: pci0000:17 - 0000:17:00.0 - switch 0000:18:00.0 - 0000:19:00.0 (gpu0)
+//	                                                        - 0000:1a:00.0 (eth1, mlx5_core)
+//	        pci0000:3a - 0000:3a:00.0 - 0000:3b:00.0 (gpu1)
+//	                   - 0000:3a:02.0 - 0000:3c:00.0 (eth2, mlx5_core)
+//	NUMA 1: pci0000:5d - 0000:5d:00.0 - switch 0000:5e:00.0 - 0000:5f:00.0 (gpu2)
+//	                                                        - 0000:60:00.0 (eth3, mlx5_core)
+//	        pci0000:00 - 0000:00:05.0 - virtio0 (eth0, virtio_net)
+func fakeSysfs(t *testing.T) string {
+	root := t.TempDir()
+	addPCIDevice := func(path string, numaNode string) string {
+		dir := filepath.Join(root, "devices", path)
+		require.NoError(t, os.MkdirAll(dir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "numa_node"), []byte(numaNode+"\n"), 0644))
+		require.NoError(t, os.MkdirAll(filepath.Join(root, "bus/pci/devices"), 0755))
+		require.NoError(t, os.Symlink(dir, filepath.Join(root, "bus/pci/devices", filepath.Base(path))))
+		return dir
+	}
+	addNetDevice := func(name, deviceDir, driver string) {
+		driverDir := filepath.Join(root, "bus/pci/drivers", driver)
+		require.NoError(t, os.MkdirAll(driverDir, 0755))
+		require.NoError(t, os.Symlink(driverDir, filepath.Join(deviceDir, "driver")))
+		netDir := filepath.Join(deviceDir, "net", name)
+		require.NoError(t, os.MkdirAll(netDir, 0755))
+		require.NoError(t, os.Symlink("../../../"+filepath.Base(deviceDir), filepath.Join(netDir, "device")))
+		require.NoError(t, os.MkdirAll(filepath.Join(root, "class/net"), 0755))
+		require.NoError(t, os.Symlink(netDir, filepath.Join(root, "class/net", name)))
+	}
+
+	addPCIDevice("pci0000:17/0000:17:00.0/0000:18:00.0/0000:19:00.0", "0")
+	addNetDevice("eth1", addPCIDevice("pci0000:17/0000:17:00.0/0000:18:00.0/0000:1a:00.0", "0"), "mlx5_core")
+	addPCIDevice("pci0000:3a/0000:3a:00.0/0000:3b:00.0", "0")
+	addNetDevice("eth2", addPCIDevice("pci0000:3a/0000:3a:02.0/0000:3c:00.0", "0"), "mlx5_core")
+	addPCIDevice("pci0000:5d/0000:5d:00.0/0000:5e:00.0/0000:5f:00.0", "1")
+	addNetDevice("eth3", addPCIDevice("pci0000:5d/0000:5d:00.0/0000:5e:00.0/0000:60:00.0", "1"), "mlx5_core")
+
+	virtio := filepath.Join(addPCIDevice("pci0000:00/0000:00:05.0", "-1"), "virtio0")
+	require.NoError(t, os.MkdirAll(virtio, 0755))
+	addNetDevice("eth0", virtio, "virtio_net")
+
+	for cpu, node := range []string{"node0", "node0", "node1", "node1"} {
+		dir := filepath.Join(root, "devices/system/cpu", "cpu"+string(rune('0'+cpu)))
+		require.NoError(t, os.MkdirAll(dir, 0755))
+		require.NoError(t, os.Symlink(filepath.Join(root, "devices/system/node", node), filepath.Join(dir, node)))
+	}
+	return root
+}
+
+func TestSysfs(t *testing.T) {
+	sysfs := NewSysfs(fakeSysfs(t))
+
+	driver, err := sysfs.NetDeviceDriver("eth1")
+	assert.NoError(t, err)
+	assert.Equal(t, "mlx5_core", driver)
+	driver, err = sysfs.NetDeviceDriver("eth0")
+	assert.NoError(t, err)
+	assert.Equal(t, "virtio_net", driver)
+
+	locality, err := sysfs.NetDevice("eth1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"pci0000:17", "0000:17:00.0", "0000:18:00.0", "0000:1a:00.0"}, locality.PCIPath)
+	assert.Equal(t, 0, locality.NUMANode)
+	assert.Equal(t, "0000:1a:00.0", locality.PCIAddress())
+
+	locality, err = sysfs.NetDevice("eth0")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"pci0000:00", "0000:00:05.0"}, locality.PCIPath)
+	assert.Equal(t, -1, locality.NUMANode)
+
+	_, err = sysfs.NetDevice("eth9")
+	assert.Error(t, err)
+
+	locality, err = sysfs.PCIDevice("5F:00.0")
+	assert.NoError(t, err)
+	assert.Equal(t, "0000:5f:00.0", locality.PCIAddress())
+	assert.Equal(t, 1, locality.NUMANode)
+
+	_, err = sysfs.PCIDevice("GPU-8f3a4d1e")
+	assert.Error(t, err)
+
+	node, err := sysfs.CPUNUMANode(2)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, node)
+	_, err = sysfs.CPUNUMANode(8)
+	assert.Error(t, err)
+}
+
+func TestDistance(t *testing.T) {
+	sysfs := NewSysfs(fakeSysfs(t))
+	locality := func(address string) *Locality {
+		l, err := sysfs.PCIDevice(address)
+		require.NoError(t, err)
+		return l
+	}
+
+	tests := []struct {
+		name  string
+		a, b  *Locality
+		level Level
+	}{
+		{"same switch", locality("0000:19:00.0"), locality("0000:1a:00.0"), LevelPIX},
+		{"same host bridge", locality("0000:3b:00.0"), locality("0000:3c:00.0"), LevelPHB},
+		{"same NUMA node", locality("0000:19:00.0"), locality("0000:3c:00.0"), LevelNode},
+		{"different NUMA nodes", locality("0000:19:00.0"), locality("0000:60:00.0"), LevelSys},
+		{"NUMA node only", &Locality{NUMANode: 1}, locality("0000:60:00.0"), LevelNode},
+		{"unknown NUMA node", &Locality{NUMANode: -1}, &Locality{NUMANode: -1}, LevelSys},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level, _ := Distance(tt.a, tt.b)
+			assert.Equal(t, tt.level, level)
+		})
+	}
+}
+
+func TestSelect(t *testing.T) {
+	sysfs := NewSysfs(fakeSysfs(t))
+	var candidates []Candidate
+	for _, name := range []string{"eth3", "eth2", "eth1"} {
+		locality, err := sysfs.NetDevice(name)
+		require.NoError(t, err)
+		candidates = append(candidates, Candidate{Name: name, Locality: locality})
+	}
+	targets := func(podDevices *PodDevices) []Target {
+		return sysfs.Targets(podDevices)
+	}
+
+	tests := []struct {
+		name       string
+		podDevices *PodDevices
+		want       []Selection
+	}{
+		{
+			name: "each gpu gets the closest device",
+			podDevices: &PodDevices{Devices: []Device{
+				{ResourceName: "nvidia.com/gpu", ID: "0000:5f:00.0"},
+				{ResourceName: "nvidia.com/gpu", ID: "0000:19:00.0"},
+			}},
+			want: []Selection{
+				{Candidate: "eth3", Target: "nvidia.com/gpu 0000:5f:00.0", Level: LevelPIX},
+				{Candidate: "eth1", Target: "nvidia.com/gpu 0000:19:00.0", Level: LevelPIX},
+			},
+		},
+		{
+			name: "gpu without switch gets the device of the host bridge",
+			podDevices: &PodDevices{Devices: []Device{
+				{ID: "3b:00.0"},
+			}},
+			want: []Selection{
+				{Candidate: "eth2", Target: "3b:00.0", Level: LevelPHB},
+			},
+		},
+		{
+			name: "gpus share the device rather than take a remote one",
+			podDevices: &PodDevices{Devices: []Device{
+				{ID: "0000:19:00.0"},
+				{ID: "0000:19:00.0"},
+			}},
+			want: []Selection{
+				{Candidate: "eth1", Target: "0000:19:00.0", Level: LevelPIX},
+				{Candidate: "eth1", Target: "0000:19:00.0", Level: LevelPIX},
+			},
+		},
+		{
+			name: "gpu located by NUMA node of device plugin",
+			podDevices: &PodDevices{Devices: []Device{
+				{ResourceName: "nvidia.com/gpu", ID: "GPU-8f3a4d1e", NUMANodes: []int64{1}},
+			}},
+			want: []Selection{
+				{Candidate: "eth3", Target: "nvidia.com/gpu GPU-8f3a4d1e", Level: LevelNode},
+			},
+		},
+		{
+			name:       "cpus are the targets without devices",
+			podDevices: &PodDevices{CPUs: []int64{3, 0, 1}},
+			want: []Selection{
+				{Candidate: "eth1", Target: "cpus on NUMA node 0", Level: LevelNode},
+				{Candidate: "eth3", Target: "cpus on NUMA node 1", Level: LevelNode},
+			},
+		},
+		{
+			name: "unknown devices",
+			podDevices: &PodDevices{Devices: []Device{
+				{ID: "GPU-8f3a4d1e"},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Select(candidates, targets(tt.podDevices)))
+		})
+	}
+}
+
+func TestNormalizePCIAddress(t *testing.T) {
+	address, ok := NormalizePCIAddress("3B:00.0")
+	assert.True(t, ok)
+	assert.Equal(t, "0000:3b:00.0", address)
+
+	_, ok = NormalizePCIAddress("GPU-8f3a4d1e")
+	assert.False(t, ok)
+}
@@ -0,0 +1,282 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+package main
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net"
+
+	current "github.com/containernetworking/cni/pkg/types/100"
+	"github.com/containernetworking/plugins/pkg/ip"
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/datapath/link"
+)
+
+// attachmentAlias is the alias of the container side of the secondary
+// interfaces, the interfaces are found by it when the pod is deleted
+const attachmentAlias = "cce-multinet"
+
+var (
+	// gatewayIPv4 and gatewayIPv6 are the gateways of the secondary interfaces
+	// in the pod, they are resolved to the host side of the veth by permanent
+	// neighbours as the gateway of the pods created by cptp
+	gatewayIPv4 = net.ParseIP("169.254.1.1")
+	gatewayIPv6 = net.ParseIP("fe80::1")
+)
+
+// attachmentIPs returns the IPs of the secondary interface as host routes, the
+// interface does not own the subnets as they are reached through the host
+func attachmentIPs(attachment *models.NetworkAttachmentResponse) ([]*net.IPNet, error) {
+	var ips []*net.IPNet
+	for _, addr := range []*models.IPAMAddressResponse{attachment.IPV4, attachment.IPV6} {
+		if addr == nil || addr.IP == "" {
+			continue
+		}
+		podIP := net.ParseIP(addr.IP)
+		if podIP == nil {
+			return nil, fmt.Errorf("invalid IP %q of interface %s", addr.IP, attachment.Interface)
+		}
+		ips = append(ips, hostNet(podIP))
+	}
+	if len(ips) == 0 {
+		return nil, fmt.Errorf("no IP is allocated to interface %s", attachment.Interface)
+	}
+	return ips, nil
+}
+
+// attachmentRoutes returns the routes of the secondary interface of the same
+// family as the pod IP
+func attachmentRoutes(routes []string, podIP net.IP) []*net.IPNet {
+	isIPv4 := podIP.To4() != nil
+	var dsts []*net.IPNet
+	for _, route := range routes {
+		_, ipn, err := net.ParseCIDR(route)
+		if err != nil || (ipn.IP.To4() != nil) != isIPv4 {
+			continue
+		}
+		dsts = append(dsts, ipn)
+	}
+	return dsts
+}
+
+// setupAttachment creates the veth pair of the secondary interface. The IPs
+// and the routes of the interface are configured in the pod, and the IPs are
+// routed to the veth on the host.
+func setupAttachment(netns ns.NetNS, hostName, ifName string, mtu int, ips []*net.IPNet, routes []string) (*current.Interface, *current.Interface, error) {
+	// the container side is created with a random name, as ifName may
+	// conflict with a link in the host netns
+	tmpName, err := ip.RandomVethName()
+	if err != nil {
+		return nil, nil, err
+	}
+	veth := &netlink.Veth{
+		LinkAttrs: netlink.LinkAttrs{Name: hostName, MTU: mtu},
+		PeerName:  tmpName,
+	}
+	if err := netlink.LinkAdd(veth); err != nil {
+		return nil, nil, fmt.Errorf("failed to create veth %s: %v", hostName, err)
+	}
+	hostVeth, err := netlink.LinkByName(hostName)
+	if err != nil {
+		return nil, nil, err
+	}
+	peer, err := netlink.LinkByName(tmpName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := netlink.LinkSetNsFd(peer, int(netns.Fd())); err != nil {
+		return nil, nil, fmt.Errorf("failed to move %s to netns: %v", tmpName, err)
+	}
+	if err := netlink.LinkSetUp(hostVeth); err != nil {
+		return nil, nil, fmt.Errorf("failed to set %s up: %v", hostName, err)
+	}
+	if err := link.DisableRpFilter(hostName); err != nil {
+		logger.WithError(err).Warning("failed to disable rp_filter of host veth")
+	}
+
+	hostIface := &current.Interface{Name: hostName, Mac: hostVeth.Attrs().HardwareAddr.String()}
+	contIface := &current.Interface{Name: ifName, Sandbox: netns.Path()}
+	var contMAC net.HardwareAddr
+	err = netns.Do(func(_ ns.NetNS) error {
+		l, err := netlink.LinkByName(tmpName)
+		if err != nil {
+			return err
+		}
+		if err := netlink.LinkSetName(l, ifName); err != nil {
+			return fmt.Errorf("failed to rename %s to %s: %v", tmpName, ifName, err)
+		}
+		if err := netlink.LinkSetAlias(l, attachmentAlias); err != nil {
+			return fmt.Errorf("failed to set alias of %s: %v", ifName, err)
+		}
+		if err := netlink.LinkSetUp(l); err != nil {
+			return fmt.Errorf("failed to set %s up: %v", ifName, err)
+		}
+		contMAC = l.Attrs().HardwareAddr
+		contIface.Mac = contMAC.String()
+
+		for _, ipn := range ips {
+			if err := netlink.AddrAdd(l, &netlink.Addr{IPNet: ipn, Flags: unix.IFA_F_NODAD}); err != nil {
+				return fmt.Errorf("failed to add address %s to %s: %v", ipn, ifName, err)
+			}
+			gateway := gatewayOf(ipn.IP)
+			if err := netlink.NeighSet(&netlink.Neigh{
+				LinkIndex:    l.Attrs().Index,
+				State:        netlink.NUD_PERMANENT,
+				IP:           gateway,
+				HardwareAddr: hostVeth.Attrs().HardwareAddr,
+			}); err != nil {
+				return fmt.Errorf("failed to add neighbour of gateway %s: %v", gateway, err)
+			}
+			// the gateway is the same as the one of eth0, the routes are
+			// onlink instead of adding another route to the gateway
+			for _, dst := range attachmentRoutes(routes, ipn.IP) {
+				if err := netlink.RouteReplace(&netlink.Route{
+					LinkIndex: l.Attrs().Index,
+					Dst:       dst,
+					Gw:        gateway,
+					Src:       ipn.IP,
+					Flags:     int(netlink.FLAG_ONLINK),
+				}); err != nil {
+					return fmt.Errorf("failed to add route to %s through %s: %v", dst, ifName, err)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, ipn := range ips {
+		if err := netlink.RouteReplace(&netlink.Route{
+			LinkIndex: hostVeth.Attrs().Index,
+			Scope:     netlink.SCOPE_LINK,
+			Dst:       ipn,
+		}); err != nil {
+			return nil, nil, fmt.Errorf("failed to add route to pod %s: %v", ipn.IP, err)
+		}
+		if err := netlink.NeighSet(&netlink.Neigh{
+			LinkIndex:    hostVeth.Attrs().Index,
+			State:        netlink.NUD_PERMANENT,
+			IP:           ipn.IP,
+			HardwareAddr: contMAC,
+		}); err != nil {
+			return nil, nil, fmt.Errorf("failed to add neighbour of pod %s: %v", ipn.IP, err)
+		}
+	}
+	return hostIface, contIface, nil
+}
+
+// teardownAttachments deletes the secondary interfaces in the netns and the
+// host veths, the links which do not exist are ignored
+func teardownAttachments(netns ns.NetNS, hostNames []string) error {
+	var errs []error
+	err := netns.Do(func(_ ns.NetNS) error {
+		links, err := netlink.LinkList()
+		if err != nil {
+			return err
+		}
+		for _, l := range links {
+			if l.Attrs().Alias != attachmentAlias {
+				continue
+			}
+			if err := netlink.LinkDel(l); err != nil {
+				return fmt.Errorf("failed to delete %s in netns: %w", l.Attrs().Name, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		errs = append(errs, err)
+	}
+	// the host veths are gone with the peers in the netns, the ones whose
+	// peers are not moved to the netns yet are deleted here
+	for _, name := range hostNames {
+		if l, err := netlink.LinkByName(name); err == nil {
+			if err := netlink.LinkDel(l); err != nil {
+				errs = append(errs, fmt.Errorf("failed to delete host veth %s: %w", name, err))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("teardown errors: %v", errs)
+	}
+	return nil
+}
+
+// validateAttachments checks the secondary interfaces in the netns have the
+// IPs in prevResult
+func validateAttachments(result *current.Result) error {
+	links, err := netlink.LinkList()
+	if err != nil {
+		return err
+	}
+	for _, l := range links {
+		if l.Attrs().Alias != attachmentAlias {
+			continue
+		}
+		index := -1
+		for i, intf := range result.Interfaces {
+			if intf.Sandbox != "" && intf.Name == l.Attrs().Name {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return fmt.Errorf("%s: interface %s is missing in prevResult", pluginName, l.Attrs().Name)
+		}
+		var ips []*current.IPConfig
+		for _, ipc := range result.IPs {
+			if ipc.Interface != nil && *ipc.Interface == index {
+				ips = append(ips, ipc)
+			}
+		}
+		if err := ip.ValidateExpectedInterfaceIPs(l.Attrs().Name, ips); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// vethNameForPod return host-side veth name for pod
+// max veth length is 15
+func vethNameForPod(name, namespace, prefix string) string {
+	// A SHA1 is always 20 bytes long, and so is sufficient for generating the
+	// veth name and mac addr.
+	h := sha1.New()
+	h.Write([]byte(namespace + "." + name))
+	return fmt.Sprintf("%s%s", prefix, hex.EncodeToString(h.Sum(nil))[:11])
+}
+
+func gatewayOf(ip net.IP) net.IP {
+	if ip.To4() != nil {
+		return gatewayIPv4
+	}
+	return gatewayIPv6
+}
+
+func hostNet(ip net.IP) *net.IPNet {
+	bits := 128
+	if ip.To4() != nil {
+		bits = 32
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}
+}
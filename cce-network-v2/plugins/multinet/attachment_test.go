@@ -0,0 +1,82 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+package main
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
+)
+
+func TestAttachmentIPs(t *testing.T) {
+	ips, err := attachmentIPs(&models.NetworkAttachmentResponse{
+		Interface: "net1",
+		IPV4:      &models.IPAMAddressResponse{IP: "10.1.0.5"},
+		IPV6:      &models.IPAMAddressResponse{IP: "fd00::5"},
+	})
+	require.NoError(t, err)
+	require.Len(t, ips, 2)
+	assert.Equal(t, "10.1.0.5/32", ips[0].String())
+	assert.Equal(t, "fd00::5/128", ips[1].String())
+
+	_, err = attachmentIPs(&models.NetworkAttachmentResponse{Interface: "net1"})
+	assert.ErrorContains(t, err, "net1")
+
+	_, err = attachmentIPs(&models.NetworkAttachmentResponse{
+		Interface: "net1",
+		IPV4:      &models.IPAMAddressResponse{IP: "10.1.0"},
+	})
+	assert.Error(t, err)
+}
+
+func TestAttachmentRoutes(t *testing.T) {
+	routes := []string{"10.2.0.0/16", "fd02::/64", "invalid"}
+
+	dsts := attachmentRoutes(routes, net.ParseIP("10.1.0.5"))
+	require.Len(t, dsts, 1)
+	assert.Equal(t, "10.2.0.0/16", dsts[0].String())
+
+	dsts = attachmentRoutes(routes, net.ParseIP("fd00::5"))
+	require.Len(t, dsts, 1)
+	assert.Equal(t, "fd02::/64", dsts[0].String())
+}
+
+func TestVethNameForPod(t *testing.T) {
+	net1 := vethNameForPod("nginx.net1", "default", "veth")
+	net2 := vethNameForPod("nginx.net2", "default", "veth")
+	assert.Len(t, net1, 15)
+	assert.NotEqual(t, net1, net2)
+	assert.NotEqual(t, vethNameForPod("nginx", "default", "veth"), net1)
+}
+
+func TestLoadConf(t *testing.T) {
+	conf, err := loadConf([]byte(`{"cniVersion":"0.4.0","name":"generic-veth","type":"multinet","mtu":1500,
+		"prevResult":{"cniVersion":"0.4.0","interfaces":[{"name":"eth0","sandbox":"/var/run/netns/test"}],
+		"ips":[{"version":"4","interface":0,"address":"10.0.0.2/32"}]}}`))
+	require.NoError(t, err)
+	assert.Equal(t, 1500, conf.MTU)
+	assert.NotNil(t, conf.PrevResult)
+
+	conf, err = loadConf([]byte(`{"cniVersion":"0.4.0","type":"multinet"}`))
+	require.NoError(t, err)
+	assert.Nil(t, conf.PrevResult)
+
+	_, err = loadConf([]byte(`{"type":`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,272 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+// multinet is a chained plugin attaching the secondary interfaces requested by
+// the annotation of the pod. The IPs of the interfaces are allocated by the
+// agent from the subnets of the PSTS of each interface, every interface is a
+// veth pair to the host, and the routes of the interface are routed through it
+// in the pod. The packets from the IPs of the interfaces leave the node through
+// the ENI owning the IPs by the source based routing rules of the agent.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"runtime"
+
+	"github.com/containernetworking/cni/pkg/skel"
+	"github.com/containernetworking/cni/pkg/types"
+	current "github.com/containernetworking/cni/pkg/types/100"
+	"github.com/containernetworking/cni/pkg/version"
+	"github.com/containernetworking/plugins/pkg/ip"
+	"github.com/containernetworking/plugins/pkg/ns"
+	bv "github.com/containernetworking/plugins/pkg/utils/buildversion"
+	"github.com/sirupsen/logrus"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/client"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/defaults"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/logging/logfields"
+)
+
+const pluginName = "multinet"
+
+var logger *logrus.Entry
+
+func init() {
+	// this ensures that main runs only on main thread (thread group leader).
+	// since namespace ops (unshare, setns) are done for a single thread, we
+	// must ensure that the goroutine does not jump from OS thread to thread
+	runtime.LockOSThread()
+}
+
+// NetConf is the configuration of the multinet plugin
+type NetConf struct {
+	types.NetConf
+	MTU int `json:"mtu"`
+}
+
+// K8SArgs k8s pod args
+type K8SArgs struct {
+	types.CommonArgs `json:"commonArgs"`
+	// IP is pod's ip address
+	IP net.IP `json:"ip"`
+	// K8S_POD_NAME is pod's name
+	K8S_POD_NAME types.UnmarshallableString `json:"k8s_pod_name"`
+	// K8S_POD_NAMESPACE is pod's namespace
+	K8S_POD_NAMESPACE types.UnmarshallableString `json:"k8s_pod_namespace"`
+	// K8S_POD_INFRA_CONTAINER_ID is pod's container ID
+	K8S_POD_INFRA_CONTAINER_ID types.UnmarshallableString `json:"k8s_pod_infra_container_id"`
+}
+
+func loadConf(data []byte) (*NetConf, error) {
+	conf := &NetConf{}
+	if err := json.Unmarshal(data, conf); err != nil {
+		return nil, fmt.Errorf("failed to load netconf: %v", err)
+	}
+	if err := version.ParsePrevResult(&conf.NetConf); err != nil {
+		return nil, fmt.Errorf("could not parse prevResult: %v", err)
+	}
+	return conf, nil
+}
+
+func loadK8SArgs(envArgs string) (*K8SArgs, error) {
+	k8sArgs := K8SArgs{}
+	if envArgs != "" {
+		err := types.LoadArgs(envArgs, &k8sArgs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &k8sArgs, nil
+}
+
+func podOwner(k8sArgs *K8SArgs) string {
+	return string(k8sArgs.K8S_POD_NAMESPACE) + "/" + string(k8sArgs.K8S_POD_NAME)
+}
+
+func cmdAdd(args *skel.CmdArgs) (err error) {
+	logging.SetupCNILogging("cni", true)
+	logger = logging.DefaultLogger.WithFields(logrus.Fields{
+		"cmdArgs": logfields.Json(args),
+		"plugin":  pluginName,
+		"mod":     "ADD",
+	})
+	defer func() {
+		if err != nil {
+			logger.WithError(err).Error("failed to exec plugin")
+		} else {
+			logger.Info("successfully to exec plugin")
+		}
+	}()
+
+	k8sArgs, err := loadK8SArgs(args.Args)
+	if err != nil {
+		return fmt.Errorf("failed to load CNI_ARGS: %v", err)
+	}
+	conf, err := loadConf(args.StdinData)
+	if err != nil {
+		return err
+	}
+	if conf.PrevResult == nil {
+		return fmt.Errorf("%s must be called as chained plugin", pluginName)
+	}
+	result, err := current.NewResultFromResult(conf.PrevResult)
+	if err != nil {
+		return fmt.Errorf("could not convert prevResult: %v", err)
+	}
+
+	c, err := client.NewDefaultClientWithTimeout(defaults.ClientConnectTimeout)
+	if err != nil {
+		return fmt.Errorf("unable to connect to network-v2-agent: %s", client.Hint(err))
+	}
+	owner := podOwner(k8sArgs)
+	attachments, err := c.IPAMCNIAllocateNetworks(owner, args.ContainerID, args.Netns)
+	if err != nil {
+		return fmt.Errorf("failed to allocate secondary interfaces: %w", err)
+	}
+	if len(attachments) == 0 {
+		return types.PrintResult(result, conf.CNIVersion)
+	}
+	logger.WithField("attachments", logfields.Json(attachments)).Info("got secondary interfaces from agent")
+
+	netns, err := ns.GetNS(args.Netns)
+	if err != nil {
+		return fmt.Errorf("failed to open netns %q: %v", args.Netns, err)
+	}
+	defer netns.Close()
+
+	var hostNames []string
+	// release the IPs and the links if any interface fails to be attached
+	defer func() {
+		if err != nil {
+			if rollbackErr := teardownAttachments(netns, hostNames); rollbackErr != nil {
+				err = fmt.Errorf("%v; rollback failed: %v", err, rollbackErr)
+			}
+			if delErr := c.IPAMCNIReleaseNetworks(owner, args.ContainerID); delErr != nil {
+				err = fmt.Errorf("%v; rollback failed: %v", err, delErr)
+			}
+		}
+	}()
+
+	for _, attachment := range attachments {
+		ips, err := attachmentIPs(attachment)
+		if err != nil {
+			return err
+		}
+		hostName := vethNameForPod(string(k8sArgs.K8S_POD_NAME)+"."+attachment.Interface, string(k8sArgs.K8S_POD_NAMESPACE), "veth")
+		hostNames = append(hostNames, hostName)
+
+		hostIface, contIface, err := setupAttachment(netns, hostName, attachment.Interface, conf.MTU, ips, attachment.Routes)
+		if err != nil {
+			return fmt.Errorf("failed to setup interface %s: %w", attachment.Interface, err)
+		}
+
+		result.Interfaces = append(result.Interfaces, hostIface, contIface)
+		contIndex := len(result.Interfaces) - 1
+		for _, ipn := range ips {
+			gateway := gatewayOf(ipn.IP)
+			result.IPs = append(result.IPs, &current.IPConfig{
+				Interface: current.Int(contIndex),
+				Address:   *ipn,
+				Gateway:   gateway,
+			})
+			for _, dst := range attachmentRoutes(attachment.Routes, ipn.IP) {
+				result.Routes = append(result.Routes, &types.Route{Dst: *dst, GW: gateway})
+			}
+		}
+	}
+
+	if err = ip.EnableForward(result.IPs); err != nil {
+		return fmt.Errorf("could not enable IP forwarding: %v", err)
+	}
+
+	logger.WithField("result", logfields.Json(result)).Infof("success to exec plugin")
+	return types.PrintResult(result, conf.CNIVersion)
+}
+
+func cmdDel(args *skel.CmdArgs) (err error) {
+	logging.SetupCNILogging("cni", true)
+	logger = logging.DefaultLogger.WithFields(logrus.Fields{
+		"cmdArgs": logfields.Json(args),
+		"plugin":  pluginName,
+		"mod":     "DEL",
+	})
+	defer func() {
+		if err != nil {
+			logger.WithError(err).Error("failed to exec plugin")
+		} else {
+			logger.Info("successfully to exec plugin")
+		}
+	}()
+
+	k8sArgs, err := loadK8SArgs(args.Args)
+	if err != nil {
+		return fmt.Errorf("failed to load CNI_ARGS: %v", err)
+	}
+
+	c, err := client.NewDefaultClientWithTimeout(defaults.ClientConnectTimeout)
+	if err != nil {
+		return fmt.Errorf("unable to connect to network-v2-agent: %s", client.Hint(err))
+	}
+	if err := c.IPAMCNIReleaseNetworks(podOwner(k8sArgs), args.ContainerID); err != nil {
+		return fmt.Errorf("failed to release secondary interfaces: %w", err)
+	}
+
+	if args.Netns == "" {
+		return nil
+	}
+	netns, err := ns.GetNS(args.Netns)
+	if err != nil {
+		// Delete can be called multiple times, the netns may be removed
+		// by the runtime already, and the veth pairs are gone with it
+		// https://github.com/kubernetes/kubernetes/issues/43014#issuecomment-287164444
+		if _, ok := err.(ns.NSPathNotExistErr); ok {
+			return nil
+		}
+		return fmt.Errorf("failed to open netns %q: %v", args.Netns, err)
+	}
+	defer netns.Close()
+	return teardownAttachments(netns, nil)
+}
+
+func cmdCheck(args *skel.CmdArgs) error {
+	conf, err := loadConf(args.StdinData)
+	if err != nil {
+		return err
+	}
+	if conf.PrevResult == nil {
+		return fmt.Errorf("%s: Required prevResult missing", pluginName)
+	}
+	result, err := current.NewResultFromResult(conf.PrevResult)
+	if err != nil {
+		return err
+	}
+
+	netns, err := ns.GetNS(args.Netns)
+	if err != nil {
+		return fmt.Errorf("failed to open netns %q: %v", args.Netns, err)
+	}
+	defer netns.Close()
+
+	return netns.Do(func(_ ns.NetNS) error {
+		return validateAttachments(result)
+	})
+}
+
+func main() {
+	skel.PluginMain(cmdAdd, cmdCheck, cmdDel, version.All, bv.BuildString(pluginName))
+}
@@ -17,10 +17,12 @@ const (
 	pluginNameEndpointProbe   = "endpoint-probe"
 	pluginNameCipam           = "cipam"
 	pluginNameCptp            = "cptp"
+	pluginNameCipvlan         = "cipvlan"
 	pluginNameEnim            = "enim"
 	pluginNameExclusiveDevice = "exclusive-device"
 	pluginNameSbrEIP          = "sbr-eip"
 	pluginNameRoce            = "roce"
+	pluginNameMultiNet        = "multinet"
 
 	// external plugins
 	pluginNamePortMap = "portmap"
@@ -40,6 +42,7 @@ var (
 	ccePlugins = map[string]CniPlugin{
 		pluginNameEndpointProbe: NewCNIPlugin(pluginNameEndpointProbe, nil),
 		pluginNameCptp:          newPtpPlugin(),
+		pluginNameCipvlan:       newIpvlanPlugin(),
 		pluginNameExclusiveDevice: NewCNIPlugin(pluginNameExclusiveDevice, CniPlugin{
 			"ipam": pluginNameEnim,
 		}),
@@ -101,6 +104,10 @@ func (plugin CniPlugin) GetType() string {
 //					},
 //					"mtu": {{ .Values.ccedConfig.mtu }}
 //				}
+//				,{
+//					"type": "multinet",
+//					"mtu": {{ .Values.ccedConfig.mtu }}
+//				}
 //				{{- range .Values.extplugins }}
 //				,{
 //					"type": "{{ .type }}"
@@ -115,7 +122,26 @@ func (plugin CniPlugin) GetType() string {
 //			]
 //		}
 //
-// 2. primary eni plugin
+// 2. ipvlan plugin for the secondary IPs of the ENIs
+//
+//	{
+//		"name":"generic-veth",
+//		"cniVersion":"0.4.0",
+//		"plugins":[
+//			{
+//				"type":"cipvlan",
+//				"ipam":{
+//					"type":"cipam",
+//				},
+//				"mtu": {{ .Values.ccedConfig.mtu }},
+//				"mode": "l2",
+//				"serviceCIDRs": ["10.96.0.0/12"]
+//			}
+//			...
+//		]
+//	}
+//
+// 3. primary eni plugin
 //
 //		{
 //			"name":"podlink",
@@ -148,11 +174,23 @@ func defaultCNIPlugin() *CniListConfig {
 	// primary eni plugin
 	if option.Config.ENI != nil && option.Config.ENI.UseMode == string(ccev2.ENIUseModePrimaryIP) {
 		result.Plugins = append(result.Plugins, ccePlugins[pluginNameExclusiveDevice])
+	} else if option.Config.IpvlanDatapathEnabled() {
+		result.Plugins = append(result.Plugins, newIpvlanPlugin())
 	} else {
 		// use cptp plugin defalt
 		result.Plugins = append(result.Plugins, newPtpPlugin())
 	}
 
+	// add multinet plugin for the secondary interfaces of the pods, the
+	// interfaces are attached by veth pairs, which is not supported by the
+	// exclusive device
+	if option.Config.EnableMultiNetwork &&
+		(option.Config.ENI == nil || option.Config.ENI.UseMode != string(ccev2.ENIUseModePrimaryIP)) {
+		result.Plugins = append(result.Plugins, NewCNIPlugin(pluginNameMultiNet, CniPlugin{
+			"mtu": option.Config.MTU,
+		}))
+	}
+
 	// add roce plugin for RDMA
 	if option.Config.EnableRDMA {
 		result.Plugins = append(result.Plugins, ccePlugins[pluginNameRoce])
@@ -242,6 +280,48 @@ func newPtpPlugin() CniPlugin {
 	if ContainerInterfaceName != DefalutContainerInterfaceName {
 		plugin["containerInterfaceName"] = ContainerInterfaceName
 	}
+	if verify := newPtpVerifyConf(); len(verify) > 0 {
+		plugin["verify"] = verify
+	}
+	if option.Config.EnableTracing {
+		plugin["tracing"] = option.Config.TracingConfig()
+	}
+	return plugin
+}
+
+// newPtpVerifyConf returns the connectivity verification of cptp, the unset
+// fields are left to the defaults of cptp
+func newPtpVerifyConf() CniPlugin {
+	verify := CniPlugin{}
+	if option.Config.PluginVerifyMode != "" {
+		verify["mode"] = option.Config.PluginVerifyMode
+	}
+	if len(option.Config.PluginVerifyTargets) > 0 {
+		verify["targets"] = option.Config.PluginVerifyTargets
+	}
+	if option.Config.PluginVerifyRetries > 0 {
+		verify["retries"] = option.Config.PluginVerifyRetries
+	}
+	if option.Config.PluginVerifyTimeout > 0 {
+		verify["timeout"] = option.Config.PluginVerifyTimeout.String()
+	}
+	return verify
+}
+
+// create new cipvlan plugin template
+func newIpvlanPlugin() CniPlugin {
+	plugin := NewCNIPlugin(pluginNameCipvlan, CniPlugin{
+		"ipam": NewCNIPlugin(pluginNameCipam, nil),
+		"mtu":  option.Config.MTU,
+		"mode": option.Config.IpvlanMode,
+	})
+	if cidrs := option.Config.ServiceCIDRs(); len(cidrs) > 0 {
+		plugin["serviceCIDRs"] = cidrs
+	}
+	// the tracing configuration is read by cipam
+	if option.Config.EnableTracing {
+		plugin["tracing"] = option.Config.TracingConfig()
+	}
 	return plugin
 }
 
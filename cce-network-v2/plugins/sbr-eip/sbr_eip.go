@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"strings"
 
 	"github.com/alexflint/go-filemutex"
 	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/client/endpoint"
@@ -36,6 +37,7 @@ import (
 	bv "github.com/containernetworking/plugins/pkg/utils/buildversion"
 	"github.com/sirupsen/logrus"
 	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
 )
 
 const firstTableID = 100
@@ -196,12 +198,7 @@ func cmdAdd(args *skel.CmdArgs) (err error) {
 		goto out
 	}
 
-	for _, ipCfg := range ipCfgs {
-		if ipCfg.Gateway != nil && ipCfg.Gateway.To4() != nil {
-			eipConfig.Gateway = ipCfg.Gateway
-			break
-		}
-	}
+	setEIPGateway(eipConfig, ipCfgs)
 
 	// Do the actual work.
 	err = withLockAndNetNS(args.Netns, func(_ ns.NetNS) error {
@@ -216,6 +213,16 @@ out:
 	return types.PrintResult(conf.PrevResult, conf.CNIVersion)
 }
 
+// setEIPGateway routes the traffic from EIP via the IPv4 gateway of the interface
+func setEIPGateway(eipConfig *current.IPConfig, ipCfgs []*current.IPConfig) {
+	for _, ipCfg := range ipCfgs {
+		if ipCfg.Gateway != nil && ipCfg.Gateway.To4() != nil {
+			eipConfig.Gateway = ipCfg.Gateway
+			break
+		}
+	}
+}
+
 func publicIPStatus(args *skel.CmdArgs) (*current.IPConfig, error) {
 	cniArgs := plugintypes.ArgsSpec{}
 	if err := types.LoadArgs(args.Args, &cniArgs); err != nil {
@@ -505,6 +512,153 @@ func main() {
 	skel.PluginMain(cmdAdd, cmdCheck, cmdDel, version.All, bv.BuildString("sbr-eip"))
 }
 
-func cmdCheck(_ *skel.CmdArgs) error {
+// cmdCheck is called for CHECK requests, it verifies the EIP address, the
+// source based rule and the routes set up by ADD are still in place.
+func cmdCheck(args *skel.CmdArgs) (err error) {
+	logging.SetupCNILogging("cni", true)
+	logger = logging.DefaultLogger.WithFields(logrus.Fields{
+		"cmdArgs": logfields.Json(args),
+		"plugin":  "sbr-eip",
+		"mod":     "CHECK",
+	})
+	defer func() {
+		if err != nil {
+			logger.WithError(err).Error("failed to exec plugin")
+		} else {
+			logger.Info("successfully to exec plugin")
+		}
+	}()
+
+	conf, err := parseConfig(args.StdinData)
+	if err != nil {
+		return fmt.Errorf("sbnr-eip failed to parse config: %v", err)
+	}
+	if conf.PrevResult == nil {
+		return fmt.Errorf("this plugin must be called as chained plugin")
+	}
+
+	ipCfgs, err := getIPCfgs(args.IfName, conf.PrevResult)
+	if err != nil {
+		return err
+	}
+
+	eipConfig, err := publicIPStatus(args)
+	if err != nil {
+		return fmt.Errorf("failed to exec sbr-ext: %v", err)
+	}
+	if eipConfig == nil {
+		logger.Debugf("no public IP found, skipping")
+		return nil
+	}
+	setEIPGateway(eipConfig, ipCfgs)
+
+	var drifts []string
+	err = withLockAndNetNS(args.Netns, func(_ ns.NetNS) error {
+		drifts, err = checkRoutes(eipConfig, args.IfName)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if len(drifts) > 0 {
+		return types.NewError(types.ErrInternal, "source based routing of eip drifted", strings.Join(drifts, "; "))
+	}
 	return nil
 }
+
+// checkRoutes lists the addresses, rules and routes in the netns, and returns
+// the drift from what doRoutes set up.
+func checkRoutes(ipCfg *current.IPConfig, iface string) ([]string, error) {
+	link, err := netlink.LinkByName(iface)
+	if err != nil {
+		return nil, fmt.Errorf("cannot find network interface %s: %w", iface, err)
+	}
+
+	addrs, err := netlink.AddrList(link, netlink.FAMILY_V4)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list addresses for interface %s: %w", iface, err)
+	}
+
+	rules, err := netlink.RuleList(netlink.FAMILY_ALL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list all rules: %w", err)
+	}
+
+	routes, err := netlink.RouteListFiltered(netlink.FAMILY_ALL, &netlink.Route{Table: unix.RT_TABLE_UNSPEC}, netlink.RT_FILTER_TABLE)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list all routes: %w", err)
+	}
+
+	return routeDrifts(ipCfg, link, addrs, rules, routes), nil
+}
+
+// routeDrifts compares the addresses, rules and routes with what doRoutes set
+// up for the EIP on link.
+func routeDrifts(ipCfg *current.IPConfig, link netlink.Link, addrs []netlink.Addr, rules []netlink.Rule, routes []netlink.Route) []string {
+	var (
+		drifts    []string
+		eip       = ipCfg.Address.IP
+		iface     = link.Attrs().Name
+		linkIndex = link.Attrs().Index
+	)
+
+	found := false
+	for _, addr := range addrs {
+		if addr.IP.Equal(eip) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		drifts = append(drifts, fmt.Sprintf("eip %s not found on interface %s", eip, iface))
+	}
+
+	table := -1
+	for _, rule := range rules {
+		if rule.Src == nil || !rule.Src.IP.Equal(eip) {
+			continue
+		}
+		if ones, bits := rule.Src.Mask.Size(); ones != bits {
+			continue
+		}
+		table = rule.Table
+		break
+	}
+	if table < 0 {
+		return append(drifts, fmt.Sprintf("rule from %s not found", eip))
+	}
+	if table < firstTableID {
+		drifts = append(drifts, fmt.Sprintf("rule from %s looks up table %d, expected a table from %d", eip, table, firstTableID))
+	}
+
+	if ipCfg.Gateway == nil {
+		return drifts
+	}
+	var foundLinkRoute, foundDefaultRoute bool
+	for _, r := range routes {
+		if r.Table != table || r.LinkIndex != linkIndex {
+			continue
+		}
+		if r.Dst != nil && r.Dst.IP.Equal(ipCfg.Gateway) && r.Scope == netlink.SCOPE_LINK {
+			foundLinkRoute = true
+		}
+		if isDefaultDst(r.Dst) && r.Gw.Equal(ipCfg.Gateway) {
+			foundDefaultRoute = true
+		}
+	}
+	if !foundLinkRoute {
+		drifts = append(drifts, fmt.Sprintf("link route to gateway %s not found in table %d", ipCfg.Gateway, table))
+	}
+	if !foundDefaultRoute {
+		drifts = append(drifts, fmt.Sprintf("default route via %s dev %s not found in table %d", ipCfg.Gateway, iface, table))
+	}
+	return drifts
+}
+
+func isDefaultDst(dst *net.IPNet) bool {
+	if dst == nil {
+		return true
+	}
+	ones, _ := dst.Mask.Size()
+	return ones == 0 && dst.IP.IsUnspecified()
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+package main
+
+import (
+	"net"
+	"testing"
+
+	current "github.com/containernetworking/cni/pkg/types/100"
+	"github.com/stretchr/testify/assert"
+	"github.com/vishvananda/netlink"
+)
+
+func TestRouteDrifts(t *testing.T) {
+	var (
+		link = &netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: "eth0", Index: 2}}
+		eip  = net.ParseIP("106.12.0.10")
+		gw   = net.ParseIP("10.0.0.1")
+		cfg  = &current.IPConfig{Address: net.IPNet{IP: eip, Mask: net.CIDRMask(32, 32)}, Gateway: gw}
+
+		addrs = []netlink.Addr{
+			{IPNet: &net.IPNet{IP: net.ParseIP("10.0.0.5"), Mask: net.CIDRMask(24, 32)}},
+			{IPNet: &cfg.Address},
+		}
+		rules = []netlink.Rule{
+			{Table: 254},
+			{Src: &net.IPNet{IP: eip, Mask: net.CIDRMask(32, 32)}, Table: 101},
+		}
+		routes = []netlink.Route{
+			{Dst: &net.IPNet{IP: gw, Mask: net.CIDRMask(32, 32)}, Table: 101, LinkIndex: 2, Scope: netlink.SCOPE_LINK},
+			{Gw: gw, Table: 101, LinkIndex: 2},
+		}
+	)
+
+	assert.Empty(t, routeDrifts(cfg, link, addrs, rules, routes))
+
+	// the default route was removed
+	assert.Len(t, routeDrifts(cfg, link, addrs, rules, routes[:1]), 1)
+
+	// the eip was removed from the interface
+	assert.Len(t, routeDrifts(cfg, link, addrs[:1], rules, routes), 1)
+
+	// the rule was removed, the routes can not be checked without the table
+	drifts := routeDrifts(cfg, link, addrs, rules[:1], routes)
+	assert.Equal(t, []string{"rule from 106.12.0.10 not found"}, drifts)
+
+	// the routes are in another table
+	rules[1].Table = 102
+	assert.Len(t, routeDrifts(cfg, link, addrs, rules, routes), 2)
+}
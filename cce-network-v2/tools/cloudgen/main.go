@@ -0,0 +1,354 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+// cloudgen generates the flow control and metrics wrappers of the cloud
+// Interface from the annotations of its methods:
+//
+//	// +cce:api:key=bcecloud/apis/v1/CreateENI
+//	// +cce:api:ratelimit=rate-limit:5/s,rate-burst:5,parallel-requests:5,max-wait-duration:30s,log:true
+//	CreateENI(ctx context.Context, args *eni.CreateEniArgs) (string, error)
+//
+// key is the name of the rate limiter of the method, it defaults to
+// bcecloud/apis/v1/<method>. ratelimit is the default parameters of the rate
+// limiter in the format of --api-rate-limit, methods without ratelimit use
+// the default parameters of the client. metric is the label of the method in
+// the cloud API metrics, it defaults to the name of the method. Methods
+// annotated with +cce:api:local do not call the cloud and are neither limited
+// nor measured.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/rate"
+)
+
+const (
+	markerKey       = "+cce:api:key="
+	markerRateLimit = "+cce:api:ratelimit="
+	markerMetric    = "+cce:api:metric="
+	markerLocal     = "+cce:api:local"
+
+	defaultKeyPrefix = "bcecloud/apis/v1/"
+)
+
+var (
+	input      = flag.String("input", "types.go", "file which defines the interface")
+	iface      = flag.String("interface", "Interface", "name of the interface to generate wrappers for")
+	outputDir  = flag.String("output-dir", ".", "directory to write the generated files to")
+	headerFile = flag.String("header", "", "file with the license header of the generated files")
+)
+
+type param struct {
+	Name     string
+	Type     string
+	Variadic bool
+}
+
+type method struct {
+	Name      string
+	Key       string
+	RateLimit string
+	Metric    string
+	Local     bool
+	Params    []param
+	Results   []string
+}
+
+// Signature returns the parameters and results of the method
+func (m method) Signature() string {
+	var params []string
+	for _, p := range m.Params {
+		params = append(params, p.Name+" "+p.Type)
+	}
+	results := strings.Join(m.Results, ", ")
+	if len(m.Results) > 1 {
+		results = "(" + results + ")"
+	}
+	return fmt.Sprintf("(%s) %s", strings.Join(params, ", "), results)
+}
+
+// Args returns the arguments to call the method with its own parameters
+func (m method) Args() string {
+	var args []string
+	for _, p := range m.Params {
+		if p.Variadic {
+			args = append(args, p.Name+"...")
+		} else {
+			args = append(args, p.Name)
+		}
+	}
+	return strings.Join(args, ", ")
+}
+
+// Ctx returns the name of the context parameter
+func (m method) Ctx() string {
+	return m.Params[0].Name
+}
+
+// Rets returns the variables to receive the results other than error
+func (m method) Rets() []string {
+	n := len(m.Results) - 1
+	if n == 1 {
+		return []string{"ret"}
+	}
+	var rets []string
+	for i := 0; i < n; i++ {
+		rets = append(rets, fmt.Sprintf("ret%d", i))
+	}
+	return rets
+}
+
+// Zeros returns the zero values of the results other than error
+func (m method) Zeros() []string {
+	var zeros []string
+	for _, r := range m.Results[:len(m.Results)-1] {
+		zeros = append(zeros, zeroValue(r))
+	}
+	return zeros
+}
+
+func zeroValue(typ string) string {
+	switch {
+	case strings.HasPrefix(typ, "*"), strings.HasPrefix(typ, "[]"), strings.HasPrefix(typ, "map["),
+		strings.HasPrefix(typ, "chan "), strings.HasPrefix(typ, "func("), typ == "error", typ == "interface{}":
+		return "nil"
+	case typ == "string":
+		return `""`
+	case typ == "bool":
+		return "false"
+	case strings.HasPrefix(typ, "int"), strings.HasPrefix(typ, "uint"), strings.HasPrefix(typ, "float"):
+		return "0"
+	default:
+		return "*new(" + typ + ")"
+	}
+}
+
+func main() {
+	flag.Parse()
+
+	methods, imports, pkg, err := parseInterface(*input, *iface)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cloudgen: %v\n", err)
+		os.Exit(1)
+	}
+
+	var header []byte
+	if *headerFile != "" {
+		if header, err = os.ReadFile(*headerFile); err != nil {
+			fmt.Fprintf(os.Stderr, "cloudgen: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	for _, o := range outputs {
+		if err := generate(filepath.Join(*outputDir, o.file), o.template, header, pkg, o.imports, imports, methods); err != nil {
+			fmt.Fprintf(os.Stderr, "cloudgen: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// parseInterface returns the annotated methods of the interface and the
+// imports of the file they refer to
+func parseInterface(file, name string) ([]method, map[string]string, string, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, nil, parser.ParseComments)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	// imports maps the name of the package to its import spec
+	imports := map[string]string{}
+	for _, spec := range f.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		if spec.Name != nil {
+			imports[spec.Name.Name] = spec.Name.Name + " " + strconv.Quote(path)
+		} else {
+			imports[filepath.Base(path)] = strconv.Quote(path)
+		}
+	}
+
+	var it *ast.InterfaceType
+	ast.Inspect(f, func(n ast.Node) bool {
+		if ts, ok := n.(*ast.TypeSpec); ok && ts.Name.Name == name {
+			it, _ = ts.Type.(*ast.InterfaceType)
+			return false
+		}
+		return true
+	})
+	if it == nil {
+		return nil, nil, "", fmt.Errorf("interface %s not found in %s", name, file)
+	}
+
+	used := map[string]string{}
+	var methods []method
+	for _, field := range it.Methods.List {
+		ft, ok := field.Type.(*ast.FuncType)
+		if !ok || len(field.Names) == 0 {
+			return nil, nil, "", fmt.Errorf("embedded interface %s is not supported", exprString(fset, field.Type))
+		}
+		m := method{Name: field.Names[0].Name}
+		if err := parseMarkers(&m, field.Doc); err != nil {
+			return nil, nil, "", err
+		}
+
+		for i, p := range ft.Params.List {
+			typ := p.Type
+			variadic := false
+			if ellipsis, ok := typ.(*ast.Ellipsis); ok {
+				typ = ellipsis.Elt
+				variadic = true
+			}
+			typeString := exprString(fset, typ)
+			if variadic {
+				typeString = "..." + typeString
+			}
+			collectPackages(typ, imports, used)
+			names := p.Names
+			if len(names) == 0 {
+				names = []*ast.Ident{ast.NewIdent(fmt.Sprintf("arg%d", i))}
+			}
+			for _, n := range names {
+				m.Params = append(m.Params, param{Name: n.Name, Type: typeString, Variadic: variadic})
+			}
+		}
+		if len(m.Params) == 0 || m.Params[0].Type != "context.Context" {
+			return nil, nil, "", fmt.Errorf("method %s must take context.Context as the first parameter", m.Name)
+		}
+
+		if ft.Results != nil {
+			for _, r := range ft.Results.List {
+				collectPackages(r.Type, imports, used)
+				n := len(r.Names)
+				if n == 0 {
+					n = 1
+				}
+				for i := 0; i < n; i++ {
+					m.Results = append(m.Results, exprString(fset, r.Type))
+				}
+			}
+		}
+		if len(m.Results) == 0 || m.Results[len(m.Results)-1] != "error" {
+			return nil, nil, "", fmt.Errorf("method %s must return error as the last result", m.Name)
+		}
+
+		methods = append(methods, m)
+	}
+	return methods, used, f.Name.Name, nil
+}
+
+func parseMarkers(m *method, doc *ast.CommentGroup) error {
+	if doc != nil {
+		for _, c := range doc.List {
+			text := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+			switch {
+			case strings.HasPrefix(text, markerKey):
+				m.Key = strings.TrimPrefix(text, markerKey)
+			case strings.HasPrefix(text, markerRateLimit):
+				m.RateLimit = strings.TrimPrefix(text, markerRateLimit)
+				if _, err := (rate.APILimiterParameters{}).MergeUserConfig(m.RateLimit); err != nil {
+					return fmt.Errorf("invalid rate limit of method %s: %w", m.Name, err)
+				}
+			case strings.HasPrefix(text, markerMetric):
+				m.Metric = strings.TrimPrefix(text, markerMetric)
+			case text == markerLocal:
+				m.Local = true
+			}
+		}
+	}
+	if m.Local && (m.Key != "" || m.RateLimit != "" || m.Metric != "") {
+		return fmt.Errorf("local method %s must not declare rate limit or metric", m.Name)
+	}
+	if m.Key == "" {
+		m.Key = defaultKeyPrefix + m.Name
+	}
+	if m.Metric == "" {
+		m.Metric = m.Name
+	}
+	return nil
+}
+
+// collectPackages adds the imports of the packages referred to by expr
+func collectPackages(expr ast.Expr, imports, used map[string]string) {
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok {
+				if spec, ok := imports[id.Name]; ok {
+					used[id.Name] = spec
+				}
+			}
+		}
+		return true
+	})
+}
+
+func exprString(fset *token.FileSet, expr ast.Expr) string {
+	var buf bytes.Buffer
+	format.Node(&buf, fset, expr)
+	return buf.String()
+}
+
+func generate(file string, tmpl *template.Template, header []byte, pkg string, extraImports []string, typeImports map[string]string, methods []method) error {
+	specs := map[string]bool{}
+	for _, spec := range extraImports {
+		specs[spec] = true
+	}
+	for _, spec := range typeImports {
+		specs[spec] = true
+	}
+
+	// the standard library imports go in the first group
+	var std, others []string
+	for spec := range specs {
+		path := spec[strings.Index(spec, `"`)+1:]
+		if strings.Contains(strings.Split(path, "/")[0], ".") {
+			others = append(others, spec)
+		} else {
+			std = append(std, spec)
+		}
+	}
+
+	sort.Strings(std)
+	sort.Strings(others)
+
+	var buf bytes.Buffer
+	buf.Write(header)
+	if err := tmpl.Execute(&buf, map[string]interface{}{
+		"Package":    pkg,
+		"StdImports": std,
+		"Imports":    others,
+		"Methods":    methods,
+	}); err != nil {
+		return err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("unable to format %s: %w\n%s", file, err, buf.String())
+	}
+	return os.WriteFile(file, src, 0644)
+}
@@ -0,0 +1,117 @@
+/*
+ * Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+ * except in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the
+ * License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions
+ * and limitations under the License.
+ *
+ */
+
+package main
+
+import (
+	"strings"
+	"text/template"
+)
+
+const modulePath = "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2"
+
+var funcs = template.FuncMap{
+	"join": func(s []string) string { return strings.Join(s, ", ") },
+}
+
+const importsTemplate = `
+// Code generated by cloudgen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{range .StdImports}}	{{.}}
+{{end}}
+{{range .Imports}}	{{.}}
+{{end}})
+`
+
+var outputs = []struct {
+	file     string
+	imports  []string
+	template *template.Template
+}{
+	{
+		file: "zz_generated.flow_control.go",
+		imports: []string{
+			`"` + modulePath + `/operator/option"`,
+			`"` + modulePath + `/pkg/rate"`,
+			`"` + modulePath + `/pkg/tracing"`,
+		},
+		template: template.Must(template.New("flow_control").Funcs(funcs).Parse(importsTemplate + `
+const (
+{{- range .Methods}}{{if not .Local}}
+	{{.Name}} = {{printf "%q" .Key}}
+{{- end}}{{end}}
+)
+
+// apiRateLimitDefaults is the default parameters of the rate limiters
+// declared by the APIs
+var apiRateLimitDefaults = map[string]rate.APILimiterParameters{
+{{- range .Methods}}{{if .RateLimit}}
+	{{.Name}}: mustParseAPILimiterParameters({{printf "%q" .RateLimit}}),
+{{- end}}{{end}}
+}
+{{range .Methods}}
+// {{.Name}} implements Interface
+func (fc *flowControlClient) {{.Name}}{{.Signature}} {
+{{- if .Local}}
+	return fc.client.{{.Name}}({{.Args}})
+{{- else}}
+	var (
+		req rate.LimitedRequest
+		err error
+	)
+	{{.Ctx}}, span := tracing.Start({{.Ctx}}, {{printf "%q" (print "cloud/" .Name)}})
+	defer func() {
+		tracing.End(span, err)
+	}()
+	if option.Config.EnableAPIRateLimit {
+		req, err = fc.wait({{.Ctx}}, {{.Name}})
+		if err != nil {
+			return {{range .Zeros}}{{.}}, {{end}}err
+		}
+		defer func() {
+			if err != nil {
+				req.Error(err)
+			} else {
+				req.Done()
+			}
+		}()
+	}
+	{{if .Rets}}{{join .Rets}}, err := {{else}}err = {{end}}fc.client.{{.Name}}({{.Args}})
+	return {{range .Rets}}{{.}}, {{end}}err
+{{- end}}
+}
+{{end}}`)),
+	},
+	{
+		file:    "zz_generated.metrics.go",
+		imports: []string{`"time"`},
+		template: template.Must(template.New("metrics").Funcs(funcs).Parse(importsTemplate + `
+{{range .Methods}}
+// {{.Name}} implements Interface
+func (mc *metricsClient) {{.Name}}{{.Signature}} {
+{{- if .Local}}
+	return mc.client.{{.Name}}({{.Args}})
+{{- else}}
+	start := time.Now()
+	{{if .Rets}}{{join .Rets}}, err := {{else}}err := {{end}}mc.client.{{.Name}}({{.Args}})
+	return {{range .Rets}}{{.}}, {{end}}exportMetricAndLog({{.Ctx}}, {{printf "%q" .Metric}}, start, err)
+{{- end}}
+}
+{{end}}`)),
+	},
+}
@@ -38,11 +38,12 @@ import (
 var (
 	FeatureKeyOfPublicIP = "publicIP"
 
-	namespace           = "kube-system"
-	serviceName         = "eip-webhook-service"
-	secretName          = "eip-webhook-server-cert"
-	mutatingWebhookName = "eip-mutating-webhook-configuration"
-	certDir             = "/tmp/k8s-webhook-server/serving-certs"
+	namespace             = "kube-system"
+	serviceName           = "eip-webhook-service"
+	secretName            = "eip-webhook-server-cert"
+	mutatingWebhookName   = "eip-mutating-webhook-configuration"
+	validatingWebhookName = "eip-validating-webhook-configuration"
+	certDir               = "/tmp/k8s-webhook-server/serving-certs"
 )
 
 // log is for logging in this package.
@@ -174,5 +175,19 @@ func setupBundle(kubeClient clientset.Interface, caBundle []byte) error {
 		return fmt.Errorf("failed to update Bundle for %s: %v", mutatingWebhookName, err)
 	}
 
+	validatingConfig, err := kubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations().Get(context.TODO(),
+		validatingWebhookName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to find ValidatingWebhookConfiguration %s", validatingWebhookName)
+	}
+
+	for i := range validatingConfig.Webhooks {
+		validatingConfig.Webhooks[i].ClientConfig.CABundle = caBundle
+	}
+	if _, err := kubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations().Update(context.TODO(),
+		validatingConfig, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update Bundle for %s: %v", validatingWebhookName, err)
+	}
+
 	return nil
 }
@@ -31,6 +31,21 @@ const (
 	EIPModeTypeDirect EIPModeType = "Direct"
 )
 
+type EIPReclaimPolicy string
+
+const (
+	// EIPReclaimPolicyRelease releases the EIP in VPC after it is unbinded
+	EIPReclaimPolicyRelease EIPReclaimPolicy = "Release"
+	// EIPReclaimPolicyRetain keeps the EIP in VPC after it is unbinded
+	EIPReclaimPolicyRetain EIPReclaimPolicy = "Retain"
+)
+
+const (
+	// LabelPodEIPBindStrategy is the label of EIP created from the DynamicEIPTemplate,
+	// whose value is the name of PodEIPBindStrategy
+	LabelPodEIPBindStrategy = "cce.baidubce.com/pod-eip-bind-strategy"
+)
+
 type TagModel struct {
 	TagKey   string `json:"tagKey"`
 	TagValue string `json:"tagValue"`
@@ -44,6 +59,8 @@ type EIPSpec struct {
 	// CCE defined filed
 	CreatedByCCE bool        `json:"createdByCCE"`
 	Mode         EIPModeType `json:"mode"`
+	// ReclaimPolicy only takes effect for the EIP created by CCE
+	ReclaimPolicy EIPReclaimPolicy `json:"reclaimPolicy,omitempty"`
 
 	// VPC defined field
 	Name            string              `json:"name,omitempty"`
@@ -82,6 +99,10 @@ type EIPStatus struct {
 	Endpoint   string        `json:"endpoint,omitempty"`
 	InstanceID string        `json:"instanceID,omitempty"`
 	PrivateIP  string        `json:"privateIP,omitempty"`
+	// ReservedEndpoint is the ns/pod-name which the EIP is reserved for after the pod is deleted
+	ReservedEndpoint string `json:"reservedEndpoint,omitempty"`
+	// ReservedUntil is the time when the reservation of EIP expires
+	ReservedUntil *metav1.Time `json:"reservedUntil,omitempty"`
 
 	// VPC defined filed
 	StatusInVPC  string `json:"statusInVPC,omitempty"`
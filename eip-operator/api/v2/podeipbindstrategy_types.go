@@ -17,6 +17,7 @@ limitations under the License.
 package v2
 
 import (
+	eipmodel "github.com/baidubce/bce-sdk-go/model"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -30,9 +31,47 @@ type PodEIPBindStrategySpec struct {
 
 	StaticEIPPool []string `json:"staticEIPPool,omitempty"`
 
+	// DynamicEIPTemplate is used to create a new EIP in VPC for the matched CCEEndpoint
+	// when there is no available EIP in StaticEIPPool
+	// +kubebuilder:validation:Optional
+	DynamicEIPTemplate *DynamicEIPTemplate `json:"dynamicEIPTemplate,omitempty"`
+
+	// ReclaimPolicy decides whether the EIP created from DynamicEIPTemplate is released
+	// in VPC when it is not used by the CCEEndpoint any more
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Release;Retain
+	// +kubebuilder:default=Release
+	ReclaimPolicy EIPReclaimPolicy `json:"reclaimPolicy,omitempty"`
+
+	// EnableReuseEIP keeps the EIP reserved for the pod with the same namespace/name after
+	// its CCEEndpoint is deleted, so that the recreated pod gets the same EIP
+	EnableReuseEIP bool `json:"enableReuseEIP,omitempty"`
+
+	// TTL How long after the CCEEndpoint is deleted, the reserved EIP will be freed, default is 7d
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+
 	Selector *metav1.LabelSelector `json:"selector"`
 }
 
+// DynamicEIPTemplate defines the EIP created in VPC for the CCEEndpoint.
+// The EIP is postpaid.
+type DynamicEIPTemplate struct {
+	// NamePrefix is the prefix of EIP name in VPC, the name of CCEEndpoint is appended to it
+	NamePrefix string `json:"namePrefix,omitempty"`
+
+	// +kubebuilder:validation:Minimum=1
+	BandWidthInMbps int `json:"bandwidthInMbps"`
+
+	// +kubebuilder:validation:Enum=ByTraffic;ByBandwidth
+	// +kubebuilder:default=ByTraffic
+	BillingMethod string `json:"billingMethod,omitempty"`
+
+	// ShareGroupID is the shared bandwidth group which the created EIP is moved into
+	ShareGroupID string `json:"shareGroupID,omitempty"`
+
+	Tags []eipmodel.TagModel `json:"tags,omitempty"`
+}
+
 // PodEIPBindStrategyStatus defines the observed state of PodEIPBindStrategy
 type PodEIPBindStrategyStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
@@ -0,0 +1,164 @@
+/*
+Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+
+	unversionedvalidation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var (
+	supportedBillingMethods  = []string{"ByTraffic", "ByBandwidth"}
+	supportedReclaimPolicies = []string{string(EIPReclaimPolicyRelease), string(EIPReclaimPolicyRetain)}
+)
+
+// log is for logging in this package.
+var pebslog = logf.Log.WithName("PodEIPBindStrategy")
+
+//+kubebuilder:webhook:path=/validate-cce-baidubce-com-v2-podeipbindstrategy,mutating=false,failurePolicy=fail,sideEffects=None,groups=cce.baidubce.com,resources=podeipbindstrategies,verbs=create;update,versions=v2,name=vpodeipbindstrategy.kb.io,admissionReviewVersions=v1
+
+//+kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingwebhookconfigurations,verbs=get;list;watch;create;update;patch;delete
+
+// PebsValidator rejects the PodEIPBindStrategy which can not bind EIPs to the
+// CCEEndpoints, and warns the EIPs which are in the pools of other strategies
+// +kubebuilder:object:generate=false
+type PebsValidator struct {
+	Client  client.Client
+	decoder *admission.Decoder
+}
+
+func (v *PebsValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	pebs := &PodEIPBindStrategy{}
+	err := v.decoder.Decode(req, pebs)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if pebs.Namespace == "" {
+		pebs.Namespace = req.Namespace
+	}
+
+	allErrs := ValidatePebsSpec(&pebs.Spec, field.NewPath("spec"))
+	if len(allErrs) > 0 {
+		pebslog.Info("reject invalid PodEIPBindStrategy", "PodEIPBindStrategy", pebs.Namespace+"/"+pebs.Name,
+			"error", allErrs.ToAggregate().Error())
+		return admission.Denied(allErrs.ToAggregate().Error())
+	}
+
+	warnings, err := v.sharedEIPWarnings(ctx, pebs)
+	if err != nil {
+		// the warnings are informative, do not block the request
+		pebslog.Error(err, "failed to list PodEIPBindStrategy")
+	}
+	return admission.Allowed("").WithWarnings(warnings...)
+}
+
+// ValidatePebsSpec validates the selector, the static EIP pool and the dynamic
+// EIP template of the PodEIPBindStrategy
+func ValidatePebsSpec(spec *PodEIPBindStrategySpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.Selector == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("selector"), "selector of CCEEndpoints must be set"))
+	} else {
+		selectorPath := fldPath.Child("selector")
+		allErrs = append(allErrs, unversionedvalidation.ValidateLabelSelector(spec.Selector,
+			unversionedvalidation.LabelSelectorValidationOptions{}, selectorPath)...)
+		// CCEEndpoints are never matched by the empty selector, see PebsMatchCep
+		if len(spec.Selector.MatchLabels)+len(spec.Selector.MatchExpressions) == 0 {
+			allErrs = append(allErrs, field.Invalid(selectorPath, spec.Selector, "empty selector matches no CCEEndpoint"))
+		}
+	}
+
+	if len(spec.StaticEIPPool) == 0 && spec.DynamicEIPTemplate == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("staticEIPPool"), "either staticEIPPool or dynamicEIPTemplate must be set"))
+	}
+	poolPath := fldPath.Child("staticEIPPool")
+	seen := make(map[string]bool, len(spec.StaticEIPPool))
+	for i, eip := range spec.StaticEIPPool {
+		if ip := net.ParseIP(eip); ip == nil || ip.To4() == nil {
+			allErrs = append(allErrs, field.Invalid(poolPath.Index(i), eip, "EIP must be an IPv4 address"))
+			continue
+		}
+		if seen[eip] {
+			allErrs = append(allErrs, field.Duplicate(poolPath.Index(i), eip))
+		}
+		seen[eip] = true
+	}
+
+	if template := spec.DynamicEIPTemplate; template != nil {
+		templatePath := fldPath.Child("dynamicEIPTemplate")
+		if template.BandWidthInMbps < 1 {
+			allErrs = append(allErrs, field.Invalid(templatePath.Child("bandwidthInMbps"), template.BandWidthInMbps, "bandwidth must be at least 1 Mbps"))
+		}
+		if template.BillingMethod != "" && !contains(supportedBillingMethods, template.BillingMethod) {
+			allErrs = append(allErrs, field.NotSupported(templatePath.Child("billingMethod"), template.BillingMethod, supportedBillingMethods))
+		}
+	}
+	if spec.ReclaimPolicy != "" && !contains(supportedReclaimPolicies, string(spec.ReclaimPolicy)) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("reclaimPolicy"), spec.ReclaimPolicy, supportedReclaimPolicies))
+	}
+	if spec.TTL != nil && spec.TTL.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("ttl"), spec.TTL.Duration.String(), "ttl must be positive"))
+	}
+	return allErrs
+}
+
+// sharedEIPWarnings returns the warnings for the static EIPs which are also in
+// the pools of other PodEIPBindStrategies in the namespace
+func (v *PebsValidator) sharedEIPWarnings(ctx context.Context, pebs *PodEIPBindStrategy) ([]string, error) {
+	if len(pebs.Spec.StaticEIPPool) == 0 {
+		return nil, nil
+	}
+	pebsList := PodEIPBindStrategyList{}
+	if err := v.Client.List(ctx, &pebsList, client.InNamespace(pebs.Namespace)); err != nil {
+		return nil, err
+	}
+	var warnings []string
+	for i := range pebsList.Items {
+		other := &pebsList.Items[i]
+		if other.Name == pebs.Name {
+			continue
+		}
+		for _, eip := range other.Spec.StaticEIPPool {
+			if contains(pebs.Spec.StaticEIPPool, eip) {
+				warnings = append(warnings, fmt.Sprintf("EIP %s is also in the staticEIPPool of PodEIPBindStrategy %s", eip, other.Name))
+			}
+		}
+	}
+	return warnings, nil
+}
+
+func (v *PebsValidator) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
@@ -27,13 +27,33 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DynamicEIPTemplate) DeepCopyInto(out *DynamicEIPTemplate) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]model.TagModel, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicEIPTemplate.
+func (in *DynamicEIPTemplate) DeepCopy() *DynamicEIPTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(DynamicEIPTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EIP) DeepCopyInto(out *EIP) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EIP.
@@ -109,6 +129,10 @@ func (in *EIPSpec) DeepCopy() *EIPSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EIPStatus) DeepCopyInto(out *EIPStatus) {
 	*out = *in
+	if in.ReservedUntil != nil {
+		in, out := &in.ReservedUntil, &out.ReservedUntil
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EIPStatus.
@@ -188,6 +212,16 @@ func (in *PodEIPBindStrategySpec) DeepCopyInto(out *PodEIPBindStrategySpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.DynamicEIPTemplate != nil {
+		in, out := &in.DynamicEIPTemplate, &out.DynamicEIPTemplate
+		*out = new(DynamicEIPTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	if in.Selector != nil {
 		in, out := &in.Selector, &out.Selector
 		*out = new(v1.LabelSelector)
@@ -117,8 +117,10 @@ func main() {
 
 	// TODO: eip GC(eip was deleted from PEBS.Status.StaticPool)
 	if err = (&controller.PodEIPBindStrategyReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		Region:       region,
+		CCEClusterID: cceClusterID,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "PodEIPBindStrategy")
 		os.Exit(1)
@@ -136,6 +138,8 @@ func main() {
 
 	mgr.GetWebhookServer().Register("/mutate-cce-baidubce-com-v2-cceendpoint",
 		&webhook.Admission{Handler: &v2.CepAnnotator{Client: mgr.GetClient()}})
+	mgr.GetWebhookServer().Register("/validate-cce-baidubce-com-v2-podeipbindstrategy",
+		&webhook.Admission{Handler: &v2.PebsValidator{Client: mgr.GetClient()}})
 
 	//+kubebuilder:scaffold:builder
 
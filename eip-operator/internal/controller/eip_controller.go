@@ -19,6 +19,7 @@ package controller
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -222,7 +223,12 @@ func (r *EIPReconciler) onStatusUnBinded(ctx context.Context, log *logr.Logger,
 		return r.handleEIPDelete(ctx, log, eip)
 	}
 
-	return ctrl.Result{}, nil
+	// the EIP is unbinded from the deleted pod and reserved for it, so it is available again
+	eip.Status.Status = v2.EIPStatusTypeAvailable
+	eip.Status.Endpoint = ""
+	eip.Status.InstanceID = ""
+	eip.Status.PrivateIP = ""
+	return ctrl.Result{}, r.updateEIPStatus(ctx, log, eip)
 }
 
 func (r *EIPReconciler) handleEIPDelete(ctx context.Context, log *logr.Logger, eip *v2.EIP) (ctrl.Result, error) {
@@ -236,6 +242,15 @@ func (r *EIPReconciler) handleEIPDelete(ctx context.Context, log *logr.Logger, e
 
 	if eip.Status.Status == v2.EIPStatusTypeUnBinded ||
 		eip.Status.Status == v2.EIPStatusTypeAvailable {
+		if shouldReleaseEIP(eip) {
+			if err := r.bceclient.DeleteEIP(ctx, eip.Name); err != nil &&
+				cloud.BceServiceErrorToHTTPCode(err) != http.StatusNotFound {
+				log.Error(err, fmt.Sprintf("EIP Reconcile, failed to Release EIP [%s] in VPC", eip.Name))
+				return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, err
+			}
+			log.Info(fmt.Sprintf("EIP Reconcile, EIP [%s] Released in VPC", eip.Name))
+		}
+
 		var finalizers []string
 		for _, f := range eip.Finalizers {
 			if f != FinalizerEIPKey {
@@ -267,6 +282,9 @@ func (r *EIPReconciler) getEIPInfoFromVPC(ctx context.Context, log *logr.Logger,
 		log.Error(fmt.Errorf(fmt.Sprintf("EIP Reconcile, failed to ListEIPs for [%s] in VPC, err: %v",
 			eip.Name, err)), "")
 		return vpceip.EipModel{}, err
+	} else if len(vpcEIPs) == 0 {
+		// the EIP created by CCE may be not listed in VPC yet
+		return vpceip.EipModel{}, fmt.Errorf("EIP [%s] not found in VPC", eip.Name)
 	} else {
 		log.Info(fmt.Sprintf("EIP Reconcile, got EIP info from VPC %+v", vpcEIPs[0]))
 		return vpcEIPs[0], nil
@@ -327,46 +345,48 @@ func IsEIPStatusStable(eip *v2.EIP) bool {
 	return false
 }
 
+// shouldReleaseEIP returns true if the EIP is created by CCE and should be released in VPC
+// when the EIP CR is deleted
+func shouldReleaseEIP(eip *v2.EIP) bool {
+	return eip.Spec.CreatedByCCE && eip.Spec.ReclaimPolicy == v2.EIPReclaimPolicyRelease
+}
+
 func IsEIPBindReady(eip *v2.EIP) bool {
 	return eip.Status.Status == v2.EIPStatusTypeBinded && eip.Spec.Mode == eip.Status.Mode
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *EIPReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	if err := r.initBCEClient(); err != nil {
+	c, err := newBCEClient(r.Region, r.CCEClusterID)
+	if err != nil {
 		return err
 	}
+	r.bceclient = c
 
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v2.EIP{}).
 		Complete(r)
 }
 
-func (r *EIPReconciler) initBCEClient() error {
+func newBCEClient(region, cceClusterID string) (cloud.Interface, error) {
 	c, err := cloud.New(
-		r.Region,       /*Region*/
-		r.CCEClusterID, /*CCE Cluseter ID*/
-		"",             /*BCE Access Key*/
-		"",             /*BCE Secure Key*/
+		region,       /*Region*/
+		cceClusterID, /*CCE Cluseter ID*/
+		"",           /*BCE Access Key*/
+		"",           /*BCE Secure Key*/
 		false,
 		k8s.Client(),
 		false,
 		30*time.Second, /*DefaultAPIRequestTimeout*/
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	c, err = cloud.NewFlowControlClient(
-		c,
+	return cloud.NewFlowControlClient(
+		cloud.NewMetricsClient(c),
 		5,  /*DefaultAPIQPSLimit*/
 		10, /*DefaultAPIBurst*/
 		15, /*DefaultAPITimeoutLimit*/
 	)
-	if err != nil {
-		return err
-	}
-
-	r.bceclient = c
-	return nil
 }
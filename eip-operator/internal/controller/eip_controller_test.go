@@ -0,0 +1,224 @@
+/*
+Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	v2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-ext-eip/api/v2"
+	vpceip "github.com/baidubce/bce-sdk-go/services/eip"
+)
+
+func TestShouldReleaseEIP(t *testing.T) {
+	tests := []struct {
+		name          string
+		createdByCCE  bool
+		reclaimPolicy v2.EIPReclaimPolicy
+		want          bool
+	}{
+		{name: "created by cce with release policy", createdByCCE: true, reclaimPolicy: v2.EIPReclaimPolicyRelease, want: true},
+		{name: "created by cce with retain policy", createdByCCE: true, reclaimPolicy: v2.EIPReclaimPolicyRetain, want: false},
+		{name: "created by cce without policy", createdByCCE: true, want: false},
+		{name: "created by user", createdByCCE: false, reclaimPolicy: v2.EIPReclaimPolicyRelease, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eip := &v2.EIP{Spec: v2.EIPSpec{CreatedByCCE: tt.createdByCCE, ReclaimPolicy: tt.reclaimPolicy}}
+			if got := shouldReleaseEIP(eip); got != tt.want {
+				t.Errorf("shouldReleaseEIP() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleEIPDelete(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        v2.EIPStatusType
+		createdByCCE  bool
+		reclaimPolicy v2.EIPReclaimPolicy
+		// wantStatus is the status of EIP CR after handleEIPDelete, empty if the finalizer is removed
+		wantStatus   v2.EIPStatusType
+		wantReleased bool
+	}{
+		{
+			name:          "available dynamic eip is released",
+			status:        v2.EIPStatusTypeAvailable,
+			createdByCCE:  true,
+			reclaimPolicy: v2.EIPReclaimPolicyRelease,
+			wantReleased:  true,
+		},
+		{
+			name:          "unbinded dynamic eip is released",
+			status:        v2.EIPStatusTypeUnBinded,
+			createdByCCE:  true,
+			reclaimPolicy: v2.EIPReclaimPolicyRelease,
+			wantReleased:  true,
+		},
+		{
+			name:          "retained dynamic eip is kept in vpc",
+			status:        v2.EIPStatusTypeAvailable,
+			createdByCCE:  true,
+			reclaimPolicy: v2.EIPReclaimPolicyRetain,
+		},
+		{
+			name:   "static eip is kept in vpc",
+			status: v2.EIPStatusTypeAvailable,
+		},
+		{
+			name:          "binded eip is unbinded first",
+			status:        v2.EIPStatusTypeBinded,
+			createdByCCE:  true,
+			reclaimPolicy: v2.EIPReclaimPolicyRelease,
+			wantStatus:    v2.EIPStatusTypeUnBinding,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			log := logr.Discard()
+
+			sim := newSimulator(t)
+			address, err := sim.CreateEIP(ctx, &vpceip.CreateEipArgs{BandWidthInMbps: 10})
+			if err != nil {
+				t.Fatal(err)
+			}
+			eip := newStaticEIP(address, tt.status)
+			eip.Finalizers = []string{FinalizerEIPKey}
+			eip.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			eip.Spec.CreatedByCCE = tt.createdByCCE
+			eip.Spec.ReclaimPolicy = tt.reclaimPolicy
+
+			r := &EIPReconciler{
+				Client:    newFakeClient(t, eip),
+				bceclient: sim,
+			}
+			if _, err := r.handleEIPDelete(ctx, &log, eip); err != nil {
+				t.Fatalf("handleEIPDelete() error = %v", err)
+			}
+
+			vpcEIPs, err := sim.ListEIPs(ctx, vpceip.ListEipArgs{Eip: address})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if released := len(vpcEIPs) == 0; released != tt.wantReleased {
+				t.Errorf("EIP released in VPC = %v, want %v", released, tt.wantReleased)
+			}
+
+			var eipCR v2.EIP
+			err = r.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: address}, &eipCR)
+			if tt.wantStatus == "" {
+				if err == nil && len(eipCR.Finalizers) != 0 {
+					t.Errorf("finalizers of EIP CR = %v, want removed", eipCR.Finalizers)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if eipCR.Status.Status != tt.wantStatus {
+				t.Errorf("status of EIP CR = %s, want %s", eipCR.Status.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestOnStatusUnBinded(t *testing.T) {
+	reservedUntil := &metav1.Time{Time: time.Now().Add(time.Hour).Truncate(time.Second)}
+	tests := []struct {
+		name     string
+		deleting bool
+		// wantStatus is the status of EIP CR after onStatusUnBinded, empty if the finalizer is removed
+		wantStatus   v2.EIPStatusType
+		wantReleased bool
+	}{
+		{
+			name:       "eip becomes available and keeps the reservation",
+			wantStatus: v2.EIPStatusTypeAvailable,
+		},
+		{
+			name:         "deleted eip is reclaimed",
+			deleting:     true,
+			wantReleased: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			log := logr.Discard()
+
+			sim := newSimulator(t)
+			address, err := sim.CreateEIP(ctx, &vpceip.CreateEipArgs{BandWidthInMbps: 10})
+			if err != nil {
+				t.Fatal(err)
+			}
+			eip := newDynamicEIP(address, "pebs", v2.EIPStatusTypeUnBinded)
+			eip.Status.Endpoint = "default/pod-1"
+			eip.Status.InstanceID = "eni-1"
+			eip.Status.PrivateIP = "10.0.0.2"
+			eip.Status.ReservedEndpoint = "default/pod-1"
+			eip.Status.ReservedUntil = reservedUntil
+			if tt.deleting {
+				eip.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			}
+
+			r := &EIPReconciler{
+				Client:    newFakeClient(t, eip),
+				bceclient: sim,
+			}
+			if _, err := r.onStatusUnBinded(ctx, &log, eip); err != nil {
+				t.Fatalf("onStatusUnBinded() error = %v", err)
+			}
+
+			vpcEIPs, err := sim.ListEIPs(ctx, vpceip.ListEipArgs{Eip: address})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if released := len(vpcEIPs) == 0; released != tt.wantReleased {
+				t.Errorf("EIP released in VPC = %v, want %v", released, tt.wantReleased)
+			}
+
+			var eipCR v2.EIP
+			err = r.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: address}, &eipCR)
+			if tt.wantStatus == "" {
+				if err == nil && len(eipCR.Finalizers) != 0 {
+					t.Errorf("finalizers of EIP CR = %v, want removed", eipCR.Finalizers)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if eipCR.Status.Status != tt.wantStatus {
+				t.Errorf("status of EIP CR = %s, want %s", eipCR.Status.Status, tt.wantStatus)
+			}
+			if eipCR.Status.Endpoint != "" || eipCR.Status.InstanceID != "" || eipCR.Status.PrivateIP != "" {
+				t.Errorf("status of EIP CR = %+v, want the binding cleared", eipCR.Status)
+			}
+			if eipCR.Status.ReservedEndpoint != "default/pod-1" || !eipCR.Status.ReservedUntil.Equal(reservedUntil) {
+				t.Errorf("reservation of EIP CR = %s until %v, want default/pod-1 until %v",
+					eipCR.Status.ReservedEndpoint, eipCR.Status.ReservedUntil, reservedUntil)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"time"
 
 	xslices "golang.org/x/exp/slices"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -37,19 +38,32 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	v2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-ext-eip/api/v2"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/bce/api/cloud"
 	ipamv2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2"
+	vpceip "github.com/baidubce/bce-sdk-go/services/eip"
 	"github.com/go-logr/logr"
 )
 
 const (
 	CCEEndpintStateReady = "ip-allocated"
 	FeatureStatusEIPKey  = "eip"
+
+	// pebsStatusReservedEndpointKey is the key of the endpoint which the EIP is reserved for
+	// in the TotalEIPs of PEBS Status
+	pebsStatusReservedEndpointKey = "reservedEndpoint"
+
+	// dynamicEIPGCGracePeriod is how long an unbound dynamic EIP which is neither reserved nor
+	// allocated to any CCEEndpoint is kept for reuse before it is released
+	dynamicEIPGCGracePeriod = 5 * time.Minute
 )
 
 // PodEIPBindStrategyReconciler reconciles a PodEIPBindStrategy object
 type PodEIPBindStrategyReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme       *runtime.Scheme
+	Region       string
+	CCEClusterID string
+	bceclient    cloud.Interface
 }
 
 //+kubebuilder:rbac:groups=cce.baidubce.com,resources=podeipbindstrategies,verbs=get;list;watch;create;update;patch;delete
@@ -97,6 +111,19 @@ func (r *PodEIPBindStrategyReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return ctrl.Result{}, err
 	}
 
+	requeueAfter, err := r.handleReservedEIPs(ctx, &log, &pebs)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	gcAfter, err := r.handleUnboundDynamicEIPs(ctx, &log, &pebs)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if requeueAfter == 0 || gcAfter != 0 && gcAfter < requeueAfter {
+		requeueAfter = gcAfter
+	}
+
 	if err := r.updatePEBSStatus(ctx, &log, &pebs); err != nil {
 		return ctrl.Result{}, err
 	}
@@ -105,7 +132,9 @@ func (r *PodEIPBindStrategyReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{}, nil
+	// reconcile again to free the reserved EIP when its reservation expires,
+	// and the unbound dynamic EIP when its grace period expires
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 func (r *PodEIPBindStrategyReconciler) handlePEBSCreate(
@@ -130,7 +159,17 @@ func (r *PodEIPBindStrategyReconciler) handlePEBSDelete(
 	pebs *v2.PodEIPBindStrategy) error {
 	log.Info(fmt.Sprintf("PEBS Reconcile, handlePEBSDelete begin for [%s]", pebs.Namespace+"/"+pebs.Name))
 
-	for _, eip := range pebs.Spec.StaticEIPPool {
+	eips := pebs.Spec.StaticEIPPool
+	dynamicEIPs, err := r.listDynamicEIPs(ctx, pebs)
+	if err != nil {
+		log.Error(err, "PEBS Reconcile, failed to List dynamic EIP CR")
+		return err
+	}
+	for _, eipCR := range dynamicEIPs {
+		eips = append(eips, eipCR.Name)
+	}
+
+	for _, eip := range eips {
 		var eipCR v2.EIP
 		if err := r.Get(ctx, types.NamespacedName{Namespace: pebs.Namespace, Name: eip}, &eipCR); err != nil {
 			if errors.IsNotFound(err) {
@@ -163,7 +202,7 @@ func (r *PodEIPBindStrategyReconciler) handlePEBSDelete(
 		}
 	}
 	pebs.Finalizers = finalizers
-	err := r.Update(ctx, pebs)
+	err = r.Update(ctx, pebs)
 	if err != nil {
 		log.Error(err, fmt.Sprintf("PEBS Reconcile, failed to delete PEBS EIP finalizer for [%s], err: %+v",
 			pebs.Namespace+"/"+pebs.Name, err))
@@ -199,7 +238,8 @@ func (r *PodEIPBindStrategyReconciler) handleEIPs(
 				Finalizers: []string{FinalizerEIPKey},
 			},
 			Spec: v2.EIPSpec{
-				CreatedByCCE: true,
+				// EIP in static pool is created in VPC by the user
+				CreatedByCCE: false,
 				Mode:         v2.EIPModeTypeDirect,
 			},
 		}
@@ -212,6 +252,112 @@ func (r *PodEIPBindStrategyReconciler) handleEIPs(
 	return nil
 }
 
+// handleReservedEIPs frees the EIPs whose reservation expired. The expired EIP in static pool
+// becomes available again, and the expired EIP created by CCE is deleted.
+// It returns the duration until the next reservation expires.
+func (r *PodEIPBindStrategyReconciler) handleReservedEIPs(
+	ctx context.Context,
+	log *logr.Logger,
+	pebs *v2.PodEIPBindStrategy) (time.Duration, error) {
+	log.Info("PEBS Reconcile, handleReservedEIPs begin")
+
+	var eipList v2.EIPList
+	if err := r.List(ctx, &eipList, client.InNamespace(pebs.Namespace)); err != nil {
+		log.Error(err, "PEBS Reconcile, failed to List EIP")
+		return 0, err
+	}
+
+	var (
+		now          = time.Now()
+		requeueAfter time.Duration
+	)
+	for i := range eipList.Items {
+		eipCR := &eipList.Items[i]
+		if !isEIPOfPEBS(pebs, eipCR) || eipCR.Status.ReservedEndpoint == "" {
+			continue
+		}
+		if isEIPReserved(eipCR, now) {
+			if left := eipCR.Status.ReservedUntil.Sub(now); requeueAfter == 0 || left < requeueAfter {
+				requeueAfter = left
+			}
+			continue
+		}
+
+		if isDynamicEIPOfPEBS(pebs, eipCR) {
+			if err := r.Client.Delete(ctx, eipCR); err != nil && !errors.IsNotFound(err) {
+				log.Error(err, fmt.Sprintf("PEBS Reconcile, failed to delete expired reserved EIP CR [%s]",
+					eipCR.Namespace+"/"+eipCR.Name))
+				return 0, err
+			}
+			log.Info(fmt.Sprintf("PEBS Reconcile, deleted EIP CR [%s] due to reservation for [%s] expired",
+				eipCR.Namespace+"/"+eipCR.Name, eipCR.Status.ReservedEndpoint))
+			continue
+		}
+
+		log.Info(fmt.Sprintf("PEBS Reconcile, reservation of EIP [%s] for [%s] expired",
+			eipCR.Name, eipCR.Status.ReservedEndpoint))
+		eipCR.Status.ReservedEndpoint = ""
+		eipCR.Status.ReservedUntil = nil
+		if err := r.updateEIPStatus(ctx, log, eipCR); err != nil {
+			return 0, err
+		}
+	}
+	return requeueAfter, nil
+}
+
+// handleUnboundDynamicEIPs deletes the dynamic EIPs which are not allocated to any CCEEndpoint
+// for longer than dynamicEIPGCGracePeriod, e.g. the EIP created for a CCEEndpoint which failed
+// to be updated and was deleted before it is handled again.
+// It returns the duration until the next unbound dynamic EIP expires.
+func (r *PodEIPBindStrategyReconciler) handleUnboundDynamicEIPs(
+	ctx context.Context,
+	log *logr.Logger,
+	pebs *v2.PodEIPBindStrategy) (time.Duration, error) {
+	log.Info("PEBS Reconcile, handleUnboundDynamicEIPs begin")
+
+	dynamicEIPs, err := r.listDynamicEIPs(ctx, pebs)
+	if err != nil {
+		log.Error(err, "PEBS Reconcile, failed to List dynamic EIP CR")
+		return 0, err
+	}
+	if len(dynamicEIPs) == 0 {
+		return 0, nil
+	}
+
+	allocated := map[string]bool{}
+	for _, cep := range r.getCEPsForPEBS(ctx, log, pebs) {
+		if eipStr := getEIPFromCCEEndpoint(&cep); eipStr != "" {
+			allocated[eipStr] = true
+		}
+	}
+
+	var (
+		now          = time.Now()
+		requeueAfter time.Duration
+	)
+	for i := range dynamicEIPs {
+		eipCR := &dynamicEIPs[i]
+		if allocated[eipCR.Name] || !isEIPUnbound(eipCR, now) {
+			continue
+		}
+		if left := eipCR.CreationTimestamp.Add(dynamicEIPGCGracePeriod).Sub(now); left > 0 {
+			if requeueAfter == 0 || left < requeueAfter {
+				requeueAfter = left
+			}
+			continue
+		}
+
+		if err := r.Client.Delete(ctx, eipCR); err != nil && !errors.IsNotFound(err) {
+			log.Error(err, fmt.Sprintf("PEBS Reconcile, failed to delete unbound dynamic EIP CR [%s]",
+				eipCR.Namespace+"/"+eipCR.Name))
+			return 0, err
+		}
+		log.Info(fmt.Sprintf("PEBS Reconcile, deleted EIP CR [%s] due to not allocated to any CCEEndpoint",
+			eipCR.Namespace+"/"+eipCR.Name))
+	}
+	return requeueAfter, nil
+}
+
 func (r *PodEIPBindStrategyReconciler) updatePEBSStatus(
 	ctx context.Context,
 	log *logr.Logger,
@@ -224,7 +370,7 @@ func (r *PodEIPBindStrategyReconciler) updatePEBSStatus(
 		return err
 	}
 
-	newStatus := calculateStatus(pebs, &eipList)
+	newStatus := calculateStatus(pebs, &eipList, time.Now())
 	if reflect.DeepEqual(newStatus, pebs.Status) {
 		log.Info("PEBS Reconcile, PEBS Status not change, do nothing")
 		return nil
@@ -238,8 +384,13 @@ func (r *PodEIPBindStrategyReconciler) updatePEBSStatus(
 	return nil
 }
 
-func calculateStatus(pebs *v2.PodEIPBindStrategy, eipList *v2.EIPList) v2.PodEIPBindStrategyStatus {
+func calculateStatus(pebs *v2.PodEIPBindStrategy, eipList *v2.EIPList, now time.Time) v2.PodEIPBindStrategyStatus {
 	totalCount := len(pebs.Spec.StaticEIPPool)
+	for _, eip := range eipList.Items {
+		if isDynamicEIPOfPEBS(pebs, &eip) {
+			totalCount += 1
+		}
+	}
 	totalEIPs := map[string]map[string]string{}
 	availableCount := 0
 	availableEIPs := map[string]map[string]string{}
@@ -247,15 +398,21 @@ func calculateStatus(pebs *v2.PodEIPBindStrategy, eipList *v2.EIPList) v2.PodEIP
 	bindedEIPs := map[string]map[string]string{}
 
 	for _, eip := range eipList.Items {
-		if !xslices.Contains(pebs.Spec.StaticEIPPool, eip.Name) {
+		if !isEIPOfPEBS(pebs, &eip) {
 			continue
 		}
 
 		totalEIPs[eip.Name] = map[string]string{}
 		totalEIPs[eip.Name]["status"] = string(eip.Status.Status)
 		totalEIPs[eip.Name]["mode"] = string(eip.Status.Mode)
+		if isEIPReserved(&eip, now) {
+			// the reserved EIP can only be allocated to the endpoint it is reserved for
+			totalEIPs[eip.Name][pebsStatusReservedEndpointKey] = eip.Status.ReservedEndpoint
+			continue
+		}
 
-		if eip.Status.Status == v2.EIPStatusTypeAvailable {
+		if eip.Status.Status == v2.EIPStatusTypeAvailable && eip.DeletionTimestamp == nil {
+			// the EIP being deleted must not be allocated
 			availableCount += 1
 			availableEIPs[eip.Name] = map[string]string{}
 			availableEIPs[eip.Name]["status"] = string(eip.Status.Status)
@@ -288,7 +445,7 @@ func (r *PodEIPBindStrategyReconciler) handleCCEEndpoints(
 	ceps := r.getCEPsForPEBS(ctx, log, pebs)
 	for _, cep := range ceps {
 		if cep.DeletionTimestamp != nil {
-			if err := r.handleCCEEndpointDelete(ctx, log, &cep); err != nil {
+			if err := r.handleCCEEndpointDelete(ctx, log, pebs, &cep); err != nil {
 				return err
 			}
 			continue
@@ -304,6 +461,7 @@ func (r *PodEIPBindStrategyReconciler) handleCCEEndpoints(
 func (r *PodEIPBindStrategyReconciler) handleCCEEndpointDelete(
 	ctx context.Context,
 	log *logr.Logger,
+	pebs *v2.PodEIPBindStrategy,
 	cep *ipamv2.CCEEndpoint) error {
 	log.Info(fmt.Sprintf("PEBS Reconcile, handleCCEEndpointDelete begin for [%s]", cep.Namespace+"/"+cep.Name))
 
@@ -320,13 +478,18 @@ func (r *PodEIPBindStrategyReconciler) handleCCEEndpointDelete(
 			log.Error(err, fmt.Sprintf("PEBS Reconcile, failed to get EIP CR [%s]", eipStr))
 			return err
 		}
-		if err := r.Client.Delete(ctx, &eipCR); err != nil {
+		if pebs.Spec.EnableReuseEIP {
+			if err := r.reserveEIP(ctx, log, pebs, cep, &eipCR); err != nil {
+				return err
+			}
+		} else if err := r.Client.Delete(ctx, &eipCR); err != nil {
 			log.Error(err, fmt.Sprintf("PEBS Reconcile, failed to delete EIP CR [%s]",
 				eipCR.Namespace+"/"+eipCR.Name))
 			return err
+		} else {
+			log.Info(fmt.Sprintf("PEBS Reconcile, deleted EIP CR [%s] due to CCEEndopint delete",
+				eipCR.Namespace+"/"+eipCR.Name))
 		}
-		log.Info(fmt.Sprintf("PEBS Reconcile, deleted EIP CR [%s] due to CCEEndopint delete",
-			eipCR.Namespace+"/"+eipCR.Name))
 	}
 
 	var finalizers []string
@@ -362,15 +525,24 @@ func (r *PodEIPBindStrategyReconciler) handleCCEEndpoint(
 
 	eipStr := getEIPFromCCEEndpoint(cep)
 	if eipStr == "" {
-		// need allocate a new EIP
-		if pebs.Status.AvailableCount == 0 {
-			return fmt.Errorf("no available EIP")
+		// need allocate a new EIP, the EIP reserved for the pod is preferred,
+		// and then the EIP in static pool
+		eipStr = getReservedEIP(pebs, cep)
+		if eipStr == "" {
+			eipStr = getAvailableStaticEIP(pebs)
 		}
-
-		// allocate EIP
-		for k := range pebs.Status.AvailableEIPs {
-			eipStr = k
-			break
+		if eipStr == "" && pebs.Spec.DynamicEIPTemplate != nil {
+			// reuse the unbound dynamic EIP before creating a new one
+			eipStr = getAvailableDynamicEIP(pebs)
+		}
+		if eipStr == "" {
+			if pebs.Spec.DynamicEIPTemplate == nil {
+				return fmt.Errorf("no available EIP")
+			}
+			var err error
+			if eipStr, err = r.createDynamicEIP(ctx, log, pebs, cep); err != nil {
+				return err
+			}
 		}
 
 		// update CCEEndpoint Status
@@ -391,6 +563,8 @@ func (r *PodEIPBindStrategyReconciler) handleCCEEndpoint(
 		}
 		log.Info(fmt.Sprintf("PEBS Reconcile, EIP [%s] Allocated for CCEEndpoint [%s]",
 			eipStr, cep.Namespace+"/"+cep.Name))
+		// the EIP must not be allocated to other CCEEndpoints handled in this round
+		delete(pebs.Status.AvailableEIPs, eipStr)
 
 		// get the updated new CCEEndpoint
 		if err := r.Get(ctx, types.NamespacedName{Namespace: cep.Namespace, Name: cep.Name}, cep); err != nil {
@@ -417,6 +591,8 @@ func (r *PodEIPBindStrategyReconciler) handleCCEEndpoint(
 		eipCR.Status.Endpoint = cep.Namespace + "/" + cep.Name
 		eipCR.Status.InstanceID = cep.Status.Networking.Addressing[0].Interface
 		eipCR.Status.PrivateIP = cep.Status.Networking.Addressing[0].IP
+		eipCR.Status.ReservedEndpoint = ""
+		eipCR.Status.ReservedUntil = nil
 		return r.updateEIPStatus(ctx, log, &eipCR)
 	}
 
@@ -435,6 +611,150 @@ func (r *PodEIPBindStrategyReconciler) handleCCEEndpoint(
 	return nil
 }
 
+// reserveEIP unbinds the EIP from the deleted CCEEndpoint and reserves it for the pod with
+// the same namespace/name until the TTL of PEBS expires
+func (r *PodEIPBindStrategyReconciler) reserveEIP(
+	ctx context.Context,
+	log *logr.Logger,
+	pebs *v2.PodEIPBindStrategy,
+	cep *ipamv2.CCEEndpoint,
+	eipCR *v2.EIP) error {
+	endpoint := cep.Namespace + "/" + cep.Name
+	if eipCR.Status.ReservedEndpoint == endpoint {
+		return nil
+	}
+
+	switch eipCR.Status.Status {
+	case v2.EIPStatusTypeBinded:
+		eipCR.Status.Status = v2.EIPStatusTypeUnBinding
+	case v2.EIPStatusTypeAvailable:
+		eipCR.Status.Endpoint = ""
+		eipCR.Status.InstanceID = ""
+		eipCR.Status.PrivateIP = ""
+	default:
+		return fmt.Errorf("EIP [%s] status [%s] is not stable, retry to reserve it later",
+			eipCR.Name, eipCR.Status.Status)
+	}
+
+	ttl := ipamv2.DefaultReuseIPTTL.Duration
+	if pebs.Spec.TTL != nil {
+		ttl = pebs.Spec.TTL.Duration
+	}
+	eipCR.Status.ReservedEndpoint = endpoint
+	eipCR.Status.ReservedUntil = &metav1.Time{Time: time.Now().Add(ttl)}
+	if err := r.updateEIPStatus(ctx, log, eipCR); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("PEBS Reconcile, EIP [%s] reserved for [%s] until %s due to CCEEndopint delete",
+		eipCR.Name, endpoint, eipCR.Status.ReservedUntil))
+	return nil
+}
+
+// createDynamicEIP creates an EIP in VPC from the DynamicEIPTemplate of PEBS for the CCEEndpoint,
+// and creates the EIP CR for it. The UID of CCEEndpoint is used as the client token, so that
+// the same EIP is returned if the CCEEndpoint failed to be updated and is handled again.
+func (r *PodEIPBindStrategyReconciler) createDynamicEIP(
+	ctx context.Context,
+	log *logr.Logger,
+	pebs *v2.PodEIPBindStrategy,
+	cep *ipamv2.CCEEndpoint) (string, error) {
+	template := pebs.Spec.DynamicEIPTemplate
+	billingMethod := template.BillingMethod
+	if billingMethod == "" {
+		billingMethod = "ByTraffic"
+	}
+	args := &vpceip.CreateEipArgs{
+		Name:            template.NamePrefix + cep.Name,
+		BandWidthInMbps: template.BandWidthInMbps,
+		Billing: &vpceip.Billing{
+			PaymentTiming: "Postpaid",
+			BillingMethod: billingMethod,
+		},
+		Tags:        template.Tags,
+		ClientToken: string(cep.UID),
+	}
+	eipStr, err := r.bceclient.CreateEIP(ctx, args)
+	if err != nil {
+		log.Error(err, fmt.Sprintf("PEBS Reconcile, failed to Create EIP in VPC for CCEEndpoint [%s]",
+			cep.Namespace+"/"+cep.Name))
+		return "", err
+	}
+	log.Info(fmt.Sprintf("PEBS Reconcile, EIP [%s] Created in VPC for CCEEndpoint [%s]",
+		eipStr, cep.Namespace+"/"+cep.Name))
+
+	if template.ShareGroupID != "" {
+		if err := r.moveEIPIntoShareGroup(ctx, log, eipStr, template.ShareGroupID); err != nil {
+			return "", err
+		}
+	}
+
+	reclaimPolicy := pebs.Spec.ReclaimPolicy
+	if reclaimPolicy == "" {
+		reclaimPolicy = v2.EIPReclaimPolicyRelease
+	}
+	eipCRToCreate := &v2.EIP{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       eipStr,
+			Namespace:  pebs.Namespace,
+			Finalizers: []string{FinalizerEIPKey},
+			Labels: map[string]string{
+				v2.LabelPodEIPBindStrategy: pebs.Name,
+			},
+		},
+		Spec: v2.EIPSpec{
+			CreatedByCCE:  true,
+			Mode:          v2.EIPModeTypeDirect,
+			ReclaimPolicy: reclaimPolicy,
+		},
+	}
+	if err := r.Client.Create(ctx, eipCRToCreate); err != nil && !errors.IsAlreadyExists(err) {
+		log.Error(err, fmt.Sprintf("PEBS Reconcile, failed to Create EIP CR for [%v]", eipStr))
+		return "", err
+	}
+	log.Info(fmt.Sprintf("PEBS Reconcile, dynamic EIP CR Created for [%s]", eipStr))
+	return eipStr, nil
+}
+
+// moveEIPIntoShareGroup moves the EIP into the shared bandwidth group if it is not in the group yet
+func (r *PodEIPBindStrategyReconciler) moveEIPIntoShareGroup(
+	ctx context.Context,
+	log *logr.Logger,
+	eipStr, shareGroupID string) error {
+	vpcEIPs, err := r.bceclient.ListEIPs(ctx, vpceip.ListEipArgs{Eip: eipStr})
+	if err != nil {
+		log.Error(err, fmt.Sprintf("PEBS Reconcile, failed to ListEIPs for [%s] in VPC", eipStr))
+		return err
+	}
+	if len(vpcEIPs) > 0 && vpcEIPs[0].ShareGroupId == shareGroupID {
+		return nil
+	}
+
+	if err := r.bceclient.EIPGroupMoveIn(ctx, shareGroupID, []string{eipStr}); err != nil {
+		log.Error(err, fmt.Sprintf("PEBS Reconcile, failed to move EIP [%s] into share group [%s] in VPC",
+			eipStr, shareGroupID))
+		return err
+	}
+	log.Info(fmt.Sprintf("PEBS Reconcile, EIP [%s] moved into share group [%s] in VPC", eipStr, shareGroupID))
+	return nil
+}
+
+// listDynamicEIPs lists the EIP CRs created from the DynamicEIPTemplate of PEBS
+func (r *PodEIPBindStrategyReconciler) listDynamicEIPs(ctx context.Context, pebs *v2.PodEIPBindStrategy) ([]v2.EIP, error) {
+	var eipList v2.EIPList
+	if err := r.List(ctx, &eipList, client.InNamespace(pebs.Namespace),
+		client.MatchingLabels{v2.LabelPodEIPBindStrategy: pebs.Name}); err != nil {
+		return nil, err
+	}
+
+	var res []v2.EIP
+	for _, eip := range eipList.Items {
+		if isDynamicEIPOfPEBS(pebs, &eip) {
+			res = append(res, eip)
+		}
+	}
+	return res, nil
+}
+
 func (r *PodEIPBindStrategyReconciler) updateEIPStatus(
 	ctx context.Context,
 	log *logr.Logger,
@@ -449,6 +769,12 @@ func (r *PodEIPBindStrategyReconciler) updateEIPStatus(
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *PodEIPBindStrategyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	c, err := newBCEClient(r.Region, r.CCEClusterID)
+	if err != nil {
+		return err
+	}
+	r.bceclient = c
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v2.PodEIPBindStrategy{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Watches(
@@ -566,7 +892,7 @@ func (r *PodEIPBindStrategyReconciler) getPEBSsForEIP(eip *v2.EIP) []v2.PodEIPBi
 
 	res := []v2.PodEIPBindStrategy{}
 	for i := 0; i < len(pebsList.Items); i++ {
-		if xslices.Contains(pebsList.Items[i].Spec.StaticEIPPool, eip.Name) {
+		if isEIPOfPEBS(&pebsList.Items[i], eip) {
 			res = append(res, pebsList.Items[i])
 		}
 	}
@@ -574,6 +900,63 @@ func (r *PodEIPBindStrategyReconciler) getPEBSsForEIP(eip *v2.EIP) []v2.PodEIPBi
 	return res
 }
 
+// getReservedEIP returns the EIP reserved for the CCEEndpoint
+func getReservedEIP(pebs *v2.PodEIPBindStrategy, cep *ipamv2.CCEEndpoint) string {
+	endpoint := cep.Namespace + "/" + cep.Name
+	for k, v := range pebs.Status.TotalEIPs {
+		if v[pebsStatusReservedEndpointKey] == endpoint {
+			return k
+		}
+	}
+	return ""
+}
+
+func isEIPReserved(eip *v2.EIP, now time.Time) bool {
+	return eip.Status.ReservedEndpoint != "" &&
+		eip.Status.ReservedUntil != nil &&
+		now.Before(eip.Status.ReservedUntil.Time)
+}
+
+// getAvailableStaticEIP returns an available EIP in the static pool of PEBS
+func getAvailableStaticEIP(pebs *v2.PodEIPBindStrategy) string {
+	for k := range pebs.Status.AvailableEIPs {
+		if xslices.Contains(pebs.Spec.StaticEIPPool, k) {
+			return k
+		}
+	}
+	return ""
+}
+
+// getAvailableDynamicEIP returns an available EIP created from the DynamicEIPTemplate of PEBS
+func getAvailableDynamicEIP(pebs *v2.PodEIPBindStrategy) string {
+	for k := range pebs.Status.AvailableEIPs {
+		if !xslices.Contains(pebs.Spec.StaticEIPPool, k) {
+			return k
+		}
+	}
+	return ""
+}
+
+// isEIPUnbound returns true if the EIP is neither bound to nor reserved for any CCEEndpoint
+func isEIPUnbound(eip *v2.EIP, now time.Time) bool {
+	return eip.Status.Status == v2.EIPStatusTypeAvailable &&
+		eip.Status.Endpoint == "" &&
+		eip.Status.InstanceID == "" &&
+		eip.Status.PrivateIP == "" &&
+		!isEIPReserved(eip, now)
+}
+
+func isEIPOfPEBS(pebs *v2.PodEIPBindStrategy, eip *v2.EIP) bool {
+	return xslices.Contains(pebs.Spec.StaticEIPPool, eip.Name) || isDynamicEIPOfPEBS(pebs, eip)
+}
+
+func isDynamicEIPOfPEBS(pebs *v2.PodEIPBindStrategy, eip *v2.EIP) bool {
+	return eip.Namespace == pebs.Namespace &&
+		eip.Spec.CreatedByCCE &&
+		eip.Labels[v2.LabelPodEIPBindStrategy] == pebs.Name &&
+		!xslices.Contains(pebs.Spec.StaticEIPPool, eip.Name)
+}
+
 func getEIPFromCCEEndpoint(cep *ipamv2.CCEEndpoint) string {
 	if cep.Status.ExtFeatureStatus == nil {
 		return ""
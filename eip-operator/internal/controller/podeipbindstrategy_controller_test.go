@@ -0,0 +1,550 @@
+/*
+Copyright (c) 2023 Baidu, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	v2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-ext-eip/api/v2"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/api/v1/models"
+	"github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/bce/api/cloud/simulator"
+	ipamv2 "github.com/baidubce/baiducloud-cce-cni-driver/cce-network-v2/pkg/k8s/apis/cce.baidubce.com/v2"
+	vpceip "github.com/baidubce/bce-sdk-go/services/eip"
+)
+
+const testNamespace = "default"
+
+func newFakeClient(t *testing.T, objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	if err := v2.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := ipamv2.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func newSimulator(t *testing.T) *simulator.Simulator {
+	s, err := simulator.New(&simulator.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func newTestPEBS() *v2.PodEIPBindStrategy {
+	return &v2.PodEIPBindStrategy{
+		ObjectMeta: metav1.ObjectMeta{Name: "pebs", Namespace: testNamespace},
+		Spec: v2.PodEIPBindStrategySpec{
+			StaticEIPPool:      []string{"1.1.1.1", "1.1.1.2"},
+			DynamicEIPTemplate: &v2.DynamicEIPTemplate{BandWidthInMbps: 10},
+			Selector:           &metav1.LabelSelector{MatchLabels: map[string]string{"app": "eip"}},
+		},
+	}
+}
+
+func newStaticEIP(name string, status v2.EIPStatusType) *v2.EIP {
+	return &v2.EIP{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+		Spec:       v2.EIPSpec{Mode: v2.EIPModeTypeDirect},
+		Status:     v2.EIPStatus{Status: status, Mode: v2.EIPModeTypeDirect},
+	}
+}
+
+func newDynamicEIP(name, pebs string, status v2.EIPStatusType) *v2.EIP {
+	eip := newStaticEIP(name, status)
+	eip.Labels = map[string]string{v2.LabelPodEIPBindStrategy: pebs}
+	eip.Finalizers = []string{FinalizerEIPKey}
+	eip.Spec.CreatedByCCE = true
+	eip.Spec.ReclaimPolicy = v2.EIPReclaimPolicyRelease
+	return eip
+}
+
+func newTestCEP(name, eip string) *ipamv2.CCEEndpoint {
+	cep := &ipamv2.CCEEndpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			UID:       types.UID("uid-" + name),
+			Labels:    map[string]string{"app": "eip"},
+		},
+		Spec: ipamv2.EndpointSpec{ExtFeatureGates: []string{v2.FeatureKeyOfPublicIP}},
+		Status: ipamv2.EndpointStatus{
+			State:               CCEEndpintStateReady,
+			ExternalIdentifiers: &models.EndpointIdentifiers{ContainerID: "container-" + name},
+			Networking: &ipamv2.EndpointNetworking{
+				Addressing: ipamv2.AddressPairList{{Family: ipamv2.IPv4Family, IP: "10.0.0.2", Interface: "eni-1"}},
+			},
+		},
+	}
+	if eip != "" {
+		cep.Status.ExtFeatureStatus = map[string]*ipamv2.ExtFeatureStatus{
+			v2.FeatureKeyOfPublicIP: {Data: map[string]string{FeatureStatusEIPKey: eip}},
+		}
+	}
+	return cep
+}
+
+func TestIsDynamicEIPOfPEBS(t *testing.T) {
+	pebs := newTestPEBS()
+	otherNamespace := newDynamicEIP("2.2.2.2", pebs.Name, v2.EIPStatusTypeAvailable)
+	otherNamespace.Namespace = "other"
+	notCreatedByCCE := newDynamicEIP("2.2.2.2", pebs.Name, v2.EIPStatusTypeAvailable)
+	notCreatedByCCE.Spec.CreatedByCCE = false
+	inStaticPool := newDynamicEIP("1.1.1.1", pebs.Name, v2.EIPStatusTypeAvailable)
+
+	tests := []struct {
+		name string
+		eip  *v2.EIP
+		want bool
+	}{
+		{name: "created for the pebs", eip: newDynamicEIP("2.2.2.2", pebs.Name, v2.EIPStatusTypeBinded), want: true},
+		{name: "created for another pebs", eip: newDynamicEIP("2.2.2.2", "other", v2.EIPStatusTypeBinded), want: false},
+		{name: "in another namespace", eip: otherNamespace, want: false},
+		{name: "not created by cce", eip: notCreatedByCCE, want: false},
+		{name: "in static pool", eip: inStaticPool, want: false},
+		{name: "static eip", eip: newStaticEIP("1.1.1.2", v2.EIPStatusTypeAvailable), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isDynamicEIPOfPEBS(pebs, tt.eip); got != tt.want {
+				t.Errorf("isDynamicEIPOfPEBS() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateStatus(t *testing.T) {
+	now := time.Now()
+	pebs := newTestPEBS()
+
+	binded := newDynamicEIP("2.2.2.1", pebs.Name, v2.EIPStatusTypeBinded)
+	binded.Status.InstanceID = "eni-1"
+	reserved := newDynamicEIP("2.2.2.2", pebs.Name, v2.EIPStatusTypeAvailable)
+	reserved.Status.ReservedEndpoint = "default/pod-1"
+	reserved.Status.ReservedUntil = &metav1.Time{Time: now.Add(time.Hour)}
+	expired := newStaticEIP("1.1.1.2", v2.EIPStatusTypeAvailable)
+	expired.Status.ReservedEndpoint = "default/pod-2"
+	expired.Status.ReservedUntil = &metav1.Time{Time: now.Add(-time.Hour)}
+	deleting := newDynamicEIP("2.2.2.3", pebs.Name, v2.EIPStatusTypeAvailable)
+	deleting.DeletionTimestamp = &metav1.Time{Time: now}
+
+	eipList := &v2.EIPList{Items: []v2.EIP{
+		*newStaticEIP("1.1.1.1", v2.EIPStatusTypeBinding),
+		*expired,
+		*binded,
+		*reserved,
+		*deleting,
+		*newDynamicEIP("3.3.3.3", "other", v2.EIPStatusTypeAvailable),
+		*newStaticEIP("4.4.4.4", v2.EIPStatusTypeAvailable),
+	}}
+
+	want := v2.PodEIPBindStrategyStatus{
+		TotalCount: 5,
+		TotalEIPs: map[string]map[string]string{
+			"1.1.1.1": {"status": "Binding", "mode": "Direct"},
+			"1.1.1.2": {"status": "Available", "mode": "Direct"},
+			"2.2.2.1": {"status": "Binded", "mode": "Direct"},
+			"2.2.2.2": {"status": "Available", "mode": "Direct", pebsStatusReservedEndpointKey: "default/pod-1"},
+			"2.2.2.3": {"status": "Available", "mode": "Direct"},
+		},
+		AvailableCount: 1,
+		AvailableEIPs: map[string]map[string]string{
+			"1.1.1.2": {"status": "Available", "mode": "Direct"},
+		},
+		BindedCount: 1,
+		BindedEIPs: map[string]map[string]string{
+			"2.2.2.1": {"status": "Binded", "mode": "Direct", "instanceId": "eni-1"},
+		},
+	}
+	if got := calculateStatus(pebs, eipList, now); !reflect.DeepEqual(got, want) {
+		t.Errorf("calculateStatus() = %+v, want %+v", got, want)
+	}
+}
+
+func TestHandleUnboundDynamicEIPs(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		log  = logr.Discard()
+		pebs = newTestPEBS()
+		old  = metav1.Time{Time: time.Now().Add(-2 * dynamicEIPGCGracePeriod)}
+	)
+
+	leaked := newDynamicEIP("2.2.2.1", pebs.Name, v2.EIPStatusTypeAvailable)
+	leaked.CreationTimestamp = old
+	fresh := newDynamicEIP("2.2.2.2", pebs.Name, v2.EIPStatusTypeAvailable)
+	fresh.CreationTimestamp = metav1.Time{Time: time.Now()}
+	allocated := newDynamicEIP("2.2.2.3", pebs.Name, v2.EIPStatusTypeAvailable)
+	allocated.CreationTimestamp = old
+	reserved := newDynamicEIP("2.2.2.4", pebs.Name, v2.EIPStatusTypeAvailable)
+	reserved.CreationTimestamp = old
+	reserved.Status.ReservedEndpoint = "default/pod-2"
+	reserved.Status.ReservedUntil = &metav1.Time{Time: time.Now().Add(time.Hour)}
+	binded := newDynamicEIP("2.2.2.5", pebs.Name, v2.EIPStatusTypeBinded)
+	binded.CreationTimestamp = old
+	binded.Status.Endpoint = "default/pod-3"
+
+	r := &PodEIPBindStrategyReconciler{
+		Client: newFakeClient(t, pebs, leaked, fresh, allocated, reserved, binded, newTestCEP("pod-1", allocated.Name)),
+	}
+	requeueAfter, err := r.handleUnboundDynamicEIPs(ctx, &log, pebs)
+	if err != nil {
+		t.Fatalf("handleUnboundDynamicEIPs() error = %v", err)
+	}
+	if requeueAfter <= 0 || requeueAfter > dynamicEIPGCGracePeriod {
+		t.Errorf("handleUnboundDynamicEIPs() requeueAfter = %v, want in (0, %v]", requeueAfter, dynamicEIPGCGracePeriod)
+	}
+
+	for _, tt := range []struct {
+		eip     string
+		deleted bool
+	}{
+		{eip: leaked.Name, deleted: true},
+		{eip: fresh.Name, deleted: false},
+		{eip: allocated.Name, deleted: false},
+		{eip: reserved.Name, deleted: false},
+		{eip: binded.Name, deleted: false},
+	} {
+		if got := isEIPCRDeleted(t, r.Client, tt.eip); got != tt.deleted {
+			t.Errorf("EIP %s deleted = %v, want %v", tt.eip, got, tt.deleted)
+		}
+	}
+}
+
+func TestHandleCCEEndpointReusesUnboundDynamicEIP(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		log  = logr.Discard()
+		pebs = newTestPEBS()
+	)
+	pebs.Spec.StaticEIPPool = nil
+	unbound := newDynamicEIP("2.2.2.1", pebs.Name, v2.EIPStatusTypeAvailable)
+	pebs.Status = calculateStatus(pebs, &v2.EIPList{Items: []v2.EIP{*unbound}}, time.Now())
+	cep := newTestCEP("pod-1", "")
+
+	sim := newSimulator(t)
+	r := &PodEIPBindStrategyReconciler{
+		Client:    newFakeClient(t, pebs, unbound, cep),
+		bceclient: sim,
+	}
+	if err := r.handleCCEEndpoint(ctx, &log, pebs, cep); err != nil {
+		t.Fatalf("handleCCEEndpoint() error = %v", err)
+	}
+
+	if got := getEIPFromCCEEndpoint(cep); got != unbound.Name {
+		t.Errorf("EIP of CCEEndpoint = %q, want %q", got, unbound.Name)
+	}
+	var eipCR v2.EIP
+	if err := r.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: unbound.Name}, &eipCR); err != nil {
+		t.Fatal(err)
+	}
+	if eipCR.Status.Endpoint != "default/pod-1" || eipCR.Status.PrivateIP != "10.0.0.2" {
+		t.Errorf("EIP status = %+v, want allocated to default/pod-1", eipCR.Status)
+	}
+	vpcEIPs, err := sim.ListEIPs(ctx, vpceip.ListEipArgs{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vpcEIPs) != 0 {
+		t.Errorf("%d EIPs created in VPC, want 0", len(vpcEIPs))
+	}
+}
+
+func TestHandleCCEEndpointCreatesDynamicEIP(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		log  = logr.Discard()
+		pebs = newTestPEBS()
+	)
+	pebs.Spec.StaticEIPPool = nil
+	cep := newTestCEP("pod-1", "")
+
+	sim := newSimulator(t)
+	r := &PodEIPBindStrategyReconciler{
+		Client:    newFakeClient(t, pebs, cep),
+		bceclient: sim,
+	}
+	if err := r.handleCCEEndpoint(ctx, &log, pebs, cep); err != nil {
+		t.Fatalf("handleCCEEndpoint() error = %v", err)
+	}
+
+	eipStr := getEIPFromCCEEndpoint(cep)
+	vpcEIPs, err := sim.ListEIPs(ctx, vpceip.ListEipArgs{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vpcEIPs) != 1 || vpcEIPs[0].Eip != eipStr {
+		t.Fatalf("EIPs in VPC = %+v, want only %s", vpcEIPs, eipStr)
+	}
+	var eipCR v2.EIP
+	if err := r.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: eipStr}, &eipCR); err != nil {
+		t.Fatal(err)
+	}
+	if !isDynamicEIPOfPEBS(pebs, &eipCR) {
+		t.Errorf("EIP CR %+v is not a dynamic EIP of PEBS", eipCR)
+	}
+}
+
+// isEIPCRDeleted returns true if the EIP CR is not found or is being deleted
+func isEIPCRDeleted(t *testing.T, c client.Client, name string) bool {
+	var eipCR v2.EIP
+	err := c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: name}, &eipCR)
+	if errors.IsNotFound(err) {
+		return true
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return eipCR.DeletionTimestamp != nil
+}
+
+func TestIsEIPReserved(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name          string
+		endpoint      string
+		reservedUntil *metav1.Time
+		want          bool
+	}{
+		{name: "reserved", endpoint: "default/pod-1", reservedUntil: &metav1.Time{Time: now.Add(time.Minute)}, want: true},
+		{name: "reservation expired", endpoint: "default/pod-1", reservedUntil: &metav1.Time{Time: now.Add(-time.Minute)}, want: false},
+		{name: "reservation expires now", endpoint: "default/pod-1", reservedUntil: &metav1.Time{Time: now}, want: false},
+		{name: "without deadline", endpoint: "default/pod-1", want: false},
+		{name: "not reserved", reservedUntil: &metav1.Time{Time: now.Add(time.Minute)}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eip := newStaticEIP("1.1.1.1", v2.EIPStatusTypeAvailable)
+			eip.Status.ReservedEndpoint = tt.endpoint
+			eip.Status.ReservedUntil = tt.reservedUntil
+			if got := isEIPReserved(eip, now); got != tt.want {
+				t.Errorf("isEIPReserved() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReserveEIP(t *testing.T) {
+	ttl := time.Hour
+	tests := []struct {
+		name       string
+		ttl        *metav1.Duration
+		status     v2.EIPStatusType
+		reservedBy string
+		wantStatus v2.EIPStatusType
+		wantTTL    time.Duration
+		wantErr    bool
+	}{
+		{
+			name:       "binded eip is unbinded and reserved",
+			ttl:        &metav1.Duration{Duration: ttl},
+			status:     v2.EIPStatusTypeBinded,
+			wantStatus: v2.EIPStatusTypeUnBinding,
+			wantTTL:    ttl,
+		},
+		{
+			name:       "available eip is reserved with default ttl",
+			status:     v2.EIPStatusTypeAvailable,
+			wantStatus: v2.EIPStatusTypeAvailable,
+			wantTTL:    ipamv2.DefaultReuseIPTTL.Duration,
+		},
+		{
+			name:    "eip in unstable status is retried",
+			status:  v2.EIPStatusTypeBinding,
+			wantErr: true,
+		},
+		{
+			name:       "eip already reserved is untouched",
+			status:     v2.EIPStatusTypeUnBinding,
+			reservedBy: "default/pod-1",
+			wantStatus: v2.EIPStatusTypeUnBinding,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			log := logr.Discard()
+			pebs := newTestPEBS()
+			pebs.Spec.EnableReuseEIP = true
+			pebs.Spec.TTL = tt.ttl
+			cep := newTestCEP("pod-1", "1.1.1.1")
+			eip := newStaticEIP("1.1.1.1", tt.status)
+			eip.Status.Endpoint = "default/pod-1"
+			eip.Status.InstanceID = "eni-1"
+			eip.Status.PrivateIP = "10.0.0.2"
+			eip.Status.ReservedEndpoint = tt.reservedBy
+
+			r := &PodEIPBindStrategyReconciler{Client: newFakeClient(t, pebs, cep, eip)}
+			start := time.Now()
+			err := r.reserveEIP(ctx, &log, pebs, cep, eip)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("reserveEIP() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			var eipCR v2.EIP
+			if err := r.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: eip.Name}, &eipCR); err != nil {
+				t.Fatal(err)
+			}
+			if eipCR.Status.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", eipCR.Status.Status, tt.wantStatus)
+			}
+			if eipCR.Status.ReservedEndpoint != "default/pod-1" {
+				t.Errorf("reservedEndpoint = %q, want default/pod-1", eipCR.Status.ReservedEndpoint)
+			}
+			if tt.wantTTL == 0 {
+				return
+			}
+			if eipCR.Status.ReservedUntil == nil {
+				t.Fatal("reservedUntil is nil")
+			}
+			// metav1.Time is serialized in seconds
+			deadline := start.Add(tt.wantTTL).Truncate(time.Second)
+			if eipCR.Status.ReservedUntil.Time.Before(deadline) || eipCR.Status.ReservedUntil.Sub(deadline) > time.Minute {
+				t.Errorf("reservedUntil = %v, want about %v", eipCR.Status.ReservedUntil, deadline)
+			}
+			if tt.status == v2.EIPStatusTypeAvailable &&
+				(eipCR.Status.Endpoint != "" || eipCR.Status.InstanceID != "" || eipCR.Status.PrivateIP != "") {
+				t.Errorf("status = %+v, want the binding of deleted endpoint cleared", eipCR.Status)
+			}
+		})
+	}
+}
+
+func TestHandleReservedEIPs(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		log  = logr.Discard()
+		pebs = newTestPEBS()
+		now  = time.Now()
+	)
+
+	reserved := newStaticEIP("1.1.1.1", v2.EIPStatusTypeAvailable)
+	reserved.Status.ReservedEndpoint = "default/pod-1"
+	reserved.Status.ReservedUntil = &metav1.Time{Time: now.Add(time.Hour)}
+	expiredStatic := newStaticEIP("1.1.1.2", v2.EIPStatusTypeAvailable)
+	expiredStatic.Status.ReservedEndpoint = "default/pod-2"
+	expiredStatic.Status.ReservedUntil = &metav1.Time{Time: now.Add(-time.Minute)}
+	expiredDynamic := newDynamicEIP("2.2.2.1", pebs.Name, v2.EIPStatusTypeAvailable)
+	expiredDynamic.Status.ReservedEndpoint = "default/pod-3"
+	expiredDynamic.Status.ReservedUntil = &metav1.Time{Time: now.Add(-time.Minute)}
+	reservedDynamic := newDynamicEIP("2.2.2.2", pebs.Name, v2.EIPStatusTypeAvailable)
+	reservedDynamic.Status.ReservedEndpoint = "default/pod-4"
+	reservedDynamic.Status.ReservedUntil = &metav1.Time{Time: now.Add(2 * time.Hour)}
+	otherPEBS := newDynamicEIP("3.3.3.3", "other", v2.EIPStatusTypeAvailable)
+	otherPEBS.Status.ReservedEndpoint = "default/pod-5"
+	otherPEBS.Status.ReservedUntil = &metav1.Time{Time: now.Add(-time.Minute)}
+
+	r := &PodEIPBindStrategyReconciler{
+		Client: newFakeClient(t, pebs, reserved, expiredStatic, expiredDynamic, reservedDynamic, otherPEBS),
+	}
+	requeueAfter, err := r.handleReservedEIPs(ctx, &log, pebs)
+	if err != nil {
+		t.Fatalf("handleReservedEIPs() error = %v", err)
+	}
+	// the earliest reservation expires in an hour
+	if requeueAfter <= 0 || requeueAfter > time.Hour {
+		t.Errorf("handleReservedEIPs() requeueAfter = %v, want in (0, 1h]", requeueAfter)
+	}
+
+	tests := []struct {
+		eip          string
+		wantDeleted  bool
+		wantReserved string
+	}{
+		{eip: reserved.Name, wantReserved: "default/pod-1"},
+		{eip: expiredStatic.Name},
+		{eip: expiredDynamic.Name, wantDeleted: true},
+		{eip: reservedDynamic.Name, wantReserved: "default/pod-4"},
+		{eip: otherPEBS.Name, wantReserved: "default/pod-5"},
+	}
+	for _, tt := range tests {
+		if deleted := isEIPCRDeleted(t, r.Client, tt.eip); deleted != tt.wantDeleted {
+			t.Errorf("EIP %s deleted = %v, want %v", tt.eip, deleted, tt.wantDeleted)
+		}
+		if tt.wantDeleted {
+			continue
+		}
+		var eipCR v2.EIP
+		if err := r.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: tt.eip}, &eipCR); err != nil {
+			t.Fatal(err)
+		}
+		if eipCR.Status.ReservedEndpoint != tt.wantReserved {
+			t.Errorf("EIP %s reservedEndpoint = %q, want %q", tt.eip, eipCR.Status.ReservedEndpoint, tt.wantReserved)
+		}
+		if tt.wantReserved == "" && eipCR.Status.ReservedUntil != nil {
+			t.Errorf("EIP %s reservedUntil = %v, want nil", tt.eip, eipCR.Status.ReservedUntil)
+		}
+	}
+}
+
+func TestHandleCCEEndpointPrefersReservedEIP(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		log  = logr.Discard()
+		pebs = newTestPEBS()
+		now  = time.Now()
+	)
+	reserved := newStaticEIP("1.1.1.1", v2.EIPStatusTypeAvailable)
+	reserved.Status.ReservedEndpoint = "default/pod-1"
+	reserved.Status.ReservedUntil = &metav1.Time{Time: now.Add(time.Hour)}
+	available := newStaticEIP("1.1.1.2", v2.EIPStatusTypeAvailable)
+	pebs.Status = calculateStatus(pebs, &v2.EIPList{Items: []v2.EIP{*reserved, *available}}, now)
+
+	tests := []struct {
+		cep  string
+		want string
+	}{
+		{cep: "pod-2", want: available.Name},
+		{cep: "pod-1", want: reserved.Name},
+	}
+	r := &PodEIPBindStrategyReconciler{Client: newFakeClient(t, pebs, reserved, available)}
+	for _, tt := range tests {
+		cep := newTestCEP(tt.cep, "")
+		if err := r.Create(ctx, cep); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.handleCCEEndpoint(ctx, &log, pebs, cep); err != nil {
+			t.Fatalf("handleCCEEndpoint() error = %v", err)
+		}
+		if got := getEIPFromCCEEndpoint(cep); got != tt.want {
+			t.Errorf("EIP of CCEEndpoint %s = %q, want %q", tt.cep, got, tt.want)
+		}
+	}
+
+	var eipCR v2.EIP
+	if err := r.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: reserved.Name}, &eipCR); err != nil {
+		t.Fatal(err)
+	}
+	if eipCR.Status.Endpoint != "default/pod-1" || eipCR.Status.ReservedEndpoint != "" || eipCR.Status.ReservedUntil != nil {
+		t.Errorf("status of reserved EIP = %+v, want allocated to default/pod-1 and reservation cleared", eipCR.Status)
+	}
+}